@@ -0,0 +1,359 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupsink"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/redact"
+)
+
+var deduplicateFullBackups = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"bulkio.backup.deduplicate_full_backups.enabled",
+	"if enabled, full backups into a collection reference the data files of the previous "+
+		"full backup in the collection for spans that are unchanged since it was taken, "+
+		"instead of exporting those spans again; each span exported by such a backup is "+
+		"written to its own files",
+	false,
+)
+
+// shouldDeduplicateFiles returns whether the backup described by details and
+// backupManifest fingerprints the spans it exports so that they can be reused
+// by a later full backup, and reuses the files of the previous full backup in
+// the collection for spans that have not changed since.
+//
+// Only full backups of the latest revisions into the default location of a
+// collection deduplicate their files. Revision history backups are excluded
+// since the revisions they contain change with every backup. Encrypted backups
+// are excluded since each full backup in a collection may be encrypted with a
+// different key.
+func shouldDeduplicateFiles(
+	sv *settings.Values, details jobspb.BackupDetails, backupManifest *backuppb.BackupManifest,
+) bool {
+	return deduplicateFullBackups.Get(sv) &&
+		backupManifest.StartTime.IsEmpty() &&
+		backupManifest.MVCCFilter == backuppb.MVCCFilter_Latest &&
+		details.CollectionURI != "" &&
+		len(details.URIsByLocalityKV) == 0 &&
+		details.EncryptionOptions == nil
+}
+
+// loadReusableBackupSpans returns the spans of the previous full backup in the
+// collection of the backup described by details whose files may be reused by
+// it, sorted by key. A span is reusable if every file covering it was written
+// for that span alone, so that each of its files can be referenced without
+// also referencing keys of other spans that may since have changed.
+//
+// The previous full backup is only a source of files to reuse, so any failure
+// to load it is logged and results in a backup that reuses nothing.
+func loadReusableBackupSpans(
+	ctx context.Context,
+	execCtx sql.JobExecContext,
+	details jobspb.BackupDetails,
+	backupManifest *backuppb.BackupManifest,
+) []execinfrapb.ReusableBackupSpan {
+	reusable, err := loadReusableBackupSpansImpl(ctx, execCtx, details, backupManifest)
+	if err != nil {
+		log.Dev.Warningf(ctx, "not reusing files of the previous full backup: %+v", err)
+		return nil
+	}
+	return reusable
+}
+
+func loadReusableBackupSpansImpl(
+	ctx context.Context,
+	execCtx sql.JobExecContext,
+	details jobspb.BackupDetails,
+	backupManifest *backuppb.BackupManifest,
+) ([]execinfrapb.ReusableBackupSpan, error) {
+	execCfg := execCtx.ExecCfg()
+	subdir, err := backuputils.AbsoluteBackupPathInCollectionURI(details.CollectionURI, details.URI)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, details.CollectionURI, execCtx.User())
+	if err != nil {
+		return nil, err
+	}
+	defer collection.Close()
+
+	baseSubdir, err := previousFullBackupSubdir(ctx, execCfg, collection, subdir)
+	if err != nil || baseSubdir == "" {
+		return nil, err
+	}
+	baseURI, err := backuputils.AppendPath(details.CollectionURI, baseSubdir)
+	if err != nil {
+		return nil, err
+	}
+	baseStore, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, baseURI, execCtx.User())
+	if err != nil {
+		return nil, err
+	}
+	defer baseStore.Close()
+
+	mem := execCfg.RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+	base, _, err := backupinfo.ReadBackupManifestFromStore(
+		ctx, &mem, baseStore, baseURI, nil /* encryption */, nil, /* kmsEnv */
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading manifest of full backup %s", baseSubdir)
+	}
+	if !base.StartTime.IsEmpty() || base.IsCompacted ||
+		base.MVCCFilter != backuppb.MVCCFilter_Latest ||
+		base.ElidedPrefix != backupManifest.ElidedPrefix {
+		return nil, nil
+	}
+
+	it, err := backupinfo.NewIterFactory(&base, baseStore, nil /* encryption */, nil /* kmsEnv */).NewFileIter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	// Group the files of the base backup by the span they were written for.
+	// Groups whose files share a path with another group, or that contain range
+	// keys, which are not fingerprinted, cannot be reused.
+	type group struct {
+		span        roachpb.Span
+		fingerprint uint64
+		files       []backuppb.BackupManifest_File
+		reusable    bool
+	}
+	groups := make(map[string]*group)
+	groupByPath := make(map[string]*group)
+	for ; ; it.Next() {
+		if ok, err := it.Valid(); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+		f := *it.Value()
+		if !f.FingerprintSpan.Valid() {
+			continue
+		}
+		g, ok := groups[string(f.FingerprintSpan.Key)]
+		if !ok {
+			g = &group{span: f.FingerprintSpan, fingerprint: f.Fingerprint, reusable: true}
+			groups[string(f.FingerprintSpan.Key)] = g
+		}
+		if !g.span.Equal(f.FingerprintSpan) || g.fingerprint != f.Fingerprint || f.HasRangeKeys {
+			g.reusable = false
+		}
+		if other, ok := groupByPath[f.Path]; ok && other != g {
+			other.reusable = false
+			g.reusable = false
+		}
+		groupByPath[f.Path] = g
+		if f.ReusedFrom == "" {
+			f.ReusedFrom = baseSubdir
+		}
+		g.files = append(g.files, f)
+	}
+
+	reusable := make([]execinfrapb.ReusableBackupSpan, 0, len(groups))
+	for _, g := range groups {
+		if !g.reusable {
+			continue
+		}
+		r := execinfrapb.ReusableBackupSpan{Span: g.span, Fingerprint: g.fingerprint}
+		for i := range g.files {
+			file, err := protoutil.Marshal(&g.files[i])
+			if err != nil {
+				return nil, err
+			}
+			r.Files = append(r.Files, file)
+		}
+		reusable = append(reusable, r)
+	}
+	sort.Slice(reusable, func(i, j int) bool {
+		return reusable[i].Span.Key.Compare(reusable[j].Span.Key) < 0
+	})
+	log.Dev.Infof(ctx, "full backup may reuse files of %d spans of full backup %s",
+		len(reusable), baseSubdir)
+	return reusable, nil
+}
+
+// previousFullBackupSubdir returns the subdirectory of the latest full backup
+// in the collection that precedes the full backup at subdir, or an empty
+// string if there is none.
+func previousFullBackupSubdir(
+	ctx context.Context, execCfg *sql.ExecutorConfig, collection cloud.ExternalStorage, subdir string,
+) (string, error) {
+	fulls, err := backupdest.ListFullBackupsInCollection(
+		ctx, collection, backupinfo.ReadBackupIndexEnabled.Get(&execCfg.Settings.SV),
+	)
+	if err != nil {
+		return "", err
+	}
+	var prev string
+	for _, full := range fulls {
+		full, err := backuputils.NormalizeSubdir(full)
+		if err != nil {
+			continue
+		}
+		if full < subdir && full > prev {
+			prev = full
+		}
+	}
+	return prev, nil
+}
+
+// reusableSpansWithin returns the reusable spans that are contained in one of
+// spans. Both reusable and spans must be sorted by key and non-overlapping.
+func reusableSpansWithin(
+	reusable []execinfrapb.ReusableBackupSpan, spans roachpb.Spans,
+) []execinfrapb.ReusableBackupSpan {
+	var within []execinfrapb.ReusableBackupSpan
+	for _, sp := range spans {
+		i := sort.Search(len(reusable), func(i int) bool {
+			return reusable[i].Span.Key.Compare(sp.Key) >= 0
+		})
+		for ; i < len(reusable) && sp.Contains(reusable[i].Span); i++ {
+			within = append(within, reusable[i])
+		}
+	}
+	return within
+}
+
+// fingerprintBackupSpan fingerprints the point keys that a full backup exports
+// from span. ok is false if the span could not be fingerprinted without waiting
+// on intents, or if it contains range keys, which are not fingerprinted.
+func fingerprintBackupSpan(
+	ctx context.Context,
+	sender kv.Sender,
+	span spanAndTime,
+	mvccFilter kvpb.MVCCFilter,
+	timeout time.Duration,
+) (fingerprint uint64, ok bool, _ error) {
+	header := kvpb.Header{
+		Timestamp:                   span.end,
+		WaitPolicy:                  lock.WaitPolicy_Error,
+		ReturnElasticCPUResumeSpans: true,
+	}
+	admissionHeader := kvpb.AdmissionHeader{
+		Priority:                 int32(admissionpb.BulkNormalPri),
+		CreateTime:               timeutil.Now().UnixNano(),
+		Source:                   kvpb.AdmissionHeader_FROM_SQL,
+		NoMemoryReservedAtSource: true,
+	}
+	for remaining := span.span; len(remaining.Key) != 0; {
+		req := &kvpb.ExportRequest{
+			RequestHeader:     kvpb.RequestHeaderFromSpan(remaining),
+			StartTime:         span.start,
+			MVCCFilter:        mvccFilter,
+			ExportFingerprint: true,
+		}
+		var rawResp kvpb.Response
+		var pErr *kvpb.Error
+		if err := timeutil.RunWithTimeout(ctx,
+			redact.Sprintf("ExportRequest fingerprint for span %s", remaining),
+			timeout, func(ctx context.Context) error {
+				rawResp, pErr = kv.SendWrappedWithAdmission(ctx, sender, header, admissionHeader, req)
+				return pErr.GoError()
+			}); err != nil {
+			if _, ok := pErr.GetDetail().(*kvpb.WriteIntentError); ok {
+				log.VEventf(ctx, 1, "not fingerprinting span %s; encountered WriteIntentError: %s", span.span, err)
+				return 0, false, nil
+			}
+			return 0, false, errors.Wrapf(err, "fingerprinting %s", span.span)
+		}
+		resp := rawResp.(*kvpb.ExportResponse)
+		for _, file := range resp.Files {
+			if len(file.SST) != 0 {
+				// Range keys are returned in an SST instead of being fingerprinted.
+				return 0, false, nil
+			}
+			fingerprint ^= file.Fingerprint
+		}
+		remaining = roachpb.Span{}
+		if resp.ResumeSpan != nil {
+			if !resp.ResumeSpan.Valid() {
+				return 0, false, errors.Errorf("invalid resume span: %s", resp.ResumeSpan)
+			}
+			remaining = *resp.ResumeSpan
+		}
+	}
+	return fingerprint, true, nil
+}
+
+// writeReusedFiles reports the files of an earlier backup that are reused for
+// span in place of exporting it again.
+func writeReusedFiles(ctx context.Context, sink *backupsink.FileSSTSink, span spanAndTime) error {
+	files := make([]backuppb.BackupManifest_File, len(span.reusable.Files))
+	for i := range span.reusable.Files {
+		if err := protoutil.Unmarshal(span.reusable.Files[i], &files[i]); err != nil {
+			return err
+		}
+	}
+	var completedSpans int32
+	if span.finishesSpec {
+		completedSpans = 1
+	}
+	log.VEventf(ctx, 1, "reusing %d files for unchanged span %s", len(files), span.span)
+	return sink.WriteReused(ctx, files, completedSpans)
+}
+
+// DeleteUnreusedBackupFiles deletes the data files of the full backup at
+// fullBackupPath in the collection that are not reused by any other full
+// backup in the collection, and returns the number of deleted files. It backs
+// the crdb_internal.delete_unreused_backup_files builtin.
+//
+// Note that planner should be a sql.PlanHookState. Due to import cycles with
+// the sql and builtins package, the interface{} type is used.
+func DeleteUnreusedBackupFiles(
+	ctx context.Context, planner interface{}, collectionURI string, fullBackupPath string,
+) (int, error) {
+	planHook, ok := planner.(sql.PlanHookState)
+	if !ok {
+		return 0, errors.New("missing job execution context")
+	}
+	if hasAdmin, err := planHook.HasAdminRole(ctx); err != nil {
+		return 0, err
+	} else if !hasAdmin {
+		return 0, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"only users with the admin role are allowed to delete backup files")
+	}
+	execCfg := planHook.ExecCfg()
+	subdir, err := resolveBackupSubdir(ctx, execCfg, planHook.User(), collectionURI, fullBackupPath)
+	if err != nil {
+		return 0, err
+	}
+	mem := execCfg.RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+	return backupdest.DeleteUnreusedFiles(
+		ctx, execCfg, &mem, collectionURI, subdir, execCfg.DistSQLSrv.ExternalStorageFromURI, planHook.User(),
+	)
+}
+
+func init() {
+	builtins.DeleteUnreusedBackupFiles = DeleteUnreusedBackupFiles
+}
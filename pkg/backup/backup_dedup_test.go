@@ -0,0 +1,136 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeduplicateFullBackups(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tempDir, tempDirCleanup := testutils.TempDir(t)
+	defer tempDirCleanup()
+	tc, db, cleanupDB := backupRestoreTestSetupEmpty(
+		t, singleNode, tempDir, InitManualReplication, base.TestClusterArgs{},
+	)
+	defer cleanupDB()
+	execCfg := tc.ApplicationLayer(0).ExecutorConfig().(sql.ExecutorConfig)
+
+	db.Exec(t, "SET CLUSTER SETTING bulkio.backup.deduplicate_full_backups.enabled = true")
+	db.Exec(t, "CREATE DATABASE d")
+	db.Exec(t, "CREATE TABLE d.unchanged (a INT PRIMARY KEY, b STRING)")
+	db.Exec(t, "INSERT INTO d.unchanged SELECT i, repeat('x', i) FROM generate_series(1, 100) AS g(i)")
+	db.Exec(t, "CREATE TABLE d.changed (a INT PRIMARY KEY, b INT)")
+	db.Exec(t, "INSERT INTO d.changed SELECT i, i FROM generate_series(1, 100) AS g(i)")
+
+	const collectionURI = "nodelocal://1/dedup"
+	backup := func() string {
+		db.Exec(t, fmt.Sprintf("BACKUP DATABASE d INTO '%s'", collectionURI))
+		var subdir string
+		db.QueryRow(t, fmt.Sprintf("SELECT path FROM [SHOW BACKUPS IN '%s'] ORDER BY path DESC LIMIT 1", collectionURI)).Scan(&subdir)
+		return subdir
+	}
+	// filesBySubdir returns the number of files of the latest backup stored in
+	// the directory of each full backup.
+	filesBySubdir := func(subdirs ...string) []int {
+		counts := make([]int, len(subdirs))
+		rows := db.QueryStr(t, fmt.Sprintf("SELECT path FROM [SHOW BACKUP FILES FROM LATEST IN '%s']", collectionURI))
+		for _, row := range rows {
+			for i, subdir := range subdirs {
+				if strings.HasPrefix(row[0], subdir+"/") {
+					counts[i]++
+				}
+			}
+		}
+		return counts
+	}
+	restoreAndCheck := func(newDBName string) {
+		db.Exec(t, fmt.Sprintf("RESTORE DATABASE d FROM LATEST IN '%s' WITH new_db_name = %s", collectionURI, newDBName))
+		for _, table := range []string{"unchanged", "changed"} {
+			db.CheckQueryResults(t,
+				fmt.Sprintf("SELECT * FROM %s.%s ORDER BY a", newDBName, table),
+				db.QueryStr(t, fmt.Sprintf("SELECT * FROM d.%s ORDER BY a", table)),
+			)
+		}
+	}
+
+	first := backup()
+	require.Equal(t, []int{len(db.QueryStr(t, fmt.Sprintf("SHOW BACKUP FILES FROM LATEST IN '%s'", collectionURI)))},
+		filesBySubdir(first))
+
+	// The second full backup reuses the files of the first for the unchanged
+	// table, and only writes new files for the changed one.
+	db.Exec(t, "UPDATE d.changed SET b = b + 1 WHERE a > 50")
+	second := backup()
+	require.NotEqual(t, first, second)
+	counts := filesBySubdir(first, second)
+	require.NotZero(t, counts[0])
+	require.NotZero(t, counts[1])
+	restoreAndCheck("d2")
+
+	// The files of the first backup that the second one reuses are retained
+	// when the first one is deleted, and the second backup is still
+	// restorable afterwards.
+	mem := execCfg.RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+	reused, err := backupdest.ListReusedFiles(
+		ctx, &execCfg, &mem, collectionURI, first, execCfg.DistSQLSrv.ExternalStorageFromURI, username.RootUserName(),
+	)
+	require.NoError(t, err)
+	require.Len(t, reused, counts[0])
+	deleteUnreused := func(subdir string) int {
+		var deleted int
+		db.QueryRow(t, "SELECT crdb_internal.delete_unreused_backup_files($1, $2)",
+			collectionURI, subdir).Scan(&deleted)
+		return deleted
+	}
+	require.NotZero(t, deleteUnreused(first))
+	restoreAndCheck("d3")
+	// The files of the latest full backup may be reused by a backup that is
+	// still running, so they cannot be deleted.
+	db.ExpectErr(t, "latest full backup in the collection",
+		"SELECT crdb_internal.delete_unreused_backup_files($1, 'LATEST')", collectionURI)
+
+	// A third full backup of unchanged data reuses the files of the unchanged
+	// table from the first backup, and those of the changed table from the
+	// second.
+	third := backup()
+	require.Equal(t, []int{counts[0], counts[1], 0}, filesBySubdir(first, second, third))
+	restoreAndCheck("d4")
+
+	// Without the setting, a full backup writes all of its files again.
+	db.Exec(t, "SET CLUSTER SETTING bulkio.backup.deduplicate_full_backups.enabled = false")
+	fourth := backup()
+	counts = filesBySubdir(first, second, third, fourth)
+	require.Equal(t, []int{0, 0, 0}, counts[:3])
+	require.NotZero(t, counts[3])
+
+	// The third backup reuses files of the second, which are retained when the
+	// second one is deleted.
+	require.NotZero(t, deleteUnreused(second))
+	db.Exec(t, fmt.Sprintf("RESTORE DATABASE d FROM '%s' IN '%s' WITH new_db_name = d5", third, collectionURI))
+	for _, table := range []string{"unchanged", "changed"} {
+		db.CheckQueryResults(t,
+			fmt.Sprintf("SELECT * FROM d5.%s ORDER BY a", table),
+			db.QueryStr(t, fmt.Sprintf("SELECT * FROM d.%s ORDER BY a", table)),
+		)
+	}
+}
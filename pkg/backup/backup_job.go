@@ -85,6 +85,16 @@ var useBulkOracle = settings.RegisterBoolSetting(
 	"randomize the selection of which replica backs up each range",
 	true)
 
+var recordTableFingerprints = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"bulkio.backup.record_table_fingerprints.enabled",
+	"if enabled, backups record a fingerprint of each table they back up as of their end "+
+		"time, which VERIFY BACKUP compares against the fingerprint of the restored table; "+
+		"each table is fingerprinted in full, one after the other, after the backup has "+
+		"exported its data, even for incremental backups, so this can add a full scan of "+
+		"every backed up table to the duration of each backup",
+	false)
+
 func countRows(raw kvpb.BulkOpSummary, pkIDs map[uint64]bool) roachpb.RowCount {
 	res := roachpb.RowCount{DataSize: raw.DataSize}
 	for id, count := range raw.EntryCounts {
@@ -174,6 +184,14 @@ func backup(
 		return roachpb.RowCount{}, 0, errors.Wrap(err, "failed to determine nodes on which to run")
 	}
 
+	// Full backups that deduplicate their files may reuse the files of the
+	// previous full backup in the collection for spans that are unchanged.
+	var reusableSpans []execinfrapb.ReusableBackupSpan
+	fingerprintSpans := shouldDeduplicateFiles(&settings.SV, details, backupManifest)
+	if fingerprintSpans {
+		reusableSpans = loadReusableBackupSpans(ctx, execCtx, details, backupManifest)
+	}
+
 	job := resumer.job
 	backupSpecs, err := distBackupPlanSpecs(
 		ctx,
@@ -193,6 +211,8 @@ func backup(
 		backupManifest.EndTime,
 		backupManifest.ElidedPrefix,
 		backupManifest.ClusterVersion.AtLeast(clusterversion.V24_1.Version()),
+		fingerprintSpans,
+		reusableSpans,
 	)
 	if err != nil {
 		return roachpb.RowCount{}, 0, err
@@ -380,6 +400,14 @@ func backup(
 		}
 	}
 
+	if recordTableFingerprints.Get(&settings.SV) {
+		backupManifest.TableFingerprints = getTableFingerprintsForBackup(
+			ctx, execCtx.ExecCfg().InternalDB.Executor(), backupManifest,
+		)
+		if testingKnobs != nil && testingKnobs.AfterTableFingerprints != nil {
+			testingKnobs.AfterTableFingerprints(backupManifest.TableFingerprints)
+		}
+	}
 	statsTable := getTableStatsForBackup(ctx, execCtx.ExecCfg().InternalDB.Executor(), backupManifest.Descriptors)
 	if err := backupinfo.WriteBackupMetadata(ctx, execCtx, defaultStore, details, &kmsEnv, backupManifest, statsTable); err != nil {
 		return roachpb.RowCount{}, 0, err
@@ -440,6 +468,52 @@ func getTableStatsForBackup(
 	}
 }
 
+// getTableFingerprintsForBackup fingerprints the data of each table in the
+// backup that VERIFY BACKUP can verify, as of the end time of the backup. The
+// fingerprints strip the table and index prefixes and the timestamps of the
+// keys, so that they match the fingerprints of the tables once restored.
+//
+// Like table statistics, we do not fail the backup if a table cannot be
+// fingerprinted; VERIFY BACKUP reports the tables without a fingerprint.
+//
+// The tables are fingerprinted serially and in full, regardless of whether
+// the backup is incremental, since VERIFY BACKUP compares the fingerprint
+// against the table restored from the whole chain. This is a scan of every
+// backed up table, which is the cost of enabling
+// bulkio.backup.record_table_fingerprints.enabled.
+func getTableFingerprintsForBackup(
+	ctx context.Context, executor isql.Executor, backupManifest *backuppb.BackupManifest,
+) []backuppb.BackupManifest_TableFingerprint {
+	var fingerprints []backuppb.BackupManifest_TableFingerprint
+	for i := range backupManifest.Descriptors {
+		tbl, _, _, _, _ := descpb.GetDescriptors(&backupManifest.Descriptors[i])
+		if tbl == nil || !isVerifiableTable(tbl) {
+			continue
+		}
+		row, err := executor.QueryRowEx(
+			ctx, "backup-table-fingerprint", nil, /* txn */
+			sessiondata.NodeUserSessionDataOverride,
+			fmt.Sprintf(
+				`SELECT crdb_internal.fingerprint(crdb_internal.table_span($1), true) AS OF SYSTEM TIME %s`,
+				backupManifest.EndTime.AsOfSystemTime(),
+			),
+			tbl.ID,
+		)
+		if err != nil {
+			log.Dev.Warningf(
+				ctx, "failed to fingerprint table: %s, table ID: %d during a backup: %s",
+				tbl.Name, tbl.ID, err,
+			)
+			continue
+		}
+		fingerprints = append(fingerprints, backuppb.BackupManifest_TableFingerprint{
+			TableID:     tbl.ID,
+			Fingerprint: uint64(tree.MustBeDInt(row[0])),
+		})
+	}
+	return fingerprints
+}
+
 func statShouldBeIncludedInBackupRestore(stat *stats.TableStatisticProto) bool {
 	// Forecasts and merged stats are computed from the persisted
 	// stats on demand and do not need to be backed up or
@@ -501,6 +575,9 @@ func (b *backupResumer) Resume(ctx context.Context, execCtx interface{}) error {
 	if initialDetails.Compact {
 		return b.ResumeCompaction(ctx, initialDetails, p, &kmsEnv)
 	}
+	if initialDetails.Continuous {
+		return b.ResumeContinuous(ctx, initialDetails, p, &kmsEnv)
+	}
 	// Resolve the backup destination. We can skip this step if we
 	// have already resolved and persisted the destination either
 	// during a previous resumption of this job.
@@ -1952,7 +2029,11 @@ func getCompletedSpans(
 		}
 
 		f := it.Value()
-		if f.StartTime.IsEmpty() && !f.EndTime.IsEmpty() {
+		if f.ReusedFrom != "" {
+			// A reused file completes the whole span it was reused for, even where
+			// it has no keys.
+			completedSpans = append(completedSpans, f.FingerprintSpan)
+		} else if f.StartTime.IsEmpty() && !f.EndTime.IsEmpty() {
 			completedIntroducedSpans = append(completedIntroducedSpans, f.Span)
 		} else {
 			completedSpans = append(completedSpans, f.Span)
@@ -290,6 +290,17 @@ type spanAndTime struct {
 	attempts     int
 	lastTried    time.Time
 	finishesSpec bool
+
+	// fingerprintSpan is set if the files exported for this span are tagged
+	// with its fingerprint, and is the span as it was before any resumes.
+	fingerprintSpan roachpb.Span
+	// fingerprinted is set once fingerprint holds the fingerprint of
+	// fingerprintSpan.
+	fingerprinted bool
+	fingerprint   uint64
+	// reusable, if set, are the files of an earlier backup for the same span
+	// that are reused instead of exporting the span if it is unchanged.
+	reusable *execinfrapb.ReusableBackupSpan
 }
 
 type errInjectingStorage struct {
@@ -314,43 +325,85 @@ func runBackupProcessor(
 	requestSpans := make([]spanAndTime, 0, totalSpans)
 	rangeSizedSpans := preSplitExports.Get(&flowCtx.Cfg.Settings.SV)
 
-	splitSpans := func(spans []roachpb.Span, start, end hlc.Timestamp) error {
-		for _, fullSpan := range spans {
-			remainingSpan := fullSpan
+	// appendRequestSpan appends a request span, which is also the span that its
+	// files are fingerprinted for if the backup fingerprints its spans.
+	appendRequestSpan := func(span roachpb.Span, start, end hlc.Timestamp, fingerprint bool) {
+		requestSpan := spanAndTime{span: span, start: start, end: end}
+		if fingerprint {
+			requestSpan.fingerprintSpan = span
+		}
+		requestSpans = append(requestSpans, requestSpan)
+	}
 
-			if rangeSizedSpans {
-				const pageSize = 100
-				rdi, err := flowCtx.Cfg.ExecutorConfig.(*sql.ExecutorConfig).RangeDescIteratorFactory.NewLazyIterator(ctx, fullSpan, pageSize)
-				if err != nil {
-					return err
+	splitSpan := func(fullSpan roachpb.Span, start, end hlc.Timestamp, fingerprint bool) error {
+		remainingSpan := fullSpan
+
+		if rangeSizedSpans {
+			const pageSize = 100
+			rdi, err := flowCtx.Cfg.ExecutorConfig.(*sql.ExecutorConfig).RangeDescIteratorFactory.NewLazyIterator(ctx, fullSpan, pageSize)
+			if err != nil {
+				return err
+			}
+			for ; rdi.Valid(); rdi.Next() {
+				rangeDesc := rdi.CurRangeDescriptor()
+				rangeSpan := roachpb.Span{Key: rangeDesc.StartKey.AsRawKey(), EndKey: rangeDesc.EndKey.AsRawKey()}
+				subspan := remainingSpan.Intersect(rangeSpan)
+				if !subspan.Valid() {
+					return errors.AssertionFailedf("%s not in %s of %s", rangeSpan, remainingSpan, fullSpan)
 				}
-				for ; rdi.Valid(); rdi.Next() {
-					rangeDesc := rdi.CurRangeDescriptor()
-					rangeSpan := roachpb.Span{Key: rangeDesc.StartKey.AsRawKey(), EndKey: rangeDesc.EndKey.AsRawKey()}
-					subspan := remainingSpan.Intersect(rangeSpan)
-					if !subspan.Valid() {
-						return errors.AssertionFailedf("%s not in %s of %s", rangeSpan, remainingSpan, fullSpan)
+				appendRequestSpan(subspan, start, end, fingerprint)
+				remainingSpan.Key = subspan.EndKey
+			}
+			if err := rdi.Error(); err != nil {
+				return err
+			}
+		}
+
+		if remainingSpan.Valid() {
+			appendRequestSpan(remainingSpan, start, end, fingerprint)
+		}
+		return nil
+	}
+
+	splitSpans := func(spans []roachpb.Span, start, end hlc.Timestamp, fingerprint bool) error {
+		// reusable is the remainder of the spec's reusable spans, which are
+		// sorted, as are spans.
+		reusable := spec.ReusableSpans
+		for _, fullSpan := range spans {
+			remainingSpan := fullSpan
+			if fingerprint {
+				// A span that may be reused is requested on its own, regardless of
+				// how it is split into ranges today, so that the files exported for
+				// it can be reused again by later backups if it does not change.
+				for ; len(reusable) > 0 && reusable[0].Span.Key.Compare(fullSpan.EndKey) < 0; reusable = reusable[1:] {
+					r := &reusable[0]
+					if !remainingSpan.Contains(r.Span) {
+						continue
 					}
-					requestSpans = append(requestSpans, spanAndTime{span: subspan, start: start, end: end})
-					remainingSpan.Key = subspan.EndKey
-				}
-				if err := rdi.Error(); err != nil {
-					return err
+					if before := (roachpb.Span{Key: remainingSpan.Key, EndKey: r.Span.Key}); before.Valid() {
+						if err := splitSpan(before, start, end, fingerprint); err != nil {
+							return err
+						}
+					}
+					appendRequestSpan(r.Span, start, end, fingerprint)
+					requestSpans[len(requestSpans)-1].reusable = r
+					remainingSpan.Key = r.Span.EndKey
 				}
 			}
-
 			if remainingSpan.Valid() {
-				requestSpans = append(requestSpans, spanAndTime{span: remainingSpan, start: start, end: end})
+				if err := splitSpan(remainingSpan, start, end, fingerprint); err != nil {
+					return err
+				}
 			}
 			requestSpans[len(requestSpans)-1].finishesSpec = true
 		}
 		return nil
 	}
 
-	if err := splitSpans(spec.IntroducedSpans, hlc.Timestamp{}, spec.BackupStartTime); err != nil {
+	if err := splitSpans(spec.IntroducedSpans, hlc.Timestamp{}, spec.BackupStartTime, false /* fingerprint */); err != nil {
 		return err
 	}
-	if err := splitSpans(spec.Spans, spec.BackupStartTime, spec.BackupEndTime); err != nil {
+	if err := splitSpans(spec.Spans, spec.BackupStartTime, spec.BackupEndTime, spec.FingerprintSpans); err != nil {
 		return err
 	}
 
@@ -482,6 +535,30 @@ func runBackupProcessor(
 				return ctx.Err()
 			case spans := <-todo:
 				for _, span := range spans {
+					if span.fingerprintSpan.Valid() && !span.fingerprinted {
+						// Fingerprint the span before exporting it, to tag the files
+						// exported for it or to skip exporting it altogether if the
+						// files of an earlier backup for it can be reused. Failing to
+						// fingerprint it just means its files are not tagged.
+						fingerprint, ok, err := fingerprintBackupSpan(
+							ctx, flowCtx.Cfg.DB.KV().NonTransactionalSender(), span, spec.MVCCFilter,
+							timeoutPerAttempt.Get(&clusterSettings.SV),
+						)
+						if err != nil {
+							return err
+						}
+						if !ok {
+							span.fingerprintSpan = roachpb.Span{}
+						}
+						span.fingerprinted, span.fingerprint = true, fingerprint
+						if ok && span.reusable != nil && span.reusable.Fingerprint == fingerprint {
+							if err := writeReusedFiles(ctx, sink, span); err != nil {
+								return err
+							}
+							continue
+						}
+					}
+
 					resumed := false
 					for len(span.span.Key) != 0 {
 						req := &kvpb.ExportRequest{
@@ -688,6 +765,10 @@ func runBackupProcessor(
 								ret.Metadata.StartTime = span.start
 								ret.Metadata.EndTime = span.end
 							}
+							if span.fingerprintSpan.Valid() {
+								ret.Metadata.FingerprintSpan = span.fingerprintSpan
+								ret.Metadata.Fingerprint = span.fingerprint
+							}
 							// If multiple files were returned for this span, only one -- the
 							// last -- should count as completing the requested span.
 							if i == len(resp.Files)-1 {
@@ -701,6 +782,13 @@ func runBackupProcessor(
 								return writeErr
 							}
 						}
+						// The files of a fingerprinted span are not shared with any other
+						// span, so that they can be reused on their own.
+						if span.fingerprintSpan.Valid() && resp.ResumeSpan == nil {
+							if err := sink.Flush(ctx); err != nil {
+								return err
+							}
+						}
 						// Emit the stats for the processed ExportRequest.
 						recordExportStats(backupProcessorSpan, resp, requestSentAt)
 						span = resumeSpan
@@ -44,6 +44,8 @@ func distBackupPlanSpecs(
 	startTime, endTime hlc.Timestamp,
 	elide execinfrapb.ElidePrefix,
 	includeValueHeader bool,
+	fingerprintSpans bool,
+	reusableSpans []execinfrapb.ReusableBackupSpan,
 ) (map[base.SQLInstanceID]*execinfrapb.BackupDataSpec, error) {
 	var span *tracing.Span
 	ctx, span = tracing.ChildSpan(ctx, "backup.distBackupPlanSpecs")
@@ -108,6 +110,10 @@ func distBackupPlanSpecs(
 			UserProto:              user.EncodeProto(),
 			ElidePrefix:            elide,
 			IncludeMVCCValueHeader: includeValueHeader,
+			FingerprintSpans:       fingerprintSpans,
+		}
+		if fingerprintSpans {
+			spec.ReusableSpans = reusableSpansWithin(reusableSpans, partition.Spans)
 		}
 		sqlInstanceIDToSpec[partition.SQLInstanceID] = spec
 	}
@@ -532,6 +532,68 @@ func ResolveBackupManifests(
 	localityInfo []jobspb.RestoreDetails_BackupLocalityInfo,
 	reservedMemSize int64,
 	_ error,
+) {
+	defaultURIs, mainBackupManifests, localityInfo, reservedMemSize, err := resolveBackupManifests(
+		ctx, execCfg, mem, defaultCollectionURI, collectionURIs, mkStore, resolvedSubdir,
+		fullyResolvedBaseDirectory, fullyResolvedIncrementalsDirectory, endTime, encryption,
+		kmsEnv, user, includeSkipped, includeCompacted, isCustomIncLocation,
+	)
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+	if err := addReusedFromURIs(defaultCollectionURI, mainBackupManifests, localityInfo); err != nil {
+		mem.Shrink(ctx, reservedMemSize)
+		return nil, nil, nil, 0, err
+	}
+	return defaultURIs, mainBackupManifests, localityInfo, reservedMemSize, nil
+}
+
+// addReusedFromURIs records the URI of each earlier full backup in the
+// collection whose files are reused by one of the given backups in the
+// locality info of that backup.
+func addReusedFromURIs(
+	defaultCollectionURI string,
+	manifests []backuppb.BackupManifest,
+	localityInfo []jobspb.RestoreDetails_BackupLocalityInfo,
+) error {
+	for i := range manifests {
+		for _, subdir := range manifests[i].ReusedFromSubdirs {
+			uri, err := backuputils.AppendPath(defaultCollectionURI, subdir)
+			if err != nil {
+				return err
+			}
+			if localityInfo[i].URIsByReusedFrom == nil {
+				localityInfo[i].URIsByReusedFrom = make(map[string]string)
+			}
+			localityInfo[i].URIsByReusedFrom[subdir] = uri
+		}
+	}
+	return nil
+}
+
+func resolveBackupManifests(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	mem *mon.BoundAccount,
+	defaultCollectionURI string,
+	collectionURIs []string,
+	mkStore cloud.ExternalStorageFromURIFactory,
+	resolvedSubdir string,
+	fullyResolvedBaseDirectory []string,
+	fullyResolvedIncrementalsDirectory []string,
+	endTime hlc.Timestamp,
+	encryption *jobspb.BackupEncryptionOptions,
+	kmsEnv cloud.KMSEnv,
+	user username.SQLUsername,
+	includeSkipped bool,
+	includeCompacted bool,
+	isCustomIncLocation bool,
+) (
+	defaultURIs []string,
+	mainBackupManifests []backuppb.BackupManifest,
+	localityInfo []jobspb.RestoreDetails_BackupLocalityInfo,
+	reservedMemSize int64,
+	_ error,
 ) {
 	rootStore, err := mkStore(ctx, defaultCollectionURI, user)
 	if err != nil {
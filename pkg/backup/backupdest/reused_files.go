@@ -0,0 +1,194 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backupdest
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupbase"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupencryption"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+)
+
+// ListReusedFiles returns the paths of the data files of the full backup at
+// subdir in the collection that are reused by other full backups in the same
+// collection. These files are referenced by the manifests of those backups, so
+// they must be retained when the backup at subdir is deleted.
+//
+// Every other full backup in the collection is inspected, rather than only the
+// later ones, so that a file is retained as long as any manifest references
+// it. A reused file always records the subdirectory that stores it, even when
+// it is reused from a backup which itself reused it, so a single pass over the
+// manifests finds every reference. A manifest that cannot be read fails the
+// listing rather than being skipped.
+//
+// Backups only reuse the files of earlier backups that are not encrypted, and
+// are themselves not encrypted, so encrypted backups are not inspected.
+func ListReusedFiles(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	mem *mon.BoundAccount,
+	collectionURI string,
+	subdir string,
+	mkStore cloud.ExternalStorageFromURIFactory,
+	user username.SQLUsername,
+) (map[string]struct{}, error) {
+	subdir, err := backuputils.NormalizeSubdir(subdir)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := mkStore(ctx, collectionURI, user)
+	if err != nil {
+		return nil, err
+	}
+	defer collection.Close()
+	fulls, err := ListFullBackupsInCollection(
+		ctx, collection, backupinfo.ReadBackupIndexEnabled.Get(&execCfg.Settings.SV),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	reused := make(map[string]struct{})
+	for _, full := range fulls {
+		full, err := backuputils.NormalizeSubdir(full)
+		if err != nil {
+			return nil, err
+		}
+		if full == subdir {
+			continue
+		}
+		if err := func() error {
+			uri, err := backuputils.AppendPath(collectionURI, full)
+			if err != nil {
+				return err
+			}
+			store, err := mkStore(ctx, uri, user)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+			// GetEncryptionInfoFiles returns an error if the backup is not
+			// encrypted.
+			if encryptionInfo, err := backupencryption.GetEncryptionInfoFiles(ctx, store); err == nil &&
+				len(encryptionInfo) > 0 {
+				return nil
+			}
+
+			manifest, memSize, err := backupinfo.ReadBackupManifestFromStore(
+				ctx, mem, store, uri, nil /* encryption */, nil, /* kmsEnv */
+			)
+			if err != nil {
+				return errors.Wrapf(err, "reading manifest of full backup %s", full)
+			}
+			defer mem.Shrink(ctx, memSize)
+			var reusesSubdir bool
+			for _, s := range manifest.ReusedFromSubdirs {
+				reusesSubdir = reusesSubdir || s == subdir
+			}
+			if !reusesSubdir {
+				return nil
+			}
+
+			it, err := backupinfo.NewIterFactory(&manifest, store, nil /* encryption */, nil /* kmsEnv */).NewFileIter(ctx)
+			if err != nil {
+				return err
+			}
+			defer it.Close()
+			for ; ; it.Next() {
+				if ok, err := it.Valid(); err != nil {
+					return err
+				} else if !ok {
+					break
+				}
+				if f := it.Value(); f.ReusedFrom == subdir {
+					reused[f.Path] = struct{}{}
+				}
+			}
+			return nil
+		}(); err != nil {
+			return nil, err
+		}
+	}
+	return reused, nil
+}
+
+// DeleteUnreusedFiles deletes the data files of the full backup at subdir in
+// the collection that are not reused by any other full backup in the
+// collection, and returns the number of files it deleted. It is meant to be
+// used when deleting the backup, and leaves the backup's metadata in place for
+// the caller to delete, so that the backup remains discoverable until its data
+// is gone.
+//
+// The latest full backup in the collection cannot be deleted, since a full
+// backup that is still running, and therefore has no manifest yet, may reuse
+// its files.
+func DeleteUnreusedFiles(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	mem *mon.BoundAccount,
+	collectionURI string,
+	subdir string,
+	mkStore cloud.ExternalStorageFromURIFactory,
+	user username.SQLUsername,
+) (int, error) {
+	subdir, err := backuputils.NormalizeSubdir(subdir)
+	if err != nil {
+		return 0, err
+	}
+	latest, err := ReadLatestFile(ctx, collectionURI, mkStore, user)
+	if err != nil {
+		return 0, err
+	}
+	if latest, err = backuputils.NormalizeSubdir(latest); err != nil {
+		return 0, err
+	} else if latest == subdir {
+		return 0, pgerror.Newf(pgcode.ObjectInUse,
+			"cannot delete the files of %s, the latest full backup in the collection", subdir)
+	}
+	reused, err := ListReusedFiles(ctx, execCfg, mem, collectionURI, subdir, mkStore, user)
+	if err != nil {
+		return 0, err
+	}
+	uri, err := backuputils.AppendPath(collectionURI, subdir)
+	if err != nil {
+		return 0, err
+	}
+	store, err := mkStore(ctx, uri, user)
+	if err != nil {
+		return 0, err
+	}
+	defer store.Close()
+
+	var unreused []string
+	if err := store.List(ctx, backupbase.ListingDelimDataSlash, "", func(name string) error {
+		name = backupbase.ListingDelimDataSlash + strings.TrimPrefix(name, "/")
+		if _, ok := reused[name]; !ok {
+			unreused = append(unreused, name)
+		}
+		return nil
+	}); err != nil {
+		return 0, errors.Wrapf(err, "listing data files of full backup %s", subdir)
+	}
+	for _, name := range unreused {
+		if err := store.Delete(ctx, name); err != nil {
+			return 0, errors.Wrapf(err, "deleting %s", name)
+		}
+	}
+	log.Dev.Infof(ctx, "deleted %d data files of full backup %s, retained %d reused by later backups",
+		len(unreused), subdir, len(reused))
+	return len(unreused), nil
+}
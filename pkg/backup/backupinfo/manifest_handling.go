@@ -1782,6 +1782,19 @@ func ConstructDateBasedIncrementalFolderName(start, end time.Time) string {
 	)
 }
 
+// reusedFromSubdirs returns the sorted subdirectories of the earlier full
+// backups that the given files are reused from.
+func reusedFromSubdirs(files []backuppb.BackupManifest_File) []string {
+	var subdirs []string
+	for i := range files {
+		if files[i].ReusedFrom != "" {
+			subdirs = append(subdirs, files[i].ReusedFrom)
+		}
+	}
+	sort.Strings(subdirs)
+	return slices.Compact(subdirs)
+}
+
 // WriteBackupMetadata writes the manifest, backup index, and statistics to
 // external storage.
 func WriteBackupMetadata(
@@ -1795,6 +1808,7 @@ func WriteBackupMetadata(
 ) error {
 	backupID := uuid.MakeV4()
 	backupManifest.ID = backupID
+	backupManifest.ReusedFromSubdirs = reusedFromSubdirs(backupManifest.Files)
 
 	// Write additional partial descriptors to each node for partitioned backups.
 	//
@@ -223,6 +223,33 @@ func (s *FileSSTSink) WriteWithNoData(resp ExportedSpan) {
 	s.midRow = false
 }
 
+// WriteReused reports files written by an earlier backup that are reused in
+// place of exporting their span again. Any files buffered by the sink are
+// flushed first.
+func (s *FileSSTSink) WriteReused(
+	ctx context.Context, files []backuppb.BackupManifest_File, completedSpans int32,
+) error {
+	if err := s.Flush(ctx); err != nil {
+		return err
+	}
+	progDetails := backuppb.BackupManifest_Progress{
+		Files:          files,
+		CompletedSpans: completedSpans,
+	}
+	var prog execinfrapb.RemoteProducerMetadata_BulkProcessorProgress
+	details, err := gogotypes.MarshalAny(&progDetails)
+	if err != nil {
+		return err
+	}
+	prog.ProgressDetails = *details
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case s.conf.ProgCh <- prog:
+	}
+	return nil
+}
+
 func (s *FileSSTSink) Close() error {
 	if log.V(1) && s.ctx != nil {
 		log.Dev.Infof(s.ctx, "backup sst sink recv'd %d files, wrote %d (%d due to size, %d due to re-ordering), %d recv files extended prior span",
@@ -123,6 +123,45 @@ func TestFileSSTSinkExtendOneFile(t *testing.T) {
 	require.Equal(t, 1, len(progDetails.Files))
 }
 
+// TestFileSSTSinkWriteReused tests that reused files are reported after the
+// files buffered in the sink are flushed.
+func TestFileSSTSinkWriteReused(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	sink, _ := fileSSTSinkTestSetup(t, st, execinfrapb.ElidePrefix_None)
+	defer func() { require.NoError(t, sink.Close()) }()
+
+	es := newExportedSpanBuilder("a", "c").withKVs([]kvAndTS{{key: "a", timestamp: 10}, {key: "b", timestamp: 10}}).build()
+	_, err := sink.Write(ctx, es)
+	require.NoError(t, err)
+
+	reused := backuppb.BackupManifest_File{
+		Span:            roachpb.Span{Key: s2k("c"), EndKey: s2k("e")},
+		Path:            "data/1.sst",
+		FingerprintSpan: roachpb.Span{Key: s2k("c"), EndKey: s2k("e")},
+		Fingerprint:     42,
+		ReusedFrom:      "/2026/10/11-120000.00",
+	}
+	require.NoError(t, sink.WriteReused(ctx, []backuppb.BackupManifest_File{reused}, 1 /* completedSpans */))
+
+	close(sink.conf.ProgCh)
+	var progs []backuppb.BackupManifest_Progress
+	for p := range sink.conf.ProgCh {
+		var progDetails backuppb.BackupManifest_Progress
+		require.NoError(t, types.UnmarshalAny(&p.ProgressDetails, &progDetails))
+		progs = append(progs, progDetails)
+	}
+	require.Len(t, progs, 2)
+	require.Len(t, progs[0].Files, 1)
+	require.Equal(t, roachpb.Span{Key: s2k0("a"), EndKey: s2k0("c")}, progs[0].Files[0].Span)
+	require.Empty(t, progs[0].Files[0].ReusedFrom)
+	require.Equal(t, []backuppb.BackupManifest_File{reused}, progs[1].Files)
+	require.Equal(t, int32(1), progs[1].CompletedSpans)
+}
+
 func randomValue(n int64) []byte {
 	// Create random data so that it does not compress well.
 	b := make([]byte, n)
@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
 )
@@ -25,6 +26,9 @@ type SSTSinkKeyWriter struct {
 	// key, this helps determine if the last key written was mid-row. This resets
 	// on each flush.
 	prevKey roachpb.Key
+	// prevTimestamp is the timestamp of the last key written using WriteKey,
+	// which orders multiple revisions of prevKey.
+	prevTimestamp hlc.Timestamp
 
 	// Caching the targetFileSize from the cluster settings to avoid multiple
 	// lookups during writes.
@@ -43,7 +47,9 @@ func MakeSSTSinkKeyWriter(conf SSTSinkConf, dest cloud.ExternalStorage) (*SSTSin
 
 // WriteKey writes a single key to the SST file. The key should be the full key,
 // including the prefix. Reset needs to be called prior to WriteKey whenever
-// writing keys from a new span. Keys must also be written in order.
+// writing keys from a new span. Keys must also be written in order. Multiple
+// revisions of a key may be written, newest first, as they are ordered in an
+// MVCC iterator.
 //
 // Once a key has been written, the caller may safely reuse the underlying
 // memory for the passed in key.
@@ -65,8 +71,13 @@ func (s *SSTSinkKeyWriter) WriteKey(ctx context.Context, key storage.MVCCKey, va
 			s.flushedFiles[len(s.flushedFiles)-1].Span,
 		)
 	}
-	if s.prevKey != nil && s.prevKey.Compare(key.Key) >= 0 {
-		return errors.AssertionFailedf("key %s must be greater than previous key %s", key.Key, s.prevKey)
+	if s.prevKey != nil {
+		if c := s.prevKey.Compare(key.Key); c > 0 || (c == 0 && !key.Timestamp.Less(s.prevTimestamp)) {
+			return errors.AssertionFailedf(
+				"key %s must be greater than previous key %s",
+				key, storage.MVCCKey{Key: s.prevKey, Timestamp: s.prevTimestamp},
+			)
+		}
 	}
 	// At this point, because of the Reset invariant, we can make the following
 	// assumptions about the new key:
@@ -95,6 +106,7 @@ func (s *SSTSinkKeyWriter) WriteKey(ctx context.Context, key storage.MVCCKey, va
 	s.flushedFiles[len(s.flushedFiles)-1].EntryCounts.Add(keyAsRowCount)
 	s.flushedSize += keyAsRowCount.DataSize
 	s.prevKey = append(s.prevKey[:0], key.Key...)
+	s.prevTimestamp = key.Timestamp
 	// Until the next key is written, we cannot determine if this key is mid-row.
 	// As a safeguard, we assume it is mid-row, and if it is the last key to be
 	// written in this span, the caller has the responsibility of explicitly
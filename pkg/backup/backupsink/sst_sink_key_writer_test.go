@@ -58,6 +58,18 @@ func TestFileSSTSinkWriteKey(t *testing.T) {
 				{{Key: s2k0("a"), EndKey: s2k0("c")}},
 			},
 		},
+		// Multiple revisions of a key may be written, newest first.
+		{
+			name: "single-span-revisions",
+			exportKVs: []*mvccKVSet{
+				newMVCCKeySet("a", "c").withKVs([]kvAndTS{
+					{key: "a", timestamp: 15}, {key: "a", timestamp: 10}, {key: "b", timestamp: 10},
+				}),
+			},
+			unflushedSpans: []roachpb.Spans{
+				{{Key: s2k0("a"), EndKey: s2k0("c")}},
+			},
+		},
 		// Flushes do not occur if the last key written exceeds the file size. The caller is
 		// required to make the last flush call.
 		{
@@ -408,6 +420,22 @@ func TestFileSSTSinkWriteKeyBadKeyFails(t *testing.T) {
 		require.NoError(t, sink.Flush(ctx))
 	})
 
+	t.Run("ooo-revision", func(t *testing.T) {
+		keySet := newMVCCKeySet("a", "c").withKVs([]kvAndTS{
+			{key: "a", timestamp: 10}, {key: "a", timestamp: 15},
+		})
+
+		require.NoError(t, sink.Reset(ctx, keySet.span))
+		require.NoError(t, sink.WriteKey(ctx, keySet.kvs[0].key, keySet.kvs[0].value))
+		require.ErrorContains(
+			t,
+			sink.WriteKey(ctx, keySet.kvs[1].key, keySet.kvs[1].value),
+			"must be greater than previous key",
+		)
+		sink.AssumeNotMidRow()
+		require.NoError(t, sink.Flush(ctx))
+	})
+
 	t.Run("key-outside-of-span", func(t *testing.T) {
 		keySet := newMVCCKeySet("a", "c").withKVs([]kvAndTS{
 			{key: "d", timestamp: 10},
@@ -0,0 +1,321 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/bulk"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/externalcatalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/externalcatalog/externalpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+type cloneTableResumer struct {
+	job *jobs.Job
+
+	// rows and bytes are the totals ingested by a successful run, which are
+	// reported to the client.
+	rows, bytes int64
+}
+
+var _ jobs.Resumer = &cloneTableResumer{}
+var _ jobs.JobResultsReporter = &cloneTableResumer{}
+
+// Resume is part of the jobs.Resumer interface.
+//
+// The job reads the latest revision of every key of the source table as of
+// the clone time, one range at a time, rewrites the keys into the new table,
+// and ingests them as SSTs. The resume key is checkpointed after each range,
+// so that a resumed job only re-reads the range it was copying. Once every
+// range has been copied, the new table is brought online and the protected
+// timestamp on the source table is released.
+func (r *cloneTableResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	p := execCtx.(sql.JobExecContext)
+	execCfg := p.ExecCfg()
+	details := r.job.Details().(jobspb.CloneTableDetails)
+	prog := r.job.Progress().Details.(*jobspb.Progress_CloneTable).CloneTable
+
+	var tbl catalog.TableDescriptor
+	if err := sql.DescsTxn(ctx, execCfg, func(ctx context.Context, txn isql.Txn, col *descs.Collection) (err error) {
+		tbl, err = col.ByIDWithoutLeased(txn.KV()).Get().Table(ctx, details.TableID)
+		return err
+	}); err != nil {
+		return err
+	}
+	kr, err := makeKeyRewriter(execCfg.Codec,
+		map[descpb.ID]catalog.TableDescriptor{details.SourceTableID: tbl}, nil, false)
+	if err != nil {
+		return err
+	}
+
+	srcSpan := execCfg.Codec.TableSpan(uint32(details.SourceTableID))
+	var rangeSpans []roachpb.Span
+	const pageSize = 100
+	rdi, err := execCfg.RangeDescIteratorFactory.NewLazyIterator(ctx, srcSpan, pageSize)
+	if err != nil {
+		return err
+	}
+	for ; rdi.Valid(); rdi.Next() {
+		rangeDesc := rdi.CurRangeDescriptor()
+		rangeSpan := roachpb.Span{Key: rangeDesc.StartKey.AsRawKey(), EndKey: rangeDesc.EndKey.AsRawKey()}
+		if sp := srcSpan.Intersect(rangeSpan); sp.Valid() {
+			rangeSpans = append(rangeSpans, sp)
+		}
+	}
+	if err := rdi.Error(); err != nil {
+		return err
+	}
+	if len(rangeSpans) == 0 {
+		rangeSpans = []roachpb.Span{srcSpan}
+	}
+
+	batcher, err := bulk.MakeSSTBatcher(ctx,
+		"clone table",
+		execCfg.DB,
+		execCfg.Settings,
+		hlc.Timestamp{}, /* disallowShadowingBelow */
+		true,            /* writeAtBatchTs */
+		false,           /* scatterSplitRanges */
+		execCfg.DistSQLSrv.BackupMonitor.MakeConcurrentBoundAccount(),
+		execCfg.DistSQLSrv.BulkSenderLimiter,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	defer batcher.Close(ctx)
+
+	pkIDs := map[uint64]bool{
+		kvpb.BulkOpSummaryID(uint64(tbl.GetID()), uint64(tbl.GetPrimaryIndexID())): true,
+	}
+	ingested := prog.Summary
+	for i, sp := range rangeSpans {
+		if prog.ResumeKey != nil {
+			if sp.EndKey.Compare(prog.ResumeKey) <= 0 {
+				continue
+			}
+			if sp.Key.Compare(prog.ResumeKey) < 0 {
+				sp.Key = prog.ResumeKey
+			}
+		}
+		if err := cloneSpan(ctx, execCfg.DB, kr, batcher, sp, details.AsOf); err != nil {
+			return errors.Wrapf(err, "cloning %s", sp)
+		}
+		if err := batcher.Flush(ctx); err != nil {
+			return err
+		}
+
+		summary := ingested.DeepCopy()
+		summary.Add(batcher.GetSummary())
+		prog.ResumeKey = sp.EndKey
+		prog.Summary = summary
+		fraction := float32(i+1) / float32(len(rangeSpans))
+		if err := r.job.NoTxn().Update(ctx, func(
+			txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+		) error {
+			if err := md.CheckRunningOrReverting(); err != nil {
+				return err
+			}
+			md.Progress.Progress = &jobspb.Progress_FractionCompleted{FractionCompleted: fraction}
+			md.Progress.Details = jobspb.WrapProgressDetails(*prog)
+			ju.UpdateProgress(md.Progress)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := sql.DescsTxn(ctx, execCfg, func(ctx context.Context, txn isql.Txn, col *descs.Collection) error {
+		mut, err := col.MutableByID(txn.KV()).Table(ctx, details.TableID)
+		if err != nil {
+			return err
+		}
+		mut.SetPublic()
+		if err := col.WriteDesc(ctx, false /* kvTrace */, mut, txn.KV()); err != nil {
+			return err
+		}
+		return releaseProtectedTimestamp(ctx,
+			execCfg.ProtectedTimestampProvider.WithTxn(txn), details.ProtectedTimestampRecord)
+	}); err != nil {
+		return errors.Wrap(err, "publishing cloned table")
+	}
+
+	rowCount := countRows(prog.Summary, pkIDs)
+	r.rows, r.bytes = rowCount.Rows, rowCount.DataSize
+	return nil
+}
+
+// cloneSpan ingests the latest revision as of asOf of every key in the given
+// span of the source table into the new table.
+func cloneSpan(
+	ctx context.Context,
+	db *kv.DB,
+	kr *KeyRewriter,
+	batcher *bulk.SSTBatcher,
+	sp roachpb.Span,
+	asOf hlc.Timestamp,
+) error {
+	header := kvpb.Header{
+		Timestamp:                   asOf,
+		ReturnElasticCPUResumeSpans: true,
+	}
+	var valueScratch []byte
+	for sp.Valid() {
+		req := &kvpb.ExportRequest{
+			RequestHeader: kvpb.RequestHeaderFromSpan(sp),
+			MVCCFilter:    kvpb.MVCCFilter_Latest,
+		}
+		resp, pErr := kv.SendWrappedWith(ctx, db.NonTransactionalSender(), header, req)
+		if pErr != nil {
+			return pErr.GoError()
+		}
+		exportResp := resp.(*kvpb.ExportResponse)
+		for _, file := range exportResp.Files {
+			if err := func() error {
+				iter, err := storage.NewMemSSTIterator(file.SST, true, storage.IterOptions{
+					KeyTypes:   storage.IterKeyTypePointsOnly,
+					LowerBound: file.Span.Key,
+					UpperBound: file.Span.EndKey,
+				})
+				if err != nil {
+					return err
+				}
+				defer iter.Close()
+				for iter.SeekGE(storage.MVCCKey{Key: file.Span.Key}); ; iter.Next() {
+					if ok, err := iter.Valid(); err != nil {
+						return err
+					} else if !ok {
+						return nil
+					}
+					key := iter.UnsafeKey().Clone()
+					v, err := iter.UnsafeValue()
+					if err != nil {
+						return err
+					}
+					valueScratch = append(valueScratch[:0], v...)
+					value, err := storage.DecodeValueFromMVCCValue(valueScratch)
+					if err != nil {
+						return err
+					}
+					var ok bool
+					key.Key, ok, err = kr.RewriteKey(key.Key, 0 /* walltimeForImportElision */)
+					if err != nil {
+						return err
+					}
+					if !ok {
+						continue
+					}
+					// Rewriting the key means the checksum needs to be updated.
+					value.ClearChecksum()
+					value.InitChecksum(key.Key)
+					if err := batcher.AddMVCCKey(ctx, key, valueScratch); err != nil {
+						return errors.Wrapf(err, "adding to batch: %s", key)
+					}
+				}
+			}(); err != nil {
+				return err
+			}
+		}
+		if exportResp.ResumeSpan == nil {
+			return nil
+		}
+		sp.Key = exportResp.ResumeSpan.Key
+	}
+	return nil
+}
+
+// ReportResults implements the jobs.JobResultsReporter interface.
+func (r *cloneTableResumer) ReportResults(ctx context.Context, resultsCh chan<- tree.Datums) error {
+	select {
+	case resultsCh <- tree.Datums{
+		tree.NewDInt(tree.DInt(r.job.ID())),
+		tree.NewDString(string(jobs.StateSucceeded)),
+		tree.NewDInt(tree.DInt(r.rows)),
+		tree.NewDInt(tree.DInt(r.bytes)),
+	}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// OnFailOrCancel is part of the jobs.Resumer interface. It drops the new table,
+// removes it from the types it references, and releases the protected
+// timestamp on the source table.
+func (r *cloneTableResumer) OnFailOrCancel(
+	ctx context.Context, execCtx interface{}, jobErr error,
+) error {
+	p := execCtx.(sql.JobExecContext)
+	execCfg := p.ExecCfg()
+	details := r.job.Details().(jobspb.CloneTableDetails)
+	return sql.DescsTxn(ctx, execCfg, func(ctx context.Context, txn isql.Txn, col *descs.Collection) error {
+		if err := releaseProtectedTimestamp(ctx,
+			execCfg.ProtectedTimestampProvider.WithTxn(txn), details.ProtectedTimestampRecord); err != nil {
+			return err
+		}
+		tbl, err := col.ByIDWithoutLeased(txn.KV()).Get().Table(ctx, details.TableID)
+		if err != nil {
+			return err
+		}
+		if tbl.Dropped() {
+			return nil
+		}
+		dbDesc, err := col.ByIDWithoutLeased(txn.KV()).Get().Database(ctx, tbl.GetParentID())
+		if err != nil {
+			return err
+		}
+		typeIDs, _, err := tbl.GetAllReferencedTypeIDs(dbDesc, func(id descpb.ID) (catalog.TypeDescriptor, error) {
+			return col.ByIDWithoutLeased(txn.KV()).Get().Type(ctx, id)
+		})
+		if err != nil {
+			return err
+		}
+		for _, typeID := range typeIDs {
+			typDesc, err := col.MutableByID(txn.KV()).Type(ctx, typeID)
+			if err != nil {
+				return err
+			}
+			if typDesc.RemoveReferencingDescriptorID(tbl.GetID()) {
+				if err := col.WriteDesc(ctx, false /* kvTrace */, typDesc, txn.KV()); err != nil {
+					return err
+				}
+			}
+		}
+		return externalcatalog.DropIngestedExternalCatalog(ctx, execCfg, p.User(),
+			externalpb.ExternalCatalog{Tables: []descpb.TableDescriptor{*tbl.TableDesc()}},
+			txn, execCfg.JobRegistry, col, "gc for failed clone of table "+tbl.GetName())
+	})
+}
+
+// CollectProfile is part of the jobs.Resumer interface.
+func (r *cloneTableResumer) CollectProfile(_ context.Context, _ interface{}) error {
+	return nil
+}
+
+func init() {
+	jobs.RegisterConstructor(
+		jobspb.TypeCloneTable,
+		func(job *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+			return &cloneTableResumer{job: job}
+		},
+		jobs.UsesTenantCostControl,
+	)
+}
@@ -0,0 +1,339 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupresolver"
+	"github.com/cockroachdb/cockroach/pkg/featureflag"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobsprotectedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/ingesting"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/rewrite"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+const cloneTableOp = "CREATE TABLE CLONE"
+
+// cloneTableHeader is the header of the results of CREATE TABLE ... CLONE OF
+// statements.
+var cloneTableHeader = colinfo.ResultColumns{
+	{Name: "job_id", Typ: types.Int},
+	{Name: "status", Typ: types.String},
+	{Name: "rows", Typ: types.Int},
+	{Name: "bytes", Typ: types.Int},
+}
+
+// checkCloneableTable returns an error if the rows of the given table cannot be
+// cloned into a new table.
+func checkCloneableTable(src catalog.TableDescriptor) error {
+	if !src.IsPhysicalTable() || src.IsSequence() || src.IsView() {
+		return pgerror.Newf(pgcode.WrongObjectType, "%q is not a table", src.GetName())
+	}
+	if src.IsTemporary() {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot clone temporary table %q", src.GetName())
+	}
+	if src.GetLocalityConfig() != nil {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot clone multi-region table %q", src.GetName())
+	}
+	if src.HasRowLevelTTL() {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot clone table %q with row-level TTL", src.GetName()),
+			"clone the table as of a time before TTL was enabled, or reset its TTL first",
+		)
+	}
+	if len(src.AllMutations()) > 0 || src.GetDeclarativeSchemaChangerState() != nil {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"cannot clone table %q as of a time when it was undergoing a schema change", src.GetName())
+	}
+	return nil
+}
+
+// makeClonedTableDescriptor returns the offline descriptor of a new table with
+// the given ID, parent and name that has the schema of src. The clone shares
+// the user-defined types referenced by src, which must be listed in typeIDs,
+// but references to other descriptors, such as foreign keys, sequences used in
+// column defaults, and triggers, are dropped, as RESTORE does when the
+// referenced descriptors are not restored.
+func makeClonedTableDescriptor(
+	src catalog.TableDescriptor, typeIDs descpb.IDs, id, parentID, parentSchemaID descpb.ID, name string,
+) (*tabledesc.Mutable, error) {
+	if err := checkCloneableTable(src); err != nil {
+		return nil, err
+	}
+	tbl := tabledesc.NewBuilder(src.TableDesc()).BuildCreatedMutableTable()
+	rewrites := jobspb.DescRewriteMap{
+		src.GetID(): {ID: id, ParentID: parentID, ParentSchemaID: parentSchemaID},
+	}
+	for _, typeID := range typeIDs {
+		rewrites[typeID] = &jobspb.DescriptorRewrite{ID: typeID}
+	}
+	if _, err := rewrite.TableDescs([]*tabledesc.Mutable{tbl}, rewrites, "" /* overrideDB */); err != nil {
+		return nil, err
+	}
+	tbl.Name = name
+	tbl.LDRJobIDs = nil
+	for i := range tbl.Columns {
+		// An identity column whose sequence was dropped above no longer generates
+		// values, so it becomes a regular column.
+		if col := &tbl.Columns[i]; col.DefaultExpr == nil &&
+			col.GeneratedAsIdentityType != catpb.GeneratedAsIdentityType_NOT_IDENTITY_COLUMN {
+			col.GeneratedAsIdentityType = catpb.GeneratedAsIdentityType_NOT_IDENTITY_COLUMN
+			col.GeneratedAsIdentitySequenceOption = nil
+		}
+	}
+	tbl.SetOffline("cloning")
+	return tbl, nil
+}
+
+// resolveCloneSource resolves the source table of a clone as of the given
+// time.
+func resolveCloneSource(
+	ctx context.Context, p sql.PlanHookState, name tree.TableName, asOf hlc.Timestamp,
+) (catalog.TableDescriptor, catalog.DatabaseDescriptor, error) {
+	pattern := &name
+	descs, _, _, descsByPattern, err := backupresolver.ResolveTargetsToDescriptors(ctx, p, asOf,
+		&tree.BackupTargetList{Tables: tree.TableAttrs{TablePatterns: tree.TablePatterns{pattern}}})
+	if err != nil {
+		return nil, nil, err
+	}
+	src, ok := descsByPattern[pattern].(catalog.TableDescriptor)
+	if !ok {
+		return nil, nil, pgerror.Newf(pgcode.UndefinedTable,
+			"relation %q does not exist as of %s", tree.ErrString(&name), asOf)
+	}
+	for _, desc := range descs {
+		if db, ok := desc.(catalog.DatabaseDescriptor); ok && db.GetID() == src.GetParentID() {
+			return src, db, nil
+		}
+	}
+	return nil, nil, errors.AssertionFailedf("database %d of table %q was not resolved",
+		src.GetParentID(), src.GetName())
+}
+
+// checkCanCreateIn returns an error if the user cannot create a table in the
+// given schema.
+func checkCanCreateIn(ctx context.Context, p sql.PlanHookState, prefix catalog.ResolvedObjectPrefix) error {
+	switch prefix.Schema.SchemaKind() {
+	case catalog.SchemaPublic:
+		return p.CheckPrivilege(ctx, prefix.Database, privilege.CREATE)
+	case catalog.SchemaUserDefined:
+		return p.CheckPrivilege(ctx, prefix.Schema, privilege.CREATE)
+	default:
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot clone a table into schema %q", prefix.Schema.GetName())
+	}
+}
+
+func cloneTableTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	if _, ok := stmt.(*tree.CloneTable); !ok {
+		return false, nil, nil
+	}
+	return true, cloneTableHeader, nil
+}
+
+// cloneTablePlanHook implements sql.PlanHookFn.
+func cloneTablePlanHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	cloneStmt, ok := stmt.(*tree.CloneTable)
+	if !ok {
+		return nil, nil, false, nil
+	}
+
+	if err := featureflag.CheckEnabled(
+		ctx, p.ExecCfg(), featureRestoreEnabled, cloneTableOp,
+	); err != nil {
+		return nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
+		if !p.ExtendedEvalContext().TxnIsSingleStmt {
+			return errors.Errorf("%s cannot be used inside a multi-statement transaction", cloneTableOp)
+		}
+		execCfg := p.ExecCfg()
+		asOf := execCfg.Clock.Now()
+		if cloneStmt.AsOf.Expr != nil {
+			asOfTime, err := p.EvalAsOfTimestamp(ctx, cloneStmt.AsOf)
+			if err != nil {
+				return err
+			}
+			asOf = asOfTime.Timestamp
+		}
+
+		src, srcDB, err := resolveCloneSource(ctx, p, cloneStmt.Source, asOf)
+		if err != nil {
+			return err
+		}
+		if err := p.CheckPrivilege(ctx, src, privilege.SELECT); err != nil {
+			return err
+		}
+		if err := checkCloneableTable(src); err != nil {
+			return err
+		}
+
+		un := cloneStmt.Table.ToUnresolvedObjectName()
+		prefix, _, err := resolver.ResolveTargetObject(ctx, p, un)
+		if err != nil {
+			return err
+		}
+		if err := checkCanCreateIn(ctx, p, prefix); err != nil {
+			return err
+		}
+		if existing, err := p.ResolveExistingObjectEx(ctx, un, false /* required */, tree.ResolveAnyTableKind); err != nil {
+			return err
+		} else if existing != nil {
+			return pgerror.Newf(pgcode.DuplicateRelation,
+				"relation %q already exists", tree.ErrString(&cloneStmt.Table))
+		}
+
+		// The clone shares the user-defined types of the source table, which
+		// belong to the database of the source table.
+		descsCol := p.Descriptors()
+		typeIDs, _, err := src.GetAllReferencedTypeIDs(srcDB, func(id descpb.ID) (catalog.TypeDescriptor, error) {
+			return descsCol.ByIDWithLeased(p.Txn()).WithoutNonPublic().Get().Type(ctx, id)
+		})
+		if err != nil {
+			return errors.Wrapf(err, "resolving types of table %q", src.GetName())
+		}
+		if len(typeIDs) > 0 && prefix.Database.GetID() != srcDB.GetID() {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot clone table %q, which uses user-defined types, into another database", src.GetName())
+		}
+
+		id, err := execCfg.DescIDGenerator.GenerateUniqueDescID(ctx)
+		if err != nil {
+			return err
+		}
+		tbl, err := makeClonedTableDescriptor(
+			src, typeIDs, id, prefix.Database.GetID(), prefix.Schema.GetID(), cloneStmt.Table.Table(),
+		)
+		if err != nil {
+			return err
+		}
+		if err := ingesting.WriteDescriptors(
+			ctx, p.Txn(), p.User(), descsCol, nil, nil, []catalog.TableDescriptor{tbl}, nil, nil,
+			tree.RequestedDescriptors, nil /* extra */, "", true, /* includePublicSchemaCreatePriv */
+			false, /* allowCrossDatabaseRefs */
+		); err != nil {
+			return err
+		}
+		for _, typeID := range typeIDs {
+			typDesc, err := descsCol.MutableByID(p.Txn()).Type(ctx, typeID)
+			if err != nil {
+				return err
+			}
+			if typDesc.AddReferencingDescriptorID(tbl.GetID()) {
+				if err := descsCol.WriteDesc(ctx, false /* kvTrace */, typDesc, p.Txn()); err != nil {
+					return err
+				}
+			}
+		}
+
+		jobID := execCfg.JobRegistry.MakeJobID()
+		// The history of the source table is protected until its rows have been
+		// copied, so that the job can be resumed after the source revisions as of
+		// the clone time fall out of the GC TTL.
+		ptsID := uuid.MakeV4()
+		if err := execCfg.ProtectedTimestampProvider.WithTxn(p.InternalSQLTxn()).Protect(ctx,
+			jobsprotectedts.MakeRecord(ptsID, int64(jobID), asOf, nil, /* deprecatedSpans */
+				jobsprotectedts.Jobs, ptpb.MakeSchemaObjectsTarget(descpb.IDs{src.GetID()})),
+		); err != nil {
+			return err
+		}
+
+		details := jobspb.CloneTableDetails{
+			SourceTableID:            src.GetID(),
+			TableID:                  tbl.GetID(),
+			AsOf:                     asOf,
+			ProtectedTimestampRecord: &ptsID,
+		}
+		srcSchema, err := descsCol.ByIDWithLeased(p.Txn()).Get().Schema(ctx, src.GetParentSchemaID())
+		if err != nil {
+			return err
+		}
+		srcName := tree.MakeTableNameWithSchema(
+			tree.Name(srcDB.GetName()), tree.Name(srcSchema.GetName()), tree.Name(src.GetName()))
+		dstName := tree.MakeTableNameWithSchema(
+			tree.Name(prefix.Database.GetName()), tree.Name(prefix.Schema.GetName()), tree.Name(tbl.GetName()))
+		jr := jobs.Record{
+			Description:   cloneTableJobDescription(srcName, dstName, asOf),
+			Details:       details,
+			Progress:      jobspb.CloneTableProgress{},
+			Username:      p.User(),
+			DescriptorIDs: descpb.IDs{tbl.GetID()},
+		}
+
+		var sj *jobs.StartableJob
+		if err := func() (err error) {
+			defer func() {
+				if err == nil || sj == nil {
+					return
+				}
+				if cleanupErr := sj.CleanupOnRollback(ctx); cleanupErr != nil {
+					log.Dev.Errorf(ctx, "failed to cleanup job: %v", cleanupErr)
+				}
+			}()
+			if err := execCfg.JobRegistry.CreateStartableJobWithTxn(
+				ctx, &sj, jobID, p.InternalSQLTxn(), jr,
+			); err != nil {
+				return err
+			}
+			return p.Txn().Commit(ctx)
+		}(); err != nil {
+			return err
+		}
+		p.InternalSQLTxn().Descriptors().ReleaseAll(ctx)
+		if err := sj.Start(ctx); err != nil {
+			return err
+		}
+		if err := sj.AwaitCompletion(ctx); err != nil {
+			return err
+		}
+		return sj.ReportExecutionResults(ctx, resultsCh)
+	}
+
+	telemetry.Count("clone-table.total")
+	return fn, cloneTableHeader, false, nil
+}
+
+// cloneTableJobDescription generates the description of a clone job, which
+// records the resolved names of the tables and the time of the clone.
+func cloneTableJobDescription(src, dst tree.TableName, asOf hlc.Timestamp) string {
+	return tree.AsString(&tree.CloneTable{
+		Table:  dst,
+		Source: src,
+		AsOf:   tree.AsOfClause{Expr: tree.NewDString(asOf.AsOfSystemTime())},
+	})
+}
+
+func init() {
+	sql.AddPlanHook("clone table", cloneTablePlanHook, cloneTableTypeCheck)
+}
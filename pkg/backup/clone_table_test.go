@@ -0,0 +1,80 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneTable(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	tempDir, tempDirCleanup := testutils.TempDir(t)
+	defer tempDirCleanup()
+	_, db, cleanupDB := backupRestoreTestSetupEmpty(
+		t, singleNode, tempDir, InitManualReplication, base.TestClusterArgs{},
+	)
+	defer cleanupDB()
+
+	db.Exec(t, "CREATE DATABASE d")
+	db.Exec(t, "CREATE TYPE d.greeting AS ENUM ('hi', 'hello')")
+	db.Exec(t, "CREATE TABLE d.p (a INT PRIMARY KEY)")
+	db.Exec(t, "INSERT INTO d.p SELECT generate_series(1, 100)")
+	db.Exec(t, `CREATE TABLE d.t (
+  a INT PRIMARY KEY REFERENCES d.p (a),
+  b STRING,
+  g d.greeting DEFAULT 'hi',
+  INDEX (b)
+)`)
+	db.Exec(t, "INSERT INTO d.t SELECT i, repeat('x', i) FROM generate_series(1, 100) AS g(i)")
+
+	var asOf string
+	db.QueryRow(t, "SELECT cluster_logical_timestamp()").Scan(&asOf)
+	db.Exec(t, "UPDATE d.t SET b = 'y' WHERE a <= 10")
+	db.Exec(t, "DELETE FROM d.t WHERE a > 50")
+	db.Exec(t, "INSERT INTO d.t VALUES (101, 'new', 'hello')")
+
+	var jobID, rows, bytes int
+	var status string
+	db.QueryRow(t, "CREATE TABLE d.c CLONE OF d.t AS OF SYSTEM TIME "+asOf).Scan(&jobID, &status, &rows, &bytes)
+	require.Equal(t, "succeeded", status)
+	require.Equal(t, 100, rows)
+	require.Positive(t, bytes)
+
+	// The clone has the rows and the secondary index of the source table as of
+	// the clone time.
+	db.CheckQueryResults(t,
+		"SELECT count(*), count(*) FILTER (WHERE b = 'y'), max(a) FROM d.c",
+		[][]string{{"100", "0", "100"}},
+	)
+	db.CheckQueryResults(t,
+		"SELECT count(*) FROM d.c@t_b_idx WHERE b = 'xxxxx'",
+		[][]string{{"1"}},
+	)
+	// The clone shares the enum type but not the foreign key.
+	db.Exec(t, "INSERT INTO d.c VALUES (1000, 'z', 'hello')")
+	db.CheckQueryResults(t,
+		"SELECT count(*) FROM [SHOW CONSTRAINTS FROM d.c] WHERE constraint_type = 'FOREIGN KEY'",
+		[][]string{{"0"}},
+	)
+	db.ExpectErr(t, "cannot drop type", "DROP TYPE d.greeting")
+
+	// Without AS OF SYSTEM TIME, the current rows are cloned.
+	db.QueryRow(t, "CREATE TABLE d.now CLONE OF d.t").Scan(&jobID, &status, &rows, &bytes)
+	require.Equal(t, 51, rows)
+
+	db.ExpectErr(t, `relation "d.c" already exists`, "CREATE TABLE d.c CLONE OF d.t")
+	db.Exec(t, "CREATE VIEW d.v AS SELECT a FROM d.t")
+	db.ExpectErr(t, `"v" is not a table`, "CREATE TABLE d.vc CLONE OF d.v")
+	db.ExpectErr(t, "does not exist", "CREATE TABLE d.vc CLONE OF d.v AS OF SYSTEM TIME "+asOf)
+}
@@ -222,28 +222,39 @@ func StartCompactionJob(
 		Compact:           true,
 	}
 	jobID := planHook.ExecCfg().JobRegistry.MakeJobID()
-	description, err := compactionJobDescription(details)
+	jobRecord, err := makeCompactionJobRecord(details, planHook.User())
 	if err != nil {
 		return 0, err
 	}
+	if _, err := planHook.ExecCfg().JobRegistry.CreateAdoptableJobWithTxn(
+		ctx, jobRecord, jobID, planHook.InternalSQLTxn(),
+	); err != nil {
+		return 0, err
+	}
+	return jobID, nil
+}
+
+// makeCompactionJobRecord returns the job record of a compaction job with the
+// given details.
+func makeCompactionJobRecord(
+	details jobspb.BackupDetails, user username.SQLUsername,
+) (jobs.Record, error) {
+	description, err := compactionJobDescription(details)
+	if err != nil {
+		return jobs.Record{}, err
+	}
 	// Note: We do not set the `CreatedBy` field in the job record to the schedule
 	// that created it because doing so creates a dependency between the record in
 	// `system.scheduled_jobs` and this compaction job. This dependency would mean
 	// that until the compaction job completes, the `system.scheduled_jobs` record
 	// for backup would not be marked ready, which would block all future
 	// scheduled backups until the compaction completes.
-	jobRecord := jobs.Record{
+	return jobs.Record{
 		Description: description,
 		Details:     details,
 		Progress:    jobspb.BackupProgress{},
-		Username:    planHook.User(),
-	}
-	if _, err := planHook.ExecCfg().JobRegistry.CreateAdoptableJobWithTxn(
-		ctx, jobRecord, jobID, planHook.InternalSQLTxn(),
-	); err != nil {
-		return 0, err
-	}
-	return jobID, nil
+		Username:    user,
+	}, nil
 }
 
 func (b *backupResumer) ResumeCompaction(
@@ -497,6 +508,12 @@ func (c *compactionChain) lastBackup() backuppb.BackupManifest {
 	return c.backupChain[c.endIdx-1]
 }
 
+// revisions returns true if the backups to compact were taken with revision
+// history, in which case every revision of a key is kept by the compaction.
+func (c *compactionChain) revisions() bool {
+	return c.lastBackup().MVCCFilter == backuppb.MVCCFilter_All
+}
+
 // newCompactionChain returns a new compacted backup chain based on the specified start and end
 // timestamps from a chain of backups. The start and end times must specify specific backups.
 // layerToIterFactory must map accordingly to the passed in manifests.
@@ -541,6 +558,15 @@ func newCompactionChain(
 			"start index %d must be less than end index %d", startIdx, endIdx,
 		)
 	}
+	// A compacted backup either has the revision history of its whole time range
+	// or none of it.
+	for _, m := range manifests[startIdx:endIdx] {
+		if m.MVCCFilter != manifests[endIdx-1].MVCCFilter {
+			return compactionChain{}, errors.Newf(
+				"cannot compact backups taken with and without revision history together",
+			)
+		}
+	}
 
 	compactedIters := make(backupinfo.LayerToBackupManifestFileIterFactory)
 	for i := startIdx; i < endIdx; i++ {
@@ -603,6 +629,7 @@ func updateCompactionBackupDetails(
 		ResolvedCompleteDbs: lastBackup.CompleteDbs,
 		FullCluster:         lastBackup.DescriptorCoverage == tree.AllDescriptors,
 		ScheduleID:          initialDetails.ScheduleID,
+		RevisionHistory:     compactionChain.revisions(),
 		Compact:             true,
 	}
 	return compactedDetails, nil
@@ -641,10 +668,13 @@ func (c compactionChain) createCompactionManifest(
 	// HasExternalManifestSSTs to false and allow it to be set to true later when
 	// it is written to storage.
 	cManifest.HasExternalManifestSSTs = false
-	// While we do not compaction on revision history backups, we still need to
-	// nil out DescriptorChanges to avoid the placeholder descriptor from the
-	// manifest of the last incremental.
+	// The descriptor changes of the last incremental only cover its own time
+	// range, so the compacted backup takes the changes of all the backups it
+	// compacts if they have revision history, and none otherwise.
 	cManifest.DescriptorChanges = nil
+	if c.revisions() {
+		cManifest.DescriptorChanges = mergeDescriptorChanges(c.chainToCompact)
+	}
 	cManifest.Files = nil
 	cManifest.EntryCounts = roachpb.RowCount{}
 
@@ -654,6 +684,31 @@ func (c compactionChain) createCompactionManifest(
 	return &cManifest, nil
 }
 
+// mergeDescriptorChanges returns the descriptor changes of a chain of backups
+// in chronological order, without the revisions repeated at the boundaries of
+// adjacent backups.
+func mergeDescriptorChanges(
+	chain []backuppb.BackupManifest,
+) []backuppb.BackupManifest_DescriptorRevision {
+	type revKey struct {
+		id descpb.ID
+		ts hlc.Timestamp
+	}
+	seen := make(map[revKey]struct{})
+	var revs []backuppb.BackupManifest_DescriptorRevision
+	for _, m := range chain {
+		for _, rev := range m.DescriptorChanges {
+			k := revKey{id: rev.ID, ts: rev.Time}
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			revs = append(revs, rev)
+		}
+	}
+	return revs
+}
+
 // resolveBackupSubdir returns the resolved base full backup subdirectory from a
 // specified sub-directory. subdir may be a specified path or the string
 // "LATEST" to resolve the latest subdirectory.
@@ -224,7 +224,7 @@ func (p *compactBackupsProcessor) runCompactBackups(ctx context.Context) error {
 		},
 		func(ctx context.Context) error {
 			return p.processSpanEntries(
-				ctx, execCfg, entryCh, encryption, defaultStore,
+				ctx, execCfg, entryCh, encryption, defaultStore, compactChain.revisions(),
 			)
 		},
 	}
@@ -240,6 +240,7 @@ func (p *compactBackupsProcessor) processSpanEntries(
 	entryCh chan execinfrapb.RestoreSpanEntry,
 	encryption *jobspb.BackupEncryptionOptions,
 	store cloud.ExternalStorage,
+	revisions bool,
 ) (err error) {
 	var fileEncryption *kvpb.FileEncryptionOptions
 	if encryption != nil {
@@ -276,7 +277,7 @@ func (p *compactBackupsProcessor) processSpanEntries(
 			} else if !assigned {
 				continue
 			}
-			sstIter, err := openSSTs(ctx, execCfg, entry, fileEncryption, p.spec.EndTime)
+			sstIter, err := openSSTs(ctx, execCfg, entry, fileEncryption, p.spec.EndTime, revisions)
 			if err != nil {
 				return errors.Wrap(err, "opening SSTs")
 			}
@@ -342,6 +343,7 @@ func openSSTs(
 	entry execinfrapb.RestoreSpanEntry,
 	encryptionOptions *kvpb.FileEncryptionOptions,
 	endTime hlc.Timestamp,
+	revisions bool,
 ) (ssts mergedSST, err error) {
 	var dirs []cloud.ExternalStorage
 	cleanupDirs := func() {
@@ -383,7 +385,11 @@ func openSSTs(
 	if err != nil {
 		return mergedSST{}, err
 	}
-	compactionIter, err := storage.NewBackupCompactionIterator(iter, endTime)
+	newCompactionIter := storage.NewBackupCompactionIterator
+	if revisions {
+		newCompactionIter = storage.NewBackupRevisionCompactionIterator
+	}
+	compactionIter, err := newCompactionIter(iter, endTime)
 	if err != nil {
 		return mergedSST{}, err
 	}
@@ -421,7 +427,9 @@ func compactSpanEntry(
 	scratch := make([]byte, 0, len(prefix))
 	scratch = append(scratch, prefix...)
 	iter := sstIter.iter
-	for iter.SeekGE(trimmedStart); ; iter.NextKey() {
+	// Next surfaces either the latest revision of each key or, when compacting
+	// revision history, every revision of each key.
+	for iter.SeekGE(trimmedStart); ; iter.Next() {
 		if _, err := pacer.Pace(ctx); err != nil {
 			return err
 		}
@@ -0,0 +1,823 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupsink"
+	"github.com/cockroachdb/cockroach/pkg/build"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/cloud/cloudpb"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/joberror"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobsprotectedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+	"github.com/gogo/protobuf/types"
+)
+
+var continuousBackupFlushInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"backup.continuous.flush_interval",
+	"the interval at which a continuous backup writes the changes it has buffered "+
+		"to a new incremental backup, which bounds how much recent history may be lost",
+	30*time.Second,
+	settings.PositiveDuration,
+)
+
+// continuousBackupMaxBufferedBytes is the size of the buffered changes past
+// which a continuous backup writes a segment before the flush interval has
+// elapsed.
+const continuousBackupMaxBufferedBytes = 64 << 20
+
+// continuousBackupMemoryLimit is the maximum memory used by the buffered
+// changes of a continuous backup. Once it is reached, the rangefeed is blocked
+// until a segment has been written.
+const continuousBackupMemoryLimit = 4 * continuousBackupMaxBufferedBytes
+
+var continuousBackupCompactionThreshold = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"backup.continuous.compaction_threshold",
+	"the number of segments written by a continuous backup after which they are "+
+		"compacted into a single backup",
+	20,
+	settings.IntWithMinimum(2),
+)
+
+// errContinuousBackupBufferFull is returned when the memory limit of the
+// buffer of a continuous backup is reached and none of the buffered changes
+// can be written yet, since the frontier has not advanced past them.
+var errContinuousBackupBufferFull = errors.New("continuous backup buffer is full")
+
+// StartContinuousBackupJob kicks off an asynchronous job which tails the spans
+// of the backup chain of the full backup at fullBackupPath in the collection,
+// and appends the changes to the chain as a series of short revision history
+// incremental backups, called segments, until it is canceled. The chain can
+// then be restored AS OF SYSTEM TIME any time covered by the segments.
+//
+// Note that planner should be a sql.PlanHookState. Due to import cycles with
+// the sql and builtins package, the interface{} type is used.
+func StartContinuousBackupJob(
+	ctx context.Context,
+	planner interface{},
+	collectionURI, incrLoc []string,
+	fullBackupPath string,
+	encryptionOpts jobspb.BackupEncryptionOptions,
+) (jobspb.JobID, error) {
+	planHook, ok := planner.(sql.PlanHookState)
+	if !ok {
+		return 0, errors.New("missing job execution context")
+	}
+	if hasAdmin, err := planHook.HasAdminRole(ctx); err != nil {
+		return 0, err
+	} else if !hasAdmin {
+		return 0, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"only users with the admin role are allowed to start a continuous backup")
+	}
+	if len(collectionURI) != 1 {
+		return 0, errors.New("locality aware backups not supported for continuous backup")
+	}
+	// The full backup is resolved once so that the job keeps extending the same
+	// chain when a new full backup is taken into the collection.
+	subdir, err := resolveBackupSubdir(
+		ctx, planHook.ExecCfg(), planHook.User(), collectionURI[0], fullBackupPath,
+	)
+	if err != nil {
+		return 0, err
+	}
+	details := jobspb.BackupDetails{
+		Destination: jobspb.BackupDetails_Destination{
+			To:                 collectionURI,
+			IncrementalStorage: incrLoc,
+			Subdir:             subdir,
+			Exists:             true,
+		},
+		EncryptionOptions: &encryptionOpts,
+		RevisionHistory:   true,
+		Continuous:        true,
+	}
+	description, err := continuousBackupJobDescription(details)
+	if err != nil {
+		return 0, err
+	}
+	jobID := planHook.ExecCfg().JobRegistry.MakeJobID()
+	jobRecord := jobs.Record{
+		Description: description,
+		Details:     details,
+		Progress:    jobspb.BackupProgress{},
+		Username:    planHook.User(),
+	}
+	if _, err := planHook.ExecCfg().JobRegistry.CreateAdoptableJobWithTxn(
+		ctx, jobRecord, jobID, planHook.InternalSQLTxn(),
+	); err != nil {
+		return 0, err
+	}
+	return jobID, nil
+}
+
+// ResumeContinuous runs a continuous backup job. The job starts a rangefeed on
+// the spans of the last backup in the chain as of its end time, and every
+// flush interval writes the changes up to the rangefeed's frontier as a new
+// incremental backup with revision history. Since the segments are regular
+// backups, they are restored and compacted by the existing machinery, and
+// when the job is resumed it picks up from the end of the last segment.
+func (b *backupResumer) ResumeContinuous(
+	ctx context.Context,
+	details jobspb.BackupDetails,
+	execCtx sql.JobExecContext,
+	kmsEnv cloud.KMSEnv,
+) error {
+	execCfg := execCtx.ExecCfg()
+	chain, _, encryption, allIters, err := getBackupChain(
+		ctx, execCfg, execCtx.User(), details.Destination, details.EncryptionOptions,
+		hlc.Timestamp{}, kmsEnv,
+	)
+	if err != nil {
+		return err
+	}
+	last := chain[len(chain)-1]
+	if err := checkContinuousBackupSupported(last); err != nil {
+		return jobs.MarkAsPermanentJobError(err)
+	}
+	descs, _, err := backupinfo.LoadSQLDescsFromBackupsAtTime(ctx, chain, allIters, last.EndTime)
+	if err != nil {
+		return err
+	}
+	// The manifests of the chain do not inline their descriptors, so the
+	// segments are built from a copy of the last one with its descriptors.
+	prev := last
+	prev.Descriptors = util.Map(descs, func(desc catalog.Descriptor) descpb.Descriptor {
+		return *desc.DescriptorProto()
+	})
+
+	if details.ProtectedTimestampRecord == nil {
+		if details, err = b.protectContinuousBackup(ctx, execCtx, details, last); err != nil {
+			return err
+		}
+	}
+
+	buf := newContinuousBackupBuffer(ctx, execCfg.DistSQLSrv.BackupMonitor, last.EndTime)
+	defer buf.close(ctx)
+	rf, err := execCfg.RangeFeedFactory.RangeFeed(
+		ctx, fmt.Sprintf("continuous-backup-%d", b.job.ID()), last.Spans, last.EndTime,
+		buf.onValue,
+		rangefeed.WithOnFrontierAdvance(buf.onFrontierAdvance),
+		rangefeed.WithOnSSTable(buf.onSSTable),
+		rangefeed.WithOnDeleteRange(buf.onDeleteRange),
+		rangefeed.WithOnInternalError(buf.onInternalError),
+	)
+	if err != nil {
+		return err
+	}
+	defer rf.Close()
+	// Unblock the rangefeed callbacks before the rangefeed is closed.
+	defer buf.release()
+
+	// compactFrom is the start time of the first segment which has not been
+	// compacted by a compaction job started by this job.
+	compactFrom, segments := last.EndTime, 0
+	var timer timeutil.Timer
+	defer timer.Stop()
+	for {
+		timer.Reset(continuousBackupFlushInterval.Get(&execCfg.Settings.SV))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		case <-buf.flushCh:
+		}
+		events, frontier, err := buf.take(ctx)
+		if errors.Is(err, errContinuousBackupBufferFull) {
+			return jobs.MarkAsRetryJobError(err)
+		} else if err != nil {
+			return jobs.MarkAsPermanentJobError(err)
+		}
+		if frontier.LessEq(prev.EndTime) {
+			continue
+		}
+		segment, err := writeContinuousBackupSegment(
+			ctx, execCtx, details, encryption, kmsEnv, prev, frontier, events,
+		)
+		if err != nil {
+			if joberror.IsPermanentBulkJobError(err) {
+				return err
+			}
+			// The job resumes from the last segment that was written, so the
+			// buffered changes are read again by the new rangefeed.
+			return jobs.MarkAsRetryJobError(errors.Wrap(err, "writing continuous backup segment"))
+		}
+		prev = segment
+		segments++
+
+		if err := execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+			return execCfg.ProtectedTimestampProvider.WithTxn(txn).UpdateTimestamp(
+				ctx, *details.ProtectedTimestampRecord, frontier,
+			)
+		}); err != nil {
+			return err
+		}
+		if err := b.job.NoTxn().UpdateStatusMessage(ctx, jobs.StatusMessage(fmt.Sprintf(
+			"backed up through %s", frontier.GoTime().UTC(),
+		))); err != nil {
+			log.Dev.Warningf(ctx, "failed to update status of continuous backup: %v", err)
+		}
+
+		// The segments are compacted by the job itself, regardless of
+		// backup.compaction.threshold, so that the chain does not grow without
+		// bound.
+		if int64(segments) >= continuousBackupCompactionThreshold.Get(&execCfg.Settings.SV) {
+			if err := startContinuousBackupCompaction(
+				ctx, execCtx, details, compactFrom, frontier,
+			); err != nil {
+				log.Dev.Warningf(ctx, "failed to compact continuous backup segments: %v", err)
+				continue
+			}
+			compactFrom, segments = frontier, 0
+		}
+	}
+}
+
+// protectContinuousBackup protects the spans of the backup from garbage
+// collection as of the end time of the last backup in the chain, and persists
+// the record in the job details. The record is advanced as segments are
+// written, and released when the job is canceled.
+func (b *backupResumer) protectContinuousBackup(
+	ctx context.Context,
+	execCtx sql.JobExecContext,
+	details jobspb.BackupDetails,
+	last backuppb.BackupManifest,
+) (jobspb.BackupDetails, error) {
+	target, err := getProtectedTimestampTargetForBackup(&last)
+	if err != nil {
+		return jobspb.BackupDetails{}, err
+	}
+	target.IgnoreIfExcludedFromBackup = true
+	ptsID := uuid.MakeV4()
+	details.ProtectedTimestampRecord = &ptsID
+	if err := execCtx.ExecCfg().InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		if err := execCtx.ExecCfg().ProtectedTimestampProvider.WithTxn(txn).Protect(
+			ctx, jobsprotectedts.MakeRecord(
+				ptsID, int64(b.job.ID()), last.EndTime, last.Spans, jobsprotectedts.Jobs, target,
+			),
+		); err != nil {
+			return err
+		}
+		return b.job.WithTxn(txn).Update(ctx, func(
+			txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+		) error {
+			if err := md.CheckRunningOrReverting(); err != nil {
+				return err
+			}
+			md.Payload.Details = jobspb.WrapPayloadDetails(details)
+			ju.UpdatePayload(md.Payload)
+			return nil
+		})
+	}); err != nil {
+		return jobspb.BackupDetails{}, err
+	}
+	return details, nil
+}
+
+// checkContinuousBackupSupported returns an error if the backup chain ending
+// in last cannot be extended by a continuous backup.
+func checkContinuousBackupSupported(last backuppb.BackupManifest) error {
+	switch {
+	case len(last.Tenants) != 0:
+		return errors.New("backups of tenants not supported for continuous backup")
+	case len(last.LocalityKVs) != 0:
+		return errors.New("locality aware backups not supported for continuous backup")
+	case last.ElidedPrefix == execinfrapb.ElidePrefix_None:
+		return errors.New("backups without elided key prefixes not supported for continuous backup")
+	}
+	return nil
+}
+
+// continuousBackupEvent is a revision of a key read from the rangefeed, with
+// its MVCC encoded value.
+type continuousBackupEvent struct {
+	key   storage.MVCCKey
+	value []byte
+}
+
+// continuousBackupBuffer buffers the events of the rangefeed of a continuous
+// backup until they are written to a segment.
+type continuousBackupBuffer struct {
+	// flushCh is signaled when the buffered events exceed
+	// continuousBackupMaxBufferedBytes, or when the memory limit is reached.
+	flushCh chan struct{}
+	mon     *mon.BytesMonitor
+
+	mu struct {
+		syncutil.Mutex
+		// spaceAvailable is signaled when buffered events are taken, or when
+		// the buffer is released.
+		spaceAvailable sync.Cond
+		events         []continuousBackupEvent
+		size           int64
+		// acc accounts for the memory of the buffered events.
+		acc mon.BoundAccount
+		// frontier is the timestamp up to which all events have been buffered.
+		frontier hlc.Timestamp
+		err      error
+		released bool
+	}
+}
+
+func newContinuousBackupBuffer(
+	ctx context.Context, parent *mon.BytesMonitor, frontier hlc.Timestamp,
+) *continuousBackupBuffer {
+	b := &continuousBackupBuffer{flushCh: make(chan struct{}, 1)}
+	b.mon = mon.NewMonitorInheritWithLimit(
+		mon.MakeName("continuous-backup-buffer"), continuousBackupMemoryLimit, parent, false, /* longLiving */
+	)
+	b.mon.StartNoReserved(ctx, parent)
+	b.mu.spaceAvailable.L = &b.mu.Mutex
+	b.mu.acc = b.mon.MakeBoundAccount()
+	b.mu.frontier = frontier
+	return b
+}
+
+// release unblocks the callers of add waiting for memory, and makes any later
+// call to add drop its event.
+func (b *continuousBackupBuffer) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.mu.released = true
+	b.mu.spaceAvailable.Broadcast()
+}
+
+// close releases the memory of the buffer. The rangefeed must be closed.
+func (b *continuousBackupBuffer) close(ctx context.Context) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.mu.events = nil
+	b.mu.size = 0
+	b.mu.acc.Close(ctx)
+	b.mon.Stop(ctx)
+}
+
+func (b *continuousBackupBuffer) onValue(ctx context.Context, value *kvpb.RangeFeedValue) {
+	// The rangefeed value carries its timestamp in the key.
+	encoded, err := storage.EncodeMVCCValue(
+		storage.MVCCValue{Value: roachpb.Value{RawBytes: value.Value.RawBytes}},
+	)
+	b.add(ctx, continuousBackupEvent{
+		key:   storage.MVCCKey{Key: value.Key, Timestamp: value.Value.Timestamp},
+		value: encoded,
+	}, err)
+}
+
+func (b *continuousBackupBuffer) onSSTable(
+	ctx context.Context, sst *kvpb.RangeFeedSSTable, registeredSpan roachpb.Span,
+) {
+	span := registeredSpan.Intersect(sst.Span)
+	iter, err := storage.NewMemSSTIterator(sst.Data, false /* verify */, storage.IterOptions{
+		KeyTypes:   storage.IterKeyTypePointsAndRanges,
+		LowerBound: span.Key,
+		UpperBound: span.EndKey,
+	})
+	if err != nil {
+		b.add(ctx, continuousBackupEvent{}, err)
+		return
+	}
+	defer iter.Close()
+	for iter.SeekGE(storage.MVCCKey{Key: span.Key}); ; iter.Next() {
+		if ok, err := iter.Valid(); err != nil {
+			b.add(ctx, continuousBackupEvent{}, err)
+			return
+		} else if !ok {
+			return
+		}
+		if _, hasRange := iter.HasPointAndRange(); hasRange {
+			b.add(ctx, continuousBackupEvent{}, errors.Newf(
+				"continuous backup does not support MVCC range tombstones in ingested SSTs in %s", span,
+			))
+			return
+		}
+		value, err := iter.UnsafeValue()
+		if err != nil {
+			b.add(ctx, continuousBackupEvent{}, err)
+			return
+		}
+		b.add(ctx, continuousBackupEvent{
+			key:   iter.UnsafeKey().Clone(),
+			value: bytes.Clone(value),
+		}, nil)
+	}
+}
+
+func (b *continuousBackupBuffer) onDeleteRange(
+	ctx context.Context, value *kvpb.RangeFeedDeleteRange,
+) {
+	b.add(ctx, continuousBackupEvent{}, errors.Newf(
+		"continuous backup does not support MVCC range tombstones, found one in %s at %s",
+		value.Span, value.Timestamp,
+	))
+}
+
+func (b *continuousBackupBuffer) onInternalError(ctx context.Context, err error) {
+	b.add(ctx, continuousBackupEvent{}, err)
+}
+
+func (b *continuousBackupBuffer) onFrontierAdvance(ctx context.Context, ts hlc.Timestamp) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.mu.frontier.Forward(ts)
+}
+
+// add buffers an event, or records the error which stops the job if err is
+// set. If the memory limit of the buffer is reached, add blocks until buffered
+// events are taken, which throttles the rangefeed.
+func (b *continuousBackupBuffer) add(ctx context.Context, ev continuousBackupEvent, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err != nil {
+		b.setErrLocked(err)
+		return
+	}
+	size := continuousBackupEventSize(ev)
+	for {
+		if b.mu.released || b.mu.err != nil {
+			return
+		}
+		if err := b.mu.acc.Grow(ctx, size); err == nil {
+			break
+		} else if !b.hasFlushableLocked() {
+			// Waiting would block forever, since the frontier cannot advance
+			// while the rangefeed callback is blocked.
+			b.setErrLocked(errors.CombineErrors(errContinuousBackupBufferFull, err))
+			b.signalFlushLocked()
+			return
+		}
+		b.signalFlushLocked()
+		b.mu.spaceAvailable.Wait()
+	}
+	b.mu.events = append(b.mu.events, ev)
+	b.mu.size += size
+	if b.mu.size >= continuousBackupMaxBufferedBytes {
+		b.signalFlushLocked()
+	}
+}
+
+func (b *continuousBackupBuffer) setErrLocked(err error) {
+	if b.mu.err == nil {
+		b.mu.err = err
+	}
+}
+
+func (b *continuousBackupBuffer) signalFlushLocked() {
+	select {
+	case b.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+// hasFlushableLocked returns whether any buffered event is at or below the
+// frontier, and would therefore be returned by take.
+func (b *continuousBackupBuffer) hasFlushableLocked() bool {
+	for _, ev := range b.mu.events {
+		if ev.key.Timestamp.LessEq(b.mu.frontier) {
+			return true
+		}
+	}
+	return false
+}
+
+func continuousBackupEventSize(ev continuousBackupEvent) int64 {
+	return int64(len(ev.key.Key) + len(ev.value))
+}
+
+// take removes and returns the buffered events at or below the frontier, as
+// well as the frontier. The memory of the returned events is released from the
+// account of the buffer, since they are written right away.
+func (b *continuousBackupBuffer) take(
+	ctx context.Context,
+) ([]continuousBackupEvent, hlc.Timestamp, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.mu.err != nil {
+		return nil, hlc.Timestamp{}, b.mu.err
+	}
+	var taken, kept []continuousBackupEvent
+	var takenSize int64
+	for _, ev := range b.mu.events {
+		if ev.key.Timestamp.LessEq(b.mu.frontier) {
+			taken = append(taken, ev)
+			takenSize += continuousBackupEventSize(ev)
+			continue
+		}
+		kept = append(kept, ev)
+	}
+	b.mu.events = kept
+	b.mu.size -= takenSize
+	b.mu.acc.Shrink(ctx, takenSize)
+	b.mu.spaceAvailable.Broadcast()
+	return taken, b.mu.frontier, nil
+}
+
+// writeContinuousBackupSegment writes the events as an incremental backup with
+// revision history from the end time of prev to end, and returns its manifest.
+func writeContinuousBackupSegment(
+	ctx context.Context,
+	execCtx sql.JobExecContext,
+	details jobspb.BackupDetails,
+	encryption *jobspb.BackupEncryptionOptions,
+	kmsEnv cloud.KMSEnv,
+	prev backuppb.BackupManifest,
+	end hlc.Timestamp,
+	events []continuousBackupEvent,
+) (backuppb.BackupManifest, error) {
+	execCfg := execCtx.ExecCfg()
+	start := prev.EndTime
+	dest, err := backupdest.ResolveDest(
+		ctx, execCtx.User(), details.Destination, start, end, execCfg,
+		details.EncryptionOptions, kmsEnv,
+	)
+	if err != nil {
+		return backuppb.BackupManifest{}, err
+	}
+	manifest, err := makeContinuousBackupSegmentManifest(ctx, execCfg, prev, end)
+	if err != nil {
+		return backuppb.BackupManifest{}, err
+	}
+
+	store, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, dest.DefaultURI, execCtx.User())
+	if err != nil {
+		return backuppb.BackupManifest{}, errors.Wrapf(err, "make storage")
+	}
+	defer store.Close()
+	if err := writeContinuousBackupSegmentData(
+		ctx, execCfg, store, encryption, &manifest, events,
+	); err != nil {
+		return backuppb.BackupManifest{}, err
+	}
+
+	destination := details.Destination
+	destination.Subdir = dest.ChosenSubdir
+	segmentDetails := jobspb.BackupDetails{
+		Destination:       destination,
+		StartTime:         start,
+		EndTime:           end,
+		URI:               dest.DefaultURI,
+		CollectionURI:     dest.CollectionURI,
+		EncryptionOptions: encryption,
+		RevisionHistory:   true,
+	}
+	statsTable := getTableStatsForBackup(ctx, execCfg.InternalDB.Executor(), manifest.Descriptors)
+	if err := backupinfo.WriteBackupMetadata(
+		ctx, execCtx, store, segmentDetails, kmsEnv, &manifest, statsTable,
+	); err != nil {
+		return backuppb.BackupManifest{}, err
+	}
+	log.Dev.VInfof(ctx, 1, "wrote continuous backup segment from %s to %s with %d files",
+		start, end, len(manifest.Files))
+	return manifest, nil
+}
+
+// makeContinuousBackupSegmentManifest returns the manifest of the segment
+// following prev and ending at end, without its files. The segment backs up
+// the same spans as prev, and the descriptor changes of its descriptors.
+func makeContinuousBackupSegmentManifest(
+	ctx context.Context, execCfg *sql.ExecutorConfig, prev backuppb.BackupManifest, end hlc.Timestamp,
+) (backuppb.BackupManifest, error) {
+	descs := make([]catalog.Descriptor, 0, len(prev.Descriptors))
+	for i := range prev.Descriptors {
+		descs = append(descs, backupinfo.NewDescriptorForManifest(&prev.Descriptors[i]))
+	}
+	// Objects created after the chain was started are not tracked, as their
+	// spans are not watched by the rangefeed.
+	revs, err := getRelevantDescChanges(
+		ctx, execCfg, prev.EndTime, end, descs, nil /* expanded */, make(map[descpb.ID]descpb.ID),
+		false, /* fullCluster */
+	)
+	if err != nil {
+		return backuppb.BackupManifest{}, err
+	}
+	latest := make(map[descpb.ID]*descpb.Descriptor, len(revs))
+	for _, rev := range revs {
+		latest[rev.ID] = rev.Desc
+	}
+	descriptors := make([]descpb.Descriptor, 0, len(descs))
+	for i, desc := range descs {
+		rev, ok := latest[desc.GetID()]
+		switch {
+		case !ok:
+			descriptors = append(descriptors, prev.Descriptors[i])
+		case rev != nil:
+			descriptors = append(descriptors, *rev)
+		}
+	}
+
+	m := prev
+	m.ID = uuid.MakeV4()
+	m.StartTime = prev.EndTime
+	m.EndTime = end
+	m.MVCCFilter = backuppb.MVCCFilter_All
+	m.RevisionStartTime = hlc.Timestamp{}
+	m.Descriptors = descriptors
+	m.DescriptorChanges = revs
+	m.IntroducedSpans = nil
+	m.Files = nil
+	m.EntryCounts = roachpb.RowCount{}
+	m.IsCompacted = false
+	m.Dir = cloudpb.ExternalStorage{}
+	m.HasExternalManifestSSTs = false
+	m.BuildInfo = build.GetInfo()
+	m.ClusterVersion = execCfg.Settings.Version.ActiveVersion(ctx).Version
+	return m, nil
+}
+
+// writeContinuousBackupSegmentData writes the events to SSTs in store, ordered
+// like in an MVCC iterator, and adds the files to the manifest.
+func writeContinuousBackupSegmentData(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	store cloud.ExternalStorage,
+	encryption *jobspb.BackupEncryptionOptions,
+	manifest *backuppb.BackupManifest,
+	events []continuousBackupEvent,
+) error {
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].key.Less(events[j].key)
+	})
+	var fileEncryption *kvpb.FileEncryptionOptions
+	if encryption != nil {
+		fileEncryption = &kvpb.FileEncryptionOptions{Key: encryption.Key}
+	}
+	progCh := make(chan execinfrapb.RemoteProducerMetadata_BulkProcessorProgress)
+	sink, err := backupsink.MakeSSTSinkKeyWriter(backupsink.SSTSinkConf{
+		ID:        execCfg.DistSQLSrv.NodeID.SQLInstanceID(),
+		Enc:       fileEncryption,
+		ProgCh:    progCh,
+		Settings:  &execCfg.Settings.SV,
+		ElideMode: manifest.ElidedPrefix,
+	}, store)
+	if err != nil {
+		return err
+	}
+
+	write := func(ctx context.Context) error {
+		defer close(progCh)
+		defer logClose(ctx, sink, "failed to close sst sink")
+		for _, sp := range manifest.Spans {
+			if err := writeContinuousBackupSpan(ctx, sink, sp, events, manifest.ElidedPrefix); err != nil {
+				return err
+			}
+		}
+		return sink.Flush(ctx)
+	}
+	collect := func(ctx context.Context) error {
+		for progress := range progCh {
+			var progDetails backuppb.BackupManifest_Progress
+			if err := types.UnmarshalAny(&progress.ProgressDetails, &progDetails); err != nil {
+				return err
+			}
+			for _, file := range progDetails.Files {
+				manifest.Files = append(manifest.Files, file)
+				manifest.EntryCounts.Add(file.EntryCounts)
+			}
+		}
+		return nil
+	}
+	return ctxgroup.GoAndWait(ctx, write, collect)
+}
+
+// writeContinuousBackupSpan writes the sorted events which are in sp to the
+// sink. The sink is reset on the part of sp under each elided prefix, as
+// every file of a backup shares a single elided prefix.
+func writeContinuousBackupSpan(
+	ctx context.Context,
+	sink *backupsink.SSTSinkKeyWriter,
+	sp roachpb.Span,
+	events []continuousBackupEvent,
+	elideMode execinfrapb.ElidePrefix,
+) error {
+	lo := sort.Search(len(events), func(i int) bool {
+		return sp.Key.Compare(events[i].key.Key) <= 0
+	})
+	hi := sort.Search(len(events), func(i int) bool {
+		return sp.EndKey.Compare(events[i].key.Key) <= 0
+	})
+	var prefix roachpb.Key
+	for i := lo; i < hi; i++ {
+		ev := events[i]
+		if i > lo && ev.key.Equal(events[i-1].key) {
+			// The rangefeed may deliver an event more than once.
+			continue
+		}
+		if i == lo || !bytes.HasPrefix(ev.key.Key, prefix) {
+			if i > lo {
+				sink.AssumeNotMidRow()
+			}
+			p, err := backupsink.ElidedPrefix(ev.key.Key, elideMode)
+			if err != nil {
+				return err
+			}
+			prefix = p
+			if err := sink.Reset(ctx, sp.Intersect(roachpb.Span{
+				Key: prefix, EndKey: prefix.PrefixEnd(),
+			})); err != nil {
+				return err
+			}
+		}
+		if err := sink.WriteKey(ctx, ev.key, ev.value); err != nil {
+			return err
+		}
+	}
+	if hi > lo {
+		sink.AssumeNotMidRow()
+	}
+	return nil
+}
+
+// startContinuousBackupCompaction starts a compaction job for the segments
+// written by a continuous backup between start and end.
+func startContinuousBackupCompaction(
+	ctx context.Context,
+	execCtx sql.JobExecContext,
+	details jobspb.BackupDetails,
+	start, end hlc.Timestamp,
+) error {
+	compactionDetails := jobspb.BackupDetails{
+		StartTime:         start,
+		EndTime:           end,
+		Destination:       details.Destination,
+		EncryptionOptions: details.EncryptionOptions,
+		Compact:           true,
+	}
+	jobRecord, err := makeCompactionJobRecord(compactionDetails, execCtx.User())
+	if err != nil {
+		return err
+	}
+	registry := execCtx.ExecCfg().JobRegistry
+	jobID := registry.MakeJobID()
+	if err := execCtx.ExecCfg().InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		_, err := registry.CreateAdoptableJobWithTxn(ctx, jobRecord, jobID, txn)
+		return err
+	}); err != nil {
+		return err
+	}
+	log.Dev.Infof(ctx, "compacting continuous backup segments from %s to %s in job %d", start, end, jobID)
+	return nil
+}
+
+// continuousBackupJobDescription generates a redacted description of the job.
+func continuousBackupJobDescription(details jobspb.BackupDetails) (string, error) {
+	fmtCtx := tree.NewFmtCtx(tree.FmtSimple)
+	redactedURIs, err := sanitizeURIList(details.Destination.To)
+	if err != nil {
+		return "", err
+	}
+	fmtCtx.WriteString("CONTINUOUS BACKUP OF ")
+	fmtCtx.WriteString(details.Destination.Subdir)
+	fmtCtx.WriteString(" IN ")
+	fmtCtx.FormatURIs(redactedURIs)
+	if details.Destination.IncrementalStorage != nil {
+		redactedIncURIs, err := sanitizeURIList(details.Destination.IncrementalStorage)
+		if err != nil {
+			return "", err
+		}
+		fmtCtx.WriteString(" WITH (incremental_location = ")
+		fmtCtx.FormatURIs(redactedIncURIs)
+		fmtCtx.WriteString(")")
+	}
+	return fmtCtx.CloseAndGetString(), nil
+}
+
+func init() {
+	builtins.StartContinuousBackupJob = StartContinuousBackupJob
+}
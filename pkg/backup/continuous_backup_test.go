@@ -0,0 +1,148 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/jobutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContinuousBackup(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tempDir, tempDirCleanup := testutils.TempDir(t)
+	defer tempDirCleanup()
+	_, db, cleanupDB := backupRestoreTestSetupEmpty(
+		t, singleNode, tempDir, InitManualReplication, base.TestClusterArgs{},
+	)
+	defer cleanupDB()
+
+	db.Exec(t, "SET CLUSTER SETTING kv.rangefeed.enabled = true")
+	db.Exec(t, "SET CLUSTER SETTING backup.continuous.flush_interval = '100ms'")
+	db.Exec(t, "CREATE DATABASE d")
+	db.Exec(t, "CREATE TABLE d.foo (a INT PRIMARY KEY, b INT)")
+	db.Exec(t, "INSERT INTO d.foo VALUES (1, 1)")
+
+	const collectionURI = "nodelocal://1/continuous"
+	backupStmt := fmt.Sprintf("BACKUP DATABASE d INTO '%s'", collectionURI)
+	db.Exec(t, backupStmt)
+	var jobID jobspb.JobID
+	db.QueryRow(
+		t, `SELECT crdb_internal.start_continuous_backup($1, 'LATEST')`, backupStmt,
+	).Scan(&jobID)
+	jobutils.WaitForJobToRun(t, db, jobID)
+
+	var afterInsert, afterDelete string
+	db.Exec(t, "INSERT INTO d.foo VALUES (2, 2)")
+	db.QueryRow(t, "SELECT cluster_logical_timestamp()").Scan(&afterInsert)
+	db.Exec(t, "UPDATE d.foo SET b = 3 WHERE a = 2")
+	db.Exec(t, "DELETE FROM d.foo WHERE a = 1")
+	db.QueryRow(t, "SELECT cluster_logical_timestamp()").Scan(&afterDelete)
+
+	// The restores fail until a segment covering the requested time has been
+	// written.
+	for i, tc := range []struct {
+		aost     string
+		expected [][]string
+	}{
+		{afterInsert, [][]string{{"1", "1"}, {"2", "2"}}},
+		{afterDelete, [][]string{{"2", "3"}}},
+	} {
+		dbName := fmt.Sprintf("d%d", i)
+		testutils.SucceedsSoon(t, func() error {
+			_, err := db.DB.ExecContext(ctx, fmt.Sprintf(
+				"RESTORE DATABASE d FROM LATEST IN '%s' AS OF SYSTEM TIME %s WITH new_db_name = %s",
+				collectionURI, tc.aost, dbName,
+			))
+			return err
+		})
+		db.CheckQueryResults(t, fmt.Sprintf("SELECT * FROM %s.foo ORDER BY a", dbName), tc.expected)
+	}
+
+	db.Exec(t, "CANCEL JOB $1", jobID)
+	jobutils.WaitForJobToCancel(t, db, jobID)
+}
+
+// TestContinuousBackupBufferLimit checks that the buffer of a continuous backup
+// throttles the rangefeed once its memory budget is exhausted, and fails if
+// none of the buffered events can be flushed.
+func TestContinuousBackupBufferLimit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	const budget, valueSize, numEvents = 64 << 10, 16 << 10, 20
+	parent := mon.NewMonitor(mon.Options{
+		Name:     mon.MakeName("test"),
+		Settings: cluster.MakeTestingClusterSettings(),
+	})
+	parent.Start(ctx, nil, mon.NewStandaloneBudget(budget))
+	defer parent.Stop(ctx)
+
+	frontier := hlc.Timestamp{WallTime: 10}
+	event := func(ts hlc.Timestamp) continuousBackupEvent {
+		return continuousBackupEvent{
+			key:   storage.MVCCKey{Key: roachpb.Key("a"), Timestamp: ts},
+			value: make([]byte, valueSize),
+		}
+	}
+
+	t.Run("throttle", func(t *testing.T) {
+		b := newContinuousBackupBuffer(ctx, parent, frontier)
+		defer b.close(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < numEvents; i++ {
+				b.add(ctx, event(hlc.Timestamp{WallTime: 5}), nil)
+			}
+		}()
+		var taken int
+		for {
+			select {
+			case <-b.flushCh:
+				events, _, err := b.take(ctx)
+				require.NoError(t, err)
+				taken += len(events)
+				continue
+			case <-done:
+			}
+			break
+		}
+		events, _, err := b.take(ctx)
+		require.NoError(t, err)
+		require.Equal(t, numEvents, taken+len(events))
+	})
+
+	t.Run("full", func(t *testing.T) {
+		b := newContinuousBackupBuffer(ctx, parent, frontier)
+		defer b.close(ctx)
+		// None of the events are at or below the frontier, so they cannot be
+		// flushed to make room.
+		for i := 0; i < numEvents; i++ {
+			b.add(ctx, event(hlc.Timestamp{WallTime: 20}), nil)
+		}
+		_, _, err := b.take(ctx)
+		require.True(t, errors.Is(err, errContinuousBackupBufferFull), "%v", err)
+	})
+}
@@ -404,6 +404,11 @@ func (rd *restoreDataProcessor) runRestoreWorkers(
 		if err != nil {
 			return errors.Wrap(err, "creating key rewriter from rekeys")
 		}
+		rowFilter, err := makeRestoreRowFilter(ctx, rd.FlowCtx.NewEvalCtx(), kr, rd.spec.RowFilter)
+		if err != nil {
+			return errors.Wrap(err, "creating row filter")
+		}
+		defer rowFilter.close(ctx)
 
 		var sstIter mergedSST
 		for {
@@ -427,7 +432,7 @@ func (rd *restoreDataProcessor) runRestoreWorkers(
 						return done, errors.Wrap(err, "opening SSTs")
 					}
 
-					summary, err := rd.processRestoreSpanEntry(ctx, kr, sstIter)
+					summary, err := rd.processRestoreSpanEntry(ctx, kr, rowFilter, sstIter)
 					if err != nil {
 						return done, errors.Wrap(err, "processing restore span entry")
 					}
@@ -457,7 +462,7 @@ func (rd *restoreDataProcessor) runRestoreWorkers(
 var backupFileReadError = errors.New("error reading backup file")
 
 func (rd *restoreDataProcessor) processRestoreSpanEntry(
-	ctx context.Context, kr *KeyRewriter, sst mergedSST,
+	ctx context.Context, kr *KeyRewriter, rowFilter *restoreRowFilter, sst mergedSST,
 ) (kvpb.BulkOpSummary, error) {
 	db := rd.FlowCtx.Cfg.DB
 	var summary kvpb.BulkOpSummary
@@ -587,6 +592,21 @@ func (rd *restoreDataProcessor) processRestoreSpanEntry(
 			continue
 		}
 
+		if rowFilter != nil {
+			// Only ingest the index entries of rows matching the RESTORE's
+			// row_filter.
+			match, err := rowFilter.matches(ctx, key.Key, value)
+			if err != nil {
+				return summary, errors.Wrapf(err, "applying row filter to %s", key.Key)
+			}
+			if !match {
+				if verbose {
+					log.Dev.Infof(ctx, "filtering out %s %s", key.Key, value.PrettyPrint())
+				}
+				continue
+			}
+		}
+
 		// Rewriting the key means the checksum needs to be updated.
 		value.ClearChecksum()
 		value.InitChecksum(key.Key)
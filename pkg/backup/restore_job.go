@@ -340,6 +340,10 @@ func maybeResetRetry(
 	return currProgress
 }
 
+// storeByLocalityKV maps the locality KV of the files of a backup to the store
+// they were written to. It also maps the subdirectory of each earlier full
+// backup that files are reused from to the store of that backup; these keys
+// never collide with locality KVs, which always contain an "=".
 type storeByLocalityKV map[string]cloudpb.ExternalStorage
 
 func makeBackupLocalityMap(
@@ -360,6 +364,15 @@ func makeBackupLocalityMap(
 				storesByLocalityKV[kv] = conf
 			}
 		}
+		for subdir, uri := range localityInfo.URIsByReusedFrom {
+			conf, err := cloud.ExternalStorageConfFromURI(uri, user)
+			if err != nil {
+				return nil, errors.Wrap(err,
+					"creating reused backup external storage configuration")
+			}
+			conf.URI = uri
+			storesByLocalityKV[subdir] = conf
+		}
 		backupLocalityMap[i] = storesByLocalityKV
 	}
 
@@ -650,6 +663,7 @@ func restore(
 			execLocality:         details.ExecutionLocality,
 			exclusiveEndKeys:     fsc.isExclusive(),
 			resumeClusterVersion: resumeClusterVersion,
+			rowFilter:            details.RowFilter,
 		}
 		return errors.Wrap(distRestore(
 			ctx,
@@ -831,6 +845,7 @@ func remapAndFilterRelevantStatistics(
 	relevantTableStatistics := make([]*stats.TableStatisticProto, 0, len(tableStatistics))
 
 	tableHasStatsInBackup := make(map[descpb.ID]struct{})
+EachStat:
 	for _, stat := range tableStatistics {
 		if statShouldBeIncludedInBackupRestore(stat) {
 			tableHasStatsInBackup[stat.TableID] = struct{}{}
@@ -848,6 +863,18 @@ func remapAndFilterRelevantStatistics(
 						rewrite.RewriteIDsInTypesT(typ, descriptorRewrites)
 					}
 				}
+				// The same applies to the column types of extended statistics.
+				if stat.HistogramData.HasExtendedStatistics() {
+					for _, typ := range stat.HistogramData.ColumnTypes {
+						if typ.UserDefined() {
+							typDescID := typedesc.GetUserDefinedTypeDescID(typ)
+							if _, ok := descriptorRewrites[typDescID]; !ok {
+								continue EachStat
+							}
+							rewrite.RewriteIDsInTypesT(typ, descriptorRewrites)
+						}
+					}
+				}
 				relevantTableStatistics = append(relevantTableStatistics, stat)
 			}
 		}
@@ -1283,6 +1310,10 @@ func createImportingDescriptors(
 		// Remove any LDR Jobs from the table descriptor, ensuring schema changes
 		// can be run on the table descriptor.
 		desc.LDRJobIDs = nil
+		// The job that maintains an incremental materialized view is not
+		// restored, so the restored view must be refreshed like any other
+		// materialized view.
+		desc.IncrementalRefreshJobID = 0
 	}
 	for _, desc := range typesToWrite {
 		desc.SetOffline("restoring")
@@ -77,6 +77,7 @@ const (
 	restoreOptSkipLocalitiesCheck       = "skip_localities_check"
 	restoreOptAsTenant                  = "virtual_cluster_name"
 	restoreOptForceTenantID             = "virtual_cluster"
+	restoreOptRowFilter                 = "row_filter"
 
 	// The temporary database system tables will be restored into for full
 	// cluster backups.
@@ -1102,6 +1103,7 @@ func resolveOptionsForRestoreJobDescription(
 		ExperimentalOnline:               opts.ExperimentalOnline,
 		ExperimentalCopy:                 opts.ExperimentalCopy,
 		RemoveRegions:                    opts.RemoveRegions,
+		RowFilter:                        opts.RowFilter,
 	}
 
 	if opts.EncryptionPassphrase != nil {
@@ -1202,6 +1204,7 @@ func restoreTypeCheck(
 			restoreStmt.Options.ForceTenantID,
 			restoreStmt.Options.AsTenant,
 			restoreStmt.Options.ExecutionLocality,
+			restoreStmt.Options.RowFilter,
 		},
 	); err != nil {
 		return false, nil, err
@@ -1340,6 +1343,19 @@ func restorePlanHook(
 		return nil, nil, false, errors.New("cannot run online restore with verify_backup_table_data")
 	}
 
+	if restoreStmt.Options.RowFilter != nil {
+		if restoreStmt.DescriptorCoverage != tree.RequestedDescriptors ||
+			len(restoreStmt.Targets.Tables.TablePatterns) == 0 {
+			return nil, nil, false, errors.Newf("%q option can only be used for RESTORE TABLE", restoreOptRowFilter)
+		}
+		if restoreStmt.Options.OnlineImpl() {
+			return nil, nil, false, errors.Newf("cannot run online restore with %q", restoreOptRowFilter)
+		}
+		if restoreStmt.Options.SchemaOnly {
+			return nil, nil, false, errors.Newf("cannot run schema_only restore with %q", restoreOptRowFilter)
+		}
+	}
+
 	var newTenantID *roachpb.TenantID
 	var newTenantName *roachpb.TenantName
 	if restoreStmt.Options.AsTenant != nil || restoreStmt.Options.ForceTenantID != nil {
@@ -1953,6 +1969,17 @@ func doRestorePlan(
 		}
 	}
 
+	var rowFilter string
+	if restoreStmt.Options.RowFilter != nil {
+		rowFilter, err = exprEval.String(ctx, restoreStmt.Options.RowFilter)
+		if err != nil {
+			return err
+		}
+		if err := validateRestoreRowFilter(ctx, p, tablesByID, rowFilter); err != nil {
+			return err
+		}
+	}
+
 	filteredTablesByID, err := maybeFilterMissingViews(
 		tablesByID,
 		typesByID,
@@ -2101,6 +2128,7 @@ func doRestorePlan(
 		ExperimentalCopy:                 restoreStmt.Options.ExperimentalCopy,
 		RemoveRegions:                    restoreStmt.Options.RemoveRegions,
 		UnsafeRestoreIncompatibleVersion: restoreStmt.Options.UnsafeRestoreIncompatibleVersion,
+		RowFilter:                        rowFilter,
 	}
 
 	jr := jobs.Record{
@@ -0,0 +1,241 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// validateRestoreRowFilter checks that the row_filter of a RESTORE can be
+// applied to every table being restored.
+//
+// The restore data processors evaluate the filter against each index entry in
+// isolation, without the rest of the row at hand, so that the primary index
+// and every secondary index of a table keep exactly the same set of rows. This
+// restricts the filter to the primary key columns, since those are the only
+// columns that can be decoded from the entries of every index.
+func validateRestoreRowFilter(
+	ctx context.Context,
+	p sql.PlanHookState,
+	tables map[descpb.ID]*tabledesc.Mutable,
+	rowFilter string,
+) error {
+	expr, err := parser.ParseExpr(rowFilter)
+	if err != nil {
+		return errors.Wrapf(err, "parsing %s", restoreOptRowFilter)
+	}
+	version := p.ExecCfg().Settings.Version.ActiveVersion(ctx)
+	for _, table := range tables {
+		if !table.IsTable() {
+			continue
+		}
+		colIDs, err := schemaexpr.ExtractColumnIDs(table, expr)
+		if err != nil {
+			return errors.Wrapf(err, "%s cannot be applied to table %q", restoreOptRowFilter, table.GetName())
+		}
+		var keyColIDs catalog.TableColSet
+		primaryIndex := table.GetPrimaryIndex()
+		for i := 0; i < primaryIndex.NumKeyColumns(); i++ {
+			keyColIDs.Add(primaryIndex.GetKeyColumnID(i))
+		}
+		if !colIDs.SubsetOf(keyColIDs) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"%s may only reference primary key columns of table %q", restoreOptRowFilter, table.GetName())
+		}
+		for _, colID := range colIDs.Ordered() {
+			col, err := catalog.MustFindColumnByID(table, colID)
+			if err != nil {
+				return err
+			}
+			if col.GetType().UserDefined() {
+				return unimplemented.Newf("restore row_filter user-defined type",
+					"%s cannot reference column %q of a user-defined type",
+					restoreOptRowFilter, col.GetName())
+			}
+		}
+		for _, idx := range table.ActiveIndexes() {
+			if idx.GetType() == idxtype.VECTOR {
+				return unimplemented.Newf("restore row_filter vector index",
+					"%s cannot be applied to table %q with vector index %q",
+					restoreOptRowFilter, table.GetName(), idx.GetName())
+			}
+		}
+		tn := tree.NewUnqualifiedTableName(tree.Name(table.GetName()))
+		if _, _, _, err := schemaexpr.DequalifyAndValidateExpr(
+			ctx, table, expr, types.Bool, tree.RestoreRowFilterExpr, p.SemaCtx(),
+			volatility.Immutable, tn, version,
+		); err != nil {
+			return errors.Wrapf(err, "%s cannot be applied to table %q", restoreOptRowFilter, table.GetName())
+		}
+	}
+	return nil
+}
+
+// restoreRowFilter decides which of the rewritten KVs ingested by a restore
+// data processor belong to rows that match the row_filter of the RESTORE. It
+// is not safe for concurrent use; each restore worker makes its own.
+type restoreRowFilter struct {
+	codec   keys.SQLCodec
+	evalCtx *eval.Context
+	// tables is keyed by the rewritten ID of each restored table.
+	tables map[descpb.ID]*restoreTableRowFilter
+}
+
+// restoreTableRowFilter is the row filter of a single restored table.
+type restoreTableRowFilter struct {
+	table catalog.TableDescriptor
+	expr  tree.TypedExpr
+	// fetchColIDs are the columns referenced by expr, which are decoded from
+	// each index entry.
+	fetchColIDs []descpb.ColumnID
+	iv          schemaexpr.RowIndexedVarContainer
+	// fetchers holds a lazily initialized fetcher per index of the table.
+	fetchers map[descpb.IndexID]*row.Fetcher
+	kvs      [1]roachpb.KeyValue
+}
+
+// makeRestoreRowFilter makes a restoreRowFilter that applies rowFilter to the
+// tables the key rewriter rewrites keys into. It returns nil if rowFilter is
+// empty.
+func makeRestoreRowFilter(
+	ctx context.Context, evalCtx *eval.Context, kr *KeyRewriter, rowFilter string,
+) (*restoreRowFilter, error) {
+	if rowFilter == "" {
+		return nil, nil
+	}
+	f := &restoreRowFilter{
+		codec:   kr.codec,
+		evalCtx: evalCtx,
+		tables:  make(map[descpb.ID]*restoreTableRowFilter, len(kr.descs)),
+	}
+	for _, table := range kr.descs {
+		if !table.IsTable() {
+			continue
+		}
+		semaCtx := tree.MakeSemaContext(nil /* resolver */)
+		cols := table.PublicColumns()
+		expr, colIDs, err := schemaexpr.MakeRowFilterExpr(
+			ctx, table, cols, rowFilter, evalCtx, &semaCtx,
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s for table %q", restoreOptRowFilter, table.GetName())
+		}
+		tf := &restoreTableRowFilter{
+			table:       table,
+			expr:        expr,
+			fetchColIDs: colIDs.Ordered(),
+			fetchers:    make(map[descpb.IndexID]*row.Fetcher),
+		}
+		tf.iv.Cols = cols
+		for i, colID := range tf.fetchColIDs {
+			tf.iv.Mapping.Set(colID, i)
+		}
+		f.tables[table.GetID()] = tf
+	}
+	return f, nil
+}
+
+// matches returns whether the given rewritten index entry belongs to a row
+// matching the filter. Entries of tables without a filter always match.
+func (f *restoreRowFilter) matches(
+	ctx context.Context, key roachpb.Key, value roachpb.Value,
+) (bool, error) {
+	remainder, err := f.codec.StripTenantPrefix(key)
+	if err != nil {
+		return false, err
+	}
+	_, tableID, indexID, err := rowenc.DecodePartialTableIDIndexID(remainder)
+	if err != nil {
+		return false, err
+	}
+	tf, ok := f.tables[tableID]
+	if !ok {
+		return true, nil
+	}
+	fetcher, err := tf.fetcher(ctx, f.codec, indexID)
+	if err != nil {
+		return false, err
+	}
+	tf.kvs[0] = roachpb.KeyValue{Key: key, Value: value}
+	if err := fetcher.ConsumeKVProvider(ctx, &row.KVProvider{KVs: tf.kvs[:]}); err != nil {
+		return false, err
+	}
+	datums, _, err := fetcher.NextRowDecoded(ctx)
+	if err != nil {
+		return false, err
+	}
+	if datums == nil {
+		return false, errors.AssertionFailedf("could not decode a row from %s", key)
+	}
+	tf.iv.CurSourceRow = datums
+	f.evalCtx.IVarContainer = &tf.iv
+	res, err := eval.Expr(ctx, f.evalCtx, tf.expr)
+	if err != nil {
+		return false, err
+	}
+	return res == tree.DBoolTrue, nil
+}
+
+// fetcher returns the fetcher used to decode the filtered columns from the
+// entries of the given index.
+func (tf *restoreTableRowFilter) fetcher(
+	ctx context.Context, codec keys.SQLCodec, indexID descpb.IndexID,
+) (*row.Fetcher, error) {
+	if fetcher, ok := tf.fetchers[indexID]; ok {
+		return fetcher, nil
+	}
+	index, err := catalog.MustFindIndexByID(tf.table, indexID)
+	if err != nil {
+		return nil, err
+	}
+	var spec fetchpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(&spec, codec, tf.table, index, tf.fetchColIDs); err != nil {
+		return nil, err
+	}
+	fetcher := &row.Fetcher{}
+	if err := fetcher.Init(ctx, row.FetcherInitArgs{
+		WillUseKVProvider: true,
+		Alloc:             &tree.DatumAlloc{},
+		Spec:              &spec,
+	}); err != nil {
+		return nil, err
+	}
+	tf.fetchers[indexID] = fetcher
+	return fetcher, nil
+}
+
+// close releases the resources held by the filter's fetchers.
+func (f *restoreRowFilter) close(ctx context.Context) {
+	if f == nil {
+		return
+	}
+	for _, tf := range f.tables {
+		for _, fetcher := range tf.fetchers {
+			fetcher.Close(ctx)
+		}
+	}
+}
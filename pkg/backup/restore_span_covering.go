@@ -328,7 +328,14 @@ func generateAndSendImportSpans(
 					Layer:                   int32(layer),
 					HasRangeKeys:            f.HasRangeKeys,
 				}
-				if dir, ok := backupLocalityMap[layer][f.LocalityKV]; ok {
+				if f.ReusedFrom != "" {
+					dir, ok := backupLocalityMap[layer][f.ReusedFrom]
+					if !ok {
+						return errors.AssertionFailedf(
+							"no store for file %s reused from full backup %s", f.Path, f.ReusedFrom)
+					}
+					fileSpec.Dir = dir
+				} else if dir, ok := backupLocalityMap[layer][f.LocalityKV]; ok {
 					fileSpec.Dir = dir
 				}
 				entry.Files = append(entry.Files, fileSpec)
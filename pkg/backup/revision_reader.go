@@ -8,11 +8,21 @@ package backup
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
 )
 
 // VersionedValues is similar to roachpb.KeyValue except instead of just the
@@ -107,3 +117,191 @@ func GetAllRevisions(
 		startKey = exportResp.ResumeSpan.Key
 	}
 }
+
+// revisionBatchSize is the number of keys GetAllRevisionsFromBackup reads
+// before sending them on its channel.
+const revisionBatchSize = 1024
+
+// ResolveRevisionHistoryBackup resolves the layers of the latest backup in the
+// collection at collectionURI, and checks that all of them were taken with
+// revision history. The manifests are returned in chronological order.
+//
+// Encrypted and locality-aware backups are not supported.
+func ResolveRevisionHistoryBackup(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user username.SQLUsername,
+	collectionURI string,
+) ([]backuppb.BackupManifest, error) {
+	mkStore := execCfg.DistSQLSrv.ExternalStorageFromURI
+	subdir, err := backupdest.ReadLatestFile(ctx, collectionURI, mkStore, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading latest backup in collection")
+	}
+	collections := []string{collectionURI}
+	baseDirs, err := backuputils.AppendPaths(collections, subdir)
+	if err != nil {
+		return nil, err
+	}
+	incDirs, err := backupdest.ResolveIncrementalsBackupLocation(
+		ctx, user, execCfg, nil /* explicitIncrementalCollections */, collections, subdir,
+	)
+	if err != nil {
+		if !errors.Is(err, cloud.ErrListingUnsupported) {
+			return nil, err
+		}
+		log.Dev.Warningf(ctx, "storage sink %s does not support listing, only resolving the base backup",
+			backuputils.RedactURIForErrorMessage(collectionURI))
+	}
+
+	// The manifests are only held by the caller for as long as it reads from the
+	// backup, so their memory is not accounted for past this function.
+	mem := execCfg.RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+	_, manifests, _, _, err := backupdest.ResolveBackupManifests(
+		ctx, execCfg, &mem, collectionURI, collections, mkStore, subdir, baseDirs, incDirs,
+		hlc.Timestamp{}, nil /* encryption */, nil /* kmsEnv */, user,
+		false /* includeSkipped */, false /* includeCompacted */, false, /* isCustomIncLocation */
+	)
+	if err != nil {
+		return nil, err
+	}
+	for i := range manifests {
+		if manifests[i].MVCCFilter != backuppb.MVCCFilter_All {
+			return nil, errors.Newf(
+				"backup layer from %s to %s was not taken with revision_history",
+				manifests[i].StartTime, manifests[i].EndTime)
+		}
+	}
+	return manifests, nil
+}
+
+// GetAllRevisionsFromBackup is like GetAllRevisions, but reads the revisions
+// of the keys in span from the files of the given chain of revision history
+// backups rather than from KV, which allows reading revisions that have since
+// been garbage collected.
+//
+// Unlike GetAllRevisions, the revision of each key that was live at startTime,
+// if any, is also returned, so that the caller can determine the previous
+// value of the first revision after startTime. Values are decoded from their
+// MVCC encoding, and deletions are returned as values with no bytes.
+func GetAllRevisionsFromBackup(
+	ctx context.Context,
+	storeFactory cloud.ExternalStorageFactory,
+	manifests []backuppb.BackupManifest,
+	span roachpb.Span,
+	startTime, endTime hlc.Timestamp,
+	allRevs chan []VersionedValues,
+) error {
+	if len(manifests) == 0 {
+		return errors.AssertionFailedf("no backup manifests to read revisions from")
+	}
+	if startTime.Less(manifests[0].RevisionStartTime) {
+		return errors.Newf("backup does not contain revisions before %s, requested %s",
+			manifests[0].RevisionStartTime, startTime)
+	}
+
+	var storeFiles []storageccl.StoreFile
+	for i := range manifests {
+		store, err := storeFactory(ctx, manifests[i].Dir)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		if err := func() error {
+			it, err := backupinfo.NewIterFactory(&manifests[i], store, nil, nil).NewFileIter(ctx)
+			if err != nil {
+				return err
+			}
+			defer it.Close()
+			for ; ; it.Next() {
+				if ok, err := it.Valid(); err != nil {
+					return err
+				} else if !ok {
+					return nil
+				}
+				f := it.Value()
+				if !f.Span.Overlaps(span) {
+					continue
+				}
+				if f.LocalityKV != "" {
+					return errors.New("locality-aware backups are not supported")
+				}
+				storeFiles = append(storeFiles, storageccl.StoreFile{Store: store, FilePath: f.Path})
+			}
+		}(); err != nil {
+			return err
+		}
+	}
+	if len(storeFiles) == 0 {
+		return nil
+	}
+
+	iter, err := storageccl.ExternalSSTReader(ctx, storeFiles, nil /* encryption */, storage.IterOptions{
+		KeyTypes:   storage.IterKeyTypePointsOnly,
+		LowerBound: span.Key,
+		UpperBound: span.EndKey,
+	})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	var res []VersionedValues
+	sendBatch := func() error {
+		if len(res) == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case allRevs <- res:
+		}
+		res = nil
+		return nil
+	}
+
+	// Revisions of a key are ordered from newest to oldest, so once a revision
+	// at or before startTime is seen, the remaining revisions of the key can be
+	// skipped.
+	var sawLiveAtStart bool
+	for iter.SeekGE(storage.MVCCKey{Key: span.Key}); ; iter.Next() {
+		if ok, err := iter.Valid(); err != nil {
+			return err
+		} else if !ok {
+			break
+		}
+		key := iter.UnsafeKey()
+		newKey := len(res) == 0 || !res[len(res)-1].Key.Equal(key.Key)
+		if newKey {
+			sawLiveAtStart = false
+		}
+		if endTime.Less(key.Timestamp) || sawLiveAtStart {
+			continue
+		}
+		sawLiveAtStart = key.Timestamp.LessEq(startTime)
+
+		v, err := iter.UnsafeValue()
+		if err != nil {
+			return err
+		}
+		mvccValue, err := storage.DecodeMVCCValue(v)
+		if err != nil {
+			return err
+		}
+		if newKey {
+			if len(res) >= revisionBatchSize {
+				if err := sendBatch(); err != nil {
+					return err
+				}
+			}
+			res = append(res, VersionedValues{Key: key.Key.Clone()})
+		}
+		value := roachpb.Value{
+			RawBytes:  append([]byte(nil), mvccValue.Value.RawBytes...),
+			Timestamp: key.Timestamp,
+		}
+		res[len(res)-1].Values = append(res[len(res)-1].Values, value)
+	}
+	return sendBatch()
+}
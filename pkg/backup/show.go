@@ -441,6 +441,10 @@ func getBackupInfoReader(p sql.PlanHookState, showStmt *tree.ShowBackup) backupI
 	return infoReader
 }
 
+// maxMissingBackupFiles is the number of missing files after which the files
+// of a backup are no longer checked.
+const maxMissingBackupFiles = 10
+
 // checkBackupFiles validates that each SST is in its expected storage location
 func checkBackupFiles(
 	ctx context.Context,
@@ -450,8 +454,28 @@ func checkBackupFiles(
 	encryption *jobspb.BackupEncryptionOptions,
 	kmsEnv cloud.KMSEnv,
 ) ([][]int64, error) {
-	const maxMissingFiles = 10
-	missingFiles := make(map[string]struct{}, maxMissingFiles)
+	manifestFileSizes, missingFiles, err := findMissingBackupFiles(ctx, info, execCfg, user)
+	if err != nil {
+		return nil, err
+	}
+	if len(missingFiles) > 0 {
+		errorMsgPrefix := "The following files are missing from the backup:"
+		if len(missingFiles) == maxMissingBackupFiles {
+			errorMsgPrefix = "Multiple files cannot be read from the backup including:"
+		}
+		return nil, errors.Newf("%s\n\t%s", errorMsgPrefix, strings.Join(missingFiles, "\n\t"))
+	}
+	return manifestFileSizes, nil
+}
+
+// findMissingBackupFiles returns the sizes of the SSTs of each layer of the
+// backup, and the sorted paths of up to maxMissingBackupFiles SSTs which are
+// not in their expected storage location. The sizes are only complete if no
+// files are missing.
+func findMissingBackupFiles(
+	ctx context.Context, info backupInfo, execCfg *sql.ExecutorConfig, user username.SQLUsername,
+) ([][]int64, []string, error) {
+	missingFiles := make(map[string]struct{}, maxMissingBackupFiles)
 
 	checkLayer := func(layer int) ([]int64, error) {
 		// TODO (msbutler): Right now, checkLayer opens stores for each backup layer. In 22.2,
@@ -496,6 +520,15 @@ func checkBackupFiles(
 			}
 			localityStores[locality] = store
 		}
+		// Stores of earlier full backups that files are reused from are keyed by
+		// their subdirectory, which cannot collide with a locality KV.
+		for subdir, uri := range info.localityInfo[layer].URIsByReusedFrom {
+			store, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, uri, user)
+			if err != nil {
+				return nil, err
+			}
+			localityStores[subdir] = store
+		}
 
 		// Check all backup SSTs.
 		fileSizes := make([]int64, 0)
@@ -514,7 +547,10 @@ func checkBackupFiles(
 			f := it.Value()
 			store := defaultStore
 			uri := info.defaultURIs[layer]
-			if _, ok := localityStores[f.LocalityKV]; ok {
+			if f.ReusedFrom != "" {
+				store = localityStores[f.ReusedFrom]
+				uri = info.localityInfo[layer].URIsByReusedFrom[f.ReusedFrom]
+			} else if _, ok := localityStores[f.LocalityKV]; ok {
 				store = localityStores[f.LocalityKV]
 				uri = info.localityInfo[layer].URIsByOriginalLocalityKV[f.LocalityKV]
 			}
@@ -524,7 +560,7 @@ func checkBackupFiles(
 				missingFile := path.Join(uriNoLocality, f.Path)
 				if _, ok := missingFiles[missingFile]; !ok {
 					missingFiles[missingFile] = struct{}{}
-					if maxMissingFiles == len(missingFiles) {
+					if maxMissingBackupFiles == len(missingFiles) {
 						break
 					}
 				}
@@ -540,26 +576,19 @@ func checkBackupFiles(
 	for layer := range info.manifests {
 		layerFileSizes, err := checkLayer(layer)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		if len(missingFiles) == maxMissingFiles {
+		if len(missingFiles) == maxMissingBackupFiles {
 			break
 		}
 		manifestFileSizes[layer] = layerFileSizes
 	}
-	if len(missingFiles) > 0 {
-		filesForMsg := make([]string, 0, len(missingFiles))
-		for file := range missingFiles {
-			filesForMsg = append(filesForMsg, file)
-		}
-		errorMsgPrefix := "The following files are missing from the backup:"
-		if len(missingFiles) == maxMissingFiles {
-			errorMsgPrefix = "Multiple files cannot be read from the backup including:"
-		}
-		sort.Strings(filesForMsg)
-		return nil, errors.Newf("%s\n\t%s", errorMsgPrefix, strings.Join(filesForMsg, "\n\t"))
+	sortedMissingFiles := make([]string, 0, len(missingFiles))
+	for file := range missingFiles {
+		sortedMissingFiles = append(sortedMissingFiles, file)
 	}
-	return manifestFileSizes, nil
+	sort.Strings(sortedMissingFiles)
+	return manifestFileSizes, sortedMissingFiles, nil
 }
 
 type backupInfo struct {
@@ -1235,6 +1264,9 @@ func backupShowerFileSetup() backupShower {
 					}
 					file := it.Value()
 					filePath := path.Join(manifestDirs[i], file.Path)
+					if file.ReusedFrom != "" {
+						filePath = path.Join(file.ReusedFrom, file.Path)
+					}
 					locality := "NULL"
 					if localityAware {
 						locality = "default"
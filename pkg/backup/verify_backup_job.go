@@ -0,0 +1,488 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupencryption"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+)
+
+// verifyBackupScratchDatabasePrefix is the prefix of the names of the scratch
+// databases that a VERIFY BACKUP job restores tables into. The names are
+// suffixed with the job ID and the name of the database of the restored
+// tables in the backup, so that tables with the same name in different
+// databases do not collide, and so that the databases of a job can be found
+// to drop them.
+const verifyBackupScratchDatabasePrefix = "crdb_verify_backup_"
+
+// VerifyBackupMetrics are the metrics of VERIFY BACKUP jobs.
+type VerifyBackupMetrics struct {
+	Runs             *metric.Counter
+	RunsWithIssues   *metric.Counter
+	TablesVerified   *metric.Counter
+	MismatchedTables *metric.Counter
+	MissingFiles     *metric.Counter
+}
+
+var _ metric.Struct = (*VerifyBackupMetrics)(nil)
+
+// MetricStruct implements the metric.Struct interface.
+func (VerifyBackupMetrics) MetricStruct() {}
+
+var (
+	metaVerifyBackupRuns = metric.Metadata{
+		Name:        "jobs.verify_backup.runs",
+		Help:        "Number of VERIFY BACKUP jobs executed",
+		Measurement: "Jobs",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaVerifyBackupRunsWithIssues = metric.Metadata{
+		Name:        "jobs.verify_backup.runs_with_issues",
+		Help:        "Number of VERIFY BACKUP jobs that found mismatched tables or missing files",
+		Measurement: "Jobs",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaVerifyBackupTablesVerified = metric.Metadata{
+		Name:        "jobs.verify_backup.tables_verified",
+		Help:        "Number of restored tables whose fingerprints matched the backup",
+		Measurement: "Tables",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaVerifyBackupMismatchedTables = metric.Metadata{
+		Name:        "jobs.verify_backup.mismatched_tables",
+		Help:        "Number of restored tables whose fingerprints did not match the backup",
+		Measurement: "Tables",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaVerifyBackupMissingFiles = metric.Metadata{
+		Name:        "jobs.verify_backup.missing_files",
+		Help:        "Number of backup files that VERIFY BACKUP jobs could not find",
+		Measurement: "Files",
+		Unit:        metric.Unit_COUNT,
+	}
+)
+
+func makeVerifyBackupMetrics() *VerifyBackupMetrics {
+	return &VerifyBackupMetrics{
+		Runs:             metric.NewCounter(metaVerifyBackupRuns),
+		RunsWithIssues:   metric.NewCounter(metaVerifyBackupRunsWithIssues),
+		TablesVerified:   metric.NewCounter(metaVerifyBackupTablesVerified),
+		MismatchedTables: metric.NewCounter(metaVerifyBackupMismatchedTables),
+		MissingFiles:     metric.NewCounter(metaVerifyBackupMissingFiles),
+	}
+}
+
+// isVerifiableTable returns whether the data of the table can be restored and
+// fingerprinted by VERIFY BACKUP. Tables are restored into a scratch database,
+// so system tables and multi-region tables, which cannot be restored into an
+// arbitrary database, are not verified.
+func isVerifiableTable(tbl *descpb.TableDescriptor) bool {
+	return tbl.State == descpb.DescriptorState_PUBLIC &&
+		tbl.IsPhysicalTable() &&
+		!tbl.ExcludeDataFromBackup &&
+		!tbl.Temporary &&
+		tbl.LocalityConfig == nil &&
+		tbl.ParentID != keys.SystemDatabaseID
+}
+
+// tableToVerify is a table of the backup that a VERIFY BACKUP job restores and
+// fingerprints.
+type tableToVerify struct {
+	name        tree.TableName
+	fingerprint uint64
+}
+
+type verifyBackupResumer struct {
+	job *jobs.Job
+
+	// progress is the outcome of a successful run, which is reported to the
+	// client if the job was not detached.
+	progress jobspb.VerifyBackupProgress
+}
+
+var _ jobs.Resumer = &verifyBackupResumer{}
+var _ jobs.JobResultsReporter = &verifyBackupResumer{}
+
+// Resume is part of the jobs.Resumer interface.
+//
+// The job checks that every file of the backup is in its expected location,
+// then restores the sampled tables of the backup that have a recorded
+// fingerprint into scratch databases, one per database in the backup, and
+// compares the fingerprints of the restored tables to the recorded ones. The
+// scratch databases are dropped once the tables have been fingerprinted. Any
+// missing file or mismatched fingerprint is recorded as a job message and
+// fails the job.
+func (r *verifyBackupResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	p := execCtx.(sql.JobExecContext)
+	execCfg := p.ExecCfg()
+	details := r.job.Details().(jobspb.VerifyBackupDetails)
+	metrics := execCfg.JobRegistry.MetricsStruct().JobSpecificMetrics[jobspb.TypeVerifyBackup].(*VerifyBackupMetrics)
+	metrics.Runs.Inc(1)
+
+	mem := execCfg.RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+	info, err := resolveBackupToVerify(ctx, execCfg, &mem, p.User(), details)
+	if err != nil {
+		return err
+	}
+
+	var progress jobspb.VerifyBackupProgress
+	_, progress.MissingFiles, err = findMissingBackupFiles(ctx, info, execCfg, p.User())
+	if err != nil {
+		return err
+	}
+
+	tables, withoutFingerprint, err := sampleTablesToVerify(ctx, info, details.SampleFraction, int64(r.job.ID()))
+	if err != nil {
+		return err
+	}
+	progress.TablesWithoutFingerprint = int64(withoutFingerprint)
+
+	// Restoring the tables is pointless if their files are missing.
+	if len(progress.MissingFiles) == 0 {
+		defer func() {
+			if err := dropVerifyBackupScratchDatabases(ctx, execCfg, p.User(), r.job.ID()); err != nil {
+				log.Dev.Warningf(ctx, "failed to drop scratch databases of job %d: %v", r.job.ID(), err)
+			}
+		}()
+		for _, dbTables := range tables {
+			mismatched, err := r.restoreAndFingerprint(ctx, execCfg, p.User(), details, info.subdir, dbTables)
+			if err != nil {
+				return err
+			}
+			progress.TablesVerified += int64(len(dbTables) - len(mismatched))
+			progress.MismatchedTables = append(progress.MismatchedTables, mismatched...)
+		}
+	}
+
+	if err := r.recordVerifyBackupResults(ctx, execCfg, progress); err != nil {
+		return err
+	}
+	metrics.TablesVerified.Inc(progress.TablesVerified)
+	metrics.MismatchedTables.Inc(int64(len(progress.MismatchedTables)))
+	metrics.MissingFiles.Inc(int64(len(progress.MissingFiles)))
+	if len(progress.MissingFiles) > 0 || len(progress.MismatchedTables) > 0 {
+		metrics.RunsWithIssues.Inc(1)
+		return jobs.MarkAsPermanentJobError(errors.Newf(
+			"backup %s failed verification: %d missing files, %d mismatched tables",
+			info.subdir, len(progress.MissingFiles), len(progress.MismatchedTables),
+		))
+	}
+	r.progress = progress
+	return nil
+}
+
+// resolveBackupToVerify resolves the chain of backups ending in the backup
+// described by details.
+func resolveBackupToVerify(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	mem *mon.BoundAccount,
+	user username.SQLUsername,
+	details jobspb.VerifyBackupDetails,
+) (backupInfo, error) {
+	defaultCollectionURI, _, err := backupdest.GetURIsByLocalityKV(details.CollectionURIs, "")
+	if err != nil {
+		return backupInfo{}, err
+	}
+	baseDirs, incDirs, _, err := resolveBackupDirs(
+		ctx, execCfg, user, details.CollectionURIs, nil /* incrementalURIs */, details.Subdir,
+	)
+	if err != nil {
+		return backupInfo{}, err
+	}
+	kmsEnv := backupencryption.MakeBackupKMSEnv(
+		execCfg.Settings, &execCfg.ExternalIODirConfig, execCfg.InternalDB, user,
+	)
+	info := backupInfo{
+		collectionURI: defaultCollectionURI,
+		subdir:        details.Subdir,
+		kmsEnv:        &kmsEnv,
+	}
+	info.defaultURIs, info.manifests, info.localityInfo, _, err = backupdest.ResolveBackupManifests(
+		ctx, execCfg, mem, defaultCollectionURI, details.CollectionURIs,
+		execCfg.DistSQLSrv.ExternalStorageFromURI, details.Subdir, baseDirs, incDirs,
+		hlc.Timestamp{}, nil /* encryption */, &kmsEnv, user,
+		false /* includeSkipped */, true /* includeCompacted */, false, /* isCustomIncLocation */
+	)
+	if err != nil {
+		return backupInfo{}, err
+	}
+	info.layerToIterFactory, err = backupinfo.GetBackupManifestIterFactories(
+		ctx, execCfg.DistSQLSrv.ExternalStorage, info.manifests, nil /* encryption */, &kmsEnv,
+	)
+	if err != nil {
+		return backupInfo{}, err
+	}
+	return info, nil
+}
+
+// sampleTablesToVerify returns the verifiable tables of the backup with a
+// recorded fingerprint, grouped by database and sampled with the given
+// fraction, as well as the number of sampled tables without a fingerprint.
+// The sample is seeded with seed so that a resumed job samples the same
+// tables.
+func sampleTablesToVerify(
+	ctx context.Context, info backupInfo, sampleFraction float64, seed int64,
+) ([][]tableToVerify, int, error) {
+	last := info.manifests[len(info.manifests)-1]
+	descs, _, err := backupinfo.LoadSQLDescsFromBackupsAtTime(
+		ctx, info.manifests, info.layerToIterFactory, last.EndTime,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	fingerprints := make(map[descpb.ID]uint64, len(last.TableFingerprints))
+	for _, f := range last.TableFingerprints {
+		fingerprints[f.TableID] = f.Fingerprint
+	}
+	names := make(map[descpb.ID]string)
+	var tables []catalog.TableDescriptor
+	for _, desc := range descs {
+		switch d := desc.(type) {
+		case catalog.DatabaseDescriptor, catalog.SchemaDescriptor:
+			names[d.GetID()] = d.GetName()
+		case catalog.TableDescriptor:
+			if isVerifiableTable(d.TableDesc()) {
+				tables = append(tables, d)
+			}
+		}
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].GetID() < tables[j].GetID() })
+
+	rng := rand.New(rand.NewSource(seed))
+	var sampled []catalog.TableDescriptor
+	for _, tbl := range tables {
+		if rng.Float64() < sampleFraction {
+			sampled = append(sampled, tbl)
+		}
+	}
+	if len(sampled) == 0 && len(tables) > 0 {
+		sampled = append(sampled, tables[rng.Intn(len(tables))])
+	}
+
+	byDB := make(map[descpb.ID][]tableToVerify)
+	var dbIDs []descpb.ID
+	withoutFingerprint := 0
+	for _, tbl := range sampled {
+		fingerprint, ok := fingerprints[tbl.GetID()]
+		if !ok {
+			withoutFingerprint++
+			continue
+		}
+		schemaName, ok := names[tbl.GetParentSchemaID()]
+		if !ok {
+			schemaName = catconstants.PublicSchemaName
+		}
+		if _, ok := byDB[tbl.GetParentID()]; !ok {
+			dbIDs = append(dbIDs, tbl.GetParentID())
+		}
+		byDB[tbl.GetParentID()] = append(byDB[tbl.GetParentID()], tableToVerify{
+			name: tree.MakeTableNameWithSchema(
+				tree.Name(names[tbl.GetParentID()]), tree.Name(schemaName), tree.Name(tbl.GetName()),
+			),
+			fingerprint: fingerprint,
+		})
+	}
+	res := make([][]tableToVerify, 0, len(dbIDs))
+	for _, id := range dbIDs {
+		res = append(res, byDB[id])
+	}
+	return res, withoutFingerprint, nil
+}
+
+// restoreAndFingerprint restores the tables, which all belong to the same
+// database in the backup, into a scratch database and returns the names of the
+// tables whose fingerprints do not match the fingerprints recorded in the
+// backup.
+func (r *verifyBackupResumer) restoreAndFingerprint(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user username.SQLUsername,
+	details jobspb.VerifyBackupDetails,
+	subdir string,
+	tables []tableToVerify,
+) ([]string, error) {
+	dbName := tables[0].name.Catalog()
+	scratchDB := fmt.Sprintf("%s%d_%s", verifyBackupScratchDatabasePrefix, r.job.ID(), dbName)
+	executor := execCfg.InternalDB.Executor()
+	override := sessiondata.InternalExecutorOverride{User: user}
+	if _, err := executor.ExecEx(
+		ctx, "verify-backup-create-scratch-db", nil /* txn */, override,
+		fmt.Sprintf(`CREATE DATABASE IF NOT EXISTS %s`, tree.NameString(scratchDB)),
+	); err != nil {
+		return nil, err
+	}
+
+	targets := make([]string, 0, len(tables))
+	for i := range tables {
+		targets = append(targets, tables[i].name.String())
+	}
+	args := []interface{}{subdir, scratchDB}
+	uris := make([]string, 0, len(details.CollectionURIs))
+	for _, uri := range details.CollectionURIs {
+		args = append(args, uri)
+		uris = append(uris, fmt.Sprintf("$%d", len(args)))
+	}
+	row, err := executor.QueryRowEx(
+		ctx, "verify-backup-restore", nil /* txn */, override,
+		fmt.Sprintf(
+			`RESTORE TABLE %s FROM $1 IN (%s) WITH OPTIONS (into_db = $2, skip_missing_foreign_keys, `+
+				`skip_missing_sequences, skip_missing_udfs, detached)`,
+			strings.Join(targets, ", "), strings.Join(uris, ", "),
+		),
+		args...,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "restoring tables of database %s", dbName)
+	}
+	restoreJobID := jobspb.JobID(tree.MustBeDInt(row[0]))
+	if err := execCfg.JobRegistry.WaitForJobs(ctx, []jobspb.JobID{restoreJobID}); err != nil {
+		return nil, errors.Wrapf(err, "restoring tables of database %s", dbName)
+	}
+
+	var mismatched []string
+	for i := range tables {
+		restored := tree.MakeTableNameWithSchema(
+			tree.Name(scratchDB), tables[i].name.SchemaName, tables[i].name.ObjectName,
+		)
+		row, err := executor.QueryRowEx(
+			ctx, "verify-backup-fingerprint", nil /* txn */, override,
+			`SELECT crdb_internal.fingerprint(crdb_internal.table_span($1::REGCLASS::OID::INT8), true)`,
+			restored.String(),
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fingerprinting restored table %s", &tables[i].name)
+		}
+		if fingerprint := uint64(tree.MustBeDInt(row[0])); fingerprint != tables[i].fingerprint {
+			log.Dev.Warningf(ctx, "restored table %s has fingerprint %d, expected %d",
+				&tables[i].name, fingerprint, tables[i].fingerprint)
+			mismatched = append(mismatched, tables[i].name.String())
+		}
+	}
+	return mismatched, nil
+}
+
+// recordVerifyBackupResults persists the progress of the job, and records job
+// messages listing the missing files and the mismatched tables.
+func (r *verifyBackupResumer) recordVerifyBackupResults(
+	ctx context.Context, execCfg *sql.ExecutorConfig, progress jobspb.VerifyBackupProgress,
+) error {
+	return execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		// Messages of the same kind written in a transaction overwrite each
+		// other, so each kind is recorded in a single message.
+		if len(progress.MissingFiles) > 0 {
+			if err := r.job.Messages().Record(
+				ctx, txn, "missing-files", strings.Join(progress.MissingFiles, ", "),
+			); err != nil {
+				return err
+			}
+		}
+		if len(progress.MismatchedTables) > 0 {
+			if err := r.job.Messages().Record(
+				ctx, txn, "mismatched-tables", strings.Join(progress.MismatchedTables, ", "),
+			); err != nil {
+				return err
+			}
+		}
+		return r.job.WithTxn(txn).Update(ctx, func(
+			txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+		) error {
+			md.Progress.Details = jobspb.WrapProgressDetails(progress)
+			ju.UpdateProgress(md.Progress)
+			return nil
+		})
+	})
+}
+
+// dropVerifyBackupScratchDatabases drops the scratch databases of the job.
+func dropVerifyBackupScratchDatabases(
+	ctx context.Context, execCfg *sql.ExecutorConfig, user username.SQLUsername, jobID jobspb.JobID,
+) error {
+	executor := execCfg.InternalDB.Executor()
+	override := sessiondata.InternalExecutorOverride{User: user}
+	rows, err := executor.QueryBufferedEx(
+		ctx, "verify-backup-list-scratch-dbs", nil /* txn */, override,
+		`SELECT name FROM crdb_internal.databases WHERE starts_with(name, $1)`,
+		fmt.Sprintf("%s%d_", verifyBackupScratchDatabasePrefix, jobID),
+	)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		name := string(tree.MustBeDString(row[0]))
+		if _, err := executor.ExecEx(
+			ctx, "verify-backup-drop-scratch-db", nil /* txn */, override,
+			fmt.Sprintf(`DROP DATABASE IF EXISTS %s CASCADE`, tree.NameString(name)),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReportResults implements the jobs.JobResultsReporter interface.
+func (r *verifyBackupResumer) ReportResults(ctx context.Context, resultsCh chan<- tree.Datums) error {
+	select {
+	case resultsCh <- tree.Datums{
+		tree.NewDInt(tree.DInt(r.job.ID())),
+		tree.NewDString(string(jobs.StateSucceeded)),
+		tree.NewDInt(tree.DInt(r.progress.TablesVerified)),
+		tree.NewDInt(tree.DInt(r.progress.TablesWithoutFingerprint)),
+	}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// OnFailOrCancel is part of the jobs.Resumer interface.
+func (r *verifyBackupResumer) OnFailOrCancel(
+	ctx context.Context, execCtx interface{}, jobErr error,
+) error {
+	p := execCtx.(sql.JobExecContext)
+	return dropVerifyBackupScratchDatabases(ctx, p.ExecCfg(), p.User(), r.job.ID())
+}
+
+// CollectProfile is part of the jobs.Resumer interface.
+func (r *verifyBackupResumer) CollectProfile(_ context.Context, _ interface{}) error {
+	return nil
+}
+
+func init() {
+	jobs.RegisterConstructor(
+		jobspb.TypeVerifyBackup,
+		func(job *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+			return &verifyBackupResumer{job: job}
+		},
+		jobs.UsesTenantCostControl,
+		jobs.WithJobMetrics(makeVerifyBackupMetrics()),
+	)
+}
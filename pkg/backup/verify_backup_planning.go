@@ -0,0 +1,256 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/featureflag"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+const verifyBackupOp = "VERIFY BACKUP"
+
+const (
+	verifyBackupOptSample   = "sample"
+	verifyBackupOptDetached = "detached"
+)
+
+var verifyBackupOptionExpectValues = map[string]exprutil.KVStringOptValidate{
+	verifyBackupOptSample:   exprutil.KVStringOptRequireValue,
+	verifyBackupOptDetached: exprutil.KVStringOptRequireNoValue,
+}
+
+// verifyBackupHeader is the header of the results of VERIFY BACKUP statements
+// that are not detached.
+var verifyBackupHeader = colinfo.ResultColumns{
+	{Name: "job_id", Typ: types.Int},
+	{Name: "status", Typ: types.String},
+	{Name: "tables_verified", Typ: types.Int},
+	{Name: "tables_without_fingerprint", Typ: types.Int},
+}
+
+// annotatedVerifyBackupStatement is a tree.VerifyBackup, optionally annotated
+// with the scheduling information.
+type annotatedVerifyBackupStatement struct {
+	*tree.VerifyBackup
+	*jobs.CreatedByInfo
+}
+
+func getVerifyBackupStatement(stmt tree.Statement) *annotatedVerifyBackupStatement {
+	switch verify := stmt.(type) {
+	case *annotatedVerifyBackupStatement:
+		return verify
+	case *tree.VerifyBackup:
+		return &annotatedVerifyBackupStatement{VerifyBackup: verify}
+	default:
+		return nil
+	}
+}
+
+// parseVerifyBackupSample returns the sample fraction in the evaluated options
+// of a VERIFY BACKUP statement, which defaults to verifying every table.
+func parseVerifyBackupSample(opts map[string]string) (float64, error) {
+	v, ok := opts[verifyBackupOptSample]
+	if !ok {
+		return 1, nil
+	}
+	sample, err := strconv.ParseFloat(v, 64)
+	if err != nil || sample <= 0 || sample > 1 {
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s must be a fraction greater than 0 and at most 1, got %q", verifyBackupOptSample, v)
+	}
+	return sample, nil
+}
+
+func checkVerifyBackupPrivileges(ctx context.Context, p sql.PlanHookState) error {
+	// Verifying a backup restores its tables and reads its files, which may
+	// belong to any database in the cluster.
+	if hasAdmin, err := p.HasAdminRole(ctx); err != nil {
+		return err
+	} else if !hasAdmin {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"only users with the admin role are allowed to run %s", verifyBackupOp)
+	}
+	return nil
+}
+
+func verifyBackupTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	verifyStmt := getVerifyBackupStatement(stmt)
+	if verifyStmt == nil {
+		return false, nil, nil
+	}
+	if err := exprutil.TypeCheck(
+		ctx, verifyBackupOp, p.SemaCtx(),
+		exprutil.Strings{verifyStmt.Subdir},
+		exprutil.StringArrays{tree.Exprs(verifyStmt.From)},
+		&exprutil.KVOptions{
+			KVOptions:  verifyStmt.Options,
+			Validation: verifyBackupOptionExpectValues,
+		},
+	); err != nil {
+		return false, nil, err
+	}
+	header = verifyBackupHeader
+	for _, opt := range verifyStmt.Options {
+		if opt.Key == verifyBackupOptDetached {
+			header = jobs.DetachedJobExecutionResultHeader
+		}
+	}
+	return true, header, nil
+}
+
+// verifyBackupPlanHook implements sql.PlanHookFn.
+func verifyBackupPlanHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	verifyStmt := getVerifyBackupStatement(stmt)
+	if verifyStmt == nil {
+		return nil, nil, false, nil
+	}
+
+	if err := featureflag.CheckEnabled(
+		ctx, p.ExecCfg(), featureRestoreEnabled, verifyBackupOp,
+	); err != nil {
+		return nil, nil, false, err
+	}
+
+	exprEval := p.ExprEvaluator(verifyBackupOp)
+	subdir, err := exprEval.String(ctx, verifyStmt.Subdir)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	from, err := exprEval.StringArray(ctx, tree.Exprs(verifyStmt.From))
+	if err != nil {
+		return nil, nil, false, err
+	}
+	opts, err := exprEval.KVOptions(ctx, verifyStmt.Options, verifyBackupOptionExpectValues)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	sample, err := parseVerifyBackupSample(opts)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	_, detached := opts[verifyBackupOptDetached]
+
+	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
+		if !(p.ExtendedEvalContext().TxnIsSingleStmt || detached) {
+			return errors.Errorf("VERIFY BACKUP cannot be used inside a multi-statement transaction without DETACHED option")
+		}
+		if err := checkVerifyBackupPrivileges(ctx, p); err != nil {
+			return err
+		}
+		defaultCollectionURI, _, err := backupdest.GetURIsByLocalityKV(from, "")
+		if err != nil {
+			return err
+		}
+		// The backup is resolved when the job is created, so that a scheduled
+		// verification verifies the latest backup as of when it ran.
+		resolvedSubdir, err := resolveBackupSubdir(ctx, p.ExecCfg(), p.User(), defaultCollectionURI, subdir)
+		if err != nil {
+			return err
+		}
+		details := jobspb.VerifyBackupDetails{
+			CollectionURIs: from,
+			Subdir:         resolvedSubdir,
+			SampleFraction: sample,
+		}
+		description, err := verifyBackupJobDescription(details)
+		if err != nil {
+			return err
+		}
+		jr := jobs.Record{
+			Description: description,
+			Details:     details,
+			Progress:    jobspb.VerifyBackupProgress{},
+			CreatedBy:   verifyStmt.CreatedByInfo,
+			Username:    p.User(),
+		}
+		jobID := p.ExecCfg().JobRegistry.MakeJobID()
+
+		if detached {
+			if _, err := p.ExecCfg().JobRegistry.CreateAdoptableJobWithTxn(
+				ctx, jr, jobID, p.InternalSQLTxn(),
+			); err != nil {
+				return err
+			}
+			resultsCh <- tree.Datums{tree.NewDInt(tree.DInt(jobID))}
+			return nil
+		}
+		var sj *jobs.StartableJob
+		if err := func() (err error) {
+			defer func() {
+				if err == nil || sj == nil {
+					return
+				}
+				if cleanupErr := sj.CleanupOnRollback(ctx); cleanupErr != nil {
+					log.Dev.Errorf(ctx, "failed to cleanup job: %v", cleanupErr)
+				}
+			}()
+			if err := p.ExecCfg().JobRegistry.CreateStartableJobWithTxn(
+				ctx, &sj, jobID, p.InternalSQLTxn(), jr,
+			); err != nil {
+				return err
+			}
+			return p.Txn().Commit(ctx)
+		}(); err != nil {
+			return err
+		}
+		p.InternalSQLTxn().Descriptors().ReleaseAll(ctx)
+		if err := sj.Start(ctx); err != nil {
+			return err
+		}
+		if err := sj.AwaitCompletion(ctx); err != nil {
+			return err
+		}
+		return sj.ReportExecutionResults(ctx, resultsCh)
+	}
+
+	telemetry.Count("verify-backup.total")
+	if detached {
+		return fn, jobs.DetachedJobExecutionResultHeader, false, nil
+	}
+	return fn, verifyBackupHeader, false, nil
+}
+
+// verifyBackupJobDescription generates a redacted description of the job.
+func verifyBackupJobDescription(details jobspb.VerifyBackupDetails) (string, error) {
+	redactedURIs, err := sanitizeURIList(details.CollectionURIs)
+	if err != nil {
+		return "", err
+	}
+	node := &tree.VerifyBackup{
+		Subdir: tree.NewDString(details.Subdir),
+		From:   redactedURIs,
+	}
+	if details.SampleFraction < 1 {
+		node.Options = tree.KVOptions{{
+			Key:   verifyBackupOptSample,
+			Value: tree.NewDString(strconv.FormatFloat(details.SampleFraction, 'g', -1, 64)),
+		}}
+	}
+	return tree.AsString(node), nil
+}
+
+func init() {
+	sql.AddPlanHook("verify backup", verifyBackupPlanHook, verifyBackupTypeCheck)
+}
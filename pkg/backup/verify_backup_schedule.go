@@ -0,0 +1,458 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupbase"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/scheduledjobs"
+	"github.com/cockroachdb/cockroach/pkg/scheduledjobs/schedulebase"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+	pbtypes "github.com/gogo/protobuf/types"
+)
+
+const scheduleVerifyBackupOp = "CREATE SCHEDULE FOR VERIFY BACKUP"
+
+var scheduledVerifyBackupOptionExpectValues = map[string]exprutil.KVStringOptValidate{
+	optFirstRun:          exprutil.KVStringOptRequireValue,
+	optOnExecFailure:     exprutil.KVStringOptRequireValue,
+	optOnPreviousRunning: exprutil.KVStringOptRequireValue,
+}
+
+// scheduledVerifyBackupHeader is the header for "CREATE SCHEDULE FOR VERIFY
+// BACKUP" statements results.
+var scheduledVerifyBackupHeader = colinfo.ResultColumns{
+	{Name: "schedule_id", Typ: types.Int},
+	{Name: "label", Typ: types.String},
+	{Name: "status", Typ: types.String},
+	{Name: "first_run", Typ: types.TimestampTZ},
+	{Name: "schedule", Typ: types.String},
+	{Name: "verify_backup_stmt", Typ: types.String},
+}
+
+type scheduledVerifyBackupExecutor struct {
+	metrics *jobs.ExecutorMetrics
+}
+
+var _ jobs.ScheduledJobExecutor = (*scheduledVerifyBackupExecutor)(nil)
+
+// ExecuteJob implements jobs.ScheduledJobExecutor interface.
+func (e *scheduledVerifyBackupExecutor) ExecuteJob(
+	ctx context.Context,
+	txn isql.Txn,
+	cfg *scheduledjobs.JobExecutionConfig,
+	env scheduledjobs.JobSchedulerEnv,
+	sj *jobs.ScheduledJob,
+) error {
+	if err := e.executeVerifyBackup(ctx, txn, cfg, sj); err != nil {
+		e.metrics.NumFailed.Inc(1)
+		return err
+	}
+	e.metrics.NumStarted.Inc(1)
+	return nil
+}
+
+func (e *scheduledVerifyBackupExecutor) executeVerifyBackup(
+	ctx context.Context, txn isql.Txn, cfg *scheduledjobs.JobExecutionConfig, sj *jobs.ScheduledJob,
+) error {
+	verifyStmt, err := extractVerifyBackupStatement(sj)
+	if err != nil {
+		return err
+	}
+
+	// Sanity check: the verification should be detached.
+	if _, detached := findKVOption(verifyStmt.Options, verifyBackupOptDetached); !detached {
+		verifyStmt.Options = append(verifyStmt.Options, tree.KVOption{Key: verifyBackupOptDetached})
+		log.Dev.Warningf(ctx, "force setting detached option for verify backup schedule %d",
+			sj.ScheduleID())
+	}
+
+	// Sanity check: make sure the schedule is not paused (this shouldn't happen
+	// since job scheduler ignores paused schedules).
+	if sj.IsPaused() {
+		return errors.New("scheduled unexpectedly paused")
+	}
+
+	hook, cleanup := cfg.PlanHookMaker(ctx, "exec-verify-backup", txn.KV(), sj.Owner())
+	defer cleanup()
+
+	planner := hook.(sql.PlanHookState)
+	currentClusterID := planner.ExtendedEvalContext().ClusterID
+	currentDetails := sj.ScheduleDetails()
+
+	// If the current cluster ID is different than the schedule's cluster ID,
+	// pause the schedule. A restored cluster should not verify the backups
+	// of the cluster it was restored from.
+	if !currentDetails.ClusterID.Equal(uuid.Nil) && currentClusterID != currentDetails.ClusterID {
+		log.Dev.Infof(ctx, "schedule %d last run by different cluster %s, pausing until manually resumed",
+			sj.ScheduleID(),
+			currentDetails.ClusterID)
+		currentDetails.ClusterID = currentClusterID
+		sj.SetScheduleDetails(*currentDetails)
+		sj.Pause()
+		return nil
+	}
+
+	log.Dev.Infof(ctx, "Starting scheduled verify backup %d", sj.ScheduleID())
+	fn, _, _, err := verifyBackupPlanHook(ctx, verifyStmt, planner)
+	if err != nil {
+		return errors.Wrapf(err, "failed to evaluate verify backup stmt")
+	}
+	return invokeVerifyBackup(ctx, fn)
+}
+
+func invokeVerifyBackup(ctx context.Context, fn sql.PlanHookRowFn) error {
+	resultCh := make(chan tree.Datums) // No need to close
+	g := ctxgroup.WithContext(ctx)
+
+	g.GoCtx(func(ctx context.Context) error {
+		select {
+		case <-resultCh:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	g.GoCtx(func(ctx context.Context) error {
+		return fn(ctx, resultCh)
+	})
+
+	return g.Wait()
+}
+
+// findKVOption returns the option with the given key.
+func findKVOption(opts tree.KVOptions, key string) (tree.KVOption, bool) {
+	for _, opt := range opts {
+		if string(opt.Key) == key {
+			return opt, true
+		}
+	}
+	return tree.KVOption{}, false
+}
+
+// NotifyJobTermination implements jobs.ScheduledJobExecutor interface.
+func (e *scheduledVerifyBackupExecutor) NotifyJobTermination(
+	ctx context.Context,
+	txn isql.Txn,
+	jobID jobspb.JobID,
+	jobState jobs.State,
+	details jobspb.Details,
+	env scheduledjobs.JobSchedulerEnv,
+	schedule *jobs.ScheduledJob,
+) error {
+	if jobState == jobs.StateSucceeded {
+		e.metrics.NumSucceeded.Inc(1)
+		log.Dev.Infof(ctx, "verify backup job %d scheduled by %d succeeded", jobID, schedule.ScheduleID())
+		return nil
+	}
+
+	e.metrics.NumFailed.Inc(1)
+	err := errors.Errorf(
+		"verify backup job %d scheduled by %d failed with state %s",
+		jobID, schedule.ScheduleID(), jobState)
+	log.Dev.Errorf(ctx, "verify backup error: %v", err)
+	jobs.DefaultHandleFailedRun(schedule, "verify backup job %d failed with err=%v", jobID, err)
+	return nil
+}
+
+// Metrics implements jobs.ScheduledJobExecutor interface.
+func (e *scheduledVerifyBackupExecutor) Metrics() metric.Struct {
+	return e.metrics
+}
+
+// GetCreateScheduleStatement implements jobs.ScheduledJobExecutor interface.
+func (e *scheduledVerifyBackupExecutor) GetCreateScheduleStatement(
+	ctx context.Context, txn isql.Txn, env scheduledjobs.JobSchedulerEnv, sj *jobs.ScheduledJob,
+) (string, error) {
+	verifyStmt, err := extractVerifyBackupStatement(sj)
+	if err != nil {
+		return "", err
+	}
+
+	wait, err := schedulebase.ParseOnPreviousRunningOption(sj.ScheduleDetails().Wait)
+	if err != nil {
+		return "", err
+	}
+	onError, err := schedulebase.ParseOnErrorOption(sj.ScheduleDetails().OnError)
+	if err != nil {
+		return "", err
+	}
+
+	var opts tree.KVOptions
+	for _, opt := range verifyStmt.Options {
+		if opt.Key != verifyBackupOptDetached {
+			opts = append(opts, opt)
+		}
+	}
+	node := &tree.ScheduledVerifyBackup{
+		VerifyBackup: &tree.VerifyBackup{
+			Subdir:  verifyStmt.Subdir,
+			From:    verifyStmt.From,
+			Options: opts,
+		},
+		ScheduleLabelSpec: tree.LabelSpec{
+			IfNotExists: false,
+			Label:       tree.NewDString(sj.ScheduleLabel()),
+		},
+		Recurrence: tree.NewDString(sj.ScheduleExpr()),
+		ScheduleOptions: tree.KVOptions{
+			tree.KVOption{
+				Key:   optOnExecFailure,
+				Value: tree.NewDString(onError),
+			},
+			tree.KVOption{
+				Key:   optOnPreviousRunning,
+				Value: tree.NewDString(wait),
+			},
+		},
+	}
+	return tree.AsString(node), nil
+}
+
+// extractVerifyBackupStatement returns the tree.VerifyBackup node encoded
+// inside the scheduled job.
+func extractVerifyBackupStatement(sj *jobs.ScheduledJob) (*annotatedVerifyBackupStatement, error) {
+	args := &backuppb.ScheduledVerifyBackupExecutionArgs{}
+	if err := pbtypes.UnmarshalAny(sj.ExecutionArgs().Args, args); err != nil {
+		return nil, errors.Wrap(err, "un-marshaling args")
+	}
+
+	node, err := parser.ParseOne(args.VerifyBackupStatement)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing verify backup statement")
+	}
+
+	if verifyStmt, ok := node.AST.(*tree.VerifyBackup); ok {
+		return &annotatedVerifyBackupStatement{
+			VerifyBackup: verifyStmt,
+			CreatedByInfo: &jobs.CreatedByInfo{
+				Name: jobs.CreatedByScheduledJobs,
+				ID:   int64(sj.ScheduleID()),
+			},
+		}, nil
+	}
+
+	return nil, errors.AssertionFailedf("unexpect node type %T", node)
+}
+
+func createVerifyBackupScheduleTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	schedule, ok := stmt.(*tree.ScheduledVerifyBackup)
+	if !ok {
+		return false, nil, nil
+	}
+	if err := exprutil.TypeCheck(ctx, scheduleVerifyBackupOp, p.SemaCtx(),
+		exprutil.Strings{
+			schedule.ScheduleLabelSpec.Label,
+			schedule.Recurrence,
+		},
+		exprutil.StringArrays{tree.Exprs(schedule.From)},
+		&exprutil.KVOptions{
+			KVOptions:  schedule.Options,
+			Validation: verifyBackupOptionExpectValues,
+		},
+		&exprutil.KVOptions{
+			KVOptions:  schedule.ScheduleOptions,
+			Validation: scheduledVerifyBackupOptionExpectValues,
+		},
+	); err != nil {
+		return false, nil, err
+	}
+	return true, scheduledVerifyBackupHeader, nil
+}
+
+// createVerifyBackupScheduleHook implements sql.PlanHookFn for a schedule that
+// periodically verifies the latest backup in a collection.
+func createVerifyBackupScheduleHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	schedule, ok := stmt.(*tree.ScheduledVerifyBackup)
+	if !ok {
+		return nil, nil, false, nil
+	}
+
+	exprEval := p.ExprEvaluator(scheduleVerifyBackupOp)
+	var label string
+	if schedule.ScheduleLabelSpec.Label != nil {
+		var err error
+		label, err = exprEval.String(ctx, schedule.ScheduleLabelSpec.Label)
+		if err != nil {
+			return nil, nil, false, err
+		}
+	}
+	if schedule.Recurrence == nil {
+		// Sanity check: recurrence must be specified.
+		return nil, nil, false, errors.New("RECURRING clause required")
+	}
+	rec, err := exprEval.String(ctx, schedule.Recurrence)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	scheduleOpts, err := exprEval.KVOptions(
+		ctx, schedule.ScheduleOptions, scheduledVerifyBackupOptionExpectValues,
+	)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	from, err := exprEval.StringArray(ctx, tree.Exprs(schedule.From))
+	if err != nil {
+		return nil, nil, false, err
+	}
+	opts, err := exprEval.KVOptions(ctx, schedule.Options, verifyBackupOptionExpectValues)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	sample, err := parseVerifyBackupSample(opts)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
+		if err := checkVerifyBackupPrivileges(ctx, p); err != nil {
+			return err
+		}
+
+		env := sql.JobSchedulerEnv(p.ExecCfg().JobsKnobs())
+		if knobs, ok := p.ExecCfg().DistSQLSrv.TestingKnobs.JobsTestingKnobs.(*jobs.TestingKnobs); ok {
+			if knobs.JobSchedulerEnv != nil {
+				env = knobs.JobSchedulerEnv
+			}
+		}
+		recurrence, err := schedulebase.ComputeScheduleRecurrence(env.Now(), &rec)
+		if err != nil {
+			return err
+		}
+
+		if schedule.ScheduleLabelSpec.Label != nil {
+			if schedule.ScheduleLabelSpec.IfNotExists {
+				exists, err := schedulebase.CheckScheduleAlreadyExists(ctx, p, label)
+				if err != nil {
+					return err
+				}
+				if exists {
+					p.BufferClientNotice(ctx,
+						pgnotice.Newf("schedule %q already exists, skipping", label),
+					)
+					return nil
+				}
+			}
+		} else {
+			label = fmt.Sprintf("VERIFY BACKUP %d", env.Now().Unix())
+		}
+
+		evalCtx := &p.ExtendedEvalContext().Context
+		firstRun, err := scheduleFirstRun(evalCtx, scheduleOpts)
+		if err != nil {
+			return err
+		}
+		details, err := makeScheduleDetails(
+			scheduleOpts, evalCtx.ClusterID, p.ExecCfg().Settings.Version.ActiveVersion(ctx),
+		)
+		if err != nil {
+			return err
+		}
+
+		// The schedule always verifies the latest backup in the collection, as
+		// of when it runs.
+		verifyNode := &tree.VerifyBackup{
+			Subdir:  tree.NewDString(backupbase.LatestFileName),
+			Options: tree.KVOptions{{Key: verifyBackupOptDetached}},
+		}
+		for _, uri := range from {
+			verifyNode.From = append(verifyNode.From, tree.NewDString(uri))
+		}
+		if _, ok := opts[verifyBackupOptSample]; ok {
+			verifyNode.Options = append(verifyNode.Options, tree.KVOption{
+				Key:   verifyBackupOptSample,
+				Value: tree.NewDString(opts[verifyBackupOptSample]),
+			})
+		}
+
+		sj := jobs.NewScheduledJob(env)
+		sj.SetScheduleLabel(label)
+		sj.SetOwner(p.User())
+		if err := sj.SetScheduleAndNextRun(recurrence.Cron); err != nil {
+			return err
+		}
+		sj.SetScheduleDetails(details)
+		args := backuppb.ScheduledVerifyBackupExecutionArgs{
+			VerifyBackupStatement: tree.AsStringWithFlags(verifyNode, tree.FmtParsable|tree.FmtShowPasswords),
+		}
+		any, err := pbtypes.MarshalAny(&args)
+		if err != nil {
+			return err
+		}
+		sj.SetExecutionDetails(
+			tree.ScheduledVerifyBackupExecutor.InternalName(), jobspb.ExecutionArguments{Args: any},
+		)
+		if firstRun != nil {
+			sj.SetNextRun(*firstRun)
+		}
+		if err := jobs.ScheduledJobTxn(p.InternalSQLTxn()).Create(ctx, sj); err != nil {
+			return err
+		}
+
+		description, err := verifyBackupJobDescription(jobspb.VerifyBackupDetails{
+			CollectionURIs: from,
+			Subdir:         backupbase.LatestFileName,
+			SampleFraction: sample,
+		})
+		if err != nil {
+			return err
+		}
+		nextRun, err := tree.MakeDTimestampTZ(sj.NextRun(), time.Microsecond)
+		if err != nil {
+			return err
+		}
+		resultsCh <- tree.Datums{
+			tree.NewDInt(tree.DInt(sj.ScheduleID())),
+			tree.NewDString(sj.ScheduleLabel()),
+			tree.NewDString("ACTIVE"),
+			nextRun,
+			tree.NewDString(sj.ScheduleExpr()),
+			tree.NewDString(description),
+		}
+		telemetry.Count("scheduled-verify-backup.create.success")
+		return nil
+	}
+	return fn, scheduledVerifyBackupHeader, false, nil
+}
+
+func init() {
+	sql.AddPlanHook(
+		"schedule verify backup", createVerifyBackupScheduleHook, createVerifyBackupScheduleTypeCheck,
+	)
+
+	jobs.RegisterScheduledJobExecutorFactory(
+		tree.ScheduledVerifyBackupExecutor.InternalName(),
+		func() (jobs.ScheduledJobExecutor, error) {
+			m := jobs.MakeExecutorMetrics(tree.ScheduledVerifyBackupExecutor.UserName())
+			return &scheduledVerifyBackupExecutor{
+				metrics: &m,
+			}, nil
+		})
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBackup(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	tempDir, tempDirCleanup := testutils.TempDir(t)
+	defer tempDirCleanup()
+	_, db, cleanupDB := backupRestoreTestSetupEmpty(
+		t, singleNode, tempDir, InitManualReplication, base.TestClusterArgs{},
+	)
+	defer cleanupDB()
+
+	db.Exec(t, "SET CLUSTER SETTING bulkio.backup.record_table_fingerprints.enabled = true")
+	db.Exec(t, "CREATE DATABASE d")
+	db.Exec(t, "CREATE SCHEMA d.sc")
+	db.Exec(t, "CREATE TABLE d.t (a INT PRIMARY KEY, b STRING)")
+	db.Exec(t, "INSERT INTO d.t SELECT i, repeat('x', i) FROM generate_series(1, 100) AS g(i)")
+	db.Exec(t, "CREATE TABLE d.sc.u (a INT PRIMARY KEY, b INT REFERENCES d.t (a))")
+	db.Exec(t, "INSERT INTO d.sc.u SELECT i, i FROM generate_series(1, 50) AS g(i)")
+	db.Exec(t, "CREATE VIEW d.v AS SELECT a FROM d.t")
+
+	const collectionURI = "nodelocal://1/verify"
+	db.Exec(t, "BACKUP DATABASE d INTO $1", collectionURI)
+	db.Exec(t, "UPDATE d.t SET b = 'y' WHERE a < 10")
+	db.Exec(t, "DELETE FROM d.sc.u WHERE a > 40")
+	db.Exec(t, "BACKUP DATABASE d INTO LATEST IN $1", collectionURI)
+
+	verify := func(stmt string) (status string, verified, withoutFingerprint int) {
+		var jobID int
+		db.QueryRow(t, stmt, collectionURI).Scan(&jobID, &status, &verified, &withoutFingerprint)
+		// The scratch databases are dropped once the tables are verified.
+		db.CheckQueryResults(t,
+			`SELECT count(*) FROM [SHOW DATABASES] WHERE starts_with(database_name, 'crdb_verify_backup_')`,
+			[][]string{{"0"}},
+		)
+		return status, verified, withoutFingerprint
+	}
+
+	// Every table of the backup chain is restored and matches the fingerprint
+	// recorded by the incremental backup.
+	status, verified, withoutFingerprint := verify("VERIFY BACKUP FROM LATEST IN $1")
+	require.Equal(t, "succeeded", status)
+	require.Equal(t, 2, verified)
+	require.Zero(t, withoutFingerprint)
+	db.CheckQueryResults(t, "SELECT count(*) FROM d.sc.u", [][]string{{"40"}})
+
+	// A sample verifies a single table when the fraction is too small to
+	// select any, rather than none.
+	status, verified, _ = verify("VERIFY BACKUP FROM LATEST IN $1 WITH sample = '0.000001'")
+	require.Equal(t, "succeeded", status)
+	require.Equal(t, 1, verified)
+
+	db.ExpectErr(t, "sample must be a fraction",
+		"VERIFY BACKUP FROM LATEST IN $1 WITH sample = '2'", collectionURI)
+
+	// Backups taken without recording fingerprints cannot be verified.
+	db.Exec(t, "SET CLUSTER SETTING bulkio.backup.record_table_fingerprints.enabled = false")
+	db.Exec(t, "BACKUP DATABASE d INTO $1", collectionURI)
+	status, verified, withoutFingerprint = verify("VERIFY BACKUP FROM LATEST IN $1")
+	require.Equal(t, "succeeded", status)
+	require.Zero(t, verified)
+	require.Equal(t, 2, withoutFingerprint)
+
+	// A missing file fails the verification and is recorded in the messages of
+	// the job.
+	db.Exec(t, "SET CLUSTER SETTING bulkio.backup.record_table_fingerprints.enabled = true")
+	db.Exec(t, "BACKUP DATABASE d INTO $1", collectionURI)
+	var file string
+	db.QueryRow(t, "SELECT path FROM [SHOW BACKUP FILES FROM LATEST IN $1] LIMIT 1", collectionURI).Scan(&file)
+	require.NoError(t, os.Remove(filepath.Join(tempDir, "verify", file)))
+	db.ExpectErr(t, "failed verification: 1 missing files",
+		"VERIFY BACKUP FROM LATEST IN $1", collectionURI)
+	var messages int
+	db.QueryRow(t,
+		`SELECT count(*) FROM system.job_message WHERE kind = 'missing-files' AND strpos(message, $1) > 0`,
+		file,
+	).Scan(&messages)
+	require.Equal(t, 1, messages)
+}
+
+// TestVerifyBackupFingerprintMismatch checks that VERIFY BACKUP fails when a
+// restored table does not match the fingerprint recorded by the backup.
+func TestVerifyBackupFingerprintMismatch(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// corruptTableID is the ID of the table whose recorded fingerprint is
+	// altered by the backup.
+	var corruptTableID atomic.Uint32
+	tempDir, tempDirCleanup := testutils.TempDir(t)
+	defer tempDirCleanup()
+	_, db, cleanupDB := backupRestoreTestSetupEmpty(
+		t, singleNode, tempDir, InitManualReplication, base.TestClusterArgs{
+			ServerArgs: base.TestServerArgs{
+				Knobs: base.TestingKnobs{
+					BackupRestore: &sql.BackupRestoreTestingKnobs{
+						AfterTableFingerprints: func(fingerprints []backuppb.BackupManifest_TableFingerprint) {
+							for i := range fingerprints {
+								if fingerprints[i].TableID == descpb.ID(corruptTableID.Load()) {
+									fingerprints[i].Fingerprint++
+								}
+							}
+						},
+					},
+				},
+			},
+		},
+	)
+	defer cleanupDB()
+
+	db.Exec(t, "SET CLUSTER SETTING bulkio.backup.record_table_fingerprints.enabled = true")
+	db.Exec(t, "CREATE DATABASE d")
+	db.Exec(t, "CREATE SCHEMA d.sc")
+	db.Exec(t, "CREATE TABLE d.t (a INT PRIMARY KEY, b STRING)")
+	db.Exec(t, "INSERT INTO d.t SELECT i, repeat('x', i) FROM generate_series(1, 100) AS g(i)")
+	db.Exec(t, "CREATE TABLE d.sc.u (a INT PRIMARY KEY, b INT)")
+	db.Exec(t, "INSERT INTO d.sc.u SELECT i, i FROM generate_series(1, 50) AS g(i)")
+
+	var tableID uint32
+	db.QueryRow(t, "SELECT 'd.sc.u'::REGCLASS::INT").Scan(&tableID)
+	corruptTableID.Store(tableID)
+
+	const collectionURI = "nodelocal://1/verify"
+	db.Exec(t, "BACKUP DATABASE d INTO $1", collectionURI)
+	db.ExpectErr(t, "failed verification: 0 missing files, 1 mismatched tables",
+		"VERIFY BACKUP FROM LATEST IN $1", collectionURI)
+
+	var message string
+	db.QueryRow(t,
+		`SELECT message FROM system.job_message WHERE kind = 'mismatched-tables'`,
+	).Scan(&message)
+	require.Contains(t, message, "sc.u")
+	require.NotContains(t, message, ".t")
+	db.CheckQueryResults(t,
+		`SELECT count(*) FROM [SHOW DATABASES] WHERE starts_with(database_name, 'crdb_verify_backup_')`,
+		[][]string{{"0"}},
+	)
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/backup"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvfeed"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// backupHistorySource is a kvfeed.HistorySource which reads the history of
+// the watched spans from the latest revision history backup in a collection,
+// which lets a changefeed with a cursor replay history which has been garbage
+// collected, up to the end time of the backup.
+type backupHistorySource struct {
+	storeFactory cloud.ExternalStorageFactory
+	// manifests are the layers of the backup, in chronological order.
+	manifests []backuppb.BackupManifest
+}
+
+var _ kvfeed.HistorySource = (*backupHistorySource)(nil)
+
+func newBackupHistorySource(
+	ctx context.Context, execCfg *sql.ExecutorConfig, user username.SQLUsername, collectionURI string,
+) (*backupHistorySource, error) {
+	manifests, err := backup.ResolveRevisionHistoryBackup(ctx, execCfg, user, collectionURI)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving backup to replay history from")
+	}
+	return &backupHistorySource{
+		storeFactory: execCfg.DistSQLSrv.ExternalStorage,
+		manifests:    manifests,
+	}, nil
+}
+
+// EndTime implements the kvfeed.HistorySource interface.
+func (s *backupHistorySource) EndTime() hlc.Timestamp {
+	return s.manifests[len(s.manifests)-1].EndTime
+}
+
+// Replay implements the kvfeed.HistorySource interface.
+func (s *backupHistorySource) Replay(
+	ctx context.Context,
+	sink kvevent.Writer,
+	spans []roachpb.Span,
+	start, end hlc.Timestamp,
+	withDiff bool,
+) error {
+	return s.forEachKey(ctx, spans, start, end, func(kv backup.VersionedValues) error {
+		// The revisions are ordered from newest to oldest, and the oldest may be
+		// the one live at start.
+		var prev roachpb.Value
+		for i := len(kv.Values) - 1; i >= 0; i-- {
+			v := kv.Values[i]
+			if v.Timestamp.LessEq(start) {
+				prev = v
+				continue
+			}
+			ev := &kvpb.RangeFeedEvent{Val: &kvpb.RangeFeedValue{Key: kv.Key, Value: v}}
+			if withDiff {
+				// Like the rangefeed, the previous value carries no timestamp.
+				ev.Val.PrevValue = roachpb.Value{RawBytes: prev.RawBytes}
+			}
+			if err := sink.Add(ctx, kvevent.MakeKVEvent(ev)); err != nil {
+				return err
+			}
+			prev = v
+		}
+		return nil
+	})
+}
+
+// Scan implements the kvfeed.HistorySource interface.
+func (s *backupHistorySource) Scan(
+	ctx context.Context, sink kvevent.Writer, spans []roachpb.Span, ts hlc.Timestamp, withDiff bool,
+) error {
+	return s.forEachKey(ctx, spans, ts, ts, func(kv backup.VersionedValues) error {
+		// Only the revision live at ts is read, and deleted keys are skipped like
+		// they would be by a scan.
+		v := kv.Values[len(kv.Values)-1]
+		if len(v.RawBytes) == 0 {
+			return nil
+		}
+		return sink.Add(ctx, kvevent.NewBackfillKVEvent(kv.Key, v.Timestamp, v.RawBytes, withDiff, ts))
+	})
+}
+
+// forEachKey calls fn with the revisions of each key in spans which are in
+// (start, end], as well as the revision live at start.
+func (s *backupHistorySource) forEachKey(
+	ctx context.Context,
+	spans []roachpb.Span,
+	start, end hlc.Timestamp,
+	fn func(backup.VersionedValues) error,
+) error {
+	for _, sp := range spans {
+		allRevs := make(chan []backup.VersionedValues)
+		g := ctxgroup.WithContext(ctx)
+		g.GoCtx(func(ctx context.Context) error {
+			defer close(allRevs)
+			return backup.GetAllRevisionsFromBackup(ctx, s.storeFactory, s.manifests, sp, start, end, allRevs)
+		})
+		g.GoCtx(func(ctx context.Context) error {
+			for revs := range allRevs {
+				for _, kv := range revs {
+					if err := fn(kv); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+		if err := g.Wait(); err != nil {
+			return errors.Wrapf(err, "replaying history of %s from backup", sp)
+		}
+	}
+	return nil
+}
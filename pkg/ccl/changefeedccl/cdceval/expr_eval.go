@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/lease"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
@@ -57,6 +58,15 @@ type familyEvaluator struct {
 	prevDesc     *cdcevent.EventDescriptor
 	prevRowTuple *tree.DTuple
 	alloc        tree.DatumAlloc
+	// udfVersions are the versions of the user defined functions referenced
+	// by the expression as of the time the current plan was made. The plan is
+	// remade when any of them changes.
+	udfVersions []udfVersion
+	// udfLeaseGeneration is the generation of the lease manager as of which
+	// udfVersions were fetched. As long as the generation doesn't change, the
+	// lease manager has no new function descriptors, so the cached versions
+	// remain valid within their timestamp intervals.
+	udfLeaseGeneration int64
 
 	// Execution context.
 	execCfg     *sql.ExecutorConfig
@@ -175,8 +185,16 @@ func (e *familyEvaluator) eval(
 	}
 
 	havePrev := prevRow.IsInitialized()
-	if !(sameVersion(e.currDesc, updatedRow.EventDescriptor) &&
-		(!havePrev || sameVersion(e.prevDesc, prevRow.EventDescriptor))) {
+	replan := !(sameVersion(e.currDesc, updatedRow.EventDescriptor) &&
+		(!havePrev || sameVersion(e.prevDesc, prevRow.EventDescriptor)))
+	if !replan {
+		changed, err := e.udfsChanged(ctx, updatedRow.SchemaTS)
+		if err != nil {
+			return cdcevent.Row{}, err
+		}
+		replan = changed
+	}
+	if replan {
 		// Descriptor versions changed; re-initialize.
 		if err := e.closeErr(); err != nil {
 			return cdcevent.Row{}, err
@@ -270,11 +288,111 @@ func (e *familyEvaluator) planAndRun(ctx context.Context) (err error) {
 		return withErrorHint(err, e.currDesc.FamilyName, e.currDesc.HasOtherFamilies)
 	}
 
+	if err = e.fetchUDFVersions(ctx, e.rowEvalCtx.udfs, e.currDesc.SchemaTS); err != nil {
+		e.performCleanup()
+		return err
+	}
+
 	e.setupProjection(plan.Presentation)
 	e.input, err = e.executePlan(ctx, plan, prevCol)
 	return err
 }
 
+// udfVersion is the version of a user defined function referenced by the
+// expression.
+type udfVersion struct {
+	id      descpb.ID
+	version descpb.DescriptorVersion
+	// validFrom and validUntil bound the interval of timestamps at which the
+	// lease manager returned this version.
+	validFrom, validUntil hlc.Timestamp
+}
+
+// validAt returns whether the version is known to be valid at the timestamp.
+func (v *udfVersion) validAt(ts hlc.Timestamp) bool {
+	return v.validFrom.LessEq(ts) && ts.Less(v.validUntil)
+}
+
+// fetchUDFVersions fetches the versions of the specified user defined
+// functions as of the specified timestamp into e.udfVersions.
+func (e *familyEvaluator) fetchUDFVersions(
+	ctx context.Context, udfs catalog.DescriptorIDSet, ts hlc.Timestamp,
+) (err error) {
+	e.udfVersions = e.udfVersions[:0]
+	if udfs.Empty() {
+		return nil
+	}
+	e.udfLeaseGeneration = e.execCfg.LeaseManager.GetLeaseGeneration()
+	udfs.ForEach(func(id descpb.ID) {
+		if err != nil {
+			return
+		}
+		var v udfVersion
+		v, err = e.fetchUDFVersion(ctx, id, ts)
+		e.udfVersions = append(e.udfVersions, v)
+	})
+	return err
+}
+
+// fetchUDFVersion returns the version of the specified user defined function
+// as of the specified timestamp. Like the descriptors of the rows themselves,
+// function descriptors are retrieved from the lease manager, which caches
+// them.
+func (e *familyEvaluator) fetchUDFVersion(
+	ctx context.Context, id descpb.ID, ts hlc.Timestamp,
+) (udfVersion, error) {
+	desc, err := e.execCfg.LeaseManager.Acquire(ctx, lease.TimestampToReadTimestamp(ts), id)
+	if err != nil {
+		return udfVersion{}, errors.Wrapf(err, "acquiring user defined function %d used by CDC expression", id)
+	}
+	// Immediately release the lease, since we only need it for the exact
+	// timestamp requested.
+	defer desc.Release(ctx)
+	return udfVersion{
+		id:         id,
+		version:    desc.Underlying().GetVersion(),
+		validFrom:  desc.Underlying().GetModificationTime(),
+		validUntil: desc.Expiration(ctx),
+	}, nil
+}
+
+// udfsChanged returns true if any of the user defined functions referenced by
+// the expression has changed since the current plan was made.
+//
+// This is called for every row, so the versions are only fetched again when
+// the lease manager has observed new descriptors, or when the timestamp is
+// outside of the interval in which the cached versions are valid.
+func (e *familyEvaluator) udfsChanged(ctx context.Context, ts hlc.Timestamp) (bool, error) {
+	if len(e.udfVersions) == 0 {
+		return false, nil
+	}
+	generation := e.execCfg.LeaseManager.GetLeaseGeneration()
+	if generation == e.udfLeaseGeneration {
+		valid := true
+		for i := range e.udfVersions {
+			if !e.udfVersions[i].validAt(ts) {
+				valid = false
+				break
+			}
+		}
+		if valid {
+			return false, nil
+		}
+	}
+	for i := range e.udfVersions {
+		v, err := e.fetchUDFVersion(ctx, e.udfVersions[i].id, ts)
+		if err != nil {
+			return false, err
+		}
+		if v.version != e.udfVersions[i].version {
+			return true, nil
+		}
+		e.udfVersions[i] = v
+	}
+	e.udfLeaseGeneration = generation
+	return false, nil
+}
+
 // preparePlan creates a plan for CDC expression. If no error is returned, the
 // caller must call e.performCleanup().
 func (e *familyEvaluator) preparePlan(
@@ -512,6 +630,9 @@ type rowEvalContext struct {
 	withDiff     bool
 	updatedRow   cdcevent.Row
 	op           tree.Datum
+	// udfs is the set of user defined functions resolved while planning the
+	// expression.
+	udfs catalog.DescriptorIDSet
 }
 
 // cdcAnnotationAddr is the address used to store relevant information
@@ -531,10 +652,11 @@ const rejectInvalidCDCExprs = tree.RejectAggregates | tree.RejectGenerators |
 // evaluation; returns cleanup function which restores previous configuration.
 func configSemaForCDC(semaCtx *tree.SemaContext, statementTS hlc.Timestamp) func() {
 	origProps, origResolver := semaCtx.Properties, semaCtx.FunctionResolver
-	semaCtx.FunctionResolver = newCDCFunctionResolver(semaCtx.FunctionResolver)
+	rowEvalCtx := &rowEvalContext{creationTime: statementTS}
+	semaCtx.FunctionResolver = newCDCFunctionResolver(semaCtx.FunctionResolver, &rowEvalCtx.udfs)
 	semaCtx.Properties.Require("cdc", rejectInvalidCDCExprs)
 	semaCtx.Annotations = tree.MakeAnnotations(cdcAnnotationAddr)
-	semaCtx.Annotations.Set(cdcAnnotationAddr, &rowEvalContext{creationTime: statementTS})
+	semaCtx.Annotations.Set(cdcAnnotationAddr, rowEvalCtx)
 
 	return func() {
 		semaCtx.Properties.Restore(origProps)
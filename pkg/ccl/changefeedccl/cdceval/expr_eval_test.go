@@ -12,6 +12,7 @@ import (
 	"sort"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
@@ -24,11 +25,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/randgen"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -632,6 +635,123 @@ $$`)
 	}
 }
 
+// Tests that replacing a user defined function used by the expression causes
+// the expression to be replanned with the new definition of the function, and
+// that dropping it is an error.
+func TestEvaluatorReplansOnUDFChange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+
+	for _, l := range []serverutils.ApplicationLayerInterface{s, srv.SystemLayer()} {
+		kvserver.RangefeedEnabled.Override(ctx, &l.ClusterSettings().SV, true)
+	}
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, email STRING)`)
+	sqlDB.Exec(t, `
+CREATE FUNCTION mask_pii(s STRING)
+RETURNS STRING IMMUTABLE LANGUAGE SQL AS $$
+  SELECT 'masked'
+$$`)
+
+	desc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "foo")
+	execCfg := s.ExecutorConfig().(sql.ExecutorConfig)
+	target := changefeedbase.Target{
+		Type:   jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+		DescID: desc.GetID(),
+	}
+	targets := changefeedbase.Targets{}
+	targets.Add(target)
+
+	e, err := newEvaluatorWithNormCheck(&execCfg, desc, s.Clock().Now(), target,
+		"SELECT a, mask_pii(email) AS email FROM foo")
+	require.NoError(t, err)
+	defer e.Close()
+
+	decoder, err := cdcevent.NewEventDecoder(ctx, &execCfg, targets, false, false)
+	require.NoError(t, err)
+	popRow, cleanup := cdctest.MakeRangeFeedValueReader(t, s.ExecutorConfig(), desc)
+	defer cleanup()
+
+	evalNext := func() (map[string]string, error) {
+		v := popRow(t)
+		updatedRow := decodeRow(t, decoder, v, cdcevent.CurrentRow)
+		prevRow := decodeRow(t, decoder, v, cdcevent.PrevRow)
+		projection, err := e.Eval(ctx, updatedRow, prevRow)
+		if err != nil {
+			return nil, err
+		}
+		return slurpValues(t, projection), nil
+	}
+
+	sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'a@example.com')`)
+	values, err := evalNext()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"a": "1", "email": "masked"}, values)
+
+	sqlDB.Exec(t, `
+CREATE OR REPLACE FUNCTION mask_pii(s STRING)
+RETURNS STRING IMMUTABLE LANGUAGE SQL AS $$
+  SELECT 'redacted'
+$$`)
+	sqlDB.Exec(t, `INSERT INTO foo VALUES (2, 'b@example.com')`)
+	values, err = evalNext()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"a": "2", "email": "redacted"}, values)
+
+	sqlDB.Exec(t, `DROP FUNCTION mask_pii`)
+	sqlDB.Exec(t, `INSERT INTO foo VALUES (3, 'c@example.com')`)
+	_, err = evalNext()
+	require.Error(t, err)
+}
+
+// Tests that the versions of user defined functions used by the expression are
+// only fetched again when the lease manager observes new descriptors, or when
+// the row timestamp is outside of the interval in which the cached versions
+// are valid.
+func TestEvaluatorCachesUDFVersions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv := serverutils.StartServerOnly(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	execCfg := srv.ApplicationLayer().ExecutorConfig().(sql.ExecutorConfig)
+
+	// The function descriptor doesn't exist, so any attempt to fetch its
+	// version fails.
+	now := srv.Clock().Now()
+	e := &familyEvaluator{
+		execCfg: &execCfg,
+		udfVersions: []udfVersion{{
+			id:         descpb.ID(10000),
+			version:    1,
+			validFrom:  now,
+			validUntil: now.Add(time.Minute.Nanoseconds(), 0),
+		}},
+	}
+
+	// Background leasing may change the generation of the lease manager, in
+	// which case the versions are fetched again.
+	testutils.SucceedsSoon(t, func() error {
+		e.udfLeaseGeneration = execCfg.LeaseManager.GetLeaseGeneration()
+		changed, err := e.udfsChanged(ctx, now.Add(time.Second.Nanoseconds(), 0))
+		require.False(t, changed)
+		return err
+	})
+
+	e.udfLeaseGeneration = execCfg.LeaseManager.GetLeaseGeneration()
+	_, err := e.udfsChanged(ctx, now.Prev())
+	require.Error(t, err)
+	_, err = e.udfsChanged(ctx, now.Add(time.Hour.Nanoseconds(), 0))
+	require.Error(t, err)
+}
+
 // Tests that use of volatile functions, without CDC specific override,
 // results in an error.
 func TestUnsupportedCDCFunctions(t *testing.T) {
@@ -9,6 +9,8 @@ import (
 	"context"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -20,13 +22,18 @@ import (
 // evaluation.
 type cdcFunctionResolver struct {
 	wrapped tree.FunctionReferenceResolver
+	// udfs, if non-nil, accumulates the descriptor IDs of the user defined
+	// functions resolved by this resolver.
+	udfs *catalog.DescriptorIDSet
 }
 
 // TODO(yevgeniy): Function resolution is complex
 // (see https://github.com/cockroachdb/cockroach/issues/75101).  It would be nice if we
 // could accomplish cdc goals without having to define custom resolver.
-func newCDCFunctionResolver(wrapped tree.FunctionReferenceResolver) tree.FunctionReferenceResolver {
-	return &cdcFunctionResolver{wrapped: wrapped}
+func newCDCFunctionResolver(
+	wrapped tree.FunctionReferenceResolver, udfs *catalog.DescriptorIDSet,
+) tree.FunctionReferenceResolver {
+	return &cdcFunctionResolver{wrapped: wrapped, udfs: udfs}
 }
 
 // ResolveFunction implements FunctionReferenceResolver interface.
@@ -78,6 +85,14 @@ func (rs *cdcFunctionResolver) ResolveFunction(
 			}
 		}
 	}
+	// Only the overload picked during type checking is used by the expression.
+	// The overloads of user defined functions usually only contain their
+	// signatures, in which case the picked overload is fetched, and noted, via
+	// ResolveFunctionByOID. Otherwise, the overload is only known up front if
+	// there is no other overload to pick from.
+	if len(funcDef.Overloads) == 1 && !funcDef.Overloads[0].UDFContainsOnlySignature {
+		rs.noteUDF(funcDef.Overloads[0].Overload)
+	}
 	return funcDef, nil
 }
 
@@ -92,9 +107,19 @@ func (rs *cdcFunctionResolver) ResolveFunctionByOID(
 	if err := checkOverloadSupported(fnName.Object(), overload); err != nil {
 		return nil, nil, err
 	}
+	rs.noteUDF(overload)
 	return fnName, overload, err
 }
 
+// noteUDF records the descriptor ID of the overload if it is a user defined
+// function.
+func (rs *cdcFunctionResolver) noteUDF(overload *tree.Overload) {
+	if rs.udfs == nil || overload.Type != tree.UDFRoutine || !funcdesc.IsOIDUserDefinedFunc(overload.Oid) {
+		return
+	}
+	rs.udfs.Add(funcdesc.UserDefinedFunctionOIDToID(overload.Oid))
+}
+
 // checkOverloadsSupported verifies function overload is supported by CDC.
 func checkOverloadSupported(fnName string, overload *tree.Overload) error {
 	switch overload.Class {
@@ -12,12 +12,18 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/lib/pq/oid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -84,7 +90,7 @@ $$`)
 				func(ctx context.Context, execCtx sql.JobExecContext, cleanup func()) (err error) {
 					defer cleanup()
 					semaCtx := execCtx.SemaCtx()
-					r := newCDCFunctionResolver(semaCtx.FunctionResolver)
+					r := newCDCFunctionResolver(semaCtx.FunctionResolver, nil /* udfs */)
 					funcDef, err = r.ResolveFunction(
 						context.Background(),
 						tree.MakeUnresolvedFunctionName(&tc.fnName),
@@ -105,3 +111,62 @@ $$`)
 		})
 	}
 }
+
+func TestResolveFunctionNotesResolvedUDF(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(context.Background())
+	s := srv.ApplicationLayer()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `
+CREATE FUNCTION yesterday(mvcc DECIMAL)
+RETURNS DECIMAL IMMUTABLE LEAKPROOF LANGUAGE SQL AS $$
+  SELECT mvcc - 24 * 3600 * 1e9
+$$`)
+	sqlDB.Exec(t, `
+CREATE FUNCTION yesterday(mvcc INT)
+RETURNS INT IMMUTABLE LEAKPROOF LANGUAGE SQL AS $$
+  SELECT mvcc - 24 * 3600 * 1000000000
+$$`)
+
+	var decimalOID, intOID int
+	sqlDB.QueryRow(t, `SELECT 'yesterday(DECIMAL)'::REGPROCEDURE::INT`).Scan(&decimalOID)
+	sqlDB.QueryRow(t, `SELECT 'yesterday(INT)'::REGPROCEDURE::INT`).Scan(&intOID)
+
+	execCfg := s.ExecutorConfig().(sql.ExecutorConfig)
+	for _, tc := range []struct {
+		expr string
+		oid  int
+	}{
+		{expr: "yesterday(1.5::DECIMAL)", oid: decimalOID},
+		{expr: "yesterday(1::INT)", oid: intOID},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			var udfs catalog.DescriptorIDSet
+			err := withPlanner(context.Background(), &execCfg, hlc.Timestamp{},
+				username.RootUserName(), s.Clock().Now(), defaultDBSessionData,
+				func(ctx context.Context, execCtx sql.JobExecContext, cleanup func()) error {
+					defer cleanup()
+					expr, err := parser.ParseExpr(tc.expr)
+					if err != nil {
+						return err
+					}
+					semaCtx := execCtx.SemaCtx()
+					defer func(resolver tree.FunctionReferenceResolver) {
+						semaCtx.FunctionResolver = resolver
+					}(semaCtx.FunctionResolver)
+					semaCtx.FunctionResolver = newCDCFunctionResolver(semaCtx.FunctionResolver, &udfs)
+					_, err = tree.TypeCheck(ctx, expr, semaCtx, types.AnyElement)
+					return err
+				})
+			require.NoError(t, err)
+
+			// Only the version of the overload picked by type checking is tracked.
+			require.Equal(t,
+				[]descpb.ID{funcdesc.UserDefinedFunctionOIDToID(oid.Oid(tc.oid))}, udfs.Ordered())
+		})
+	}
+}
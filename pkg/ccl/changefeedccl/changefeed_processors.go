@@ -88,6 +88,12 @@ type changeAggregator struct {
 	// sink is the Sink to write rows to. Resolved timestamps are never written
 	// by changeAggregator.
 	sink EventSink
+	// schemaChangeEmitter, if non-nil, emits schema change messages observed
+	// by the schemafeed on a sink of its own.
+	schemaChangeEmitter *schemaChangeEmitter
+	// postgresSink, if non-nil, is the sink when it is a postgres sink. Schema
+	// changes observed by the schemafeed are applied to its target.
+	postgresSink *postgresSink
 	// changedRowBuf, if non-nil, contains changed rows to be emitted. Anything
 	// queued in `resolvedSpanBuf` is dependent on these having been emitted, so
 	// this one must be empty before moving on to that one.
@@ -443,6 +449,22 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 	if b, ok := ca.sink.(*bufferSink); ok {
 		ca.changedRowBuf = &b.buf
 	}
+	if p, ok := ca.sink.(*postgresSink); ok {
+		ca.postgresSink = p
+	}
+
+	if topic := opts.GetSchemaChangeTopic(); topic != `` {
+		schemaChangeSink, err := getEventSink(ctx, ca.FlowCtx.Cfg, ca.spec.Feed, timestampOracle,
+			ca.spec.User(), ca.spec.JobID, recorder, ca.targets)
+		if err != nil {
+			err = changefeedbase.MarkRetryableError(err)
+			log.Changefeed.Warningf(ca.Ctx(), "moving to draining due to error getting schema change sink: %v", err)
+			ca.MoveToDraining(err)
+			ca.cancel()
+			return
+		}
+		ca.schemaChangeEmitter = makeSchemaChangeEmitter(schemaChangeSink, topic)
+	}
 
 	// If the initial scan was disabled the highwater would've already been forwarded
 	needsInitialScan := ca.frontier.Frontier().IsEmpty()
@@ -569,6 +591,15 @@ func (ca *changeAggregator) makeKVFeedCfg(
 	} else {
 		sf = schemafeed.New(ctx, cfg, schemaChange.EventClass, ca.targets,
 			initialHighWater, &ca.metrics.SchemaFeedMetrics, config.Opts.GetCanHandle())
+		if ca.schemaChangeEmitter != nil {
+			observed := schemafeed.NewUnfiltered(ctx, cfg, ca.targets,
+				initialHighWater, &ca.metrics.SchemaFeedMetrics, config.Opts.GetCanHandle())
+			sf = makeSchemaChangeObservingFeed(sf, observed, ca.schemaChangeEmitter.emit)
+		} else if ca.postgresSink != nil {
+			observed := schemafeed.NewUnfiltered(ctx, cfg, ca.targets,
+				initialHighWater, &ca.metrics.SchemaFeedMetrics, config.Opts.GetCanHandle())
+			sf = makeSchemaChangeObservingFeed(sf, observed, ca.postgresSink.applySchemaChanges)
+		}
 	}
 
 	monitoringCfg, err := makeKVFeedMonitoringCfg(ctx, ca.sliMetrics, opts, ca.FlowCtx.Cfg.Settings)
@@ -576,6 +607,26 @@ func (ca *changeAggregator) makeKVFeedCfg(
 		return kvfeed.Config{}, err
 	}
 
+	var historySource kvfeed.HistorySource
+	if uri := opts.GetReplayFromBackup(); uri != `` {
+		execCfg := cfg.ExecutorConfig.(*sql.ExecutorConfig)
+		if ca.knobs.OverrideExecCfg != nil {
+			execCfg = ca.knobs.OverrideExecCfg(execCfg)
+		}
+		src, err := newBackupHistorySource(ctx, execCfg, ca.spec.User(), uri)
+		if err != nil {
+			// Once the feed has made progress past the cursor, it may no longer
+			// need the backup, so do not fail the feed if it cannot be read.
+			// Should the history still be needed, the rangefeeds will fail.
+			if !ca.spec.Feed.StatementTime.Less(initialHighWater) {
+				return kvfeed.Config{}, err
+			}
+			log.Changefeed.Warningf(ctx, "not replaying history from backup: %v", err)
+		} else {
+			historySource = src
+		}
+	}
+
 	// Create the initial span-timestamp pairs from the frontier
 	// (which already has checkpoint info restored).
 	var initialSpanTimePairs []kvcoord.SpanTimePair
@@ -607,6 +658,7 @@ func (ca *changeAggregator) makeKVFeedCfg(
 		SchemaChangeEvents:   schemaChange.EventClass,
 		SchemaChangePolicy:   schemaChange.Policy,
 		SchemaFeed:           sf,
+		HistorySource:        historySource,
 		Knobs:                ca.knobs.FeedKnobs,
 		ScopedTimers:         ca.sliMetrics.Timers,
 		MonitoringCfg:        monitoringCfg,
@@ -721,6 +773,9 @@ func (ca *changeAggregator) close() {
 		// Best effort: context is often cancel by now, so we expect to see an error
 		_ = ca.sink.Close()
 	}
+	if ca.schemaChangeEmitter != nil {
+		_ = ca.schemaChangeEmitter.Close()
+	}
 
 	// The sliMetrics registry may hold on to some state for each aggregator
 	// (ex. last known resolved timestamp). De-register the aggregator so this
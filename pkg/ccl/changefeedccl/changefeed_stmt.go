@@ -508,8 +508,9 @@ func changefeedPlanHook(
 				err = errors.Wrapf(err,
 					"could not create changefeed: cursor %s is older than the GC threshold %d",
 					opts.GetCursor(), e.Threshold.WallTime)
-				err = errors.WithHint(err,
-					"use a more recent cursor")
+				err = errors.WithHintf(err,
+					"use a more recent cursor, or replay history from a revision history backup with %s",
+					changefeedbase.OptReplayFromBackup)
 			}
 		}
 		return err
@@ -1471,6 +1472,10 @@ func validateDetailsAndOptions(
 	if err := opts.ValidateForCreateChangefeed(details.Select != ""); err != nil {
 		return err
 	}
+	if details.SinkURI == `` && opts.IsSet(changefeedbase.OptSchemaChangeTopic) {
+		return errors.Errorf(
+			`%s is not supported by sinkless changefeeds`, changefeedbase.OptSchemaChangeTopic)
+	}
 	if isDBLevelChangefeed(details) {
 		scanType, err := opts.GetInitialScanType()
 		if err != nil {
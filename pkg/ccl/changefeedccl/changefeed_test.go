@@ -12181,6 +12181,90 @@ func TestPubsubAttributes(t *testing.T) {
 	cdcTest(t, testFn, feedTestForceSink("pubsub"))
 }
 
+// TestPubsubCloudEvents tests that the pubsub sink wraps rows as CloudEvents
+// when the cloudevents option is specified.
+func TestPubsubCloudEvents(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		db := sqlutils.MakeSQLRunner(s.DB)
+		db.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+		db.Exec(t, `INSERT INTO foo VALUES (1, 'one')`)
+
+		// nextMessage returns the next message published to the mock server.
+		// The messages are inspected directly since the data of a CloudEvent
+		// doesn't have the format expected by pubsubFeed.Next.
+		nextMessage := func(feed cdctest.TestFeed) *mockPubsubMessage {
+			pf := feed.(*pubsubFeed)
+			for {
+				if msg := pf.mockServer.Pop(); msg != nil {
+					return msg
+				}
+				require.NoError(t, pf.waitForMessage())
+			}
+		}
+		// checkRow checks that the given data is the encoded row of foo, and
+		// returns the event that is expected for it.
+		checkRow := func(data []byte) cloudEvent {
+			var row struct {
+				After   map[string]interface{} `json:"after"`
+				Updated string                 `json:"updated"`
+			}
+			require.NoError(t, gojson.Unmarshal(data, &row))
+			require.Equal(t, map[string]interface{}{"a": float64(1), "b": "one"}, row.After)
+			return makeRowCloudEvent(`foo`, []byte(`[1]`), parseTimeToHLC(t, row.Updated))
+		}
+
+		t.Run("structured", func(t *testing.T) {
+			foo := feed(t, f, `CREATE CHANGEFEED FOR foo INTO 'gcpubsub://testfeed' `+
+				`WITH updated, cloudevents = 'structured'`)
+			defer closeFeed(t, foo)
+
+			msg := nextMessage(foo)
+			require.Nil(t, msg.attributes)
+			var event struct {
+				SpecVersion     string            `json:"specversion"`
+				Type            string            `json:"type"`
+				Source          string            `json:"source"`
+				ID              string            `json:"id"`
+				Time            string            `json:"time"`
+				Subject         string            `json:"subject"`
+				DataContentType string            `json:"datacontenttype"`
+				Data            gojson.RawMessage `json:"data"`
+			}
+			require.NoError(t, gojson.Unmarshal([]byte(msg.data), &event))
+			expected := checkRow(event.Data)
+			require.Equal(t, cloudEventsSpecVersion, event.SpecVersion)
+			require.Equal(t, cloudEventsRowType, event.Type)
+			require.Equal(t, cloudEventsSource, event.Source)
+			require.Equal(t, expected.id, event.ID)
+			require.Equal(t, expected.time, event.Time)
+			require.Equal(t, `foo/[1]`, event.Subject)
+			require.Equal(t, applicationTypeJSON, event.DataContentType)
+		})
+
+		t.Run("binary", func(t *testing.T) {
+			foo := feed(t, f, `CREATE CHANGEFEED FOR foo INTO 'gcpubsub://testfeed' `+
+				`WITH updated, cloudevents = 'binary'`)
+			defer closeFeed(t, foo)
+
+			msg := nextMessage(foo)
+			expected := checkRow([]byte(msg.data))
+			require.Equal(t, map[string]string{
+				`ce-specversion`: cloudEventsSpecVersion,
+				`ce-type`:        cloudEventsRowType,
+				`ce-source`:      cloudEventsSource,
+				`ce-id`:          expected.id,
+				`ce-time`:        expected.time,
+				`ce-subject`:     `foo/[1]`,
+			}, msg.attributes)
+		})
+	}
+
+	cdcTest(t, testFn, feedTestForceSink("pubsub"))
+}
+
 // TestChangefeedAvroDecimalColumnWithDiff is a regression test for
 // https://github.com/cockroachdb/cockroach/issues/118647.
 func TestChangefeedAvroDecimalColumnWithDiff(t *testing.T) {
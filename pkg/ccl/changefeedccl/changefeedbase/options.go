@@ -53,6 +53,10 @@ type SchemaChangeEventClass string
 // change event which is a member of the changefeed's schema change events.
 type SchemaChangePolicy string
 
+// CloudEventsMode configures how events are wrapped as CloudEvents 1.0
+// messages by the sinks which support it.
+type CloudEventsMode string
+
 // VirtualColumnVisibility defines the behaviour of how the changefeed will
 // include virtual columns in an event
 type VirtualColumnVisibility string
@@ -136,6 +140,14 @@ const (
 	// sinks as well (eg cloudstorage, webhook, ..). Currently it's kafka-only.
 	OptHeadersJSONColumnName = `headers_json_column_name`
 	OptExtraHeaders          = `extra_headers`
+	OptCloudEvents           = `cloudevents`
+	// OptSchemaChangeTopic names the topic on which a message is emitted for
+	// every schema change observed on a watched table.
+	OptSchemaChangeTopic = `schema_change_topic`
+	// OptReplayFromBackup names a backup collection whose latest revision
+	// history backup is used as the source of the history between the cursor
+	// and the end time of the backup, which may have been garbage collected.
+	OptReplayFromBackup = `replay_from_backup`
 
 	OptVirtualColumnsOmitted VirtualColumnVisibility = `omitted`
 	OptVirtualColumnsNull    VirtualColumnVisibility = `null`
@@ -194,6 +206,13 @@ const (
 	OptFormatParquet  FormatType = `parquet`
 	OptFormatProtobuf FormatType = `protobuf`
 
+	// OptCloudEventsStructured emits each event as a CloudEvents JSON object,
+	// with the encoded row as its data.
+	OptCloudEventsStructured CloudEventsMode = `structured`
+	// OptCloudEventsBinary emits the encoded row as the message body and the
+	// CloudEvents attributes as ce-* headers or message attributes.
+	OptCloudEventsBinary CloudEventsMode = `binary`
+
 	OptOnErrorFail  OnErrorType = `fail`
 	OptOnErrorPause OnErrorType = `pause`
 
@@ -245,6 +264,8 @@ const (
 	SinkSchemeWebhookHTTPS          = `webhook-https`
 	SinkSchemePulsar                = `pulsar`
 	SinkSchemeExternalConnection    = `external`
+	SinkSchemePostgres              = `postgres`
+	SinkSchemePostgresql            = `postgresql`
 	SinkParamSASLEnabled            = `sasl_enabled`
 	SinkParamSASLHandshake          = `sasl_handshake`
 	SinkParamSASLUser               = `sasl_user`
@@ -434,6 +455,9 @@ var ChangefeedOptionExpectValues = map[string]OptionPermittedValues{
 	OptRangeDistributionStrategy:          enum(string(ChangefeedRangeDistributionStrategyDefault), string(ChangefeedRangeDistributionStrategyBalancedSimple)),
 	OptHeadersJSONColumnName:              stringOption,
 	OptExtraHeaders:                       jsonOption,
+	OptCloudEvents:                        enum(string(OptCloudEventsStructured), string(OptCloudEventsBinary)).orEmptyMeans(string(OptCloudEventsStructured)),
+	OptSchemaChangeTopic:                  stringOption,
+	OptReplayFromBackup:                   stringOption,
 }
 
 // CommonOptions is options common to all sinks
@@ -448,23 +472,26 @@ var CommonOptions = makeStringSet(OptCursor, OptEndTime, OptEnvelope,
 	OptMinCheckpointFrequency, OptMetricsScope, OptVirtualColumns, Topics, OptExpirePTSAfter,
 	OptExecutionLocality, OptLaggingRangesThreshold, OptLaggingRangesPollingInterval,
 	OptIgnoreDisableChangefeedReplication, OptEncodeJSONValueNullAsObject, OptEnrichedProperties,
-	OptRangeDistributionStrategy,
+	OptRangeDistributionStrategy, OptReplayFromBackup,
 )
 
 // SQLValidOptions is options exclusive to SQL sink
 var SQLValidOptions map[string]struct{} = nil
 
+// PostgresValidOptions is options exclusive to the postgres sink
+var PostgresValidOptions map[string]struct{} = nil
+
 // KafkaValidOptions is options exclusive to Kafka sink
-var KafkaValidOptions = makeStringSet(OptAvroSchemaPrefix, OptConfluentSchemaRegistry, OptKafkaSinkConfig, OptHeadersJSONColumnName, OptExtraHeaders)
+var KafkaValidOptions = makeStringSet(OptAvroSchemaPrefix, OptConfluentSchemaRegistry, OptKafkaSinkConfig, OptHeadersJSONColumnName, OptExtraHeaders, OptSchemaChangeTopic)
 
 // CloudStorageValidOptions is options exclusive to cloud storage sink
-var CloudStorageValidOptions = makeStringSet(OptCompression)
+var CloudStorageValidOptions = makeStringSet(OptCompression, OptSchemaChangeTopic)
 
 // WebhookValidOptions is options exclusive to webhook sink
-var WebhookValidOptions = makeStringSet(OptWebhookAuthHeader, OptWebhookClientTimeout, OptWebhookSinkConfig, OptCompression, OptExtraHeaders)
+var WebhookValidOptions = makeStringSet(OptWebhookAuthHeader, OptWebhookClientTimeout, OptWebhookSinkConfig, OptCompression, OptExtraHeaders, OptCloudEvents, OptSchemaChangeTopic)
 
 // PubsubValidOptions is options exclusive to pubsub sink
-var PubsubValidOptions = makeStringSet(OptPubsubSinkConfig)
+var PubsubValidOptions = makeStringSet(OptPubsubSinkConfig, OptCloudEvents, OptSchemaChangeTopic)
 
 // ExternalConnectionValidOptions is options exclusive to the external
 // connection sink.
@@ -476,7 +503,7 @@ var ExternalConnectionValidOptions = unionStringSets(SQLValidOptions, KafkaValid
 
 // CaseInsensitiveOpts options which supports case Insensitive value
 var CaseInsensitiveOpts = makeStringSet(OptFormat, OptEnvelope, OptCompression, OptSchemaChangeEvents,
-	OptSchemaChangePolicy, OptOnError, OptInitialScan)
+	OptSchemaChangePolicy, OptOnError, OptInitialScan, OptCloudEvents)
 
 // RetiredOptions are the options which are no longer active.
 var RetiredOptions = makeStringSet(DeprecatedOptProtectDataFromGCOnPause)
@@ -507,6 +534,7 @@ var RedactedOptions = map[string]redactionFunc{
 	OptExtraHeaders:            redactSimple,
 	SinkParamClientKey:         redactSimple,
 	OptConfluentSchemaRegistry: RedactUserFromURI,
+	OptReplayFromBackup:        redactSimple,
 }
 
 // NoLongerExperimental aliases options prefixed with experimental that no longer need to be
@@ -539,7 +567,7 @@ var ParquetFormatUnsupportedOptions OptionsSet = makeStringSet(OptTopicInValue)
 // allowed to alter either of these options. We need to support the alteration
 // of these fields.
 var AlterChangefeedUnsupportedOptions OptionsSet = makeStringSet(OptCursor, OptInitialScan,
-	OptNoInitialScan, OptInitialScanOnly, OptEndTime)
+	OptNoInitialScan, OptInitialScanOnly, OptEndTime, OptReplayFromBackup)
 
 // AlterChangefeedOptionExpectValues is used to parse alter changefeed options
 // using PlanHookState.TypeAsStringOpts().
@@ -594,6 +622,7 @@ var incompatibleOptionsMap = makeInvertedIndex([]incompatibleOptions{
 
 var dependentOptionsMap = makeDirectedInvertedIndex([]dependentOption{
 	{opt1: OptCustomKeyColumn, opt2: OptUnordered, reason: `using a value other than the primary key as the message key means end-to-end ordering cannot be preserved`},
+	{opt1: OptReplayFromBackup, opt2: OptCursor, reason: `history is replayed from the backup starting at the cursor`},
 })
 
 // MakeStatementOptions wraps and canonicalizes the options we get
@@ -892,6 +921,7 @@ type EncodingOptions struct {
 	CustomKeyColumn             string
 	EnrichedProperties          map[EnrichedProperty]struct{}
 	HeadersJSONColName          string
+	CloudEvents                 CloudEventsMode
 }
 
 // GetEncodingOptions populates and validates an EncodingOptions.
@@ -941,6 +971,12 @@ func (s StatementOptions) GetEncodingOptions() (EncodingOptions, error) {
 	o.CustomKeyColumn = s.m[OptCustomKeyColumn]
 	o.HeadersJSONColName = s.m[OptHeadersJSONColumnName]
 
+	cloudEvents, err := s.getEnumValue(OptCloudEvents)
+	if err != nil {
+		return o, err
+	}
+	o.CloudEvents = CloudEventsMode(cloudEvents)
+
 	enrichedProperties, err := s.getCSVValues(OptEnrichedProperties)
 	if err != nil {
 		return o, err
@@ -978,6 +1014,10 @@ func (e EncodingOptions) Validate() error {
 		}
 	}
 
+	if e.CloudEvents != `` && e.Format != OptFormatJSON {
+		return errors.Errorf(`%s is only usable with %s=%s`, OptCloudEvents, OptFormat, OptFormatJSON)
+	}
+
 	if e.HeadersJSONColName != `` && (e.Format != OptFormatJSON && e.Format != OptFormatAvro) {
 		return errors.Errorf(`%s is only usable with %s=%s/%s`, OptHeadersJSONColumnName, OptFormat, OptFormatJSON, OptFormatAvro)
 	}
@@ -1013,6 +1053,9 @@ func (e EncodingOptions) Validate() error {
 type SchemaChangeHandlingOptions struct {
 	EventClass SchemaChangeEventClass
 	Policy     SchemaChangePolicy
+	// Topic, if set, is the topic on which schema change messages are
+	// emitted.
+	Topic string
 }
 
 // GetSchemaChangeHandlingOptions populates and validates a SchemaChangeHandlingOptions.
@@ -1038,6 +1081,21 @@ func (s StatementOptions) GetSchemaChangeHandlingOptions() (SchemaChangeHandling
 		o.Policy = SchemaChangePolicy(p)
 	}
 
+	o.Topic = s.m[OptSchemaChangeTopic]
+	if _, ok := s.m[OptSchemaChangeTopic]; ok {
+		if o.Topic == `` {
+			return o, errors.Errorf(`%s must not be empty`, OptSchemaChangeTopic)
+		}
+		if o.Policy == OptSchemaChangePolicyIgnore {
+			return o, errors.Errorf(`%s cannot be used with %s=%s`,
+				OptSchemaChangeTopic, OptSchemaChangePolicy, OptSchemaChangePolicyIgnore)
+		}
+		if f := s.m[OptFormat]; f != `` && f != string(OptFormatJSON) {
+			return o, errors.Errorf(`%s is only usable with %s=%s`,
+				OptSchemaChangeTopic, OptFormat, OptFormatJSON)
+		}
+	}
+
 	return o, nil
 
 }
@@ -1176,6 +1234,19 @@ func (s StatementOptions) GetMetricScope() (string, bool) {
 	return v, ok
 }
 
+// GetSchemaChangeTopic returns the topic on which schema change messages are
+// emitted, or the empty string if they are not emitted.
+func (s StatementOptions) GetSchemaChangeTopic() string {
+	return s.m[OptSchemaChangeTopic]
+}
+
+// GetReplayFromBackup returns the URI of the backup collection from which
+// history is replayed, or the empty string if history is not replayed from a
+// backup.
+func (s StatementOptions) GetReplayFromBackup() string {
+	return s.m[OptReplayFromBackup]
+}
+
 // GetLaggingRangesConfig returns the threshold and polling rate to use for
 // lagging ranges metrics.
 func (s StatementOptions) GetLaggingRangesConfig(
@@ -1377,6 +1448,17 @@ func (s StatementOptions) ValidateForCreateChangefeed(isPredicateChangefeed bool
 			}
 		}
 	}
+	if s.IsSet(OptReplayFromBackup) {
+		if s.m[OptReplayFromBackup] == `` {
+			return errors.Errorf(`%s must not be empty`, OptReplayFromBackup)
+		}
+		if scanType != NoInitialScan {
+			return errors.Errorf(`%s cannot be used with an initial scan`, OptReplayFromBackup)
+		}
+	}
+	if _, err := s.GetSchemaChangeHandlingOptions(); err != nil {
+		return err
+	}
 	return nil
 }
 
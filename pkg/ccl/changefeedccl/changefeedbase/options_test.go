@@ -37,6 +37,20 @@ func TestOptionsValidations(t *testing.T) {
 		{map[string]string{"initial_scan_only": "", "resolved": ""}, true, "cannot specify both initial_scan='only'"},
 		{map[string]string{"initial_scan_only": "", "resolved": ""}, true, "cannot specify both initial_scan='only'"},
 		{map[string]string{"key_column": "b"}, false, "requires the unordered option"},
+		{map[string]string{"cloudevents": "batch"}, false, "unknown cloudevents"},
+		{map[string]string{"schema_change_topic": "ddl"}, false, ""},
+		{map[string]string{"schema_change_topic": ""}, false, "schema_change_topic must not be empty"},
+		{map[string]string{"schema_change_topic": "ddl", "schema_change_policy": "ignore"}, false,
+			"schema_change_topic cannot be used with schema_change_policy=ignore"},
+		{map[string]string{"schema_change_topic": "ddl", "format": "avro"}, false,
+			"schema_change_topic is only usable with format=json"},
+		{map[string]string{"replay_from_backup": "nodelocal://1/backups", "cursor": "1"}, false, ""},
+		{map[string]string{"replay_from_backup": "nodelocal://1/backups"}, false,
+			"replay_from_backup requires the cursor option"},
+		{map[string]string{"replay_from_backup": "", "cursor": "1"}, false,
+			"replay_from_backup must not be empty"},
+		{map[string]string{"replay_from_backup": "nodelocal://1/backups", "cursor": "1", "initial_scan": "yes"}, false,
+			"replay_from_backup cannot be used with an initial scan"},
 	}
 
 	for _, test := range tests {
@@ -66,6 +80,8 @@ func TestEncodingOptionsValidations(t *testing.T) {
 		{EncodingOptions{Format: OptFormatAvro, Envelope: OptEnvelopeBare, UpdatedTimestamps: true}, "is only usable with envelope=wrapped"},
 		{EncodingOptions{Format: OptFormatAvro, Envelope: OptEnvelopeBare, MVCCTimestamps: true}, "is only usable with envelope=wrapped"},
 		{EncodingOptions{Format: OptFormatAvro, Envelope: OptEnvelopeBare, Diff: true}, "is only usable with envelope=wrapped"},
+		{EncodingOptions{Format: OptFormatCSV, CloudEvents: OptCloudEventsBinary}, "cloudevents is only usable with format=json"},
+		{EncodingOptions{Format: OptFormatJSON, Envelope: OptEnvelopeWrapped, CloudEvents: OptCloudEventsStructured}, ""},
 	}
 
 	for _, c := range cases {
@@ -0,0 +1,108 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+)
+
+const (
+	cloudEventsSpecVersion = `1.0`
+	cloudEventsSource      = `/cockroachdb/changefeed`
+	cloudEventsRowType     = `com.cockroachlabs.changefeed.row`
+	// cloudEventsResolvedType is the type of resolved timestamp events.
+	cloudEventsResolvedType = `com.cockroachlabs.changefeed.resolved`
+
+	// cloudEventsHeaderPrefix prefixes the attributes of a binary-mode event
+	// when sent as HTTP headers or Pub/Sub message attributes.
+	cloudEventsHeaderPrefix = `ce-`
+
+	applicationTypeCloudEvents      = `application/cloudevents+json`
+	applicationTypeCloudEventsBatch = `application/cloudevents-batch+json`
+)
+
+// cloudEvent holds the context attributes of a CloudEvents 1.0 event. The
+// data of the event is the encoded row or resolved timestamp.
+type cloudEvent struct {
+	id      string
+	typ     string
+	time    string
+	subject string
+}
+
+// makeRowCloudEvent returns the attributes of the event for a row. Its id is
+// derived from the row's MVCC timestamp, table and key, so that an event
+// re-emitted after a changefeed restart has the same id and can be
+// deduplicated by consumers.
+func makeRowCloudEvent(tableName string, key []byte, mvcc hlc.Timestamp) cloudEvent {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(tableName))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write(key)
+	return cloudEvent{
+		id:      fmt.Sprintf(`%s-%016x`, mvcc.AsOfSystemTime(), h.Sum64()),
+		typ:     cloudEventsRowType,
+		time:    mvcc.GoTime().UTC().Format(time.RFC3339Nano),
+		subject: tableName + `/` + string(key),
+	}
+}
+
+// makeResolvedCloudEvent returns the attributes of the event for an encoded
+// resolved timestamp message.
+func makeResolvedCloudEvent(payload []byte) cloudEvent {
+	h := fnv.New64a()
+	_, _ = h.Write(payload)
+	return cloudEvent{
+		id:  fmt.Sprintf(`resolved-%016x`, h.Sum64()),
+		typ: cloudEventsResolvedType,
+	}
+}
+
+// forEachAttribute calls fn with the name and value of every attribute that
+// is set on the event.
+func (ce cloudEvent) forEachAttribute(fn func(k, v string)) {
+	fn(`specversion`, cloudEventsSpecVersion)
+	fn(`type`, ce.typ)
+	fn(`source`, cloudEventsSource)
+	fn(`id`, ce.id)
+	if ce.time != `` {
+		fn(`time`, ce.time)
+	}
+	if ce.subject != `` {
+		fn(`subject`, ce.subject)
+	}
+}
+
+// binaryAttributes returns the attributes of the event as binary-mode
+// headers.
+func (ce cloudEvent) binaryAttributes() map[string]string {
+	attrs := make(map[string]string, 6)
+	ce.forEachAttribute(func(k, v string) {
+		attrs[cloudEventsHeaderPrefix+k] = v
+	})
+	return attrs
+}
+
+// appendStructured writes the event as a structured-mode JSON object, with
+// the JSON-encoded data as its payload.
+func (ce cloudEvent) appendStructured(buf *bytes.Buffer, data []byte) {
+	buf.WriteByte('{')
+	ce.forEachAttribute(func(k, v string) {
+		json.FromString(k).Format(buf)
+		buf.WriteByte(':')
+		json.FromString(v).Format(buf)
+		buf.WriteByte(',')
+	})
+	buf.WriteString(`"datacontenttype":"` + applicationTypeJSON + `","data":`)
+	buf.Write(data)
+	buf.WriteByte('}')
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package kvfeed
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/span"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+)
+
+// HistorySource provides the revisions of the keys in the watched spans for a
+// window of time which may no longer be readable from KV, for example because
+// it has been garbage collected. The kvfeed reads the history it has not yet
+// seen from the source, up to the source's end time, before it starts the
+// rangefeeds.
+type HistorySource interface {
+	// EndTime returns the timestamp up to and including which the source
+	// provides history.
+	EndTime() hlc.Timestamp
+	// Replay writes a KV event to sink for every revision of the keys in spans
+	// with a timestamp in (start, end], in increasing timestamp order for each
+	// key. If withDiff is set, the events carry the previous value of the key.
+	Replay(
+		ctx context.Context, sink kvevent.Writer, spans []roachpb.Span,
+		start, end hlc.Timestamp, withDiff bool,
+	) error
+	// Scan writes a backfill KV event to sink for the revision of each key in
+	// spans which is live at ts.
+	Scan(
+		ctx context.Context, sink kvevent.Writer, spans []roachpb.Span,
+		ts hlc.Timestamp, withDiff bool,
+	) error
+}
+
+// historyScanner is a kvScanner which reads from a HistorySource. It is used
+// for the backfills of schema changes in the window of time covered by the
+// source.
+type historyScanner struct {
+	source HistorySource
+}
+
+var _ kvScanner = (*historyScanner)(nil)
+
+// Scan implements the kvScanner interface.
+func (s *historyScanner) Scan(ctx context.Context, sink kvevent.Writer, cfg scanConfig) error {
+	ctx, sp := tracing.ChildSpan(ctx, "changefeed.kvfeed.history_scanner.scan")
+	defer sp.Finish()
+
+	if err := s.source.Scan(ctx, sink, cfg.Spans, cfg.Timestamp, cfg.WithDiff); err != nil {
+		return err
+	}
+	for _, sp := range cfg.Spans {
+		if err := sink.Add(ctx, kvevent.NewBackfillResolvedEvent(sp, cfg.Timestamp, cfg.Boundary)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scannerAt returns the scanner to use for a scan at ts.
+func (f *kvFeed) scannerAt(ts hlc.Timestamp) kvScanner {
+	if f.historySource != nil && ts.LessEq(f.historySource.EndTime()) {
+		return &historyScanner{source: f.historySource}
+	}
+	return f.scanner
+}
+
+// replayHistory replays the history of the watched spans from the history
+// source, starting at the frontier, and forwards the frontier to the time up
+// to which the history was replayed. The replay stops at the earliest of the
+// end time of the source, the time before the next table event and the end
+// time of the feed. tableEventReached is true if the replay stopped because
+// of a table event, and an errEndTimeReached is returned if it stopped
+// because of the end time of the feed.
+func (f *kvFeed) replayHistory(
+	ctx context.Context, resumeFrontier span.Frontier,
+) (tableEventReached bool, _ error) {
+	ctx, sp := tracing.ChildSpan(ctx, "changefeed.kvfeed.replay_history")
+	defer sp.Finish()
+
+	start := resumeFrontier.Frontier()
+	end := f.historySource.EndTime()
+	if end.LessEq(start) {
+		return false, nil
+	}
+
+	events, err := f.tableFeed.Peek(ctx, end)
+	if err != nil {
+		return false, err
+	}
+	if len(events) > 0 {
+		end = events[0].Timestamp().Prev()
+		tableEventReached = true
+	}
+	var endTimeReached bool
+	if f.endTime.IsSet() && f.endTime.LessEq(end) {
+		end = f.endTime.Prev()
+		tableEventReached, endTimeReached = false, true
+	}
+	if end.LessEq(start) {
+		// The frontier is already at the boundary.
+		if endTimeReached {
+			return false, &errEndTimeReached{endTime: f.endTime}
+		}
+		return tableEventReached, nil
+	}
+
+	// Each span is replayed from its own resolved timestamp, which may be ahead
+	// of the frontier if the feed was restarted part way through a replay.
+	var stps []kvcoord.SpanTimePair
+	for sp, ts := range resumeFrontier.Entries() {
+		if ts.Less(end) {
+			stps = append(stps, kvcoord.SpanTimePair{Span: sp, StartAfter: ts})
+		}
+	}
+	for _, stp := range stps {
+		if err := f.historySource.Replay(
+			ctx, f.writer, []roachpb.Span{stp.Span}, stp.StartAfter, end, f.withDiff,
+		); err != nil {
+			return false, err
+		}
+		if err := f.writer.Add(
+			ctx, kvevent.NewBackfillResolvedEvent(stp.Span, end, jobspb.ResolvedSpan_NONE),
+		); err != nil {
+			return false, err
+		}
+		if _, err := resumeFrontier.Forward(stp.Span, end); err != nil {
+			return false, err
+		}
+	}
+	if endTimeReached {
+		return false, &errEndTimeReached{endTime: f.endTime}
+	}
+	return tableEventReached, nil
+}
@@ -69,6 +69,11 @@ type Config struct {
 	SchemaChangePolicy changefeedbase.SchemaChangePolicy
 	SchemaFeed         schemafeed.SchemaFeed
 
+	// HistorySource, if set, is used to read the history of the watched spans
+	// up to its end time, instead of the rangefeeds and scans, which can only
+	// read history which has not been garbage collected.
+	HistorySource HistorySource
+
 	// If true, the feed will begin with a dump of data at exactly the
 	// InitialHighWater. This is a peculiar behavior. In general the
 	// InitialHighWater is a point in time at which all data is known to have
@@ -148,6 +153,7 @@ func Run(ctx context.Context, cfg Config) error {
 		cfg.SchemaFeed,
 		sc, pff, bf, cfg.Targets, cfg.ScopedTimers, cfg.Knobs)
 	f.onBackfillCallback = cfg.MonitoringCfg.OnBackfillCallback
+	f.historySource = cfg.HistorySource
 	f.rangeObserver = startLaggingRangesObserver(g, cfg.MonitoringCfg.LaggingRangesCallback,
 		cfg.MonitoringCfg.LaggingRangesPollingInterval, cfg.MonitoringCfg.LaggingRangesThreshold)
 
@@ -274,6 +280,7 @@ type kvFeed struct {
 	codec                keys.SQLCodec
 
 	onBackfillCallback func() func()
+	historySource      HistorySource
 	rangeObserver      kvcoord.RangeObserver
 	schemaChangeEvents changefeedbase.SchemaChangeEventClass
 	schemaChangePolicy changefeedbase.SchemaChangePolicy
@@ -395,7 +402,17 @@ func (f *kvFeed) run(ctx context.Context) (err error) {
 			return errChangefeedCompleted
 		}
 
-		if err = f.runUntilTableEvent(ctx, rangeFeedResumeFrontier); err != nil {
+		// Replay whatever history the history source has before starting the
+		// rangefeeds, which would fail for history that has been garbage
+		// collected. The replay stops before the next table event, if any.
+		var tableEventReached bool
+		if f.historySource != nil {
+			tableEventReached, err = f.replayHistory(ctx, rangeFeedResumeFrontier)
+		}
+		if err == nil && !tableEventReached {
+			err = f.runUntilTableEvent(ctx, rangeFeedResumeFrontier)
+		}
+		if err != nil {
 			if tErr := (*errEndTimeReached)(nil); errors.As(err, &tErr) {
 				if err := emitResolved(rangeFeedResumeFrontier.Frontier(), jobspb.ResolvedSpan_EXIT); err != nil {
 					return err
@@ -573,7 +590,7 @@ func (f *kvFeed) scanIfShould(
 	if initialScanOnly {
 		boundaryType = jobspb.ResolvedSpan_EXIT
 	}
-	if err := f.scanner.Scan(ctx, f.writer, scanConfig{
+	if err := f.scannerAt(scanTime).Scan(ctx, f.writer, scanConfig{
 		Spans:     spansToBackfill.Slice(),
 		Timestamp: scanTime,
 		WithDiff:  !isInitialScan && f.withDiff,
@@ -108,6 +108,7 @@ func TestKVFeed(t *testing.T) {
 		spans                []roachpb.Span
 		initialSpanTimePairs []kvcoord.SpanTimePair
 		events               []kvpb.RangeFeedEvent
+		history              *testHistorySource
 
 		descs []catalog.TableDescriptor
 
@@ -155,6 +156,9 @@ func TestKVFeed(t *testing.T) {
 			tf, sf, rangefeedFactory(ref.run), bufferFactory,
 			changefeedbase.Targets{},
 			st, TestingKnobs{})
+		if tc.history != nil {
+			f.historySource = tc.history
+		}
 		ctx, cancel := context.WithCancel(context.Background())
 		g := ctxgroup.WithContext(ctx)
 		g.GoCtx(func(ctx context.Context) error {
@@ -428,6 +432,77 @@ func TestKVFeed(t *testing.T) {
 			},
 			expEventsCount: 4,
 		},
+		{
+			name:               "history replay",
+			schemaChangeEvents: changefeedbase.OptSchemaChangeEventClassDefault,
+			schemaChangePolicy: changefeedbase.OptSchemaChangePolicyBackfill,
+			needsInitialScan:   false,
+			initialHighWater:   ts(2),
+			spans: []roachpb.Span{
+				tableSpan(codec, 42),
+			},
+			initialSpanTimePairs: []kvcoord.SpanTimePair{
+				{Span: tableSpan(codec, 42), StartAfter: ts(2)},
+			},
+			history: &testHistorySource{
+				endTime: ts(5),
+				events: []kvpb.RangeFeedEvent{
+					kvEvent(codec, 42, "a", "b", ts(2)), // ensure that events are filtered
+					kvEvent(codec, 42, "a", "c", ts(3)),
+					kvEvent(codec, 42, "a", "d", ts(5)),
+				},
+			},
+			events: []kvpb.RangeFeedEvent{
+				kvEvent(codec, 42, "a", "e", ts(6)),
+			},
+			expEvents: []kvpb.RangeFeedEvent{
+				kvEvent(codec, 42, "a", "c", ts(3)),
+				kvEvent(codec, 42, "a", "d", ts(5)),
+				checkpointEvent(tableSpan(codec, 42), ts(5)),
+				kvEvent(codec, 42, "a", "e", ts(6)),
+			},
+			expEventsCount: 4,
+		},
+		{
+			name:               "history replay - stops at table event",
+			schemaChangeEvents: changefeedbase.OptSchemaChangeEventClassDefault,
+			schemaChangePolicy: changefeedbase.OptSchemaChangePolicyBackfill,
+			needsInitialScan:   false,
+			initialHighWater:   ts(2),
+			spans: []roachpb.Span{
+				tableSpan(codec, 42),
+			},
+			initialSpanTimePairs: []kvcoord.SpanTimePair{
+				{Span: tableSpan(codec, 42), StartAfter: ts(2)},
+			},
+			history: &testHistorySource{
+				endTime: ts(5),
+				events: []kvpb.RangeFeedEvent{
+					kvEvent(codec, 42, "a", "c", ts(3)),
+					kvEvent(codec, 42, "a", "d", ts(5)),
+				},
+			},
+			descs: []catalog.TableDescriptor{
+				makeTableDesc(42, 1, ts(1), 2, 1),
+				addColumnDropBackfillMutation(makeTableDesc(42, 2, ts(4), 1, 1)),
+			},
+			events: []kvpb.RangeFeedEvent{
+				kvEvent(codec, 42, "a", "e", ts(6)),
+			},
+			// The backfill of the schema change is read from the history source
+			// rather than the scanner.
+			expEvents: []kvpb.RangeFeedEvent{
+				kvEvent(codec, 42, "a", "c", ts(3)),
+				checkpointEvent(tableSpan(codec, 42), ts(4).Prev()),
+				checkpointEvent(tableSpan(codec, 42), ts(4).Prev()),
+				kvEvent(codec, 42, "a", "c", ts(3)),
+				checkpointEvent(tableSpan(codec, 42), ts(4)),
+				kvEvent(codec, 42, "a", "d", ts(5)),
+				checkpointEvent(tableSpan(codec, 42), ts(5)),
+				kvEvent(codec, 42, "a", "e", ts(6)),
+			},
+			expEventsCount: 8,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			runTest(t, tc)
@@ -435,6 +510,65 @@ func TestKVFeed(t *testing.T) {
 	}
 }
 
+// testHistorySource is a HistorySource which replays a fixed set of events.
+type testHistorySource struct {
+	endTime hlc.Timestamp
+	events  []kvpb.RangeFeedEvent
+}
+
+var _ HistorySource = (*testHistorySource)(nil)
+
+// EndTime implements the HistorySource interface.
+func (h *testHistorySource) EndTime() hlc.Timestamp {
+	return h.endTime
+}
+
+// Replay implements the HistorySource interface.
+func (h *testHistorySource) Replay(
+	ctx context.Context,
+	sink kvevent.Writer,
+	spans []roachpb.Span,
+	start, end hlc.Timestamp,
+	withDiff bool,
+) error {
+	for i := range h.events {
+		if ts := h.events[i].Val.Value.Timestamp; start.Less(ts) && ts.LessEq(end) {
+			if err := sink.Add(ctx, kvevent.MakeKVEvent(&h.events[i])); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Scan implements the HistorySource interface.
+func (h *testHistorySource) Scan(
+	ctx context.Context, sink kvevent.Writer, spans []roachpb.Span, ts hlc.Timestamp, withDiff bool,
+) error {
+	live := make(map[string]*kvpb.RangeFeedEvent)
+	var keys []string
+	for i := range h.events {
+		ev := &h.events[i]
+		if ts.Less(ev.Val.Value.Timestamp) {
+			continue
+		}
+		k := string(ev.Val.Key)
+		if _, ok := live[k]; !ok {
+			keys = append(keys, k)
+		}
+		live[k] = ev
+	}
+	for _, k := range keys {
+		ev := live[k]
+		if err := sink.Add(ctx, kvevent.NewBackfillKVEvent(
+			ev.Val.Key, ev.Val.Value.Timestamp, ev.Val.Value.RawBytes, withDiff, ts,
+		)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type scannerFunc func(ctx context.Context, sink kvevent.Writer, cfg scanConfig) error
 
 func (s scannerFunc) Scan(ctx context.Context, sink kvevent.Writer, cfg scanConfig) error {
@@ -0,0 +1,270 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/schemafeed"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// schemaChangeEmitter emits a message describing each schema change observed
+// by the schemafeed on the topic named by the schema_change_topic option.
+//
+// The messages are emitted by a schemaChangeObservingFeed before the kvfeed
+// reads any row at or after the schema change, and emit flushes the sink before
+// returning, so a consumer of the schema change topic sees the change before it
+// sees any row written with the new schema. Every aggregator observes every
+// schema change, so a message may be delivered once per aggregator; its key,
+// the table ID and the new descriptor version, can be used to deduplicate
+// messages.
+type schemaChangeEmitter struct {
+	// sink is dedicated to schema change messages, which are emitted from the
+	// kvfeed goroutine concurrently with the rows emitted by the aggregator.
+	sink  EventSink
+	topic *schemaChangeTopic
+}
+
+func makeSchemaChangeEmitter(sink EventSink, topic string) *schemaChangeEmitter {
+	return &schemaChangeEmitter{
+		sink:  sink,
+		topic: &schemaChangeTopic{name: topic},
+	}
+}
+
+// emit emits a message for each of the events and flushes the sink.
+func (e *schemaChangeEmitter) emit(ctx context.Context, events []schemafeed.TableEvent) error {
+	for _, ev := range events {
+		key, value := encodeSchemaChangeEvent(ev)
+		if err := e.sink.EmitRow(
+			ctx, e.topic, key, value, ev.Timestamp(), ev.Timestamp(), kvevent.Alloc{}, nil, /* headers */
+		); err != nil {
+			return err
+		}
+	}
+	return e.sink.Flush(ctx)
+}
+
+// Close closes the underlying sink.
+func (e *schemaChangeEmitter) Close() error {
+	return e.sink.Close()
+}
+
+// schemaChangeObservingFeed is the SchemaFeed of the kvfeed of a changefeed
+// with the schema_change_topic option. It wraps the schema feed that decides
+// where the kvfeed stops, and also runs an unfiltered schema feed, whose events
+// it passes to a callback before returning any event at or before the same
+// timestamp. The kvfeed peeks the schema feed before reading any row, so the
+// callback sees every new version of a watched table's descriptor, including
+// the ones that the schema_change_events option filters out, before the kvfeed
+// reads any row written with it.
+type schemaChangeObservingFeed struct {
+	schemafeed.SchemaFeed
+
+	// observed is the unfiltered schema feed.
+	observed schemafeed.SchemaFeed
+	// onSchemaChange is called with the events popped from observed.
+	onSchemaChange func(ctx context.Context, events []schemafeed.TableEvent) error
+
+	// mu serializes calls to onSchemaChange, so that events are passed to it in
+	// order.
+	mu syncutil.Mutex
+}
+
+var _ schemafeed.SchemaFeed = (*schemaChangeObservingFeed)(nil)
+
+func makeSchemaChangeObservingFeed(
+	sf, observed schemafeed.SchemaFeed,
+	onSchemaChange func(ctx context.Context, events []schemafeed.TableEvent) error,
+) *schemaChangeObservingFeed {
+	return &schemaChangeObservingFeed{
+		SchemaFeed:     sf,
+		observed:       observed,
+		onSchemaChange: onSchemaChange,
+	}
+}
+
+// Run implements the schemafeed.SchemaFeed interface.
+func (f *schemaChangeObservingFeed) Run(ctx context.Context) error {
+	g := ctxgroup.WithContext(ctx)
+	g.GoCtx(f.SchemaFeed.Run)
+	g.GoCtx(f.observed.Run)
+	return g.Wait()
+}
+
+// Peek implements the schemafeed.SchemaFeed interface.
+func (f *schemaChangeObservingFeed) Peek(
+	ctx context.Context, atOrBefore hlc.Timestamp,
+) ([]schemafeed.TableEvent, error) {
+	if err := f.observe(ctx, atOrBefore); err != nil {
+		return nil, err
+	}
+	return f.SchemaFeed.Peek(ctx, atOrBefore)
+}
+
+// Pop implements the schemafeed.SchemaFeed interface.
+func (f *schemaChangeObservingFeed) Pop(
+	ctx context.Context, atOrBefore hlc.Timestamp,
+) ([]schemafeed.TableEvent, error) {
+	if err := f.observe(ctx, atOrBefore); err != nil {
+		return nil, err
+	}
+	return f.SchemaFeed.Pop(ctx, atOrBefore)
+}
+
+// observe passes the events of the unfiltered schema feed up to atOrBefore to
+// onSchemaChange.
+func (f *schemaChangeObservingFeed) observe(ctx context.Context, atOrBefore hlc.Timestamp) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	events, err := f.observed.Pop(ctx, atOrBefore)
+	if err != nil || len(events) == 0 {
+		return err
+	}
+	return f.onSchemaChange(ctx, events)
+}
+
+// encodeSchemaChangeEvent returns the JSON key and value of the message for
+// a schema change. The key is the table ID and its new descriptor version,
+// and the value looks like:
+//
+//	{
+//	  "table": "foo",
+//	  "table_id": 104,
+//	  "version": 3,
+//	  "previous_version": 2,
+//	  "updated": "1700000000000000000.0000000000",
+//	  "statements": ["ALTER TABLE foo ADD COLUMN c INT8"],
+//	  "before": {"columns": [...], "primary_key": ["a"]},
+//	  "after": {"columns": [...], "primary_key": ["a"]}
+//	}
+//
+// The statements are only known for schema changes run by the declarative
+// schema changer, and are omitted otherwise.
+func encodeSchemaChangeEvent(ev schemafeed.TableEvent) (key, value []byte) {
+	keyBuilder := json.NewArrayBuilder(2)
+	keyBuilder.Add(json.FromInt64(int64(ev.After.GetID())))
+	keyBuilder.Add(json.FromInt64(int64(ev.After.GetVersion())))
+
+	b := json.NewObjectBuilder(8)
+	b.Add(`table`, json.FromString(ev.After.GetName()))
+	b.Add(`table_id`, json.FromInt64(int64(ev.After.GetID())))
+	b.Add(`version`, json.FromInt64(int64(ev.After.GetVersion())))
+	b.Add(`previous_version`, json.FromInt64(int64(ev.Before.GetVersion())))
+	b.Add(`updated`, json.FromString(ev.Timestamp().AsOfSystemTime()))
+	if stmts := schemaChangeStatements(ev); len(stmts) > 0 {
+		sb := json.NewArrayBuilder(len(stmts))
+		for _, stmt := range stmts {
+			sb.Add(json.FromString(stmt))
+		}
+		b.Add(`statements`, sb.Build())
+	}
+	b.Add(`before`, tableSchemaToJSON(ev.Before))
+	b.Add(`after`, tableSchemaToJSON(ev.After))
+
+	var buf bytes.Buffer
+	keyBuilder.Build().Format(&buf)
+	key = append([]byte(nil), buf.Bytes()...)
+	buf.Reset()
+	b.Build().Format(&buf)
+	return key, buf.Bytes()
+}
+
+// schemaChangeStatements returns the statements of the declarative schema
+// change, if any, that the event is a step of.
+func schemaChangeStatements(ev schemafeed.TableEvent) []string {
+	for _, desc := range []catalog.TableDescriptor{ev.After, ev.Before} {
+		state := desc.GetDeclarativeSchemaChangerState()
+		if state == nil || len(state.RelevantStatements) == 0 {
+			continue
+		}
+		stmts := make([]string, 0, len(state.RelevantStatements))
+		for _, stmt := range state.RelevantStatements {
+			stmts = append(stmts, stmt.Statement.Statement)
+		}
+		return stmts
+	}
+	return nil
+}
+
+// tableSchemaToJSON renders the public columns and the primary key of a table
+// descriptor.
+func tableSchemaToJSON(desc catalog.TableDescriptor) json.JSON {
+	cols := desc.PublicColumns()
+	cb := json.NewArrayBuilder(len(cols))
+	for _, col := range cols {
+		c := json.NewObjectBuilder(4)
+		c.Add(`name`, json.FromString(col.GetName()))
+		c.Add(`type`, json.FromString(col.GetType().SQLString()))
+		c.Add(`nullable`, json.FromBool(col.IsNullable()))
+		c.Add(`hidden`, json.FromBool(col.IsHidden()))
+		cb.Add(c.Build())
+	}
+
+	pk := desc.GetPrimaryIndex()
+	pb := json.NewArrayBuilder(pk.NumKeyColumns())
+	for i := 0; i < pk.NumKeyColumns(); i++ {
+		pb.Add(json.FromString(pk.GetKeyColumnName(i)))
+	}
+
+	b := json.NewObjectBuilder(2)
+	b.Add(`columns`, cb.Build())
+	b.Add(`primary_key`, pb.Build())
+	return b.Build()
+}
+
+// schemaChangeTopic is the TopicDescriptor of the schema change topic.
+type schemaChangeTopic struct {
+	name string
+}
+
+var _ fixedNameTopic = (*schemaChangeTopic)(nil)
+
+// GetNameComponents implements the TopicDescriptor interface.
+func (t *schemaChangeTopic) GetNameComponents() (changefeedbase.StatementTimeName, []string) {
+	return changefeedbase.StatementTimeName(t.name), nil
+}
+
+// GetTopicIdentifier implements the TopicDescriptor interface. No table has
+// the zero ID, so the identifier does not collide with the table topics.
+func (t *schemaChangeTopic) GetTopicIdentifier() TopicIdentifier {
+	return TopicIdentifier{}
+}
+
+// GetVersion implements the TopicDescriptor interface.
+func (t *schemaChangeTopic) GetVersion() descpb.DescriptorVersion {
+	return 0
+}
+
+// GetTargetSpecification implements the TopicDescriptor interface.
+func (t *schemaChangeTopic) GetTargetSpecification() changefeedbase.Target {
+	return changefeedbase.Target{
+		Type:              jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+		StatementTimeName: changefeedbase.StatementTimeName(t.name),
+	}
+}
+
+// GetTableName implements the TopicDescriptor interface.
+func (t *schemaChangeTopic) GetTableName() string {
+	return t.name
+}
+
+// fixedTopicName implements the fixedNameTopic interface, so that the
+// topic_prefix and topic_name sink options do not redirect schema change
+// messages to the topics of the watched tables.
+func (t *schemaChangeTopic) fixedTopicName() string {
+	return t.name
+}
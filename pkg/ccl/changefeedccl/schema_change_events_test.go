@@ -0,0 +1,172 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	gojson "encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/schemafeed"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSink struct {
+	topics, keys, values []string
+	flushes              int
+}
+
+var _ EventSink = (*recordingSink)(nil)
+
+func (s *recordingSink) getConcreteType() sinkType { return sinkTypeNull }
+func (s *recordingSink) Dial() error               { return nil }
+func (s *recordingSink) Close() error              { return nil }
+func (s *recordingSink) Flush(context.Context) error {
+	s.flushes++
+	return nil
+}
+func (s *recordingSink) EmitRow(
+	ctx context.Context,
+	topic TopicDescriptor,
+	key, value []byte,
+	updated, mvcc hlc.Timestamp,
+	alloc kvevent.Alloc,
+	headers rowHeaders,
+) error {
+	s.topics = append(s.topics, topic.GetTableName())
+	s.keys = append(s.keys, string(key))
+	s.values = append(s.values, string(value))
+	return nil
+}
+
+func TestSchemaChangeEmitter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	makeDesc := func(version descpb.DescriptorVersion, cols ...descpb.ColumnDescriptor) catalog.TableDescriptor {
+		return tabledesc.NewBuilder(&descpb.TableDescriptor{
+			Name:             `foo`,
+			ID:               104,
+			Version:          version,
+			ModificationTime: hlc.Timestamp{WallTime: 10},
+			Columns:          cols,
+			PrimaryIndex: descpb.IndexDescriptor{
+				ID:             1,
+				Name:           `foo_pkey`,
+				KeyColumnNames: []string{`a`},
+				KeyColumnIDs:   []descpb.ColumnID{1},
+			},
+		}).BuildImmutableTable()
+	}
+	a := descpb.ColumnDescriptor{ID: 1, Name: `a`, Type: types.Int}
+	b := descpb.ColumnDescriptor{ID: 2, Name: `b`, Type: types.String, Nullable: true}
+	ev := schemafeed.TableEvent{Before: makeDesc(2, a), After: makeDesc(3, a, b)}
+
+	sink := &recordingSink{}
+	e := makeSchemaChangeEmitter(sink, `ddl`)
+	require.NoError(t, e.emit(context.Background(), []schemafeed.TableEvent{ev}))
+	require.Equal(t, 1, sink.flushes)
+	require.Equal(t, []string{`ddl`}, sink.topics)
+	require.Equal(t, []string{`[104, 3]`}, sink.keys)
+	require.Equal(t, []string{`{"after": {"columns": [` +
+		`{"hidden": false, "name": "a", "nullable": false, "type": "INT8"}, ` +
+		`{"hidden": false, "name": "b", "nullable": true, "type": "STRING"}], "primary_key": ["a"]}, ` +
+		`"before": {"columns": [` +
+		`{"hidden": false, "name": "a", "nullable": false, "type": "INT8"}], "primary_key": ["a"]}, ` +
+		`"previous_version": 2, "table": "foo", "table_id": 104, ` +
+		`"updated": "10.0000000000", "version": 3}`,
+	}, sink.values)
+}
+
+// TestChangefeedSchemaChangeTopic verifies that a changefeed with the
+// schema_change_topic option emits a message for every schema change observed
+// by the schemafeed, including the ones that do not stop the changefeed for a
+// backfill, before it emits any row written with the new schema.
+func TestChangefeedSchemaChangeTopic(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'x')`)
+
+		// The topic_prefix sink option applies to the topics of the watched
+		// tables, but not to the schema change topic.
+		foo := feed(t, f, `CREATE CHANGEFEED FOR foo `+
+			`INTO 'kafka://does.not.matter/?topic_prefix=pre_' WITH schema_change_topic = 'ddl'`)
+		defer closeFeed(t, foo)
+		assertPayloads(t, foo, []string{`pre_foo: [1]->{"after": {"a": 1, "b": "x"}}`})
+
+		// Neither of these schema changes stops the changefeed for a backfill.
+		sqlDB.Exec(t, `CREATE INDEX ON foo (b)`)
+		sqlDB.Exec(t, `ALTER TABLE foo RENAME COLUMN b TO c`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (2, 'y')`)
+
+		type schema struct {
+			Columns []struct {
+				Name string `json:"name"`
+			} `json:"columns"`
+		}
+		columnNames := func(s schema) []string {
+			var names []string
+			for _, c := range s.Columns {
+				names = append(names, c.Name)
+			}
+			return names
+		}
+
+		var sawCreateIndex, sawRename bool
+		for {
+			m, err := foo.Next()
+			require.NoError(t, err)
+			if len(m.Resolved) > 0 {
+				continue
+			}
+			if m.Topic != `ddl` {
+				// Both schema changes must have been emitted before the row
+				// written after them.
+				require.Equal(t, `pre_foo`, m.Topic)
+				require.Equal(t, `[2]`, string(m.Key))
+				break
+			}
+
+			var msg struct {
+				Table      string   `json:"table"`
+				Statements []string `json:"statements"`
+				Before     schema   `json:"before"`
+				After      schema   `json:"after"`
+			}
+			require.NoError(t, gojson.Unmarshal(m.Value, &msg), "%s", m.Value)
+			require.Equal(t, `foo`, msg.Table)
+			for _, stmt := range msg.Statements {
+				if strings.Contains(stmt, `CREATE INDEX`) {
+					sawCreateIndex = true
+				}
+			}
+			if slices.Equal(columnNames(msg.Before), []string{`a`, `b`}) &&
+				slices.Equal(columnNames(msg.After), []string{`a`, `c`}) {
+				sawRename = true
+			}
+		}
+		require.True(t, sawCreateIndex, "no message for CREATE INDEX")
+		require.True(t, sawRename, "no message for RENAME COLUMN")
+	}
+
+	cdcTest(t, testFn, feedTestForceSink("kafka"))
+}
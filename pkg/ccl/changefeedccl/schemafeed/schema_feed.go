@@ -110,6 +110,23 @@ func New(
 	return m
 }
 
+// NewUnfiltered creates a SchemaFeed tracking 'targets' like New, but emitting
+// an event for every new version of a target table's descriptor, including the
+// versions that no schema_change_events class stops a changefeed at.
+func NewUnfiltered(
+	ctx context.Context,
+	cfg *execinfra.ServerConfig,
+	targets changefeedbase.Targets,
+	initialFrontier hlc.Timestamp,
+	metrics *Metrics,
+	tolerances changefeedbase.CanHandle,
+) SchemaFeed {
+	m := New(ctx, cfg, changefeedbase.OptSchemaChangeEventClassDefault, targets,
+		initialFrontier, metrics, tolerances).(*schemaFeed)
+	m.filter = nil
+	return m
+}
+
 // schemaFeed tracks changes to a set of tables and exports them as a queue of
 // events. The queue allows clients to provide a timestamp at or before which
 // all events must be seen by the time Peek or Pop returns. This allows clients
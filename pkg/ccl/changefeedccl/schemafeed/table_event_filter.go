@@ -125,6 +125,11 @@ type tableEventFilter map[tableEventType]bool
 func (filter tableEventFilter) shouldFilter(
 	ctx context.Context, e TableEvent, targets changefeedbase.Targets,
 ) (ok bool, _ error) {
+	// A nil filter permits every table event.
+	if filter == nil {
+		return false, nil
+	}
+
 	et := classifyTableEvent(e)
 
 	if log.V(2) {
@@ -57,6 +57,7 @@ const (
 	sinkTypeCloudstorage
 	sinkTypeSQL
 	sinkTypePulsar
+	sinkTypePostgres
 )
 
 func (st sinkType) String() string {
@@ -77,6 +78,8 @@ func (st sinkType) String() string {
 		return `sql`
 	case sinkTypePulsar:
 		return `pulsar`
+	case sinkTypePostgres:
+		return `postgres`
 	default:
 		return `unknown`
 	}
@@ -316,6 +319,16 @@ func getSink(
 			return validateOptionsAndMakeSink(changefeedbase.SQLValidOptions, func() (Sink, error) {
 				return makeSQLSink(&changefeedbase.SinkURL{URL: u}, sqlSinkTableName, targets, metricsBuilder)
 			})
+		case isPostgresSink(u):
+			return validateOptionsAndMakeSink(changefeedbase.PostgresValidOptions, func() (Sink, error) {
+				// Rows are only applied to the target once they are covered by a
+				// resolved timestamp.
+				if !opts.IsSet(changefeedbase.OptResolvedTimestamps) {
+					return nil, errors.Errorf(`this sink requires the %s option`,
+						changefeedbase.OptResolvedTimestamps)
+				}
+				return makePostgresSink(&changefeedbase.SinkURL{URL: u}, encodingOpts, targets, jobID, metricsBuilder)
+			})
 		case u.Scheme == changefeedbase.SinkSchemeExternalConnection:
 			return validateOptionsAndMakeSink(changefeedbase.ExternalConnectionValidOptions, func() (Sink, error) {
 				return makeExternalConnectionSink(
@@ -0,0 +1,655 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/schemafeed"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq"
+)
+
+const (
+	// postgresSinkStagingTableFmt names the table, on the target database, into
+	// which every aggregator stages the rows it emits. Rows are only applied to
+	// the mirrored tables once they are covered by a resolved timestamp. The
+	// table is suffixed with the ID of the feed; see postgresSinkFeedID.
+	postgresSinkStagingTableFmt   = `crdb_changefeed_staging_%s`
+	postgresSinkCreateStagingStmt = `CREATE TABLE IF NOT EXISTS %s (
+		target_table TEXT NOT NULL,
+		key TEXT NOT NULL,
+		mvcc NUMERIC NOT NULL,
+		value TEXT NOT NULL,
+		PRIMARY KEY (target_table, key, mvcc)
+	)`
+	postgresSinkStageStmt = `INSERT INTO %s (target_table, key, mvcc, value)`
+	postgresSinkStageCols = 4
+
+	// postgresSinkResolvedTable records, per feed, the resolved timestamp as of
+	// which the mirrored tables are consistent.
+	postgresSinkResolvedTable      = `crdb_changefeed_resolved`
+	postgresSinkCreateResolvedStmt = `CREATE TABLE IF NOT EXISTS ` + postgresSinkResolvedTable + ` (
+		feed_id TEXT PRIMARY KEY,
+		resolved NUMERIC NOT NULL
+	)`
+	postgresSinkUpsertResolvedStmt = `INSERT INTO ` + postgresSinkResolvedTable + ` (feed_id, resolved)
+		VALUES ($1, $2) ON CONFLICT (feed_id) DO UPDATE SET resolved = excluded.resolved`
+
+	postgresSinkAddColumnStmt = `ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s`
+
+	// Number of rows staged per INSERT statement.
+	postgresSinkStageBatchSize = 128
+
+	postgresSinkColumnsQuery = `SELECT column_name FROM information_schema.columns
+		WHERE table_schema = $1 AND table_name = $2 ORDER BY ordinal_position`
+	postgresSinkPrimaryKeyQuery = `SELECT kcu.column_name
+		FROM information_schema.table_constraints AS tc
+		JOIN information_schema.key_column_usage AS kcu
+			ON tc.constraint_name = kcu.constraint_name
+			AND tc.table_schema = kcu.table_schema
+			AND tc.table_name = kcu.table_name
+		WHERE tc.constraint_type = 'PRIMARY KEY'
+			AND tc.table_schema = $1 AND tc.table_name = $2
+		ORDER BY kcu.ordinal_position`
+)
+
+func isPostgresSink(u *url.URL) bool {
+	switch u.Scheme {
+	case changefeedbase.SinkSchemePostgres, changefeedbase.SinkSchemePostgresql:
+		return true
+	default:
+		return false
+	}
+}
+
+// postgresSink applies changes to a mirrored schema on a Postgres-compatible
+// database (Postgres or CockroachDB).
+//
+// Each changefeed aggregator stages the rows it emits in a staging table on
+// the target. Whenever the change frontier emits a resolved timestamp, every
+// staged row at or below it is applied to the mirrored tables as an UPSERT or
+// DELETE, in a single transaction which also records the resolved timestamp.
+// The mirrored tables are therefore always consistent as of the timestamp
+// recorded in crdb_changefeed_resolved, regardless of how the changefeed is
+// distributed.
+//
+// The mirrored tables must already exist on the target with the same primary
+// key as the source tables. Schema changes of the source tables are observed
+// by the schemafeed and passed to applySchemaChanges before any row written
+// with the new schema is emitted: added columns are added to the mirrored
+// tables, while other column and primary key changes stop the changefeed.
+type postgresSink struct {
+	db *gosql.DB
+
+	uri          string
+	feedID       string
+	stagingTable string
+	topicNamer   *TopicNamer
+	// ownsStagingTable is set if the staging table is private to this sink, in
+	// which case it is dropped when the sink is closed.
+	ownsStagingTable bool
+
+	rowBuf []interface{}
+
+	metrics metricsRecorder
+}
+
+// postgresTargetTable describes a mirrored table on the target.
+type postgresTargetTable struct {
+	// name is the quoted, schema-qualified name of the table.
+	name       string
+	columns    []string
+	primaryKey []string
+}
+
+var _ Sink = (*postgresSink)(nil)
+
+func (s *postgresSink) getConcreteType() sinkType {
+	return sinkTypePostgres
+}
+
+func makePostgresSink(
+	u *changefeedbase.SinkURL,
+	encodingOpts changefeedbase.EncodingOptions,
+	targets changefeedbase.Targets,
+	jobID jobspb.JobID,
+	mb metricsRecorderBuilder,
+) (Sink, error) {
+	u.Scheme = changefeedbase.SinkSchemePostgres
+
+	if u.Path == `` {
+		return nil, errors.Errorf(`must specify database`)
+	}
+	if encodingOpts.Format != changefeedbase.OptFormatJSON {
+		return nil, errors.Errorf(`this sink is incompatible with %s=%s`,
+			changefeedbase.OptFormat, encodingOpts.Format)
+	}
+	if encodingOpts.Envelope != changefeedbase.OptEnvelopeWrapped {
+		return nil, errors.Errorf(`this sink is incompatible with %s=%s`,
+			changefeedbase.OptEnvelope, encodingOpts.Envelope)
+	}
+	if err := targets.EachTarget(func(t changefeedbase.Target) error {
+		if t.Type != jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY {
+			return errors.Errorf(`this sink does not support column family targets`)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	topicNamer, err := MakeTopicNamer(targets)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := u.String()
+	u.ConsumeParam(`sslcert`)
+	u.ConsumeParam(`sslkey`)
+	u.ConsumeParam(`sslmode`)
+	u.ConsumeParam(`sslrootcert`)
+	u.ConsumeParam(`application_name`)
+
+	if unknownParams := u.RemainingQueryParams(); len(unknownParams) > 0 {
+		return nil, errors.Errorf(
+			`unknown postgres sink query parameters: %s`, strings.Join(unknownParams, ", "))
+	}
+
+	feedID := postgresSinkFeedID(jobID)
+	return &postgresSink{
+		uri:              uri,
+		feedID:           feedID,
+		stagingTable:     pq.QuoteIdentifier(fmt.Sprintf(postgresSinkStagingTableFmt, feedID)),
+		topicNamer:       topicNamer,
+		ownsStagingTable: jobID == jobspb.InvalidJobID,
+		metrics:          mb(requiresResourceAccounting),
+	}, nil
+}
+
+// postgresSinkFeedID returns the ID which identifies the feed's staging table
+// and resolved timestamp on the target. The aggregators and the change
+// frontier of a changefeed job share them, so the ID is the job ID. Sinks
+// without a job, such as the one used to validate the sink URI when a
+// changefeed is created, never share their staging table, so they are given
+// a unique ID of their own; otherwise, concurrent feeds would use the same
+// staging table.
+func postgresSinkFeedID(jobID jobspb.JobID) string {
+	if jobID != jobspb.InvalidJobID {
+		return strconv.FormatInt(int64(jobID), 10)
+	}
+	return `nojob_` + strings.ReplaceAll(uuid.MakeV4().String(), `-`, ``)
+}
+
+// Dial implements the Sink interface.
+func (s *postgresSink) Dial() error {
+	connector, err := pq.NewConnector(s.uri)
+	if err != nil {
+		return err
+	}
+
+	s.metrics.netMetrics().WrapPqDialer(connector, "postgres")
+	db := gosql.OpenDB(connector)
+	for _, stmt := range []string{
+		fmt.Sprintf(postgresSinkCreateStagingStmt, s.stagingTable),
+		postgresSinkCreateResolvedStmt,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return err
+		}
+	}
+	s.db = db
+	return nil
+}
+
+// EmitRow implements the Sink interface.
+func (s *postgresSink) EmitRow(
+	ctx context.Context,
+	topicDescr TopicDescriptor,
+	key, value []byte,
+	updated, mvcc hlc.Timestamp,
+	alloc kvevent.Alloc,
+	_headers rowHeaders,
+) error {
+	defer alloc.Release(ctx)
+	defer s.metrics.recordOneMessage()(mvcc, len(key)+len(value), sinkDoesNotCompress)
+
+	topic, err := s.topicNamer.Name(topicDescr)
+	if err != nil {
+		return err
+	}
+
+	s.rowBuf = append(s.rowBuf, topic, string(key), mvcc.AsOfSystemTime(), string(value))
+	if len(s.rowBuf)/postgresSinkStageCols >= postgresSinkStageBatchSize {
+		return s.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush implements the Sink interface. Flushing stages the buffered rows on
+// the target; they are applied once covered by a resolved timestamp.
+func (s *postgresSink) Flush(ctx context.Context) error {
+	defer s.metrics.recordFlushRequestCallback()()
+
+	if len(s.rowBuf) == 0 {
+		return nil
+	}
+
+	var stmt strings.Builder
+	fmt.Fprintf(&stmt, postgresSinkStageStmt, s.stagingTable)
+	for i := 0; i < len(s.rowBuf); i++ {
+		if i == 0 {
+			stmt.WriteString(` VALUES (`)
+		} else if i%postgresSinkStageCols == 0 {
+			stmt.WriteString(`),(`)
+		} else {
+			stmt.WriteString(`,`)
+		}
+		fmt.Fprintf(&stmt, `$%d`, i+1)
+	}
+	// Aggregators may re-emit rows after a restart, so staging is idempotent.
+	stmt.WriteString(`) ON CONFLICT (target_table, key, mvcc) DO NOTHING`)
+	if _, err := s.db.ExecContext(ctx, stmt.String(), s.rowBuf...); err != nil {
+		return err
+	}
+	s.rowBuf = s.rowBuf[:0]
+	return nil
+}
+
+// EmitResolvedTimestamp implements the Sink interface. It applies every
+// staged row at or below the resolved timestamp in a single transaction.
+func (s *postgresSink) EmitResolvedTimestamp(
+	ctx context.Context, _ Encoder, resolved hlc.Timestamp,
+) (retErr error) {
+	defer s.metrics.recordResolvedCallback()()
+
+	tx, err := s.db.BeginTx(ctx, nil /* opts */)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			retErr = errors.CombineErrors(retErr, tx.Rollback())
+		}
+	}()
+
+	resolvedStr := resolved.AsOfSystemTime()
+	if err := s.topicNamer.Each(func(topic string) error {
+		return s.applyStaged(ctx, tx, topic, resolvedStr)
+	}); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		fmt.Sprintf(`DELETE FROM %s WHERE mvcc <= $1`, s.stagingTable), resolvedStr,
+	); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, postgresSinkUpsertResolvedStmt, s.feedID, resolvedStr); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// applyStaged applies the latest staged revision, at or below resolved, of
+// every key of the given topic to its mirrored table.
+func (s *postgresSink) applyStaged(
+	ctx context.Context, tx *gosql.Tx, topic string, resolved string,
+) error {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(
+		`SELECT key, value FROM %s WHERE target_table = $1 AND mvcc <= $2 ORDER BY key, mvcc`,
+		s.stagingTable), topic, resolved)
+	if err != nil {
+		return err
+	}
+	// Only the last revision of each key matters; rows are sorted by key and
+	// then by timestamp, so the last value seen for a key wins.
+	var keys []string
+	latest := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return errors.CombineErrors(err, rows.Close())
+		}
+		if _, ok := latest[key]; !ok {
+			keys = append(keys, key)
+		}
+		latest[key] = value
+	}
+	if err := errors.CombineErrors(rows.Err(), rows.Close()); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	table, err := loadPostgresTargetTable(ctx, tx, topic)
+	if err != nil {
+		return err
+	}
+
+	upserts := json.NewArrayBuilder(len(keys))
+	deletes := json.NewArrayBuilder(len(keys))
+	var numUpserts, numDeletes int
+	for _, key := range keys {
+		after, err := parsePostgresSinkAfter(latest[key])
+		if err != nil {
+			return err
+		}
+		if after == nil {
+			pk, err := table.keyToObject(key)
+			if err != nil {
+				return err
+			}
+			deletes.Add(pk)
+			numDeletes++
+			continue
+		}
+		if err := table.checkColumns(after); err != nil {
+			return err
+		}
+		upserts.Add(after)
+		numUpserts++
+	}
+
+	if numDeletes > 0 {
+		if _, err := tx.ExecContext(ctx, table.deleteStmt(), deletes.Build().String()); err != nil {
+			return errors.Wrapf(err, `deleting from %s`, table.name)
+		}
+	}
+	if numUpserts > 0 {
+		if _, err := tx.ExecContext(ctx, table.upsertStmt(), upserts.Build().String()); err != nil {
+			return errors.Wrapf(err, `upserting into %s`, table.name)
+		}
+	}
+	return nil
+}
+
+// parsePostgresSinkAfter extracts the `after` field of a wrapped JSON
+// envelope. It returns nil for deletions.
+func parsePostgresSinkAfter(value string) (json.JSON, error) {
+	j, err := json.ParseJSON(value)
+	if err != nil {
+		return nil, err
+	}
+	after, err := j.FetchValKey(`after`)
+	if err != nil {
+		return nil, err
+	}
+	if after == nil || after.Type() == json.NullJSONType {
+		return nil, nil
+	}
+	if after.Type() != json.ObjectJSONType {
+		return nil, errors.AssertionFailedf(`unexpected after value: %s`, after)
+	}
+	return after, nil
+}
+
+// loadPostgresTargetTable returns the schema of the mirrored table for the
+// topic. It is reloaded for every resolved timestamp so that schema changes
+// made on the target are picked up without restarting the changefeed.
+func loadPostgresTargetTable(
+	ctx context.Context, tx *gosql.Tx, topic string,
+) (*postgresTargetTable, error) {
+	schema, name := postgresTargetTableName(topic)
+	queryNames := func(query string) ([]string, error) {
+		rows, err := tx.QueryContext(ctx, query, schema, name)
+		if err != nil {
+			return nil, err
+		}
+		var res []string
+		for rows.Next() {
+			var col string
+			if err := rows.Scan(&col); err != nil {
+				return nil, errors.CombineErrors(err, rows.Close())
+			}
+			res = append(res, col)
+		}
+		return res, errors.CombineErrors(rows.Err(), rows.Close())
+	}
+	columns, err := queryNames(postgresSinkColumnsQuery)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, errors.Errorf(`table %s.%s does not exist on the target`, schema, name)
+	}
+	primaryKey, err := queryNames(postgresSinkPrimaryKeyQuery)
+	if err != nil {
+		return nil, err
+	}
+	if len(primaryKey) == 0 {
+		return nil, errors.Errorf(`table %s.%s has no primary key on the target`, schema, name)
+	}
+	return &postgresTargetTable{
+		name:       pq.QuoteIdentifier(schema) + `.` + pq.QuoteIdentifier(name),
+		columns:    columns,
+		primaryKey: primaryKey,
+	}, nil
+}
+
+// postgresTargetTableName splits a topic name into the schema and table of
+// the mirrored table. Topics produced with full_table_name include the
+// database, which is given by the sink URI instead.
+func postgresTargetTableName(topic string) (schema string, table string) {
+	parts := strings.Split(topic, `.`)
+	switch len(parts) {
+	case 1:
+		return `public`, parts[0]
+	default:
+		return parts[len(parts)-2], parts[len(parts)-1]
+	}
+}
+
+// keyToObject converts a JSON-encoded primary key (an array of values in
+// primary key order) into an object keyed by primary key column names.
+func (t *postgresTargetTable) keyToObject(key string) (json.JSON, error) {
+	j, err := json.ParseJSON(key)
+	if err != nil {
+		return nil, err
+	}
+	if j.Type() != json.ArrayJSONType || j.Len() != len(t.primaryKey) {
+		return nil, errors.Errorf(`key %s does not match the primary key (%s) of %s`,
+			key, strings.Join(t.primaryKey, `, `), t.name)
+	}
+	b := json.NewObjectBuilder(len(t.primaryKey))
+	for i, col := range t.primaryKey {
+		v, err := j.FetchValIdx(i)
+		if err != nil {
+			return nil, err
+		}
+		b.Add(col, v)
+	}
+	return b.Build(), nil
+}
+
+// checkColumns returns an error if the row has a column which does not exist
+// on the mirrored table.
+func (t *postgresTargetTable) checkColumns(after json.JSON) error {
+	it, err := after.ObjectIter()
+	if err != nil {
+		return err
+	}
+	for it.Next() {
+		found := false
+		for _, col := range t.columns {
+			if col == it.Key() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Errorf(`column %s does not exist in %s on the target`, it.Key(), t.name)
+		}
+	}
+	return nil
+}
+
+func quoteIdentifiers(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = pq.QuoteIdentifier(n)
+	}
+	return strings.Join(quoted, `, `)
+}
+
+// upsertStmt returns a statement which upserts a JSON array of rows into the
+// table. json_populate_recordset is used so that column values are converted
+// by the target using the mirrored table's types.
+func (t *postgresTargetTable) upsertStmt() string {
+	cols := quoteIdentifiers(t.columns)
+	var stmt strings.Builder
+	fmt.Fprintf(&stmt, `INSERT INTO %[1]s (%[2]s) SELECT %[2]s FROM json_populate_recordset(NULL::%[1]s, $1::JSON) `+
+		`ON CONFLICT (%[3]s) DO `, t.name, cols, quoteIdentifiers(t.primaryKey))
+
+	var set []string
+	for _, col := range t.columns {
+		isKey := false
+		for _, pk := range t.primaryKey {
+			if pk == col {
+				isKey = true
+				break
+			}
+		}
+		if !isKey {
+			set = append(set, fmt.Sprintf(`%[1]s = excluded.%[1]s`, pq.QuoteIdentifier(col)))
+		}
+	}
+	if len(set) == 0 {
+		stmt.WriteString(`NOTHING`)
+	} else {
+		stmt.WriteString(`UPDATE SET `)
+		stmt.WriteString(strings.Join(set, `, `))
+	}
+	return stmt.String()
+}
+
+// deleteStmt returns a statement which deletes a JSON array of primary keys
+// from the table.
+func (t *postgresTargetTable) deleteStmt() string {
+	pk := quoteIdentifiers(t.primaryKey)
+	return fmt.Sprintf(`DELETE FROM %[1]s WHERE (%[2]s) IN (SELECT %[2]s FROM json_populate_recordset(NULL::%[1]s, $1::JSON))`,
+		t.name, pk)
+}
+
+// Topics gives the names of all topics that have been initialized
+// and will receive resolved timestamps.
+func (s *postgresSink) Topics() []string {
+	return s.topicNamer.DisplayNamesSlice()
+}
+
+// applySchemaChanges applies schema changes of the watched tables, observed
+// by the schemafeed, to the mirrored tables on the target. Columns added to a
+// watched table are added to its mirrored table. Any other change to the
+// columns or the primary key of a watched table cannot be applied safely, so
+// it stops the changefeed with an error: the change must be applied to the
+// target by hand, and the changefeed recreated.
+func (s *postgresSink) applySchemaChanges(
+	ctx context.Context, events []schemafeed.TableEvent,
+) error {
+	for _, ev := range events {
+		topic, ok := s.topicName(ev.After.GetID())
+		if !ok {
+			continue
+		}
+		schema, name := postgresTargetTableName(topic)
+		table := pq.QuoteIdentifier(schema) + `.` + pq.QuoteIdentifier(name)
+		added, err := postgresSinkAddedColumns(ev)
+		if err != nil {
+			return changefeedbase.WithTerminalError(errors.Wrapf(err,
+				`cannot apply schema change of table %q (version %d) to %s on the target`,
+				ev.After.GetName(), ev.After.GetVersion(), table))
+		}
+		for _, col := range added {
+			typ := col.GetType()
+			typmod := typ.TypeModifier()
+			if _, err := s.db.ExecContext(ctx, fmt.Sprintf(postgresSinkAddColumnStmt, table,
+				pq.QuoteIdentifier(col.GetName()), typ.SQLStandardNameWithTypmod(typmod != -1, int(typmod)),
+			)); err != nil {
+				return errors.Wrapf(err, `adding column %s to %s on the target`, col.GetName(), table)
+			}
+		}
+	}
+	return nil
+}
+
+// topicName returns the topic name of the watched table with the given ID.
+func (s *postgresSink) topicName(id descpb.ID) (string, bool) {
+	for t, name := range s.topicNamer.DisplayNames {
+		if t.DescID == id {
+			return name, true
+		}
+	}
+	return ``, false
+}
+
+// postgresSinkAddedColumns returns the columns, emitted by the changefeed,
+// that a schema change added to a table. It returns an error if the schema
+// change made any other change to those columns or to the primary key.
+func postgresSinkAddedColumns(ev schemafeed.TableEvent) ([]catalog.Column, error) {
+	before := make(map[descpb.ColumnID]catalog.Column)
+	for _, col := range ev.Before.VisibleColumns() {
+		if !col.IsVirtual() {
+			before[col.GetID()] = col
+		}
+	}
+	var added []catalog.Column
+	for _, col := range ev.After.VisibleColumns() {
+		if col.IsVirtual() {
+			continue
+		}
+		prev, ok := before[col.GetID()]
+		if !ok {
+			added = append(added, col)
+			continue
+		}
+		delete(before, col.GetID())
+		if prev.GetName() != col.GetName() {
+			return nil, errors.Errorf(`column %s was renamed to %s`, prev.GetName(), col.GetName())
+		}
+		if !prev.GetType().Identical(col.GetType()) {
+			return nil, errors.Errorf(`type of column %s was changed from %s to %s`,
+				col.GetName(), prev.GetType().SQLString(), col.GetType().SQLString())
+		}
+	}
+	for _, col := range before {
+		return nil, errors.Errorf(`column %s was dropped`, col.GetName())
+	}
+
+	beforePK, afterPK := ev.Before.GetPrimaryIndex(), ev.After.GetPrimaryIndex()
+	samePK := beforePK.NumKeyColumns() == afterPK.NumKeyColumns()
+	for i := 0; samePK && i < beforePK.NumKeyColumns(); i++ {
+		samePK = beforePK.GetKeyColumnID(i) == afterPK.GetKeyColumnID(i)
+	}
+	if !samePK {
+		return nil, errors.Errorf(`primary key was changed`)
+	}
+	return added, nil
+}
+
+// Close implements the Sink interface.
+func (s *postgresSink) Close() error {
+	if s.db == nil {
+		return nil
+	}
+	var err error
+	if s.ownsStagingTable {
+		_, err = s.db.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s`, s.stagingTable))
+	}
+	return errors.CombineErrors(err, s.db.Close())
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/testutils/pgurlutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresSink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, sqlDBRaw, _ := serverutils.StartServer(t, base.TestServerArgs{
+		DefaultTestTenant: base.TestIsForStuffThatShouldWorkWithSharedProcessModeButDoesntYet(
+			base.TestTenantProbabilistic, 112863,
+		),
+		UseDatabase: "d",
+	})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(sqlDBRaw)
+	sqlDB.Exec(t, `CREATE DATABASE d`)
+	sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+
+	pgURL, cleanup := pgurlutils.PGUrl(t, s.ApplicationLayer().AdvSQLAddr(), t.Name(), url.User(username.RootUser))
+	defer cleanup()
+	pgURL.Path = `d`
+
+	td := tabledesc.NewBuilder(&descpb.TableDescriptor{Name: `foo`, ID: 52}).BuildImmutableTable()
+	spec := changefeedbase.Target{
+		Type:              jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+		DescID:            td.GetID(),
+		StatementTimeName: changefeedbase.StatementTimeName(`foo`),
+	}
+	fooTopic := &tableDescriptorTopic{Metadata: makeMetadata(td), spec: spec}
+	targets := changefeedbase.Targets{}
+	targets.Add(spec)
+
+	encodingOpts := changefeedbase.EncodingOptions{
+		Format:   changefeedbase.OptFormatJSON,
+		Envelope: changefeedbase.OptEnvelopeWrapped,
+	}
+	sink, err := makePostgresSink(
+		&changefeedbase.SinkURL{URL: &pgURL}, encodingOpts, targets, jobspb.JobID(1), nilMetricsRecorderBuilder,
+	)
+	require.NoError(t, err)
+	require.NoError(t, sink.Dial())
+	defer func() { require.NoError(t, sink.Close()) }()
+
+	ts := func(wall int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wall} }
+	emit := func(key, value string, mvcc hlc.Timestamp) {
+		require.NoError(t, sink.EmitRow(ctx, fooTopic, []byte(key), []byte(value), zeroTS, mvcc, zeroAlloc, nil))
+	}
+	var e testEncoder
+
+	// Flushed rows are staged but not applied.
+	emit(`[1]`, `{"after": {"a": 1, "b": "one"}}`, ts(1))
+	emit(`[2]`, `{"after": {"a": 2, "b": "two"}}`, ts(1))
+	emit(`[1]`, `{"after": {"a": 1, "b": "uno"}}`, ts(2))
+	emit(`[3]`, `{"after": {"a": 3, "b": "three"}}`, ts(3))
+	require.NoError(t, sink.Flush(ctx))
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM crdb_changefeed_staging_1`, [][]string{{`4`}})
+	sqlDB.CheckQueryResults(t, `SELECT a, b FROM foo ORDER BY a`, [][]string{})
+
+	// Only rows at or below the resolved timestamp are applied, using the
+	// latest revision of each key.
+	require.NoError(t, sink.EmitResolvedTimestamp(ctx, e, ts(2)))
+	sqlDB.CheckQueryResults(t, `SELECT a, b FROM foo ORDER BY a`,
+		[][]string{{`1`, `uno`}, {`2`, `two`}},
+	)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM crdb_changefeed_staging_1`, [][]string{{`1`}})
+	sqlDB.CheckQueryResults(t, `SELECT resolved FROM crdb_changefeed_resolved WHERE feed_id = '1'`,
+		[][]string{{ts(2).AsOfSystemTime()}},
+	)
+
+	// Deletes, and re-staging an already applied row, are handled.
+	emit(`[2]`, `{"after": null}`, ts(4))
+	emit(`[1]`, `{"after": {"a": 1, "b": "uno"}}`, ts(2))
+	require.NoError(t, sink.Flush(ctx))
+	require.NoError(t, sink.EmitResolvedTimestamp(ctx, e, ts(4)))
+	sqlDB.CheckQueryResults(t, `SELECT a, b FROM foo ORDER BY a`,
+		[][]string{{`1`, `uno`}, {`3`, `three`}},
+	)
+
+	// A column which does not exist on the target holds back the target until
+	// the column is added.
+	emit(`[1]`, `{"after": {"a": 1, "b": "eins", "c": true}}`, ts(5))
+	require.NoError(t, sink.Flush(ctx))
+	require.Regexp(t, `column c does not exist`, sink.EmitResolvedTimestamp(ctx, e, ts(5)))
+	sqlDB.CheckQueryResults(t, `SELECT a, b FROM foo ORDER BY a`,
+		[][]string{{`1`, `uno`}, {`3`, `three`}},
+	)
+	sqlDB.Exec(t, `ALTER TABLE foo ADD COLUMN c BOOL`)
+	require.NoError(t, sink.EmitResolvedTimestamp(ctx, e, ts(5)))
+	sqlDB.CheckQueryResults(t, `SELECT a, b, c FROM foo ORDER BY a`,
+		[][]string{{`1`, `eins`, `true`}, {`3`, `three`, `NULL`}},
+	)
+}
+
+func TestPostgresSinkValidation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	targets := changefeedbase.Targets{}
+	targets.Add(changefeedbase.Target{
+		Type:              jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+		DescID:            52,
+		StatementTimeName: `foo`,
+	})
+	makeSink := func(uri string, opts changefeedbase.EncodingOptions) error {
+		u, err := url.Parse(uri)
+		require.NoError(t, err)
+		_, err = makePostgresSink(&changefeedbase.SinkURL{URL: u}, opts, targets, 1, nilMetricsRecorderBuilder)
+		return err
+	}
+	wrappedJSON := changefeedbase.EncodingOptions{
+		Format:   changefeedbase.OptFormatJSON,
+		Envelope: changefeedbase.OptEnvelopeWrapped,
+	}
+
+	require.NoError(t, makeSink(`postgres://root@host:26257/d?sslmode=disable`, wrappedJSON))
+	require.NoError(t, makeSink(`postgresql://root@host:5432/d`, wrappedJSON))
+	require.Regexp(t, `must specify database`, makeSink(`postgres://root@host:5432`, wrappedJSON))
+	require.Regexp(t, `unknown postgres sink query parameters: foo`,
+		makeSink(`postgres://root@host:5432/d?foo=bar`, wrappedJSON))
+	require.Regexp(t, `incompatible with format=avro`, makeSink(`postgres://root@host:5432/d`,
+		changefeedbase.EncodingOptions{Format: changefeedbase.OptFormatAvro, Envelope: changefeedbase.OptEnvelopeWrapped}))
+	require.Regexp(t, `incompatible with envelope=bare`, makeSink(`postgres://root@host:5432/d`,
+		changefeedbase.EncodingOptions{Format: changefeedbase.OptFormatJSON, Envelope: changefeedbase.OptEnvelopeBare}))
+
+	// Sinks without a job never share their staging table.
+	require.Equal(t, `1`, postgresSinkFeedID(1))
+	require.NotEqual(t, postgresSinkFeedID(jobspb.InvalidJobID), postgresSinkFeedID(jobspb.InvalidJobID))
+
+	for _, topic := range []string{`t`, `d.public.t`} {
+		schema, table := postgresTargetTableName(topic)
+		require.Equal(t, `public`, schema)
+		require.Equal(t, `t`, table)
+	}
+}
+
+func TestPostgresSinkChangefeed(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, sqlDBRaw, _ := serverutils.StartServer(t, base.TestServerArgs{
+		DefaultTestTenant: base.TestIsForStuffThatShouldWorkWithSharedProcessModeButDoesntYet(
+			base.TestTenantProbabilistic, 112863,
+		),
+		UseDatabase: "d",
+	})
+	defer s.Stopper().Stop(ctx)
+	systemDB := sqlutils.MakeSQLRunner(s.SystemLayer().SQLConn(t))
+	systemDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sqlDB := sqlutils.MakeSQLRunner(sqlDBRaw)
+	sqlDB.Exec(t, `CREATE DATABASE d`)
+	sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+	sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'one'), (2, 'two')`)
+	sqlDB.Exec(t, `CREATE DATABASE target`)
+	sqlDB.Exec(t, `CREATE TABLE target.foo (a INT PRIMARY KEY, b STRING)`)
+
+	pgURL, cleanup := pgurlutils.PGUrl(t, s.ApplicationLayer().AdvSQLAddr(), t.Name(), url.User(username.RootUser))
+	defer cleanup()
+	pgURL.Path = `target`
+
+	var jobID jobspb.JobID
+	sqlDB.QueryRow(t, `CREATE CHANGEFEED FOR foo INTO $1 WITH resolved = '10ms'`, pgURL.String()).Scan(&jobID)
+
+	// The initial scan, and subsequent changes, are applied to the target.
+	sqlDB.CheckQueryResultsRetry(t, `SELECT a, b FROM target.foo ORDER BY a`,
+		[][]string{{`1`, `one`}, {`2`, `two`}},
+	)
+	sqlDB.Exec(t, `UPDATE foo SET b = 'uno' WHERE a = 1`)
+	sqlDB.Exec(t, `DELETE FROM foo WHERE a = 2`)
+	sqlDB.CheckQueryResultsRetry(t, `SELECT a, b FROM target.foo ORDER BY a`,
+		[][]string{{`1`, `uno`}},
+	)
+
+	// Added columns are added to the target.
+	sqlDB.Exec(t, `ALTER TABLE foo ADD COLUMN c BOOL`)
+	sqlDB.Exec(t, `INSERT INTO foo VALUES (3, 'three', true)`)
+	sqlDB.CheckQueryResultsRetry(t, `SELECT a, b, c FROM target.foo ORDER BY a`,
+		[][]string{{`1`, `uno`, `NULL`}, {`3`, `three`, `true`}},
+	)
+
+	// Other schema changes stop the changefeed.
+	sqlDB.Exec(t, `ALTER TABLE foo RENAME COLUMN b TO d`)
+	sqlDB.Exec(t, `INSERT INTO foo VALUES (4, 'four', false)`)
+	waitForJobState(sqlDB, t, jobID, jobs.StateFailed)
+	var jobErr string
+	sqlDB.QueryRow(t, `SELECT error FROM [SHOW JOBS] WHERE job_id = $1`, jobID).Scan(&jobErr)
+	require.Regexp(t, `cannot apply schema change of table "foo" .*: column b was renamed to d`, jobErr)
+	sqlDB.CheckQueryResults(t, `SELECT a, b, c FROM target.foo ORDER BY a`,
+		[][]string{{`1`, `uno`, `NULL`}, {`3`, `three`, `true`}},
+	)
+}
@@ -63,6 +63,7 @@ type pubsubSinkClient struct {
 	format                 changefeedbase.FormatType
 	batchCfg               sinkBatchConfig
 	withTableNameAttribute bool
+	cloudEvents            changefeedbase.CloudEventsMode
 	mu                     struct {
 		syncutil.RWMutex
 
@@ -138,6 +139,7 @@ func makePubsubSinkClient(
 		batchCfg:               batchCfg,
 		projectID:              projectID,
 		withTableNameAttribute: withTableNameAttribute,
+		cloudEvents:            encodingOpts.CloudEvents,
 	}
 	sinkClient.mu.topicCache = make(map[string]struct{})
 
@@ -151,12 +153,14 @@ func (sc *pubsubSinkClient) FlushResolvedPayload(
 	forEachTopic func(func(topic string) error) error,
 	retryOpts retry.Options,
 ) error {
+	msg := &pb.PubsubMessage{Data: body}
+	if sc.cloudEvents != `` {
+		msg = sc.makeCloudEventMessage(makeResolvedCloudEvent(body), body)
+	}
 	return forEachTopic(func(topic string) error {
 		pl := &pb.PublishRequest{
-			Topic: sc.gcPubsubTopic(topic),
-			Messages: []*pb.PubsubMessage{{
-				Data: body,
-			}},
+			Topic:    sc.gcPubsubTopic(topic),
+			Messages: []*pb.PubsubMessage{msg},
 		}
 		return retry.WithMaxAttempts(ctx, retryOpts, retryOpts.MaxRetries+1, func() error {
 			return sc.Flush(ctx, pl)
@@ -237,10 +241,35 @@ type pubsubBuffer struct {
 
 var _ BatchBuffer = (*pubsubBuffer)(nil)
 
+// makeCloudEventMessage returns the message for a CloudEvent, in the sink's
+// CloudEvents mode. Binary-mode events carry their attributes as message
+// attributes.
+func (sc *pubsubSinkClient) makeCloudEventMessage(ce cloudEvent, data []byte) *pb.PubsubMessage {
+	if sc.cloudEvents == changefeedbase.OptCloudEventsBinary {
+		return &pb.PubsubMessage{Data: data, Attributes: ce.binaryAttributes()}
+	}
+	var buffer bytes.Buffer
+	ce.appendStructured(&buffer, data)
+	return &pb.PubsubMessage{Data: buffer.Bytes()}
+}
+
 // Append implements the BatchBuffer interface
 func (psb *pubsubBuffer) Append(
 	ctx context.Context, key []byte, value []byte, attributes attributes,
 ) {
+	if psb.sc.cloudEvents != `` {
+		msg := psb.sc.makeCloudEventMessage(makeRowCloudEvent(attributes.tableName, key, attributes.mvcc), value)
+		if psb.sc.withTableNameAttribute {
+			if msg.Attributes == nil {
+				msg.Attributes = make(map[string]string, 1)
+			}
+			msg.Attributes["TABLE_NAME"] = attributes.tableName
+		}
+		psb.messages = append(psb.messages, msg)
+		psb.numBytes += len(msg.Data)
+		return
+	}
+
 	var content []byte
 	switch psb.sc.format {
 	case changefeedbase.OptFormatJSON:
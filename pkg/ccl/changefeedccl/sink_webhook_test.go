@@ -16,6 +16,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -1064,3 +1065,65 @@ func TestWebhookSinkErrorCompressedResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestWebhookSinkCloudEvents(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	fooTopic := &tableDescriptorTopic{Metadata: cdcevent.Metadata{TableID: 52, TableName: `foo`}}
+	key := []byte(`[1001]`)
+	value := []byte(`{"after":{"col1":"val1","rowid":1000},"key":[1001],"topic":"foo"}`)
+	mvcc := hlc.Timestamp{WallTime: 1700000000000000000, Logical: 1}
+	ce := makeRowCloudEvent(`foo`, key, mvcc)
+
+	testFn := func(t *testing.T, mode changefeedbase.CloudEventsMode) {
+		cert, certEncoded, err := cdctest.NewCACertBase64Encoded()
+		require.NoError(t, err)
+		sinkDest, err := cdctest.StartMockWebhookSink(cert)
+		require.NoError(t, err)
+		defer sinkDest.Close()
+
+		sinkDestHost, err := url.Parse(sinkDest.URL())
+		require.NoError(t, err)
+		params := sinkDestHost.Query()
+		params.Set(changefeedbase.SinkParamCACert, certEncoded)
+		sinkDestHost.RawQuery = params.Encode()
+
+		opts := getGenericWebhookSinkOptions(struct {
+			key   string
+			value string
+		}{changefeedbase.OptCloudEvents, string(mode)})
+		details := jobspb.ChangefeedDetails{
+			SinkURI: fmt.Sprintf("webhook-%s", sinkDestHost.String()),
+			Opts:    opts.AsMap(),
+		}
+		sinkSrc, err := setupWebhookSinkWithDetails(ctx, details, 1, timeutil.DefaultTimeSource{})
+		require.NoError(t, err)
+		defer func() { require.NoError(t, sinkSrc.Close()) }()
+
+		require.NoError(t, sinkSrc.EmitRow(ctx, fooTopic, key, value, zeroTS, mvcc, zeroAlloc, nil))
+		require.NoError(t, sinkSrc.Flush(ctx))
+
+		row := sinkDest.PopWithHeaders()
+		switch mode {
+		case changefeedbase.OptCloudEventsStructured:
+			require.Equal(t, applicationTypeCloudEventsBatch, row.Headers.Get(contentTypeHeader))
+			require.Equal(t, `[{"specversion":"1.0","type":"com.cockroachlabs.changefeed.row",`+
+				`"source":"/cockroachdb/changefeed","id":"`+ce.id+`","time":"2023-11-14T22:13:20Z",`+
+				`"subject":"foo/[1001]","datacontenttype":"application/json","data":`+string(value)+`}]`,
+				row.Row)
+		case changefeedbase.OptCloudEventsBinary:
+			require.Equal(t, applicationTypeJSON, row.Headers.Get(contentTypeHeader))
+			require.Equal(t, string(value), row.Row)
+			require.Equal(t, `1.0`, row.Headers.Get(`ce-specversion`))
+			require.Equal(t, cloudEventsRowType, row.Headers.Get(`ce-type`))
+			require.Equal(t, cloudEventsSource, row.Headers.Get(`ce-source`))
+			require.Equal(t, ce.id, row.Headers.Get(`ce-id`))
+			require.Equal(t, `2023-11-14T22:13:20Z`, row.Headers.Get(`ce-time`))
+			require.Equal(t, `foo/[1001]`, row.Headers.Get(`ce-subject`))
+		}
+	}
+
+	t.Run("structured", func(t *testing.T) { testFn(t, changefeedbase.OptCloudEventsStructured) })
+	t.Run("binary", func(t *testing.T) { testFn(t, changefeedbase.OptCloudEventsBinary) })
+}
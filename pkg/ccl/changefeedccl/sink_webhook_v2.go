@@ -57,6 +57,7 @@ type webhookSinkClient struct {
 	client            *httputil.Client
 	settings          *cluster.Settings
 	compression       compressionAlgo
+	cloudEvents       changefeedbase.CloudEventsMode
 }
 
 var _ SinkClient = (*webhookSinkClient)(nil)
@@ -97,6 +98,7 @@ func makeWebhookSinkClient(
 		batchCfg:          batchCfg,
 		settings:          settings,
 		compression:       compression,
+		cloudEvents:       encodingOpts.CloudEvents,
 	}
 
 	var connTimeout time.Duration
@@ -225,11 +227,42 @@ func (sc *webhookSinkClient) makePayloadForBytes(body []byte) (SinkPayload, erro
 	return req, nil
 }
 
+// makeCloudEventPayload returns the request for a single CloudEvent, in the
+// sink's CloudEvents mode.
+func (sc *webhookSinkClient) makeCloudEventPayload(ce cloudEvent, data []byte) (SinkPayload, error) {
+	if sc.cloudEvents == changefeedbase.OptCloudEventsBinary {
+		pl, err := sc.makePayloadForBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		req := pl.(*http.Request)
+		for k, v := range ce.binaryAttributes() {
+			req.Header.Set(k, v)
+		}
+		return req, nil
+	}
+
+	var buffer bytes.Buffer
+	ce.appendStructured(&buffer, data)
+	pl, err := sc.makePayloadForBytes(buffer.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	pl.(*http.Request).Header.Set(contentTypeHeader, applicationTypeCloudEvents)
+	return pl, nil
+}
+
 // FlushResolvedPayload implements the SinkClient interface.
 func (sc *webhookSinkClient) FlushResolvedPayload(
 	ctx context.Context, body []byte, _ func(func(topic string) error) error, retryOpts retry.Options,
 ) error {
-	pl, err := sc.makePayloadForBytes(body)
+	var pl SinkPayload
+	var err error
+	if sc.cloudEvents != `` {
+		pl, err = sc.makeCloudEventPayload(makeResolvedCloudEvent(body), body)
+	} else {
+		pl, err = sc.makePayloadForBytes(body)
+	}
 	if err != nil {
 		return err
 	}
@@ -415,8 +448,67 @@ func (jb *webhookJSONBuffer) Close() (SinkPayload, error) {
 	return jb.sc.makePayloadForBytes(buffer.Bytes())
 }
 
+// webhookCloudEventsBuffer batches rows as CloudEvents. In structured mode,
+// the batch is sent as a JSON array of events. Binary mode carries the event
+// attributes in the request headers, so every request holds a single event.
+type webhookCloudEventsBuffer struct {
+	events   []cloudEvent
+	messages [][]byte
+	numBytes int
+	sc       *webhookSinkClient
+}
+
+var _ BatchBuffer = (*webhookCloudEventsBuffer)(nil)
+
+// Append implements the BatchBuffer interface.
+func (cb *webhookCloudEventsBuffer) Append(
+	ctx context.Context, key []byte, value []byte, attributes attributes,
+) {
+	cb.events = append(cb.events, makeRowCloudEvent(attributes.tableName, key, attributes.mvcc))
+	cb.messages = append(cb.messages, value)
+	cb.numBytes += len(value)
+}
+
+// ShouldFlush implements the BatchBuffer interface.
+func (cb *webhookCloudEventsBuffer) ShouldFlush() bool {
+	if cb.sc.cloudEvents == changefeedbase.OptCloudEventsBinary {
+		return len(cb.messages) > 0
+	}
+	return shouldFlushBatch(cb.numBytes, len(cb.messages), cb.sc.batchCfg)
+}
+
+// Close implements the BatchBuffer interface.
+func (cb *webhookCloudEventsBuffer) Close() (SinkPayload, error) {
+	if cb.sc.cloudEvents == changefeedbase.OptCloudEventsBinary {
+		if len(cb.messages) != 1 {
+			return nil, errors.AssertionFailedf(
+				"expected a single message per binary-mode CloudEvents request, found %d", len(cb.messages))
+		}
+		return cb.sc.makeCloudEventPayload(cb.events[0], cb.messages[0])
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteByte('[')
+	for i, msg := range cb.messages {
+		if i != 0 {
+			buffer.WriteByte(',')
+		}
+		cb.events[i].appendStructured(&buffer, msg)
+	}
+	buffer.WriteByte(']')
+	pl, err := cb.sc.makePayloadForBytes(buffer.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	pl.(*http.Request).Header.Set(contentTypeHeader, applicationTypeCloudEventsBatch)
+	return pl, nil
+}
+
 // MakeBatchBuffer implements the SinkClient interface.
 func (sc *webhookSinkClient) MakeBatchBuffer(topic string) BatchBuffer {
+	if sc.cloudEvents != `` {
+		return &webhookCloudEventsBuffer{sc: sc}
+	}
 	if sc.format == changefeedbase.OptFormatCSV {
 		return &webhookCSVBuffer{sc: sc}
 	} else {
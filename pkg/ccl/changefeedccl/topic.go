@@ -137,8 +137,23 @@ func MakeTopicNamer(targets changefeedbase.Targets, opts ...TopicNameOption) (*T
 
 const familyPlaceholder = "{family}"
 
+// fixedNameTopic is implemented by topic descriptors that do not correspond to
+// a changefeed target. Their names are used as is, rather than being overridden
+// by the topic_prefix and topic_name sink options.
+type fixedNameTopic interface {
+	TopicDescriptor
+	fixedTopicName() string
+}
+
 // Name generates (with caching) a sink's topic identifier string.
 func (tn *TopicNamer) Name(td TopicDescriptor) (string, error) {
+	if ft, ok := td.(fixedNameTopic); ok {
+		name := ft.fixedTopicName()
+		if tn.sanitize != nil {
+			name = tn.sanitize(name)
+		}
+		return name, nil
+	}
 	if name, ok := tn.FullNames[td.GetTopicIdentifier()]; ok {
 		return name, nil
 	}
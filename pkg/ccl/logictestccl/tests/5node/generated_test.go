@@ -89,6 +89,13 @@ func TestCCLLogic_drop_index(
 	runCCLLogicTest(t, "drop_index")
 }
 
+func TestCCLLogic_partition_wise_join(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "partition_wise_join")
+}
+
 func TestCCLLogic_partitioning_hash_sharded_index(
 	t *testing.T,
 ) {
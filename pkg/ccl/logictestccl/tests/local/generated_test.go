@@ -341,6 +341,13 @@ func TestCCLLogic_restore(
 	runCCLLogicTest(t, "restore")
 }
 
+func TestCCLLogic_result_cache(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "result_cache")
+}
+
 func TestCCLLogic_schema_change_in_txn(
 	t *testing.T,
 ) {
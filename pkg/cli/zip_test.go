@@ -123,7 +123,8 @@ table_name NOT IN (
 	'transaction_statistics',
 	'tenant_usage_details',
 	'pg_catalog_table_is_implemented',
-	'fully_qualified_names'
+	'fully_qualified_names',
+	'plan_baselines'
 )
 ORDER BY name ASC`)
 	assert.NoError(t, err)
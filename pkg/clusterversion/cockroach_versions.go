@@ -241,6 +241,15 @@ const (
 	// meta1 and meta2.
 	V26_1_InstallMeta2StaticSplitPoint
 
+	// V26_1_LogicalReplicationMeshTieBreak is the version after which every
+	// node breaks ties between equal origin timestamps of logical replication
+	// writes by the LocalOriginID of their WriteOptions.
+	V26_1_LogicalReplicationMeshTieBreak
+
+	// V26_1_SystemVersionedTables is the version after which every node writes
+	// history rows for tables with SYSTEM VERSIONING.
+	V26_1_SystemVersionedTables
+
 	// *************************************************
 	// Step (1) Add new versions above this comment.
 	// Do not add new versions to a patch release.
@@ -311,6 +320,10 @@ var versionTable = [numKeys]roachpb.Version{
 
 	V26_1_InstallMeta2StaticSplitPoint: {Major: 25, Minor: 4, Internal: 4},
 
+	V26_1_LogicalReplicationMeshTieBreak: {Major: 25, Minor: 4, Internal: 6},
+
+	V26_1_SystemVersionedTables: {Major: 25, Minor: 4, Internal: 8},
+
 	// *************************************************
 	// Step (2): Add new versions above this comment.
 	// Do not add new versions to a patch release.
@@ -0,0 +1,254 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package logical
+
+import (
+	"bytes"
+	"context"
+	"slices"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// firstMeshOriginID is the origin ID of the cluster with the smallest cluster
+// ID in a logical replication mesh. Origin ID 0 identifies local writes and
+// origin ID 1 identifies remote writes of unspecified origin.
+const firstMeshOriginID = 2
+
+// meshOriginIDs assigns an origin ID to each cluster of a mesh in the order of
+// their cluster IDs. Every cluster of the mesh thus assigns the same origin IDs,
+// and breaking last-writer-wins ties by origin ID is the same as breaking them
+// by cluster ID.
+func meshOriginIDs(clusterIDs []uuid.UUID) (map[uuid.UUID]uint32, error) {
+	sorted := slices.Clone(clusterIDs)
+	slices.SortFunc(sorted, func(a, b uuid.UUID) int {
+		return bytes.Compare(a.GetBytes(), b.GetBytes())
+	})
+	originIDs := make(map[uuid.UUID]uint32, len(sorted))
+	for i, id := range sorted {
+		if i > 0 && sorted[i-1] == id {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"cluster %s is listed more than once in the mesh", id)
+		}
+		originIDs[id] = firstMeshOriginID + uint32(i)
+	}
+	return originIDs, nil
+}
+
+// checkLogicalReplicationMeshOptions returns an error if the statement sets an
+// option which cannot be used to create a mesh.
+func checkLogicalReplicationMeshOptions(stmt *tree.CreateLogicalReplicationStream) error {
+	var unsupported string
+	switch opts := stmt.Options; {
+	case opts.Cursor != nil:
+		unsupported = "CURSOR"
+	case opts.DefaultFunction != nil:
+		unsupported = "DEFAULT FUNCTION"
+	case opts.UserFunctions != nil:
+		unsupported = "FUNCTION"
+	case opts.Unidirectional != nil:
+		unsupported = "UNIDIRECTIONAL"
+	case opts.BidirectionalURI != nil:
+		unsupported = "BIDIRECTIONAL"
+	default:
+		return nil
+	}
+	return pgerror.Newf(pgcode.InvalidParameterValue,
+		"%s cannot be used with CREATE LOGICAL REPLICATION MESH", unsupported)
+}
+
+// createLogicalReplicationMesh creates the streams replicating the tables of
+// the statement into the local cluster from every other cluster of a mesh and,
+// unless the statement was issued by another cluster of the mesh, issues it on
+// every other cluster so that each creates its own streams. Each URI must be an
+// external connection which refers to the same cluster on every cluster of the
+// mesh; the URI of the local cluster is only used to identify it.
+//
+// Every stream tags the rows it writes with the origin ID of its source
+// cluster. Since sources only replicate rows written locally, with an origin ID
+// of 0, every write is replicated once from the cluster where it was written to
+// every other cluster of the mesh, and never back to its origin.
+func createLogicalReplicationMesh(
+	ctx context.Context,
+	p sql.PlanHookState,
+	stmt *tree.CreateLogicalReplicationStream,
+	meshURIs []string,
+	options *resolvedLogicalReplicationOptions,
+	mode jobspb.LogicalReplicationDetails_ApplyMode,
+	discard jobspb.LogicalReplicationDetails_Discard,
+	resolvedDestObjects ResolvedDestObjects,
+) (_ []jobspb.JobID, retErr error) {
+	if err := checkLogicalReplicationMeshOptions(stmt); err != nil {
+		return nil, err
+	}
+	if len(meshURIs) < 2 {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"a logical replication mesh requires at least 2 clusters")
+	}
+	// Nodes running an older version ignore the LocalOriginID of the write
+	// options, and would break ties between equal origin timestamps
+	// differently than the other clusters of the mesh.
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V26_1_LogicalReplicationMeshTieBreak) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"cannot create a logical replication mesh until the cluster version is finalized")
+	}
+
+	localClusterID := p.ExecCfg().NodeInfo.LogicalClusterID()
+	sources := make([]logicalReplicationSource, 0, len(meshURIs))
+	// planned records which sources are consumed by a job; the streams of the
+	// other sources are completed once the mesh is created.
+	planned := make([]bool, len(meshURIs))
+	defer func() {
+		for i := range sources {
+			if !planned[i] {
+				retErr = errors.CombineErrors(retErr, sources[i].client.Complete(ctx, sources[i].spec.StreamID, false))
+			}
+			sources[i].close(ctx)
+		}
+	}()
+
+	clusterIDs := make([]uuid.UUID, 0, len(meshURIs))
+	for _, uri := range meshURIs {
+		src, err := openLogicalReplicationSource(ctx, p, uri, stmt, options)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, src)
+		clusterIDs = append(clusterIDs, src.spec.SourceClusterID)
+	}
+	originIDs, err := meshOriginIDs(clusterIDs)
+	if err != nil {
+		return nil, err
+	}
+	localOriginID, ok := originIDs[localClusterID]
+	if !ok {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"the clusters of a logical replication mesh must include the local cluster")
+	}
+
+	jobIDs := make([]jobspb.JobID, 0, len(sources)-1)
+	// peers are the sources on which the statement creating their streams was
+	// issued, including one on which it failed.
+	var peers []logicalReplicationSource
+	defer func() {
+		if retErr != nil {
+			cancelPartialMesh(ctx, p, jobIDs, peers)
+		}
+	}()
+	for i, src := range sources {
+		if src.spec.SourceClusterID == localClusterID {
+			continue
+		}
+		jobID, err := planLogicalReplicationJob(
+			ctx, p, stmt, src, options, mode, discard, resolvedDestObjects,
+			originIDs[src.spec.SourceClusterID], localOriginID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		planned[i] = true
+		jobIDs = append(jobIDs, jobID)
+	}
+	log.Dev.Infof(ctx, "created %d streams into mesh cluster %s with origin ID %d",
+		len(jobIDs), localClusterID, localOriginID)
+
+	if options.ParentID != 0 {
+		return jobIDs, nil
+	}
+	peerStmt := meshPeerStatement(stmt, meshURIs, options, jobIDs[0])
+	for _, src := range sources {
+		if src.spec.SourceClusterID == localClusterID {
+			continue
+		}
+		peers = append(peers, src)
+		if err := src.client.ExecStatement(ctx, peerStmt, "create-mesh-peer-streams"); err != nil {
+			return nil, errors.Wrapf(err,
+				"created streams %v into the local cluster but failed to create the streams into %s",
+				jobIDs, src.cleanedURI)
+		}
+	}
+	return jobIDs, nil
+}
+
+// cancelMeshPeerJobs cancels the logical replication jobs created on behalf of
+// the job with the given ID.
+var cancelMeshPeerJobs = `CANCEL JOBS (` + checkJobWithSameParent + `)`
+
+// cancelPartialMesh cancels the streams into the local cluster with the given
+// job IDs, and those created on each of the peers on behalf of the first of
+// them, so that a failure to create a mesh does not leave some of its streams
+// running. Cancelation is best effort: failures are logged, and the streams
+// that could not be canceled must be canceled manually.
+func cancelPartialMesh(
+	ctx context.Context,
+	p sql.PlanHookState,
+	jobIDs []jobspb.JobID,
+	peers []logicalReplicationSource,
+) {
+	for _, jobID := range jobIDs {
+		if err := func() error {
+			job, err := p.ExecCfg().JobRegistry.LoadJob(ctx, jobID)
+			if err != nil {
+				return err
+			}
+			return job.NoTxn().CancelRequested(ctx)
+		}(); err != nil {
+			log.Dev.Warningf(ctx, "failed to cancel stream %d of a partially created mesh: %v", jobID, err)
+		}
+	}
+	if len(jobIDs) == 0 {
+		return
+	}
+	for _, peer := range peers {
+		if err := peer.client.ExecStatement(
+			ctx, cancelMeshPeerJobs, "cancel-mesh-peer-streams", jobIDs[0].String(),
+		); err != nil {
+			log.Dev.Warningf(ctx, "failed to cancel the streams of a partially created mesh into %s: %v",
+				peer.cleanedURI, err)
+		}
+	}
+}
+
+// meshPeerStatement returns the statement which creates the streams into
+// another cluster of the mesh on behalf of the job with the given ID.
+func meshPeerStatement(
+	stmt *tree.CreateLogicalReplicationStream,
+	meshURIs []string,
+	options *resolvedLogicalReplicationOptions,
+	parentID jobspb.JobID,
+) string {
+	peerStmt := tree.CreateLogicalReplicationStream{
+		From: stmt.From,
+		Into: stmt.Into,
+		Options: tree.LogicalReplicationOptions{
+			ParentID: tree.NewStrVal(parentID.String()),
+		},
+	}
+	for _, uri := range meshURIs {
+		peerStmt.MeshURIs = append(peerStmt.MeshURIs, tree.NewStrVal(uri))
+	}
+	if m, ok := options.GetMode(); ok {
+		peerStmt.Options.Mode = tree.NewStrVal(m)
+	}
+	if d, ok := options.Discard(); ok {
+		peerStmt.Options.Discard = tree.NewStrVal(d)
+	}
+	if options.metricsLabel != "" {
+		peerStmt.Options.MetricsLabel = tree.NewStrVal(options.metricsLabel)
+	}
+	if options.SkipSchemaCheck() {
+		peerStmt.Options.SkipSchemaCheck = tree.DBoolTrue
+	}
+	return peerStmt.String()
+}
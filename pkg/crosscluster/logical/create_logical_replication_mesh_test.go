@@ -0,0 +1,205 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package logical
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/skip"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeshOriginIDs(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	a := uuid.FromStringOrNil("00000000-0000-0000-0000-000000000001")
+	b := uuid.FromStringOrNil("00000000-0000-0000-0000-000000000002")
+	c := uuid.FromStringOrNil("10000000-0000-0000-0000-000000000000")
+
+	// Every ordering of the same clusters assigns the same origin IDs.
+	for _, ids := range [][]uuid.UUID{{a, b, c}, {c, b, a}, {b, c, a}} {
+		originIDs, err := meshOriginIDs(ids)
+		require.NoError(t, err)
+		require.Equal(t, map[uuid.UUID]uint32{a: 2, b: 3, c: 4}, originIDs)
+	}
+
+	_, err := meshOriginIDs([]uuid.UUID{a, b, a})
+	require.ErrorContains(t, err, "listed more than once")
+}
+
+func TestMeshPeerStatement(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	stmt, err := parser.ParseOne(
+		"CREATE LOGICAL REPLICATION MESH FROM TABLE foo ON ($1, $2, $3) WITH MODE = 'validated', LABEL = 'mesh'",
+	)
+	require.NoError(t, err)
+	createStmt := stmt.AST.(*tree.CreateLogicalReplicationStream)
+	require.NoError(t, checkLogicalReplicationMeshOptions(createStmt))
+
+	options := &resolvedLogicalReplicationOptions{mode: "validated", metricsLabel: "mesh"}
+	peerStmt := meshPeerStatement(
+		createStmt, []string{"external://a", "external://b", "external://c"}, options, 123,
+	)
+
+	parsed, err := parser.ParseOne(peerStmt)
+	require.NoError(t, err)
+	peer := parsed.AST.(*tree.CreateLogicalReplicationStream)
+	require.Len(t, peer.MeshURIs, 3)
+	require.Equal(t, "'123'", tree.AsString(peer.Options.ParentID))
+	require.Equal(t, "'validated'", tree.AsString(peer.Options.Mode))
+	require.Equal(t, "'mesh'", tree.AsString(peer.Options.MetricsLabel))
+
+	withCursor, err := parser.ParseOne(
+		"CREATE LOGICAL REPLICATION MESH FROM TABLE foo ON ($1, $2) WITH CURSOR = '1.0'",
+	)
+	require.NoError(t, err)
+	require.ErrorContains(t,
+		checkLogicalReplicationMeshOptions(withCursor.AST.(*tree.CreateLogicalReplicationStream)),
+		"CURSOR cannot be used",
+	)
+}
+
+// TestLogicalReplicationMeshEqualTimestamps writes a different version of the
+// same row on each cluster of a mesh at the same timestamp, and checks that
+// every cluster keeps the version of the cluster with the greatest origin ID,
+// in both immediate and validated mode.
+func TestLogicalReplicationMeshEqualTimestamps(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	skip.UnderDeadlock(t)
+	skip.UnderRace(t, "starts three servers")
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	const numClusters = 3
+	servers := make([]serverutils.ApplicationLayerInterface, numClusters)
+	dbs := make([]*sqlutils.SQLRunner, numClusters)
+	clusterIDs := make([]uuid.UUID, numClusters)
+	for i := range servers {
+		srv := serverutils.StartServerOnly(t, testClusterBaseClusterArgs.ServerArgs)
+		defer srv.Stopper().Stop(ctx)
+		sysDB := sqlutils.MakeSQLRunner(srv.SystemLayer().SQLConn(t))
+		for _, stmt := range testClusterSystemSettings {
+			sysDB.Exec(t, stmt)
+		}
+		servers[i] = srv.ApplicationLayer()
+		sqlutils.MakeSQLRunner(servers[i].SQLConn(t)).Exec(t, "CREATE DATABASE a")
+		dbs[i] = sqlutils.MakeSQLRunner(servers[i].SQLConn(t, serverutils.DBName("a")))
+		for _, stmt := range testClusterSettings {
+			dbs[i].Exec(t, stmt)
+		}
+		clusterIDs[i] = servers[i].ExecutorConfig().(sql.ExecutorConfig).NodeInfo.LogicalClusterID()
+	}
+	// The external connection c<i> refers to cluster i on every cluster.
+	meshURIs := make([]string, numClusters)
+	for i := range servers {
+		pgURL, cleanup := servers[i].PGUrl(t, serverutils.DBName("a"))
+		defer cleanup()
+		for _, db := range dbs {
+			db.Exec(t, fmt.Sprintf("CREATE EXTERNAL CONNECTION c%d AS '%s'", i, pgURL.String()))
+		}
+		meshURIs[i] = fmt.Sprintf("'external://c%d'", i)
+	}
+	// The cluster with the greatest cluster ID has the greatest origin ID.
+	winner := 0
+	for i := range clusterIDs {
+		if bytes.Compare(clusterIDs[i].GetBytes(), clusterIDs[winner].GetBytes()) > 0 {
+			winner = i
+		}
+	}
+
+	for _, mode := range []string{"immediate", "validated"} {
+		t.Run(mode, func(t *testing.T) {
+			table := "tab_" + mode
+			for _, db := range dbs {
+				createBasicTable(t, db, table)
+			}
+			jobIDs := dbs[0].QueryStr(t, fmt.Sprintf(
+				"CREATE LOGICAL REPLICATION MESH FROM TABLE %s ON (%s, %s, %s) WITH MODE = '%s'",
+				table, meshURIs[0], meshURIs[1], meshURIs[2], mode,
+			))
+			require.Len(t, jobIDs, numClusters-1)
+			meshJobs := fmt.Sprintf(
+				"SELECT job_id FROM [SHOW JOBS] WHERE job_type = 'LOGICAL REPLICATION' AND description LIKE '%%%s%%'",
+				table,
+			)
+			// Each cluster replicates from every other cluster.
+			for _, db := range dbs {
+				testutils.SucceedsSoon(t, func() error {
+					var n int
+					db.QueryRow(t, fmt.Sprintf("SELECT count(*) FROM (%s) AS j", meshJobs)).Scan(&n)
+					if n != numClusters-1 {
+						return errors.Newf("%d streams of the mesh, expected %d", n, numClusters-1)
+					}
+					return nil
+				})
+			}
+
+			// Pause the streams, so that no replicated row is written before the
+			// local rows, which are written at a fixed timestamp.
+			for _, db := range dbs {
+				db.Exec(t, fmt.Sprintf("PAUSE JOBS (%s)", meshJobs))
+				db.CheckQueryResultsRetry(t, fmt.Sprintf(
+					"SELECT DISTINCT status FROM [SHOW JOBS] WHERE job_id IN (%s)", meshJobs,
+				), [][]string{{"paused"}})
+			}
+			ts := servers[0].Clock().Now().Add(time.Second.Nanoseconds(), 0)
+			for i, db := range dbs {
+				var tableID uint32
+				db.QueryRow(t, fmt.Sprintf("SELECT '%s'::REGCLASS::INT", table)).Scan(&tableID)
+				codec := servers[i].Codec()
+				key := keys.MakeFamilyKey(
+					encoding.EncodeVarintAscending(codec.IndexPrefix(tableID, 1), 1), 0,
+				)
+				tuple, err := valueside.Encode(
+					nil, valueside.MakeColumnIDDelta(0, 2), tree.NewDString(fmt.Sprintf("c%d", i)),
+				)
+				require.NoError(t, err)
+				var value roachpb.Value
+				value.SetTuple(tuple)
+				value.InitChecksum(key)
+				require.NoError(t, servers[i].DB().Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+					if err := txn.SetFixedTimestamp(ctx, ts); err != nil {
+						return err
+					}
+					return txn.Put(ctx, key, &value)
+				}))
+			}
+			for _, db := range dbs {
+				db.Exec(t, fmt.Sprintf("RESUME JOBS (%s)", meshJobs))
+			}
+
+			expected := [][]string{{"1", fmt.Sprintf("c%d", winner)}}
+			for _, db := range dbs {
+				db.CheckQueryResultsRetry(t, fmt.Sprintf("SELECT pk, payload FROM %s", table), expected)
+			}
+			for _, db := range dbs {
+				db.Exec(t, fmt.Sprintf("CANCEL JOBS (%s)", meshJobs))
+			}
+		})
+	}
+}
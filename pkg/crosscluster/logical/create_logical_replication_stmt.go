@@ -82,9 +82,20 @@ func createLogicalReplicationStreamPlanHook(
 
 	exprEval := p.ExprEvaluator("LOGICAL REPLICATION STREAM")
 
-	from, err := exprEval.String(ctx, stmt.PGURL)
-	if err != nil {
-		return nil, nil, false, err
+	var from string
+	var meshURIs []string
+	if len(stmt.MeshURIs) > 0 {
+		var err error
+		meshURIs, err = exprEval.StringArray(ctx, stmt.MeshURIs)
+		if err != nil {
+			return nil, nil, false, err
+		}
+	} else {
+		var err error
+		from, err = exprEval.String(ctx, stmt.PGURL)
+		if err != nil {
+			return nil, nil, false, err
+		}
 	}
 
 	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) (retErr error) {
@@ -200,148 +211,234 @@ func createLogicalReplicationStreamPlanHook(
 			}
 		}
 
-		configUri, err := streamclient.ParseConfigUri(from)
-		if err != nil {
-			return err
-		}
-		if !configUri.IsExternalOrTestScheme() {
-			return errors.New("uri must be an external connection")
-		}
-
-		clusterUri, err := configUri.AsClusterUri(ctx, p.ExecCfg().InternalDB)
-		if err != nil {
-			return err
-		}
-
-		cleanedURI, err := cloud.SanitizeExternalStorageURI(from, nil)
-		if err != nil {
-			return err
+		if len(meshURIs) > 0 {
+			jobIDs, err := createLogicalReplicationMesh(
+				ctx, p, stmt, meshURIs, options, mode, discard, resolvedDestObjects,
+			)
+			if err != nil {
+				return err
+			}
+			for _, jobID := range jobIDs {
+				resultsCh <- tree.Datums{tree.NewDInt(tree.DInt(jobID))}
+			}
+			return nil
 		}
 
-		client, err := streamclient.NewStreamClient(ctx, clusterUri, p.ExecCfg().InternalDB, streamclient.WithLogical())
+		src, err := openLogicalReplicationSource(ctx, p, from, stmt, options)
 		if err != nil {
 			return err
 		}
+		defer src.close(ctx)
 		defer func() {
-			_ = client.Close(ctx)
+			if retErr != nil {
+				retErr = errors.CombineErrors(retErr, src.client.Complete(ctx, src.spec.StreamID, false))
+			}
 		}()
 
-		srcTableNames := make([]string, len(stmt.From.Tables))
-		for i, tb := range stmt.From.Tables {
-			srcTableNames[i] = tb.String()
-		}
-		spec, err := client.CreateForTables(ctx, &streampb.ReplicationProducerRequest{
-			TableNames:                  srcTableNames,
-			AllowOffline:                options.ParentID != 0,
-			UnvalidatedReverseStreamURI: options.BidirectionalURI(),
-		})
+		jobID, err := planLogicalReplicationJob(
+			ctx, p, stmt, src, options, mode, discard, resolvedDestObjects,
+			0 /* originID */, 0, /* localOriginID */
+		)
 		if err != nil {
 			return err
 		}
-		defer func() {
-			if retErr != nil {
-				retErr = errors.CombineErrors(retErr, client.Complete(ctx, spec.StreamID, false))
-			}
-		}()
+		resultsCh <- tree.Datums{tree.NewDInt(tree.DInt(jobID))}
+		return nil
+	}
 
-		sourceTypes := make([]*descpb.TypeDescriptor, len(spec.ExternalCatalog.Types))
-		for i, desc := range spec.ExternalCatalog.Types {
-			sourceTypes[i] = &desc
-		}
-		crossClusterResolver := crosscluster.MakeCrossClusterTypeResolver(sourceTypes)
+	return fn, streamCreationHeader, false, nil
+}
 
-		replicationStartTime := spec.ReplicationStartTime
-		progress := jobspb.LogicalReplicationProgress{}
-		if cursor, ok := options.GetCursor(); ok {
-			replicationStartTime = cursor
-			progress.ReplicatedTime = cursor
-		}
+// logicalReplicationSource is a source cluster on which a replication producer
+// was created for the source tables of a statement.
+type logicalReplicationSource struct {
+	configUri  streamclient.ConfigUri
+	cleanedURI string
+	client     streamclient.Client
+	spec       *streampb.ReplicationProducerSpec
+}
 
-		// If the user asked to ignore "ttl-deletes", make sure that at least one of
-		// the source tables actually has a TTL job which sets the omit bit that
-		// is used for filtering; if not, they probably forgot that step.
-		throwNoTTLWithCDCIgnoreError := discard == jobspb.LogicalReplicationDetails_DiscardCDCIgnoredTTLDeletes
+func (src *logicalReplicationSource) close(ctx context.Context) {
+	_ = src.client.Close(ctx)
+}
 
-		// TODO: consider moving repPair construction into doLDRPlan after dest tables are created.
-		repPairs := make([]jobspb.LogicalReplicationDetails_ReplicationPair, len(spec.ExternalCatalog.Tables))
-		for i, td := range spec.ExternalCatalog.Tables {
-			cpy := tabledesc.NewBuilder(&td).BuildCreatedMutableTable()
-			if err := typedesc.HydrateTypesInDescriptor(ctx, cpy, crossClusterResolver); err != nil {
-				return err
-			}
-			// TODO: i don't like this at all. this could be fixed if repPairs were
-			// populated in doLDRPlan.
-			spec.ExternalCatalog.Tables[i] = *cpy.TableDesc()
+// sourceTableNames returns the names of the source tables of the statement.
+func sourceTableNames(stmt *tree.CreateLogicalReplicationStream) []string {
+	srcTableNames := make([]string, len(stmt.From.Tables))
+	for i, tb := range stmt.From.Tables {
+		srcTableNames[i] = tb.String()
+	}
+	return srcTableNames
+}
 
-			if !stmt.CreateTable {
-				repPairs[i].DstDescriptorID = int32(resolvedDestObjects.TableIDs[i])
-			}
-			repPairs[i].SrcDescriptorID = int32(td.ID)
-			if td.RowLevelTTL != nil && td.RowLevelTTL.DisableChangefeedReplication {
-				throwNoTTLWithCDCIgnoreError = false
-			}
-		}
-		if uf, ok := options.GetUserFunctions(); ok {
-			for i, name := range srcTableNames {
-				repPairs[i].DstFunctionID = uf[name]
-			}
-		}
-		if throwNoTTLWithCDCIgnoreError {
-			return pgerror.Newf(pgcode.InvalidParameterValue, "DISCARD = 'ttl-deletes' specified but no tables have changefeed-excluded TTLs")
-		}
-
-		// Default conflict resolution if not set will be LWW
-		defaultConflictResolution := jobspb.LogicalReplicationDetails_DefaultConflictResolution{
-			ConflictResolutionType: jobspb.LogicalReplicationDetails_DefaultConflictResolution_LWW,
-		}
-		if cr, ok := options.GetDefaultFunction(); ok {
-			defaultConflictResolution = *cr
-		}
-
-		jobID := p.ExecCfg().JobRegistry.MakeJobID()
-		var reverseStreamCmd string
-		if stmt.CreateTable && options.BidirectionalURI() != "" {
-			reverseStmt := *stmt
-			reverseStmt.From, reverseStmt.Into = reverseStmt.Into, reverseStmt.From
-			reverseStmt.CreateTable = false
-			reverseStmt.Options.BidirectionalURI = nil
-			reverseStmt.Options.ParentID = tree.NewStrVal(jobID.String())
-			reverseStmt.PGURL = tree.NewStrVal(options.BidirectionalURI())
-			reverseStmt.Options.Cursor = &tree.Placeholder{Idx: 0}
-			reverseStreamCmd = reverseStmt.String()
-		}
-
-		jr := jobs.Record{
-			JobID:       jobID,
-			Description: fmt.Sprintf("LOGICAL REPLICATION STREAM into %s from %s", resolvedDestObjects.TargetDescription(), cleanedURI),
-			Username:    p.User(),
-			Details: jobspb.LogicalReplicationDetails{
-				StreamID:                  uint64(spec.StreamID),
-				SourceClusterID:           spec.SourceClusterID,
-				ReplicationStartTime:      replicationStartTime,
-				ReplicationPairs:          repPairs,
-				SourceClusterConnUri:      configUri.Serialize(),
-				TableNames:                srcTableNames,
-				DefaultConflictResolution: defaultConflictResolution,
-				Discard:                   discard,
-				Mode:                      mode,
-				MetricsLabel:              options.metricsLabel,
-				CreateTable:               stmt.CreateTable,
-				ReverseStreamCommand:      reverseStreamCmd,
-				ParentID:                  int64(options.ParentID),
-				Command:                   stmt.String(),
-				SkipSchemaCheck:           options.SkipSchemaCheck(),
-			},
-			Progress: progress,
-		}
-		if err := doLDRPlan(ctx, p.User(), p.ExecCfg(), jr, spec.ExternalCatalog, resolvedDestObjects); err != nil {
-			return err
+// openLogicalReplicationSource connects to the source cluster at the given
+// external connection URI and creates a replication producer for the source
+// tables of the statement. The caller is responsible for closing the source,
+// and for completing its stream if the replication job is not created.
+func openLogicalReplicationSource(
+	ctx context.Context,
+	p sql.PlanHookState,
+	from string,
+	stmt *tree.CreateLogicalReplicationStream,
+	options *resolvedLogicalReplicationOptions,
+) (_ logicalReplicationSource, retErr error) {
+	configUri, err := streamclient.ParseConfigUri(from)
+	if err != nil {
+		return logicalReplicationSource{}, err
+	}
+	if !configUri.IsExternalOrTestScheme() {
+		return logicalReplicationSource{}, errors.New("uri must be an external connection")
+	}
+
+	clusterUri, err := configUri.AsClusterUri(ctx, p.ExecCfg().InternalDB)
+	if err != nil {
+		return logicalReplicationSource{}, err
+	}
+
+	cleanedURI, err := cloud.SanitizeExternalStorageURI(from, nil)
+	if err != nil {
+		return logicalReplicationSource{}, err
+	}
+
+	client, err := streamclient.NewStreamClient(ctx, clusterUri, p.ExecCfg().InternalDB, streamclient.WithLogical())
+	if err != nil {
+		return logicalReplicationSource{}, err
+	}
+	defer func() {
+		if retErr != nil {
+			_ = client.Close(ctx)
 		}
-		resultsCh <- tree.Datums{tree.NewDInt(tree.DInt(jr.JobID))}
-		return nil
+	}()
+
+	spec, err := client.CreateForTables(ctx, &streampb.ReplicationProducerRequest{
+		TableNames:                  sourceTableNames(stmt),
+		AllowOffline:                options.ParentID != 0,
+		UnvalidatedReverseStreamURI: options.BidirectionalURI(),
+	})
+	if err != nil {
+		return logicalReplicationSource{}, err
+	}
+	return logicalReplicationSource{
+		configUri:  configUri,
+		cleanedURI: cleanedURI,
+		client:     client,
+		spec:       spec,
+	}, nil
+}
+
+// planLogicalReplicationJob creates the job replicating the source tables of
+// the statement from the given source into the destination tables, tagging
+// the replicated rows with the given origin IDs, and returns its ID.
+func planLogicalReplicationJob(
+	ctx context.Context,
+	p sql.PlanHookState,
+	stmt *tree.CreateLogicalReplicationStream,
+	src logicalReplicationSource,
+	options *resolvedLogicalReplicationOptions,
+	mode jobspb.LogicalReplicationDetails_ApplyMode,
+	discard jobspb.LogicalReplicationDetails_Discard,
+	resolvedDestObjects ResolvedDestObjects,
+	originID, localOriginID uint32,
+) (jobspb.JobID, error) {
+	spec := src.spec
+	srcTableNames := sourceTableNames(stmt)
+
+	sourceTypes := make([]*descpb.TypeDescriptor, len(spec.ExternalCatalog.Types))
+	for i, desc := range spec.ExternalCatalog.Types {
+		sourceTypes[i] = &desc
 	}
+	crossClusterResolver := crosscluster.MakeCrossClusterTypeResolver(sourceTypes)
 
-	return fn, streamCreationHeader, false, nil
+	replicationStartTime := spec.ReplicationStartTime
+	progress := jobspb.LogicalReplicationProgress{}
+	if cursor, ok := options.GetCursor(); ok {
+		replicationStartTime = cursor
+		progress.ReplicatedTime = cursor
+	}
+
+	// If the user asked to ignore "ttl-deletes", make sure that at least one of
+	// the source tables actually has a TTL job which sets the omit bit that
+	// is used for filtering; if not, they probably forgot that step.
+	throwNoTTLWithCDCIgnoreError := discard == jobspb.LogicalReplicationDetails_DiscardCDCIgnoredTTLDeletes
+
+	// TODO: consider moving repPair construction into doLDRPlan after dest tables are created.
+	repPairs := make([]jobspb.LogicalReplicationDetails_ReplicationPair, len(spec.ExternalCatalog.Tables))
+	for i, td := range spec.ExternalCatalog.Tables {
+		cpy := tabledesc.NewBuilder(&td).BuildCreatedMutableTable()
+		if err := typedesc.HydrateTypesInDescriptor(ctx, cpy, crossClusterResolver); err != nil {
+			return 0, err
+		}
+		// TODO: i don't like this at all. this could be fixed if repPairs were
+		// populated in doLDRPlan.
+		spec.ExternalCatalog.Tables[i] = *cpy.TableDesc()
+
+		if !stmt.CreateTable {
+			repPairs[i].DstDescriptorID = int32(resolvedDestObjects.TableIDs[i])
+		}
+		repPairs[i].SrcDescriptorID = int32(td.ID)
+		if td.RowLevelTTL != nil && td.RowLevelTTL.DisableChangefeedReplication {
+			throwNoTTLWithCDCIgnoreError = false
+		}
+	}
+	if uf, ok := options.GetUserFunctions(); ok {
+		for i, name := range srcTableNames {
+			repPairs[i].DstFunctionID = uf[name]
+		}
+	}
+	if throwNoTTLWithCDCIgnoreError {
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue, "DISCARD = 'ttl-deletes' specified but no tables have changefeed-excluded TTLs")
+	}
+
+	// Default conflict resolution if not set will be LWW
+	defaultConflictResolution := jobspb.LogicalReplicationDetails_DefaultConflictResolution{
+		ConflictResolutionType: jobspb.LogicalReplicationDetails_DefaultConflictResolution_LWW,
+	}
+	if cr, ok := options.GetDefaultFunction(); ok {
+		defaultConflictResolution = *cr
+	}
+
+	jobID := p.ExecCfg().JobRegistry.MakeJobID()
+	var reverseStreamCmd string
+	if stmt.CreateTable && options.BidirectionalURI() != "" {
+		reverseStmt := *stmt
+		reverseStmt.From, reverseStmt.Into = reverseStmt.Into, reverseStmt.From
+		reverseStmt.CreateTable = false
+		reverseStmt.Options.BidirectionalURI = nil
+		reverseStmt.Options.ParentID = tree.NewStrVal(jobID.String())
+		reverseStmt.PGURL = tree.NewStrVal(options.BidirectionalURI())
+		reverseStmt.Options.Cursor = &tree.Placeholder{Idx: 0}
+		reverseStreamCmd = reverseStmt.String()
+	}
+
+	jr := jobs.Record{
+		JobID:       jobID,
+		Description: fmt.Sprintf("LOGICAL REPLICATION STREAM into %s from %s", resolvedDestObjects.TargetDescription(), src.cleanedURI),
+		Username:    p.User(),
+		Details: jobspb.LogicalReplicationDetails{
+			StreamID:                  uint64(spec.StreamID),
+			SourceClusterID:           spec.SourceClusterID,
+			ReplicationStartTime:      replicationStartTime,
+			ReplicationPairs:          repPairs,
+			SourceClusterConnUri:      src.configUri.Serialize(),
+			TableNames:                srcTableNames,
+			DefaultConflictResolution: defaultConflictResolution,
+			Discard:                   discard,
+			Mode:                      mode,
+			MetricsLabel:              options.metricsLabel,
+			CreateTable:               stmt.CreateTable,
+			ReverseStreamCommand:      reverseStreamCmd,
+			ParentID:                  int64(options.ParentID),
+			Command:                   stmt.String(),
+			SkipSchemaCheck:           options.SkipSchemaCheck(),
+			OriginID:                  originID,
+			LocalOriginID:             localOriginID,
+		},
+		Progress: progress,
+	}
+	if err := doLDRPlan(ctx, p.User(), p.ExecCfg(), jr, spec.ExternalCatalog, resolvedDestObjects); err != nil {
+		return 0, err
+	}
+	return jobID, nil
 }
 
 type ResolvedDestObjects struct {
@@ -523,6 +620,7 @@ func createLogicalReplicationStreamTypeCheck(
 	}
 	toTypeCheck := []exprutil.ToTypeCheck{
 		exprutil.Strings{stmt.PGURL},
+		exprutil.Strings(stmt.MeshURIs),
 		exprutil.Strings{
 			stmt.Options.Cursor,
 			stmt.Options.DefaultFunction,
@@ -0,0 +1,511 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package logical
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/crosscluster"
+	"github.com/cockroachdb/cockroach/pkg/crosscluster/streamclient"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/repstream/streampb"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/asof"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	sql.AddPlanHook("replay dead letter queue", replayDeadLetterQueuePlanHook, replayDeadLetterQueueTypeCheck)
+}
+
+const replayDeadLetterQueueOp = "REPLAY DEAD LETTER QUEUE"
+
+const (
+	dlqReplayOptTableName  = "table_name"
+	dlqReplayOptErrorClass = "error_class"
+	dlqReplayOptStartTime  = "start_time"
+	dlqReplayOptEndTime    = "end_time"
+)
+
+var dlqReplayOptionExpectValues = exprutil.KVOptionValidationMap{
+	dlqReplayOptTableName:  exprutil.KVStringOptRequireValue,
+	dlqReplayOptErrorClass: exprutil.KVStringOptRequireValue,
+	dlqReplayOptStartTime:  exprutil.KVStringOptRequireValue,
+	dlqReplayOptEndTime:    exprutil.KVStringOptRequireValue,
+}
+
+// The outcomes of replaying a row of a dead letter queue. Rows with any outcome
+// other than dlqReplayFailed are removed from the dead letter queue.
+const (
+	// dlqReplayApplied means the row was written to the destination table.
+	dlqReplayApplied = "applied"
+	// dlqReplaySuperseded means the row lost last-writer-wins conflict
+	// resolution to a newer version of the row in the destination table.
+	dlqReplaySuperseded = "superseded"
+	// dlqReplayDiscarded means the row is a delete and the job discards deletes.
+	dlqReplayDiscarded = "discarded"
+	// dlqReplayFailed means the row could not be decoded or applied.
+	dlqReplayFailed = "failed"
+)
+
+var dlqReplayHeader = colinfo.ResultColumns{
+	{Name: "table_name", Typ: types.String},
+	{Name: "dlq_id", Typ: types.Int},
+	{Name: "dlq_timestamp", Typ: types.TimestampTZ},
+	{Name: "outcome", Typ: types.String},
+	{Name: "error", Typ: types.String},
+}
+
+// dlqReplayFilter restricts which rows of the dead letter queues of a job are
+// replayed.
+type dlqReplayFilter struct {
+	// tableID, if set, is the destination table whose rows are replayed, and
+	// tableName is its name as specified by the user.
+	tableID   descpb.ID
+	tableName string
+	// errorClass, if set, is the retryEligibility which caused the rows to be
+	// added to the dead letter queue.
+	errorClass string
+	// startTime and endTime, if set, bound the time at which the rows were
+	// added to the dead letter queue.
+	startTime, endTime hlc.Timestamp
+}
+
+// dlqErrorClasses are the reasons for which rows are added to the dead letter
+// queue, which may be used to filter the rows which are replayed.
+var dlqErrorClasses = []retryEligibility{noSpace, tooOld, errType}
+
+func parseDLQErrorClass(class string) (string, error) {
+	names := make([]string, 0, len(dlqErrorClasses))
+	for _, c := range dlqErrorClasses {
+		if strings.EqualFold(class, c.String()) {
+			return c.String(), nil
+		}
+		names = append(names, fmt.Sprintf("%q", c.String()))
+	}
+	return "", pgerror.Newf(pgcode.InvalidParameterValue,
+		"unknown %s %q: must be one of %s", dlqReplayOptErrorClass, class, strings.Join(names, ", "))
+}
+
+// dlqReplayBatchSize is the number of rows of a dead letter queue which are
+// read and replayed at a time.
+var dlqReplayBatchSize = 1000
+
+// dlqReplayQuery returns the query which reads the next batch of rows of the
+// given dead letter queue table which are replayed, along with its arguments.
+// Rows are read in primary key order, and after, if set, is the dlq_timestamp
+// and id of the last row of the previous batch.
+func dlqReplayQuery(
+	dlqTableName string, jobID jobspb.JobID, filter dlqReplayFilter, after tree.Datums,
+) (string, []interface{}) {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "SELECT id, dlq_timestamp, key_value_bytes FROM %s WHERE ingestion_job_id = $1", dlqTableName)
+	args := []interface{}{int64(jobID)}
+	if !filter.startTime.IsEmpty() {
+		args = append(args, tree.MustMakeDTimestampTZ(filter.startTime.GoTime(), time.Microsecond))
+		fmt.Fprintf(&buf, " AND dlq_timestamp >= $%d", len(args))
+	}
+	if !filter.endTime.IsEmpty() {
+		args = append(args, tree.MustMakeDTimestampTZ(filter.endTime.GoTime(), time.Microsecond))
+		fmt.Fprintf(&buf, " AND dlq_timestamp < $%d", len(args))
+	}
+	if filter.errorClass != "" {
+		// The reason is recorded as "<error> (<retry eligibility>)".
+		args = append(args, fmt.Sprintf("%%(%s)", filter.errorClass))
+		fmt.Fprintf(&buf, " AND dlq_reason LIKE $%d", len(args))
+	}
+	if after != nil {
+		// Rows which fail to replay are left in the dead letter queue, so batches
+		// are paged through by key rather than by offset.
+		args = append(args, after[0], after[1])
+		fmt.Fprintf(&buf, " AND (dlq_timestamp, id) > ($%d, $%d)", len(args)-1, len(args))
+	}
+	args = append(args, dlqReplayBatchSize)
+	fmt.Fprintf(&buf, " ORDER BY dlq_timestamp, id LIMIT $%d", len(args))
+	return buf.String(), args
+}
+
+const deleteReplayedDLQRowStmt = `DELETE FROM %s WHERE ingestion_job_id = $1 AND dlq_timestamp = $2 AND id = $3`
+
+func replayDeadLetterQueueTypeCheck(
+	ctx context.Context, untypedStmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	stmt, ok := untypedStmt.(*tree.ReplayDeadLetterQueue)
+	if !ok {
+		return false, nil, nil
+	}
+	if err := exprutil.TypeCheck(ctx, replayDeadLetterQueueOp, p.SemaCtx(),
+		exprutil.Ints{stmt.JobID},
+		&exprutil.KVOptions{
+			KVOptions:  stmt.Options,
+			Validation: dlqReplayOptionExpectValues,
+		},
+	); err != nil {
+		return false, nil, err
+	}
+	return true, dlqReplayHeader, nil
+}
+
+// replayDeadLetterQueuePlanHook implements sql.PlanHookFn.
+func replayDeadLetterQueuePlanHook(
+	ctx context.Context, untypedStmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	stmt, ok := untypedStmt.(*tree.ReplayDeadLetterQueue)
+	if !ok {
+		return nil, nil, false, nil
+	}
+
+	exprEval := p.ExprEvaluator(replayDeadLetterQueueOp)
+	jobID, err := exprEval.Int(ctx, stmt.JobID)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	opts, err := exprEval.KVOptions(ctx, stmt.Options, dlqReplayOptionExpectValues)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
+		defer span.Finish()
+
+		if err := utilccl.CheckEnterpriseEnabled(p.ExecCfg().Settings, replayDeadLetterQueueOp); err != nil {
+			return err
+		}
+		// Rows are replayed outside of the statement's transaction, so they could
+		// not be rolled back along with it.
+		if !p.ExtendedEvalContext().TxnIsSingleStmt {
+			return pgerror.Newf(pgcode.InvalidTransactionState,
+				"%s cannot be used inside a multi-statement transaction", replayDeadLetterQueueOp)
+		}
+
+		filter, err := evalDLQReplayFilter(ctx, p, opts)
+		if err != nil {
+			return err
+		}
+		telemetry.Count("logical_replication_stream.dlq_replayed")
+		return replayDeadLetterQueue(ctx, p, jobspb.JobID(jobID), filter, resultsCh)
+	}
+	return fn, dlqReplayHeader, false, nil
+}
+
+func evalDLQReplayFilter(
+	ctx context.Context, p sql.PlanHookState, opts map[string]string,
+) (dlqReplayFilter, error) {
+	var filter dlqReplayFilter
+	if name, ok := opts[dlqReplayOptTableName]; ok {
+		un, err := parser.ParseTableName(name)
+		if err != nil {
+			return filter, err
+		}
+		table, err := p.ResolveExistingObjectEx(ctx, un, true /* required */, tree.ResolveRequireTableDesc)
+		if err != nil {
+			return filter, err
+		}
+		filter.tableID, filter.tableName = table.GetID(), name
+	}
+	if class, ok := opts[dlqReplayOptErrorClass]; ok {
+		var err error
+		if filter.errorClass, err = parseDLQErrorClass(class); err != nil {
+			return filter, err
+		}
+	}
+	evalTime := func(opt string) (hlc.Timestamp, error) {
+		s, ok := opts[opt]
+		if !ok {
+			return hlc.Timestamp{}, nil
+		}
+		ts, err := asof.Eval(ctx, tree.AsOfClause{Expr: tree.NewStrVal(s)}, p.SemaCtx(), &p.ExtendedEvalContext().Context)
+		if err != nil {
+			return hlc.Timestamp{}, errors.Wrapf(err, "invalid %s", opt)
+		}
+		return ts.Timestamp, nil
+	}
+	var err error
+	if filter.startTime, err = evalTime(dlqReplayOptStartTime); err != nil {
+		return filter, err
+	}
+	if filter.endTime, err = evalTime(dlqReplayOptEndTime); err != nil {
+		return filter, err
+	}
+	if !filter.startTime.IsEmpty() && !filter.endTime.IsEmpty() && !filter.startTime.Less(filter.endTime) {
+		return filter, pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s must be before %s", dlqReplayOptStartTime, dlqReplayOptEndTime)
+	}
+	return filter, nil
+}
+
+// replayDeadLetterQueue re-applies the rows of the dead letter queues of the
+// given logical replication job which match the filter. The rows are decoded
+// using the current descriptors of the source and destination tables and
+// applied with the same last-writer-wins conflict resolution as the SQL
+// writer. The outcome of each row is returned as a result row, and rows which
+// no longer need to be applied are removed from the dead letter queues.
+func replayDeadLetterQueue(
+	ctx context.Context,
+	p sql.PlanHookState,
+	jobID jobspb.JobID,
+	filter dlqReplayFilter,
+	resultsCh chan<- tree.Datums,
+) error {
+	execCfg := p.ExecCfg()
+	job, err := execCfg.JobRegistry.LoadJobWithTxn(ctx, jobID, p.InternalSQLTxn())
+	if err != nil {
+		return err
+	}
+	payload, ok := job.Details().(jobspb.LogicalReplicationDetails)
+	if !ok {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"job %d is not a logical replication job", jobID)
+	}
+	progress := job.Progress().Details.(*jobspb.Progress_LogicalReplication).LogicalReplication
+
+	if payload.DefaultConflictResolution.FunctionId != 0 {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported for jobs with user-defined conflict resolution", replayDeadLetterQueueOp)
+	}
+
+	var pairs []jobspb.LogicalReplicationDetails_ReplicationPair
+	for _, pair := range payload.ReplicationPairs {
+		if pair.DstFunctionID != 0 {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"%s is not supported for jobs with user-defined conflict resolution", replayDeadLetterQueueOp)
+		}
+		if filter.tableID == 0 || filter.tableID == descpb.ID(pair.DstDescriptorID) {
+			pairs = append(pairs, pair)
+		}
+	}
+	if len(pairs) == 0 {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"table %s is not replicated by job %d", filter.tableName, jobID)
+	}
+
+	dstTables := make([]dstTableMetadata, 0, len(pairs))
+	if err := checkDLQReplayPrivileges(ctx, p, pairs, func(table catalog.TableDescriptor) error {
+		descriptors := p.InternalSQLTxn().Descriptors()
+		dbDesc, err := descriptors.ByIDWithoutLeased(p.InternalSQLTxn().KV()).WithoutNonPublic().Get().Database(ctx, table.GetParentID())
+		if err != nil {
+			return err
+		}
+		scDesc, err := descriptors.ByIDWithoutLeased(p.InternalSQLTxn().KV()).WithoutNonPublic().Get().Schema(ctx, table.GetParentSchemaID())
+		if err != nil {
+			return err
+		}
+		dstTables = append(dstTables, dstTableMetadata{
+			database: dbDesc.GetName(),
+			schema:   scDesc.GetName(),
+			table:    table.GetName(),
+			tableID:  table.GetID(),
+		})
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	configByDestID, err := loadDLQReplaySourceDescriptors(ctx, execCfg, payload, progress, pairs)
+	if err != nil {
+		return err
+	}
+
+	sd := sql.NewInternalSessionData(ctx, execCfg.Settings, "replay-dlq")
+	sd.OriginIDForLogicalDataReplication = payload.OriginID
+	sd.LocalOriginIDForLogicalDataReplication = payload.LocalOriginID
+	writer, err := newCrudSqlWriter(
+		ctx, &execCfg.DistSQLSrv.ServerConfig, &p.ExtendedEvalContext().Context,
+		sd, payload.Discard, configByDestID, jobID,
+	)
+	if err != nil {
+		return err
+	}
+	defer writer.Close(ctx)
+
+	ie := execCfg.InternalDB.Executor()
+	for _, dst := range dstTables {
+		dlqTableName := dst.toDLQTableName()
+		tableName := tree.MakeTableNameWithSchema(
+			tree.Name(dst.database), tree.Name(dst.schema), tree.Name(dst.table),
+		)
+		var after tree.Datums
+		for {
+			query, args := dlqReplayQuery(dlqTableName, jobID, filter, after)
+			rows, err := ie.QueryBufferedEx(ctx, "read-dlq", nil, /* txn */
+				sessiondata.NodeUserSessionDataOverride, query, args...)
+			if err != nil {
+				if pgerror.GetPGCode(err) == pgcode.UndefinedTable {
+					// The job has not created the dead letter queue of this table.
+					break
+				}
+				return errors.Wrapf(err, "failed to read dead letter queue %s", dlqTableName)
+			}
+
+			for _, row := range rows {
+				id, dlqTimestamp := row[0], row[1]
+				outcome, applyErr := replayDLQRow(ctx, writer, payload.Discard, []byte(tree.MustBeDBytes(row[2])))
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				errDatum := tree.DNull
+				if applyErr != nil {
+					errDatum = tree.NewDString(applyErr.Error())
+				} else if _, err := ie.ExecEx(ctx, "delete-replayed-dlq-row", nil, /* txn */
+					sessiondata.NodeUserSessionDataOverride,
+					fmt.Sprintf(deleteReplayedDLQRowStmt, dlqTableName),
+					int64(jobID), dlqTimestamp, id,
+				); err != nil {
+					return errors.Wrapf(err, "failed to remove replayed row from dead letter queue %s", dlqTableName)
+				}
+				select {
+				case resultsCh <- tree.Datums{
+					tree.NewDString(tableName.String()),
+					id,
+					dlqTimestamp,
+					tree.NewDString(outcome),
+					errDatum,
+				}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			if len(rows) < dlqReplayBatchSize {
+				break
+			}
+			last := rows[len(rows)-1]
+			after = tree.Datums{last[1], last[0]}
+		}
+	}
+	return nil
+}
+
+// replayDLQRow applies a marshalled replication event from a dead letter queue
+// and returns its outcome.
+func replayDLQRow(
+	ctx context.Context,
+	writer BatchHandler,
+	discard jobspb.LogicalReplicationDetails_Discard,
+	kvBytes []byte,
+) (string, error) {
+	var kv streampb.StreamEvent_KV
+	if err := protoutil.Unmarshal(kvBytes, &kv); err != nil {
+		return dlqReplayFailed, errors.Wrap(err, "failed to unmarshal kv event")
+	}
+	if discard == jobspb.LogicalReplicationDetails_DiscardAllDeletes && kv.KeyValue.Value.RawBytes == nil {
+		return dlqReplayDiscarded, nil
+	}
+	stats, err := writer.HandleBatch(ctx, []streampb.StreamEvent_KV{kv})
+	if err != nil {
+		return dlqReplayFailed, err
+	}
+	if stats.kvWriteTooOld > 0 {
+		return dlqReplaySuperseded, nil
+	}
+	return dlqReplayApplied, nil
+}
+
+// checkDLQReplayPrivileges checks that the user may write replicated rows to
+// each destination table of the given replication pairs, and calls fn with the
+// descriptor of each of them.
+func checkDLQReplayPrivileges(
+	ctx context.Context,
+	p sql.PlanHookState,
+	pairs []jobspb.LogicalReplicationDetails_ReplicationPair,
+	fn func(catalog.TableDescriptor) error,
+) error {
+	globalErr := p.CheckPrivilege(ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.REPLICATIONDEST)
+	txn := p.InternalSQLTxn()
+	for _, pair := range pairs {
+		table, err := txn.Descriptors().ByIDWithoutLeased(txn.KV()).WithoutNonPublic().Get().Table(ctx, descpb.ID(pair.DstDescriptorID))
+		if err != nil {
+			return errors.Wrapf(err, "failed to look up table descriptor %d", pair.DstDescriptorID)
+		}
+		if globalErr != nil {
+			if err := p.CheckPrivilege(ctx, table, privilege.REPLICATIONDEST); err != nil {
+				return err
+			}
+		}
+		if err := fn(table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadDLQReplaySourceDescriptors reads the descriptors of the source tables of
+// the given replication pairs as of the replicated time of the job.
+func loadDLQReplaySourceDescriptors(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	payload jobspb.LogicalReplicationDetails,
+	progress *jobspb.LogicalReplicationProgress,
+	pairs []jobspb.LogicalReplicationDetails_ReplicationPair,
+) (map[descpb.ID]sqlProcessorTableConfig, error) {
+	clusterUri, err := streamclient.LookupClusterUri(ctx, payload.SourceClusterConnUri, execCfg.InternalDB)
+	if err != nil {
+		return nil, err
+	}
+	client, err := streamclient.GetFirstActiveClient(ctx,
+		[]streamclient.ClusterUri{clusterUri},
+		execCfg.InternalDB,
+		streamclient.WithStreamID(streampb.StreamID(payload.StreamID)),
+		streamclient.WithLogical(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer closeAndLog(ctx, client)
+
+	asOf := progress.ReplicatedTime
+	if asOf.IsEmpty() {
+		asOf = payload.ReplicationStartTime
+	}
+	req := streampb.LogicalReplicationPlanRequest{
+		PlanAsOf: asOf,
+		StreamID: streampb.StreamID(payload.StreamID),
+	}
+	for _, pair := range pairs {
+		req.TableIDs = append(req.TableIDs, pair.SrcDescriptorID)
+	}
+	plan, err := client.PlanLogicalReplication(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	crossClusterResolver := crosscluster.MakeCrossClusterTypeResolver(plan.SourceTypes)
+	configByDestID := make(map[descpb.ID]sqlProcessorTableConfig, len(pairs))
+	for _, pair := range pairs {
+		desc, ok := plan.DescriptorMap[pair.SrcDescriptorID]
+		if !ok {
+			return nil, errors.Newf("source table %d not found", pair.SrcDescriptorID)
+		}
+		srcDesc := tabledesc.NewBuilder(&desc).BuildImmutableTable()
+		if err := typedesc.HydrateTypesInDescriptor(ctx, srcDesc, crossClusterResolver); err != nil {
+			return nil, err
+		}
+		configByDestID[descpb.ID(pair.DstDescriptorID)] = sqlProcessorTableConfig{srcDesc: srcDesc}
+	}
+	return configByDestID, nil
+}
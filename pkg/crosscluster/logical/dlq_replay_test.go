@@ -0,0 +1,177 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package logical
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/crosscluster/replicationtestutils"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/skip"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDLQReplayQuery(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const dlqTable = `db.crdb_replication."dlq_104_public_t"`
+	jobID := jobspb.JobID(123)
+
+	query, args := dlqReplayQuery(dlqTable, jobID, dlqReplayFilter{}, nil /* after */)
+	require.Equal(t,
+		`SELECT id, dlq_timestamp, key_value_bytes FROM db.crdb_replication."dlq_104_public_t" `+
+			`WHERE ingestion_job_id = $1 ORDER BY dlq_timestamp, id LIMIT $2`,
+		query)
+	require.Equal(t, []interface{}{int64(123), dlqReplayBatchSize}, args)
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	end := start.Add(time.Hour)
+	query, args = dlqReplayQuery(dlqTable, jobID, dlqReplayFilter{
+		errorClass: tooOld.String(),
+		startTime:  hlc.Timestamp{WallTime: start.UnixNano()},
+		endTime:    hlc.Timestamp{WallTime: end.UnixNano()},
+	}, nil /* after */)
+	require.Equal(t,
+		`SELECT id, dlq_timestamp, key_value_bytes FROM db.crdb_replication."dlq_104_public_t" `+
+			`WHERE ingestion_job_id = $1 AND dlq_timestamp >= $2 AND dlq_timestamp < $3 `+
+			`AND dlq_reason LIKE $4 ORDER BY dlq_timestamp, id LIMIT $5`,
+		query)
+	require.Equal(t, []interface{}{
+		int64(123),
+		tree.MustMakeDTimestampTZ(start, time.Microsecond),
+		tree.MustMakeDTimestampTZ(end, time.Microsecond),
+		"%(age limit)",
+		dlqReplayBatchSize,
+	}, args)
+
+	// Subsequent batches start after the last row of the previous one.
+	lastTS := tree.MustMakeDTimestampTZ(start.Add(time.Minute), time.Microsecond)
+	lastID := tree.NewDInt(42)
+	query, args = dlqReplayQuery(dlqTable, jobID, dlqReplayFilter{
+		errorClass: tooOld.String(),
+	}, tree.Datums{lastTS, lastID})
+	require.Equal(t,
+		`SELECT id, dlq_timestamp, key_value_bytes FROM db.crdb_replication."dlq_104_public_t" `+
+			`WHERE ingestion_job_id = $1 AND dlq_reason LIKE $2 `+
+			`AND (dlq_timestamp, id) > ($3, $4) ORDER BY dlq_timestamp, id LIMIT $5`,
+		query)
+	require.Equal(t, []interface{}{
+		int64(123), "%(age limit)", lastTS, lastID, dlqReplayBatchSize,
+	}, args)
+}
+
+func TestParseDLQErrorClass(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, c := range dlqErrorClasses {
+		class, err := parseDLQErrorClass(c.String())
+		require.NoError(t, err)
+		require.Equal(t, c.String(), class)
+	}
+	class, err := parseDLQErrorClass("Not Retryable")
+	require.NoError(t, err)
+	require.Equal(t, errType.String(), class)
+
+	_, err = parseDLQErrorClass("allowed")
+	require.ErrorContains(t, err, `unknown error_class "allowed": must be one of "size limit", "age limit", "not retryable"`)
+}
+
+func TestReplayDeadLetterQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	skip.UnderDeadlock(t)
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	// Replay two rows at a time so that the replay pages through the dead
+	// letter queue, past rows which fail again.
+	defer testutils.TestingHook(&dlqReplayBatchSize, 2)()
+
+	server, s, dbA, dbB := setupLogicalTestServer(t, ctx, testClusterBaseClusterArgs, 1)
+	defer server.Stopper().Stop(ctx)
+
+	dbA.Exec(t, "SET CLUSTER SETTING logical_replication.consumer.retry_queue_duration = '100ms'")
+	dbA.Exec(t, "SET CLUSTER SETTING logical_replication.consumer.retry_queue_backoff = '1ms'")
+	dbBURL := replicationtestutils.GetExternalConnectionURI(t, s, s, serverutils.DBName("b"))
+
+	const schema = `
+		CREATE TABLE parent (key STRING PRIMARY KEY);
+		CREATE TABLE child (key STRING PRIMARY KEY, parent STRING REFERENCES parent (key));`
+	dbA.Exec(t, schema)
+	dbB.Exec(t, schema)
+
+	var jobID jobspb.JobID
+	dbA.QueryRow(t, fmt.Sprintf(
+		`CREATE LOGICAL REPLICATION STREAM FROM TABLE child ON '%s' INTO TABLE child WITH mode = 'validated'`,
+		dbBURL.String())).Scan(&jobID)
+	WaitUntilReplicatedTime(t, s.Clock().Now(), dbA, jobID)
+
+	// Only the child table is replicated, so every replicated row violates the
+	// foreign key in the destination and is added to the dead letter queue.
+	dbB.Exec(t, `INSERT INTO parent VALUES ('p1'), ('p2'), ('p3')`)
+	dbB.Exec(t, `INSERT INTO child VALUES ('c1', 'p1'), ('c2', 'p1'), ('c3', 'p2'), ('c4', 'p3'), ('c5', 'p1')`)
+	WaitUntilReplicatedTime(t, s.Clock().Now(), dbA, jobID)
+
+	dlqTable := fmt.Sprintf("crdb_replication.dlq_%d_public_child",
+		sqlutils.QueryTableID(t, dbA.DB, "a", "public", "child"))
+	dlqKeysQuery := fmt.Sprintf(`SELECT incoming_row->>'key' FROM %s ORDER BY 1`, dlqTable)
+	dbA.CheckQueryResults(t, dlqKeysQuery, [][]string{{"c1"}, {"c2"}, {"c3"}, {"c4"}, {"c5"}})
+
+	// Fix the cause for all rows but c4, and write a newer version of c5 in the
+	// destination, which wins over the replicated one.
+	dbA.Exec(t, `INSERT INTO parent VALUES ('p1'), ('p2')`)
+	dbA.Exec(t, `INSERT INTO child VALUES ('c5', NULL)`)
+
+	replay := func() map[string]int {
+		outcomes := make(map[string]int)
+		rows := dbA.Query(t, fmt.Sprintf(`REPLAY DEAD LETTER QUEUE FOR JOB %d`, jobID))
+		defer rows.Close()
+		for rows.Next() {
+			var tableName, outcome string
+			var id int64
+			var ts time.Time
+			var errMsg *string
+			require.NoError(t, rows.Scan(&tableName, &id, &ts, &outcome, &errMsg))
+			require.Equal(t, "a.public.child", tableName)
+			if outcome == dlqReplayFailed {
+				require.NotNil(t, errMsg)
+				require.Contains(t, *errMsg, "violates foreign key constraint")
+			} else {
+				require.Nil(t, errMsg)
+			}
+			outcomes[outcome]++
+		}
+		require.NoError(t, rows.Err())
+		return outcomes
+	}
+
+	require.Equal(t, map[string]int{
+		dlqReplayApplied:    3,
+		dlqReplaySuperseded: 1,
+		dlqReplayFailed:     1,
+	}, replay())
+	dbA.CheckQueryResults(t, `SELECT key, parent FROM child ORDER BY key`, [][]string{
+		{"c1", "p1"}, {"c2", "p1"}, {"c3", "p2"}, {"c5", "NULL"},
+	})
+	dbA.CheckQueryResults(t, dlqKeysQuery, [][]string{{"c4"}})
+
+	// The row which failed again is replayed once its cause is fixed.
+	dbA.Exec(t, `INSERT INTO parent VALUES ('p3')`)
+	require.Equal(t, map[string]int{dlqReplayApplied: 1}, replay())
+	dbA.CheckQueryResults(t, `SELECT key, parent FROM child WHERE key = 'c4'`, [][]string{{"c4", "p3"}})
+	dbA.CheckQueryResults(t, dlqKeysQuery, [][]string{})
+}
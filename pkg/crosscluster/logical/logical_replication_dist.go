@@ -38,6 +38,8 @@ func constructLogicalReplicationWriterSpecs(
 	mode jobspb.LogicalReplicationDetails_ApplyMode,
 	metricsLabel string,
 	writer sqlclustersettings.LDRWriterType,
+	originID, localOriginID uint32,
+	schemaChangeBarrier hlc.Timestamp,
 ) (map[base.SQLInstanceID][]execinfrapb.LogicalReplicationWriterSpec, error) {
 	spanGroup := roachpb.SpanGroup{}
 	baseSpec := execinfrapb.LogicalReplicationWriterSpec{
@@ -53,6 +55,9 @@ func constructLogicalReplicationWriterSpecs(
 		MetricsLabel:                metricsLabel,
 		TypeDescriptors:             srcTypes,
 		WriterType:                  string(writer),
+		OriginID:                    originID,
+		LocalOriginID:               localOriginID,
+		SchemaChangeBarrier:         schemaChangeBarrier,
 	}
 
 	writerSpecs := make(map[base.SQLInstanceID][]execinfrapb.LogicalReplicationWriterSpec, len(destSQLInstances))
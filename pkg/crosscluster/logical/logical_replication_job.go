@@ -218,6 +218,10 @@ func (r *logicalReplicationResumer) ingest(
 		return err
 	}
 
+	if err := r.maybeReplaySourceSchemaChange(ctx, jobExecCtx); err != nil {
+		return err
+	}
+
 	asOf := replicatedTimeAtStart
 	if asOf.IsEmpty() {
 		asOf = payload.ReplicationStartTime
@@ -314,6 +318,10 @@ func (r *logicalReplicationResumer) ingest(
 			rangeStats: replicationutils.NewAggregateRangeStatsCollector(
 				planInfo.writeProcessorCount,
 			),
+			schemaChangeBarrier: progress.SchemaChangeBarrier,
+			onSourceSchemaChange: func(ctx context.Context, ts hlc.Timestamp) error {
+				return r.recordSourceSchemaChange(ctx, client, planInfo, ts)
+			},
 			r: r,
 		}
 		rowResultWriter := sql.NewCallbackResultWriter(rh.handleRow)
@@ -481,6 +489,9 @@ type logicalReplicationPlanInfo struct {
 	sourceSpans      []roachpb.Span
 	partitionPgUrls  []string
 	destTableBySrcID map[descpb.ID]dstTableMetadata
+	// sourceDescriptors are the descriptors of the source tables, keyed by
+	// their ID, as of the time the plan was generated.
+	sourceDescriptors map[descpb.ID]descpb.TableDescriptor
 	// Number of processors writing data on the destination cluster (offline or
 	// otherwise).
 	writeProcessorCount int
@@ -520,7 +531,8 @@ func (p *logicalReplicationPlanner) generatePlanImpl(
 		progress = p.job.Progress().Details.(*jobspb.Progress_LogicalReplication).LogicalReplication
 		payload  = p.job.Payload().Details.(*jobspb.Payload_LogicalReplicationDetails).LogicalReplicationDetails
 		info     = logicalReplicationPlanInfo{
-			destTableBySrcID: make(map[descpb.ID]dstTableMetadata),
+			destTableBySrcID:  make(map[descpb.ID]dstTableMetadata),
+			sourceDescriptors: make(map[descpb.ID]descpb.TableDescriptor),
 		}
 	)
 	asOf := progress.ReplicatedTime
@@ -535,6 +547,9 @@ func (p *logicalReplicationPlanner) generatePlanImpl(
 		UseTableSpan: payload.CreateTable && progress.ReplicatedTime.IsEmpty(),
 		StreamID:     streampb.StreamID(payload.StreamID),
 	}
+	// The writers observe the schema changes of the source tables in the
+	// stream of the descriptors.
+	req.IncludeDescriptorSpans = !req.UseTableSpan && propagateSchemaChanges.Get(&execCfg.Settings.SV)
 	for _, pair := range payload.ReplicationPairs {
 		req.TableIDs = append(req.TableIDs, pair.SrcDescriptorID)
 	}
@@ -564,6 +579,7 @@ func (p *logicalReplicationPlanner) generatePlanImpl(
 	if err := sql.DescsTxn(ctx, execCfg, func(ctx context.Context, txn isql.Txn, descriptors *descs.Collection) error {
 		for _, pair := range payload.ReplicationPairs {
 			srcTableDesc := plan.DescriptorMap[pair.SrcDescriptorID]
+			info.sourceDescriptors[descpb.ID(pair.SrcDescriptorID)] = srcTableDesc
 			cpy := tabledesc.NewBuilder(&srcTableDesc).BuildCreatedMutableTable()
 			if err := typedesc.HydrateTypesInDescriptor(ctx, cpy, crossClusterResolver); err != nil {
 				return err
@@ -643,6 +659,9 @@ func (p *logicalReplicationPlanner) generatePlanImpl(
 		payload.Mode,
 		payload.MetricsLabel,
 		writer,
+		payload.OriginID,
+		payload.LocalOriginID,
+		progress.SchemaChangeBarrier,
 	)
 	if err != nil {
 		return nil, nil, info, err
@@ -794,6 +813,16 @@ type rowHandler struct {
 
 	lastPartitionUpdate time.Time
 
+	// schemaChangeBarrier, if set, is the timestamp at which ingestion stops
+	// until a schema change of the source tables has been replayed.
+	schemaChangeBarrier hlc.Timestamp
+	// onSourceSchemaChange is called when a writer observes a schema change of
+	// the source tables before the schema change barrier.
+	onSourceSchemaChange func(context.Context, hlc.Timestamp) error
+	// restartErr, if set, is returned on the next checkpoint to restart
+	// ingestion.
+	restartErr error
+
 	r *logicalReplicationResumer
 }
 
@@ -819,6 +848,24 @@ func (rh *rowHandler) handleMeta(ctx context.Context, meta *execinfrapb.Producer
 		return nil
 	}
 
+	if pbtypes.Is(&meta.BulkProcessorProgress.ProgressDetails, &execinfrapb.LogicalReplicationSourceSchemaChange{}) {
+		var change execinfrapb.LogicalReplicationSourceSchemaChange
+		if err := pbtypes.UnmarshalAny(&meta.BulkProcessorProgress.ProgressDetails, &change); err != nil {
+			return errors.Wrap(err, "unable to unmarshal schema change")
+		}
+		// Ingestion already stops at the recorded barrier, so only an earlier
+		// schema change needs to be recorded.
+		if rh.schemaChangeBarrier.IsSet() && rh.schemaChangeBarrier.LessEq(change.Timestamp) {
+			return nil
+		}
+		// Errors returned from here do not stop the flow, so the next checkpoint
+		// returns it instead.
+		if rh.restartErr == nil {
+			rh.restartErr = rh.onSourceSchemaChange(ctx, change.Timestamp)
+		}
+		return nil
+	}
+
 	var stats streampb.StreamEvent_RangeStats
 	if err := pbtypes.UnmarshalAny(&meta.BulkProcessorProgress.ProgressDetails, &stats); err != nil {
 		return errors.Wrap(err, "unable to unmarshal progress details")
@@ -830,6 +877,9 @@ func (rh *rowHandler) handleMeta(ctx context.Context, meta *execinfrapb.Producer
 }
 
 func (rh *rowHandler) handleRow(ctx context.Context, row tree.Datums) error {
+	if rh.restartErr != nil {
+		return rh.restartErr
+	}
 	raw, ok := row[0].(*tree.DBytes)
 	if !ok {
 		return errors.AssertionFailedf(`unexpected datum type %T`, row[0])
@@ -846,7 +896,9 @@ func (rh *rowHandler) handleRow(ctx context.Context, row tree.Datums) error {
 		}
 	}
 	replicatedTime := rh.frontier.Frontier()
-	alwaysPersist := rh.replicatedTimeAtStart.Less(replicatedTime) && rh.replicatedTimeAtStart.IsEmpty()
+	reachedBarrier := rh.schemaChangeBarrier.IsSet() && rh.schemaChangeBarrier.LessEq(replicatedTime)
+	alwaysPersist := (rh.replicatedTimeAtStart.Less(replicatedTime) && rh.replicatedTimeAtStart.IsEmpty()) ||
+		reachedBarrier
 
 	updateFreq := jobCheckpointFrequency.Get(rh.settings)
 	if !alwaysPersist && (updateFreq == 0 || timeutil.Since(rh.lastPartitionUpdate) < updateFreq) {
@@ -917,6 +969,9 @@ func (rh *rowHandler) handleRow(ctx context.Context, row tree.Datums) error {
 		// new heartbeat will be sent.
 		return errOfflineInitialScanComplete
 	}
+	if reachedBarrier {
+		return errSchemaChangeBarrierReached
+	}
 	return nil
 }
 
@@ -934,10 +989,11 @@ func (r *logicalReplicationResumer) ingestWithRetries(
 			break
 		}
 		// By default, all errors are retryable unless it's marked as
-		// permanent job error in which case we pause the job.
+		// permanent job error in which case we pause the job, or as a
+		// request to pause the job.
 		// We also stop the job when this is a context cancellation error
 		// as requested pause or cancel will trigger a context cancellation.
-		if jobs.IsPermanentJobError(err) || ctx.Err() != nil {
+		if jobs.IsPermanentJobError(err) || jobs.IsPauseSelfError(err) || ctx.Err() != nil {
 			break
 		}
 
@@ -2340,6 +2340,41 @@ func TestLogicalReplicationSchemaChanges(t *testing.T) {
 	dbB.Exec(t, testCases[0].cmd)
 }
 
+// TestLogicalReplicationPropagateSchemaChanges verifies that a supported schema
+// change of a source table is observed in the stream and replayed on the
+// destination table before the rows written after it are applied.
+func TestLogicalReplicationPropagateSchemaChanges(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	skip.UnderDeadlock(t)
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+
+	server, s, dbA, dbB := setupLogicalTestServer(t, ctx, testClusterBaseClusterArgs, 1)
+	defer server.Stopper().Stop(ctx)
+
+	dbA.Exec(t, "SET CLUSTER SETTING logical_replication.consumer.propagate_schema_changes.enabled = true")
+	dbB.Exec(t, "INSERT INTO tab VALUES (1, 'hello')")
+
+	dbBURL := replicationtestutils.GetExternalConnectionURI(t, s, s, serverutils.DBName("b"))
+	var jobAID jobspb.JobID
+	dbA.QueryRow(t, "CREATE LOGICAL REPLICATION STREAM FROM TABLE tab ON $1 INTO TABLE tab", dbBURL.String()).Scan(&jobAID)
+	WaitUntilReplicatedTime(t, s.Clock().Now(), dbA, jobAID)
+
+	dbB.Exec(t, "CREATE INDEX idx ON tab (payload)")
+	dbB.Exec(t, "INSERT INTO tab VALUES (2, 'world')")
+	WaitUntilReplicatedTime(t, s.Clock().Now(), dbA, jobAID)
+
+	dbA.CheckQueryResults(t,
+		"SELECT DISTINCT index_name FROM [SHOW INDEXES FROM tab] WHERE index_name = 'idx'",
+		[][]string{{"idx"}})
+	dbA.CheckQueryResults(t, "SELECT * FROM tab", [][]string{{"1", "hello"}, {"2", "world"}})
+
+	progress := jobutils.GetJobProgress(t, dbA, jobAID).Details.(*jobspb.Progress_LogicalReplication).LogicalReplication
+	require.True(t, progress.SchemaChangeBarrier.IsEmpty())
+	require.Empty(t, progress.PendingSchemaChanges)
+}
+
 // TestUserDefinedTypes verifies that user-defined types are correctly
 // replicated if the type is defined identically on both sides.
 func TestUserDefinedTypes(t *testing.T) {
@@ -44,6 +44,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/bulk"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/logcrash"
 	"github.com/cockroachdb/cockroach/pkg/util/pprofutil"
@@ -53,6 +54,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/logtags"
+	pbtypes "github.com/gogo/protobuf/types"
 )
 
 var logicalReplicationWriterResultType = []*types.T{
@@ -132,6 +134,14 @@ type logicalReplicationWriterProcessor struct {
 
 	rangeStatsCh chan *streampb.StreamEvent_RangeStats
 
+	// schemaChangeCh reports the timestamp of a schema change of the source
+	// tables to the job.
+	schemaChangeCh chan hlc.Timestamp
+
+	// sourceSchemaChanges, if set, observes the source descriptors in the
+	// stream and holds back the rows until the descriptors are resolved.
+	sourceSchemaChanges *sourceSchemaChangeDetector
+
 	agg      *tracing.TracingAggregator
 	aggTimer timeutil.Timer
 
@@ -206,6 +216,11 @@ func newLogicalReplicationWriterProcessor(
 		}
 	}
 
+	sourceSchemaChanges, err := newSourceSchemaChangeDetector(spec, procConfigByDestTableID, destTableBySrcID)
+	if err != nil {
+		return nil, err
+	}
+
 	dlqDbExec := flowCtx.Cfg.DB.Executor(isql.WithSessionData(sql.NewInternalSessionData(ctx, flowCtx.Cfg.Settings, "" /* opName */)))
 
 	var numTablesWithSecondaryIndexes int
@@ -223,6 +238,7 @@ func newLogicalReplicationWriterProcessor(
 		stopCh:         make(chan struct{}),
 		checkpointCh:   make(chan []jobspb.ResolvedSpan),
 		rangeStatsCh:   make(chan *streampb.StreamEvent_RangeStats),
+		schemaChangeCh: make(chan hlc.Timestamp),
 		errCh:          make(chan error, 1),
 		logBufferEvery: log.Every(30 * time.Second),
 		debug: streampb.DebugLogicalConsumerStatus{
@@ -234,6 +250,8 @@ func newLogicalReplicationWriterProcessor(
 		seenEvery:  log.Every(1 * time.Minute),
 		retryEvery: log.Every(1 * time.Minute),
 		pacer:      kvbulk.NewCPUPacer(ctx, flowCtx.Cfg.DB.KV(), useLowPriority),
+
+		sourceSchemaChanges: sourceSchemaChanges,
 	}
 	lrw.purgatory = purgatory{
 		deadline:    func() time.Duration { return retryQueueAgeLimit.Get(&flowCtx.Cfg.Settings.SV) },
@@ -412,6 +430,20 @@ func (lrw *logicalReplicationWriterProcessor) Next() (
 			return nil, lrw.DrainHelper()
 		}
 		return nil, meta
+	case ts := <-lrw.schemaChangeCh:
+		details, err := pbtypes.MarshalAny(&execinfrapb.LogicalReplicationSourceSchemaChange{Timestamp: ts})
+		if err != nil {
+			lrw.MoveToDrainingAndLogError(err)
+			return nil, lrw.DrainHelper()
+		}
+		return nil, &execinfrapb.ProducerMetadata{
+			BulkProcessorProgress: &execinfrapb.RemoteProducerMetadata_BulkProcessorProgress{
+				NodeID:          lrw.FlowCtx.NodeID.SQLInstanceID(),
+				FlowID:          lrw.FlowCtx.ID,
+				ProcessorID:     lrw.ProcessorID,
+				ProgressDetails: *details,
+			},
+		}
 	case err := <-lrw.errCh:
 		lrw.MoveToDrainingAndLogError(err)
 		return nil, lrw.DrainHelper()
@@ -442,6 +474,9 @@ func (lrw *logicalReplicationWriterProcessor) close() {
 	}
 	log.Dev.Infof(lrw.Ctx(), "logical replication writer processor closing")
 	defer lrw.frontier.Release()
+	if lrw.sourceSchemaChanges != nil {
+		defer lrw.sourceSchemaChanges.frontier.Release()
+	}
 
 	if lrw.streamPartitionClient != nil {
 		_ = lrw.streamPartitionClient.Close(lrw.Ctx())
@@ -563,6 +598,39 @@ func (lrw *logicalReplicationWriterProcessor) maybeCheckpoint(
 		}
 	}
 
+	// Apply the rows which were held back until the source descriptors were
+	// resolved past them, and report a schema change once it is known to be the
+	// earliest one.
+	limit := lrw.schemaChangeBarrier()
+	if d := lrw.sourceSchemaChanges; d != nil {
+		ready, err := d.forward(checkpoint.ResolvedSpans)
+		if err != nil {
+			return err
+		}
+		if err := lrw.applyEvents(ctx, ready); err != nil {
+			return err
+		}
+		if ts, ok := d.maybeReport(); ok {
+			if err := lrw.reportSourceSchemaChange(ctx, ts); err != nil {
+				return err
+			}
+		}
+		limit = d.barrier
+		if resolved := d.resolved(); limit.IsEmpty() || resolved.Less(limit) {
+			limit = resolved
+		}
+	}
+
+	// Rows written after the schema change barrier, or still held back, are not
+	// applied, so the frontier must not advance past them.
+	if limit.IsSet() {
+		for i := range checkpoint.ResolvedSpans {
+			if limit.Less(checkpoint.ResolvedSpans[i].Timestamp) {
+				checkpoint.ResolvedSpans[i].Timestamp = limit
+			}
+		}
+	}
+
 	// If purgatory is non-empty, it intercepts the checkpoint and then we can try
 	// to drain it.
 	if !lrw.purgatory.Empty() {
@@ -639,8 +707,28 @@ func (lrw *logicalReplicationWriterProcessor) checkpoint(
 // handleStreamBuffer handles a buffer of KV events from the incoming stream.
 func (lrw *logicalReplicationWriterProcessor) handleStreamBuffer(
 	ctx context.Context, kvs []streampb.StreamEvent_KV,
+) error {
+	if d := lrw.sourceSchemaChanges; d != nil {
+		var err error
+		if kvs, err = d.filter(kvs); err != nil {
+			return err
+		}
+	}
+	return lrw.applyEvents(ctx, kvs)
+}
+
+// applyEvents applies the rows which were written at or before the schema
+// change barrier, if any.
+func (lrw *logicalReplicationWriterProcessor) applyEvents(
+	ctx context.Context, kvs []streampb.StreamEvent_KV,
 ) error {
 	const notRetry = false
+	if barrier := lrw.schemaChangeBarrier(); barrier.IsSet() {
+		kvs = filterAfterSchemaChangeBarrier(kvs, barrier)
+	}
+	if len(kvs) == 0 {
+		return nil
+	}
 	unapplied, unappliedBytes, err := lrw.flushBuffer(ctx, kvs, notRetry, lrw.purgatory.Enabled())
 	if err != nil {
 		return err
@@ -653,6 +741,43 @@ func (lrw *logicalReplicationWriterProcessor) handleStreamBuffer(
 	return nil
 }
 
+// schemaChangeBarrier returns the timestamp of the earliest known schema change
+// of the source tables which has not been replayed yet, if any.
+func (lrw *logicalReplicationWriterProcessor) schemaChangeBarrier() hlc.Timestamp {
+	if d := lrw.sourceSchemaChanges; d != nil {
+		return d.barrier
+	}
+	return lrw.spec.SchemaChangeBarrier
+}
+
+// reportSourceSchemaChange reports the timestamp of a schema change of the
+// source tables to the job, which stops ingestion at it.
+func (lrw *logicalReplicationWriterProcessor) reportSourceSchemaChange(
+	ctx context.Context, ts hlc.Timestamp,
+) error {
+	log.Dev.Infof(ctx, "observed schema change of source tables at %s", ts)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case lrw.schemaChangeCh <- ts:
+		return nil
+	case <-lrw.stopCh:
+		// See rangeStats.
+		return nil
+	}
+}
+
+// filterAfterSchemaChangeBarrier removes the events written after the schema
+// change barrier. They are streamed again once the job resumes ingestion past
+// the barrier since the frontier does not advance past it.
+func filterAfterSchemaChangeBarrier(
+	kvs []streampb.StreamEvent_KV, barrier hlc.Timestamp,
+) []streampb.StreamEvent_KV {
+	return slices.DeleteFunc(kvs, func(kv streampb.StreamEvent_KV) bool {
+		return barrier.Less(kv.KeyValue.Value.Timestamp)
+	})
+}
+
 func filterRemaining(kvs []streampb.StreamEvent_KV) []streampb.StreamEvent_KV {
 	remaining := kvs
 	var j int
@@ -701,6 +826,8 @@ func (lrw *logicalReplicationWriterProcessor) setupBatchHandlers(ctx context.Con
 		var rp BatchHandler
 		var err error
 		sd := sql.NewInternalSessionData(ctx, flowCtx.Cfg.Settings, "" /* opName */)
+		sd.OriginIDForLogicalDataReplication = lrw.spec.OriginID
+		sd.LocalOriginIDForLogicalDataReplication = lrw.spec.LocalOriginID
 
 		switch writer {
 		case sqlclustersettings.LDRWriterTypeSQL:
@@ -51,6 +51,9 @@ type kvRowProcessor struct {
 
 	pacer *admission.Pacer
 
+	// writeOptions tag every replicated write with the origin of the stream.
+	writeOptions *kvpb.WriteOptions
+
 	failureInjector
 }
 
@@ -84,6 +87,8 @@ func newKVRowProcessor(
 		writers:  make(map[descpb.ID]*kvTableWriter, len(procConfigByDestID)),
 		decoder:  decoder,
 		pacer:    bulk.NewCPUPacer(ctx, cfg.DB.KV(), useLowPriority),
+
+		writeOptions: replicationWriteOptions(spec.OriginID, spec.LocalOriginID),
 	}
 	return p, nil
 }
@@ -116,7 +121,24 @@ func newCdcEventDecoder(
 	return cdcevent.NewEventDecoderWithCache(ctx, rfCache, false, false), nil
 }
 
-var originID1Options = &kvpb.WriteOptions{OriginID: 1}
+// defaultOriginID is the origin ID of rows replicated from a source of
+// unspecified origin, i.e. by jobs that are not part of a mesh.
+const defaultOriginID = 1
+
+var originID1Options = &kvpb.WriteOptions{OriginID: defaultOriginID}
+
+// replicationWriteOptions returns the WriteOptions of the KV batches that
+// replicate rows from the cluster identified by originID into the cluster
+// identified by localOriginID.
+func replicationWriteOptions(originID, localOriginID uint32) *kvpb.WriteOptions {
+	if originID == 0 {
+		originID = defaultOriginID
+	}
+	if originID == defaultOriginID && localOriginID == 0 {
+		return originID1Options
+	}
+	return &kvpb.WriteOptions{OriginID: originID, LocalOriginID: localOriginID}
+}
 
 func (p *kvRowProcessor) HandleBatch(
 	ctx context.Context, batch []streampb.StreamEvent_KV,
@@ -229,9 +251,9 @@ func (p *kvRowProcessor) ReleaseLeases(ctx context.Context) {
 // ConditionFailedError with HadNewerOriginTimetamp=true.
 const maxRefreshCount = 10
 
-func makeKVBatch(lowPri bool, txn *kv.Txn) *kv.Batch {
+func makeKVBatch(lowPri bool, txn *kv.Txn, writeOptions *kvpb.WriteOptions) *kv.Batch {
 	b := txn.NewBatch()
-	b.Header.WriteOptions = originID1Options
+	b.Header.WriteOptions = writeOptions
 	if lowPri {
 		b.AdmissionHeader.Priority = int32(admissionpb.BulkLowPri)
 	} else {
@@ -252,7 +274,7 @@ func (p *kvRowProcessor) processOneRow(
 ) error {
 	if err := p.cfg.DB.KV().Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
 		txn.SetBufferedWritesEnabled(false)
-		b := makeKVBatch(useLowPriority.Get(&p.cfg.Settings.SV), txn)
+		b := makeKVBatch(useLowPriority.Get(&p.cfg.Settings.SV), txn, p.writeOptions)
 
 		if err := p.addToBatch(ctx, txn, b, dstTableID, row, k, prevValue); err != nil {
 			return err
@@ -403,7 +403,7 @@ var (
 		// session before each query because 1) each IE query creates a new session;
 		// 2) we do not plan to use multi row insert statements during LDR ingestion
 		// via sql.
-		OriginIDForLogicalDataReplication: 1,
+		OriginIDForLogicalDataReplication: defaultOriginID,
 		// Use generic query plans since our queries are extremely simple and
 		// won't benefit from custom plans.
 		PlanCacheMode: &forceGenericPlan,
@@ -433,6 +433,18 @@ func getIEOverride(opName string, jobID jobspb.JobID) sessiondata.InternalExecut
 	return o
 }
 
+// withReplicationOrigin binds the origin IDs of the session data, if any, to
+// the override, in place of the default origin ID.
+func withReplicationOrigin(
+	o sessiondata.InternalExecutorOverride, sd *sessiondata.SessionData,
+) sessiondata.InternalExecutorOverride {
+	if sd.OriginIDForLogicalDataReplication != 0 {
+		o.OriginIDForLogicalDataReplication = sd.OriginIDForLogicalDataReplication
+	}
+	o.LocalOriginIDForLogicalDataReplication = sd.LocalOriginIDForLogicalDataReplication
+	return o
+}
+
 func init() {
 }
 
@@ -486,9 +498,9 @@ func makeSQLProcessor(
 			insertQueries: make(map[catid.DescID]map[catid.FamilyID]queryBuilder, len(tableConfigByDestID)),
 		},
 		tombstoneUpdaters:          make(map[descpb.ID]*tombstoneUpdater, len(tableConfigByDestID)),
-		ieOverrideOptimisticInsert: getIEOverride(replicatedOptimisticInsertOpName, jobID),
-		ieOverrideInsert:           getIEOverride(replicatedInsertOpName, jobID),
-		ieOverrideDelete:           getIEOverride(replicatedDeleteOpName, jobID),
+		ieOverrideOptimisticInsert: withReplicationOrigin(getIEOverride(replicatedOptimisticInsertOpName, jobID), sd),
+		ieOverrideInsert:           withReplicationOrigin(getIEOverride(replicatedInsertOpName, jobID), sd),
+		ieOverrideDelete:           withReplicationOrigin(getIEOverride(replicatedDeleteOpName, jobID), sd),
 	}
 	var udfQuerier querier
 	if needUDFQuerier {
@@ -578,11 +590,17 @@ type lwwQuerier struct {
 func (lww *lwwQuerier) AddTable(targetDescID int32, tc sqlProcessorTableConfig) error {
 	td := tc.srcDesc
 	var err error
-	lww.queryBuffer.insertQueries[td.GetID()], err = makeLWWInsertQueries(targetDescID, td)
+	originID, localOriginID := lww.ieOverrideInsert.OriginIDForLogicalDataReplication,
+		lww.ieOverrideInsert.LocalOriginIDForLogicalDataReplication
+	lww.queryBuffer.insertQueries[td.GetID()], err = makeLWWInsertQueries(
+		targetDescID, td, originID, localOriginID,
+	)
 	if err != nil {
 		return err
 	}
-	lww.queryBuffer.deleteQueries[td.GetID()], err = makeLWWDeleteQuery(targetDescID, td)
+	lww.queryBuffer.deleteQueries[td.GetID()], err = makeLWWDeleteQuery(
+		targetDescID, td, originID, localOriginID,
+	)
 	if err != nil {
 		return err
 	}
@@ -735,12 +753,38 @@ VALUES (%s)
 ON CONFLICT (%s)
 DO UPDATE SET
 %s
-WHERE (t.crdb_internal_mvcc_timestamp < $%[6]d
-    AND t.crdb_internal_origin_timestamp IS NULL)
- OR (t.crdb_internal_origin_timestamp < $%[6]d
- 	AND t.crdb_internal_origin_timestamp IS NOT NULL)`
+WHERE %s`
 )
 
+// lwwWinnerPredicate returns the predicate of the replicated insert and delete
+// queries which holds if the replicated row, whose origin timestamp is bound to
+// placeholder originTSIdx, wins last-write-wins against the existing row t.
+//
+// If localOriginID is set, the cluster is part of a logical replication mesh,
+// and rows with equal timestamps are ordered by origin ID, where a row written
+// locally has the local origin ID. This is the same order the KV writer uses
+// (see isOriginTimestampWinner in the storage package), so every cluster of
+// the mesh keeps the same row.
+func lwwWinnerPredicate(originTSIdx int, originID, localOriginID uint32) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `(t.crdb_internal_mvcc_timestamp < $%[1]d
+    AND t.crdb_internal_origin_timestamp IS NULL)
+ OR (t.crdb_internal_origin_timestamp < $%[1]d
+    AND t.crdb_internal_origin_timestamp IS NOT NULL)`, originTSIdx)
+	if localOriginID == 0 {
+		return b.String()
+	}
+	if originID > localOriginID {
+		fmt.Fprintf(&b, `
+ OR (t.crdb_internal_mvcc_timestamp = $%[1]d
+    AND t.crdb_internal_origin_timestamp IS NULL)`, originTSIdx)
+	}
+	fmt.Fprintf(&b, `
+ OR (t.crdb_internal_origin_timestamp = $%[1]d
+    AND t.crdb_internal_origin_id < %[2]d)`, originTSIdx, originID)
+	return b.String()
+}
+
 func sqlEscapedJoin(parts []string, sep string) string {
 	switch len(parts) {
 	case 0:
@@ -811,7 +855,7 @@ func valueStringForNumItems(count int, startIndex int) string {
 }
 
 func makeLWWInsertQueries(
-	dstTableDescID int32, td catalog.TableDescriptor,
+	dstTableDescID int32, td catalog.TableDescriptor, originID, localOriginID uint32,
 ) (map[catid.FamilyID]queryBuilder, error) {
 	queryBuilders := make(map[catid.FamilyID]queryBuilder, td.NumFamilies())
 	if err := td.ForeachFamily(func(family *descpb.ColumnFamilyDescriptor) error {
@@ -854,7 +898,7 @@ func makeLWWInsertQueries(
 			valStr,
 			sqlEscapedJoin(td.TableDesc().PrimaryIndex.KeyColumnNames, ","),
 			onConflictUpdateClause.String(),
-			originTSIdx,
+			lwwWinnerPredicate(originTSIdx, originID, localOriginID),
 		))
 		if err != nil {
 			return err
@@ -876,7 +920,9 @@ func makeLWWInsertQueries(
 	return queryBuilders, nil
 }
 
-func makeLWWDeleteQuery(dstTableDescID int32, td catalog.TableDescriptor) (queryBuilder, error) {
+func makeLWWDeleteQuery(
+	dstTableDescID int32, td catalog.TableDescriptor, originID, localOriginID uint32,
+) (queryBuilder, error) {
 	var whereClause strings.Builder
 	names := td.TableDesc().PrimaryIndex.KeyColumnNames
 	for i := range names {
@@ -890,13 +936,10 @@ func makeLWWDeleteQuery(dstTableDescID int32, td catalog.TableDescriptor) (query
 	originTSIdx := len(names) + 1
 	baseQuery := `
 DELETE FROM [%d as t] WHERE %s
-   AND ((t.crdb_internal_mvcc_timestamp < $%[3]d
-        AND t.crdb_internal_origin_timestamp IS NULL)
-    OR (t.crdb_internal_origin_timestamp < $%[3]d
-        AND t.crdb_internal_origin_timestamp IS NOT NULL))
+   AND (%s)
 RETURNING *`
-	stmt, err := parser.ParseOne(
-		fmt.Sprintf(baseQuery, dstTableDescID, whereClause.String(), originTSIdx))
+	stmt, err := parser.ParseOne(fmt.Sprintf(baseQuery, dstTableDescID, whereClause.String(),
+		lwwWinnerPredicate(originTSIdx, originID, localOriginID)))
 	if err != nil {
 		return queryBuilder{}, err
 	}
@@ -0,0 +1,496 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package logical
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/crosscluster/streamclient"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/repstream/streampb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/span"
+	"github.com/cockroachdb/errors"
+)
+
+var propagateSchemaChanges = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"logical_replication.consumer.propagate_schema_changes.enabled",
+	"if enabled, supported schema changes of the source tables are replayed on the "+
+		"destination tables, pausing ingestion at the schema change until they are applied; "+
+		"rows are only applied once the source descriptors are known to be unchanged at "+
+		"their timestamp, which adds up to a checkpoint interval of replication latency",
+	false,
+)
+
+var errSchemaChangeBarrierReached = errors.New("reached the schema change barrier")
+
+// sourceSchemaChange is a schema change of the source tables of a stream.
+type sourceSchemaChange struct {
+	// timestamp is the modification time of the changed source descriptors.
+	timestamp hlc.Timestamp
+	// statements replay the schema change on the destination tables.
+	statements []string
+	// unsupported, if set, describes why the schema change cannot be replayed.
+	unsupported string
+}
+
+// planSourceSchemaChange returns the statements which replay the schema change
+// between two versions of a source table on its destination table, or an error
+// if the destination table cannot be changed the same way. Only the following
+// schema changes are replayed:
+//   - adding a nullable column without a default or computed expression;
+//   - adding a non-unique, non-partial forward index on non-virtual columns;
+//   - dropping a column.
+//
+// Other changes which do not affect the columns or the primary key of the
+// table, like dropping an index, are ignored.
+func planSourceSchemaChange(
+	prev, cur catalog.TableDescriptor, dstName *tree.TableName,
+) ([]string, error) {
+	srcName := cur.GetName()
+	if !prev.GetPrimaryIndex().CollectKeyColumnIDs().Equals(cur.GetPrimaryIndex().CollectKeyColumnIDs()) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"the primary key of source table %q was altered", srcName)
+	}
+
+	curCols := make(map[descpb.ColumnID]catalog.Column)
+	for _, col := range cur.PublicColumns() {
+		curCols[col.GetID()] = col
+	}
+	prevCols := make(map[descpb.ColumnID]catalog.Column)
+	var stmts []string
+	for _, col := range prev.PublicColumns() {
+		prevCols[col.GetID()] = col
+		curCol, ok := curCols[col.GetID()]
+		if !ok {
+			stmts = append(stmts, (&tree.AlterTable{
+				Table: dstName.ToUnresolvedObjectName(),
+				Cmds: tree.AlterTableCmds{&tree.AlterTableDropColumn{
+					IfExists: true,
+					Column:   tree.Name(col.GetName()),
+				}},
+			}).String())
+			continue
+		}
+		if curCol.GetName() != col.GetName() ||
+			!curCol.GetType().Identical(col.GetType()) ||
+			curCol.IsNullable() != col.IsNullable() ||
+			curCol.IsComputed() != col.IsComputed() ||
+			curCol.IsVirtual() != col.IsVirtual() ||
+			curCol.IsHidden() != col.IsHidden() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %q of source table %q was altered", col.GetName(), srcName)
+		}
+	}
+
+	for _, col := range cur.PublicColumns() {
+		if _, ok := prevCols[col.GetID()]; ok {
+			continue
+		}
+		if !col.IsNullable() || col.HasDefault() || col.IsComputed() || col.HasOnUpdate() ||
+			col.IsHidden() || col.GetType().UserDefined() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %q added to source table %q must be a visible nullable column of a "+
+					"built-in type without a default, computed or ON UPDATE expression",
+				col.GetName(), srcName)
+		}
+		stmts = append(stmts, (&tree.AlterTable{
+			Table: dstName.ToUnresolvedObjectName(),
+			Cmds: tree.AlterTableCmds{&tree.AlterTableAddColumn{
+				IfNotExists: true,
+				ColumnDef: &tree.ColumnTableDef{
+					Name: tree.Name(col.GetName()),
+					Type: col.GetType(),
+				},
+			}},
+		}).String())
+	}
+
+	prevIndexes := make(map[descpb.IndexID]struct{})
+	for _, idx := range prev.PublicNonPrimaryIndexes() {
+		prevIndexes[idx.GetID()] = struct{}{}
+	}
+	for _, idx := range cur.PublicNonPrimaryIndexes() {
+		if _, ok := prevIndexes[idx.GetID()]; ok {
+			continue
+		}
+		if idx.GetType() != idxtype.FORWARD || idx.IsUnique() || idx.IsPartial() || idx.IsSharded() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"index %q added to source table %q must be a non-unique, non-partial forward index",
+				idx.GetName(), srcName)
+		}
+		createIndex := tree.CreateIndex{
+			Name:        tree.Name(idx.GetName()),
+			Table:       *dstName,
+			IfNotExists: true,
+		}
+		for i := 0; i < idx.NumKeyColumns(); i++ {
+			if col, ok := curCols[idx.GetKeyColumnID(i)]; !ok || col.IsVirtual() {
+				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"index %q added to source table %q must not index virtual columns or expressions",
+					idx.GetName(), srcName)
+			}
+			elem := tree.IndexElem{Column: tree.Name(idx.GetKeyColumnName(i))}
+			if idx.GetKeyColumnDirection(i) == catenumpb.IndexColumn_DESC {
+				elem.Direction = tree.Descending
+			}
+			createIndex.Columns = append(createIndex.Columns, elem)
+		}
+		for i := 0; i < idx.NumSecondaryStoredColumns(); i++ {
+			createIndex.Storing = append(createIndex.Storing, tree.Name(idx.GetStoredColumnName(i)))
+		}
+		stmts = append(stmts, createIndex.String())
+	}
+	return stmts, nil
+}
+
+// pollSourceSchemaChange reads the descriptors of the source tables as of the
+// given timestamp and returns the earliest schema change since the given descriptors which
+// affects the destination tables, or nil if there is none.
+func pollSourceSchemaChange(
+	ctx context.Context,
+	client streamclient.Client,
+	streamID streampb.StreamID,
+	asOf hlc.Timestamp,
+	planned map[descpb.ID]descpb.TableDescriptor,
+	destTableBySrcID map[descpb.ID]dstTableMetadata,
+) (*sourceSchemaChange, error) {
+	req := streampb.LogicalReplicationPlanRequest{
+		PlanAsOf: asOf,
+		StreamID: streamID,
+	}
+	for id := range planned {
+		req.TableIDs = append(req.TableIDs, int32(id))
+	}
+	plan, err := client.PlanLogicalReplication(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var earliest *sourceSchemaChange
+	for id, prevDesc := range planned {
+		curDesc, ok := plan.DescriptorMap[int32(id)]
+		if !ok || curDesc.Version <= prevDesc.Version {
+			continue
+		}
+		prev := tabledesc.NewBuilder(&prevDesc).BuildImmutableTable()
+		cur := tabledesc.NewBuilder(&curDesc).BuildImmutableTable()
+		dst := destTableBySrcID[id]
+		dstName := tree.MakeTableNameWithSchema(
+			tree.Name(dst.database), tree.Name(dst.schema), tree.Name(dst.table),
+		)
+
+		change := sourceSchemaChange{timestamp: cur.GetModificationTime()}
+		if change.timestamp.IsEmpty() {
+			// The change happened at or before the read timestamp.
+			change.timestamp = asOf
+		}
+		stmts, err := planSourceSchemaChange(prev, cur, &dstName)
+		if err != nil {
+			change.unsupported = err.Error()
+		} else if len(stmts) == 0 {
+			continue
+		}
+		change.statements = stmts
+
+		switch {
+		case earliest == nil || change.timestamp.Less(earliest.timestamp):
+			earliest = &change
+		case change.timestamp.Equal(earliest.timestamp):
+			earliest.statements = append(earliest.statements, change.statements...)
+			if earliest.unsupported == "" {
+				earliest.unsupported = change.unsupported
+			}
+		}
+	}
+	return earliest, nil
+}
+
+// recordSourceSchemaChange records a schema change barrier in the job progress
+// at the timestamp at which a writer processor observed a schema change of the
+// source tables in the stream, and returns an error which restarts ingestion so
+// that every writer stops at the barrier.
+func (r *logicalReplicationResumer) recordSourceSchemaChange(
+	ctx context.Context,
+	client streamclient.Client,
+	planInfo logicalReplicationPlanInfo,
+	ts hlc.Timestamp,
+) error {
+	payload := r.job.Details().(jobspb.LogicalReplicationDetails)
+	change, err := pollSourceSchemaChange(ctx, client, streampb.StreamID(payload.StreamID),
+		ts, planInfo.sourceDescriptors, planInfo.destTableBySrcID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the schema change of source tables at %s", ts)
+	}
+	if change == nil {
+		// The writer only reports schema changes which affect the destination
+		// tables, so this should not happen. Stopping at the barrier anyway
+		// replans ingestion with the source descriptors as of the change.
+		change = &sourceSchemaChange{timestamp: ts}
+	}
+
+	if err := r.job.NoTxn().Update(ctx, func(txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater) error {
+		prog := md.Progress.Details.(*jobspb.Progress_LogicalReplication).LogicalReplication
+		prog.SchemaChangeBarrier = change.timestamp
+		prog.PendingSchemaChanges = change.statements
+		prog.UnsupportedSchemaChange = change.unsupported
+		ju.UpdateProgress(md.Progress)
+		return nil
+	}); err != nil {
+		return err
+	}
+	return errors.Mark(
+		errors.Newf("pausing ingestion at schema change of source tables at %s", change.timestamp),
+		sql.ErrPlanChanged,
+	)
+}
+
+// isSourceDescriptorKey returns whether a key streamed from the source cluster
+// belongs to the descriptor table.
+func isSourceDescriptorKey(key roachpb.Key) bool {
+	key, err := keys.StripTenantPrefix(key)
+	if err != nil {
+		return false
+	}
+	_, tableID, err := keys.SystemSQLCodec.DecodeTablePrefix(key)
+	return err == nil && tableID == keys.DescriptorTableID
+}
+
+// sourceSchemaChangeDetector observes the descriptors of the source tables in
+// the stream of a writer processor. Since the events of different spans are not
+// ordered in the stream, rows are only applied once the descriptor spans have
+// been resolved past them, so that a row written after a schema change is never
+// applied before the schema change has been observed.
+type sourceSchemaChangeDetector struct {
+	// frontier tracks the resolved timestamps of the descriptor spans.
+	frontier span.Frontier
+	// tables are the source tables, keyed by ID, as of the planning of the
+	// writer.
+	tables map[descpb.ID]sourceTable
+	// barrier is the timestamp of the earliest schema change which affects the
+	// destination tables, if any. No row written after it is applied.
+	barrier hlc.Timestamp
+	// reported is set once the barrier has been reported to the job.
+	reported bool
+	// pending are the rows written after the resolved timestamp of the
+	// descriptor spans. They hold at most the rows received between two
+	// checkpoints of the descriptor spans.
+	pending []streampb.StreamEvent_KV
+}
+
+type sourceTable struct {
+	desc    catalog.TableDescriptor
+	dstName tree.TableName
+}
+
+// newSourceSchemaChangeDetector returns a detector for the descriptor spans of
+// the partition of a writer processor, or nil if the partition does not stream
+// the descriptors of the source tables.
+func newSourceSchemaChangeDetector(
+	spec execinfrapb.LogicalReplicationWriterSpec,
+	configByTable map[descpb.ID]sqlProcessorTableConfig,
+	destTableBySrcID map[descpb.ID]dstTableMetadata,
+) (*sourceSchemaChangeDetector, error) {
+	var descSpans []roachpb.Span
+	for _, sp := range spec.PartitionSpec.Spans {
+		if isSourceDescriptorKey(sp.Key) {
+			descSpans = append(descSpans, sp)
+		}
+	}
+	if len(descSpans) == 0 {
+		return nil, nil
+	}
+
+	// The writer is planned with the source descriptors as of the previous
+	// replicated time, or the initial scan time if there is none, so all the
+	// schema changes until then have been observed.
+	start := spec.PreviousReplicatedTimestamp
+	if start.IsEmpty() {
+		start = spec.InitialScanTimestamp
+	}
+	frontier, err := span.MakeFrontierAt(start, descSpans...)
+	if err != nil {
+		return nil, err
+	}
+	d := &sourceSchemaChangeDetector{
+		frontier: frontier,
+		tables:   make(map[descpb.ID]sourceTable, len(configByTable)),
+		// A schema change barrier in the spec has already been recorded in the
+		// job progress.
+		barrier:  spec.SchemaChangeBarrier,
+		reported: spec.SchemaChangeBarrier.IsSet(),
+	}
+	for _, tc := range configByTable {
+		dst := destTableBySrcID[tc.srcDesc.GetID()]
+		d.tables[tc.srcDesc.GetID()] = sourceTable{
+			desc: tc.srcDesc,
+			dstName: tree.MakeTableNameWithSchema(
+				tree.Name(dst.database), tree.Name(dst.schema), tree.Name(dst.table),
+			),
+		}
+	}
+	return d, nil
+}
+
+// filter observes the descriptors in a buffer of events and returns the rows
+// which are ready to be applied. The rows written after the resolved timestamp
+// of the descriptor spans are held back until a later checkpoint.
+func (d *sourceSchemaChangeDetector) filter(
+	kvs []streampb.StreamEvent_KV,
+) ([]streampb.StreamEvent_KV, error) {
+	resolved := d.frontier.Frontier()
+	ready := kvs[:0]
+	for _, kv := range kvs {
+		if isSourceDescriptorKey(kv.KeyValue.Key) {
+			if err := d.observeDescriptor(kv.KeyValue); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if resolved.Less(kv.KeyValue.Value.Timestamp) {
+			d.pending = append(d.pending, kv)
+			continue
+		}
+		ready = append(ready, kv)
+	}
+	return ready, nil
+}
+
+// observeDescriptor sets the barrier at the timestamp of a new version of a
+// source table descriptor if it is the earliest one which affects the
+// destination table.
+func (d *sourceSchemaChangeDetector) observeDescriptor(kv roachpb.KeyValue) error {
+	ts := kv.Value.Timestamp
+	if (d.barrier.IsSet() && d.barrier.LessEq(ts)) || !kv.Value.IsPresent() {
+		return nil
+	}
+	b, err := descbuilder.FromSerializedValue(&kv.Value)
+	if err != nil || b == nil {
+		return err
+	}
+	cur, ok := b.BuildImmutable().(catalog.TableDescriptor)
+	if !ok || cur.Dropped() {
+		return nil
+	}
+	src, ok := d.tables[cur.GetID()]
+	if !ok || cur.GetVersion() <= src.desc.GetVersion() {
+		return nil
+	}
+	// Intermediate versions of a schema change, and changes which do not affect
+	// the destination table, are ignored.
+	if stmts, err := planSourceSchemaChange(src.desc, cur, &src.dstName); err == nil && len(stmts) == 0 {
+		return nil
+	}
+	d.barrier = ts
+	d.reported = false
+	return nil
+}
+
+// forward advances the resolved timestamps of the descriptor spans and returns
+// the held back rows which are now ready to be applied.
+func (d *sourceSchemaChangeDetector) forward(
+	resolvedSpans []jobspb.ResolvedSpan,
+) ([]streampb.StreamEvent_KV, error) {
+	for _, sp := range resolvedSpans {
+		if _, err := d.frontier.Forward(sp.Span, sp.Timestamp); err != nil {
+			return nil, err
+		}
+	}
+	resolved := d.frontier.Frontier()
+	var ready []streampb.StreamEvent_KV
+	pending := d.pending[:0]
+	for _, kv := range d.pending {
+		if resolved.Less(kv.KeyValue.Value.Timestamp) {
+			pending = append(pending, kv)
+		} else {
+			ready = append(ready, kv)
+		}
+	}
+	clear(d.pending[len(pending):])
+	d.pending = pending
+	return ready, nil
+}
+
+// resolved returns the timestamp up to which every schema change of the source
+// tables has been observed.
+func (d *sourceSchemaChangeDetector) resolved() hlc.Timestamp {
+	return d.frontier.Frontier()
+}
+
+// maybeReport returns the barrier once the descriptor spans have been resolved
+// past it, which guarantees that there is no earlier schema change, if it has
+// not been reported to the job yet.
+func (d *sourceSchemaChangeDetector) maybeReport() (hlc.Timestamp, bool) {
+	if d.reported || d.barrier.IsEmpty() || d.frontier.Frontier().Less(d.barrier) {
+		return hlc.Timestamp{}, false
+	}
+	d.reported = true
+	return d.barrier, true
+}
+
+// maybeReplaySourceSchemaChange replays the schema change at the schema change
+// barrier on the destination tables once the replicated time has reached it,
+// and clears the barrier so that ingestion resumes past it. If the schema
+// change cannot be replayed, the barrier is cleared and the job pauses so that
+// the destination tables can be changed manually before it is resumed.
+func (r *logicalReplicationResumer) maybeReplaySourceSchemaChange(
+	ctx context.Context, jobExecCtx sql.JobExecContext,
+) error {
+	progress := r.job.Progress().Details.(*jobspb.Progress_LogicalReplication).LogicalReplication
+	barrier := progress.SchemaChangeBarrier
+	if barrier.IsEmpty() || progress.ReplicatedTime.Less(barrier) {
+		return nil
+	}
+
+	if progress.UnsupportedSchemaChange == "" {
+		override := sessiondata.NodeUserSessionDataOverride
+		override.AllowSchemaChangesOnLDRTables = true
+		ie := jobExecCtx.ExecCfg().InternalDB.Executor()
+		for _, stmt := range progress.PendingSchemaChanges {
+			if _, err := ie.ExecEx(ctx, "replay-source-schema-change", nil /* txn */, override, stmt); err != nil {
+				return errors.Wrapf(err, "failed to replay schema change of source tables")
+			}
+			log.Dev.Infof(ctx, "replayed schema change of source tables at %s: %s", barrier, stmt)
+		}
+	}
+
+	if err := r.job.NoTxn().Update(ctx, func(txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater) error {
+		prog := md.Progress.Details.(*jobspb.Progress_LogicalReplication).LogicalReplication
+		prog.SchemaChangeBarrier = hlc.Timestamp{}
+		prog.PendingSchemaChanges = nil
+		prog.UnsupportedSchemaChange = ""
+		ju.UpdateProgress(md.Progress)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if reason := progress.UnsupportedSchemaChange; reason != "" {
+		return jobs.MarkPauseRequestError(errors.Newf(
+			"unsupported schema change of source tables at %s: %s; apply it to the destination "+
+				"tables before resuming the job", barrier, reason))
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package logical
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/repstream/streampb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanSourceSchemaChange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	k := descpb.ColumnDescriptor{ID: 1, Name: "k", Type: types.Int}
+	v := descpb.ColumnDescriptor{ID: 2, Name: "v", Type: types.String, Nullable: true}
+	w := descpb.ColumnDescriptor{ID: 3, Name: "w", Type: types.Int, Nullable: true}
+	primaryIndex := descpb.IndexDescriptor{
+		ID:                  1,
+		Name:                "t_pkey",
+		Unique:              true,
+		KeyColumnIDs:        []descpb.ColumnID{1},
+		KeyColumnNames:      []string{"k"},
+		KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC},
+		EncodingType:        catenumpb.PrimaryIndexEncoding,
+	}
+	makeDesc := func(cols []descpb.ColumnDescriptor, indexes ...descpb.IndexDescriptor) catalog.TableDescriptor {
+		return tabledesc.NewBuilder(&descpb.TableDescriptor{
+			ID:           104,
+			Name:         "t",
+			Columns:      cols,
+			PrimaryIndex: primaryIndex,
+			Indexes:      indexes,
+		}).BuildImmutableTable()
+	}
+	dstName := tree.MakeTableNameWithSchema("db", "public", "t")
+	base := makeDesc([]descpb.ColumnDescriptor{k, v})
+
+	t.Run("add column and index", func(t *testing.T) {
+		cur := makeDesc([]descpb.ColumnDescriptor{k, v, w}, descpb.IndexDescriptor{
+			ID:                  2,
+			Name:                "t_v_idx",
+			KeyColumnIDs:        []descpb.ColumnID{2},
+			KeyColumnNames:      []string{"v"},
+			KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_DESC},
+			StoreColumnIDs:      []descpb.ColumnID{3},
+			StoreColumnNames:    []string{"w"},
+		})
+		stmts, err := planSourceSchemaChange(base, cur, &dstName)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"ALTER TABLE db.public.t ADD COLUMN IF NOT EXISTS w INT8",
+			"CREATE INDEX IF NOT EXISTS t_v_idx ON db.public.t (v DESC) STORING (w)",
+		}, stmts)
+	})
+
+	t.Run("drop column", func(t *testing.T) {
+		stmts, err := planSourceSchemaChange(base, makeDesc([]descpb.ColumnDescriptor{k}), &dstName)
+		require.NoError(t, err)
+		require.Equal(t, []string{"ALTER TABLE db.public.t DROP COLUMN IF EXISTS v"}, stmts)
+	})
+
+	t.Run("unaffected", func(t *testing.T) {
+		stmts, err := planSourceSchemaChange(base, makeDesc([]descpb.ColumnDescriptor{k, v}), &dstName)
+		require.NoError(t, err)
+		require.Empty(t, stmts)
+	})
+
+	t.Run("add non-nullable column", func(t *testing.T) {
+		notNull := w
+		notNull.Nullable = false
+		_, err := planSourceSchemaChange(base, makeDesc([]descpb.ColumnDescriptor{k, v, notNull}), &dstName)
+		require.ErrorContains(t, err, `column "w" added to source table "t" must be a visible nullable column`)
+	})
+
+	t.Run("alter column type", func(t *testing.T) {
+		altered := v
+		altered.Type = types.Bytes
+		_, err := planSourceSchemaChange(base, makeDesc([]descpb.ColumnDescriptor{k, altered}), &dstName)
+		require.ErrorContains(t, err, `column "v" of source table "t" was altered`)
+	})
+
+	t.Run("add unique index", func(t *testing.T) {
+		cur := makeDesc([]descpb.ColumnDescriptor{k, v}, descpb.IndexDescriptor{
+			ID:                  2,
+			Name:                "t_v_key",
+			Unique:              true,
+			KeyColumnIDs:        []descpb.ColumnID{2},
+			KeyColumnNames:      []string{"v"},
+			KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC},
+		})
+		_, err := planSourceSchemaChange(base, cur, &dstName)
+		require.ErrorContains(t, err, `index "t_v_key" added to source table "t" must be a non-unique`)
+	})
+}
+
+func TestSourceSchemaChangeDetector(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const tableID = 104
+	k := descpb.ColumnDescriptor{ID: 1, Name: "k", Type: types.Int}
+	v := descpb.ColumnDescriptor{ID: 2, Name: "v", Type: types.String, Nullable: true}
+	idx := descpb.IndexDescriptor{
+		ID:                  2,
+		Name:                "t_v_idx",
+		KeyColumnIDs:        []descpb.ColumnID{2},
+		KeyColumnNames:      []string{"v"},
+		KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC},
+	}
+	makeDesc := func(version descpb.DescriptorVersion) *descpb.TableDescriptor {
+		return &descpb.TableDescriptor{
+			ID:      tableID,
+			Name:    "t",
+			Version: version,
+			Columns: []descpb.ColumnDescriptor{k, v},
+			PrimaryIndex: descpb.IndexDescriptor{
+				ID:                  1,
+				Name:                "t_pkey",
+				Unique:              true,
+				KeyColumnIDs:        []descpb.ColumnID{1},
+				KeyColumnNames:      []string{"k"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC},
+				EncodingType:        catenumpb.PrimaryIndexEncoding,
+			},
+		}
+	}
+	ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+	descKV := func(desc *descpb.TableDescriptor, wallTime int64) streampb.StreamEvent_KV {
+		kv := roachpb.KeyValue{Key: keys.SystemSQLCodec.DescMetadataKey(tableID)}
+		require.NoError(t, kv.Value.SetProto(tabledesc.NewBuilder(desc).BuildImmutable().DescriptorProto()))
+		kv.Value.Timestamp = ts(wallTime)
+		return streampb.StreamEvent_KV{KeyValue: kv}
+	}
+	rowKV := func(pk int64, wallTime int64) streampb.StreamEvent_KV {
+		kv := roachpb.KeyValue{Key: encoding.EncodeVarintAscending(keys.SystemSQLCodec.IndexPrefix(tableID, 1), pk)}
+		kv.Value.SetString("v")
+		kv.Value.Timestamp = ts(wallTime)
+		return streampb.StreamEvent_KV{KeyValue: kv}
+	}
+	timestamps := func(kvs []streampb.StreamEvent_KV) []hlc.Timestamp {
+		var res []hlc.Timestamp
+		for _, kv := range kvs {
+			res = append(res, kv.KeyValue.Value.Timestamp)
+		}
+		return res
+	}
+
+	tableSpan := keys.SystemSQLCodec.TableSpan(tableID)
+	descKey := keys.SystemSQLCodec.DescMetadataKey(tableID)
+	descSpan := roachpb.Span{Key: descKey, EndKey: descKey.PrefixEnd()}
+	spec := execinfrapb.LogicalReplicationWriterSpec{
+		PreviousReplicatedTimestamp: ts(10),
+		PartitionSpec: execinfrapb.StreamIngestionPartitionSpec{
+			Spans: []roachpb.Span{tableSpan, descSpan},
+		},
+	}
+	configByTable := map[descpb.ID]sqlProcessorTableConfig{
+		200: {srcDesc: tabledesc.NewBuilder(makeDesc(1)).BuildImmutableTable()},
+	}
+	destTableBySrcID := map[descpb.ID]dstTableMetadata{
+		tableID: {database: "db", schema: "public", table: "t", tableID: 200},
+	}
+
+	t.Run("no descriptor spans", func(t *testing.T) {
+		noDescs := spec
+		noDescs.PartitionSpec.Spans = []roachpb.Span{tableSpan}
+		d, err := newSourceSchemaChangeDetector(noDescs, configByTable, destTableBySrcID)
+		require.NoError(t, err)
+		require.Nil(t, d)
+	})
+
+	d, err := newSourceSchemaChangeDetector(spec, configByTable, destTableBySrcID)
+	require.NoError(t, err)
+	require.NotNil(t, d)
+	defer d.frontier.Release()
+
+	// The index is added in an intermediate version, which does not affect the
+	// destination table, and becomes public in the next one. Rows are only
+	// ready once the descriptors are resolved past them, regardless of the order
+	// in which they are received.
+	adding := makeDesc(2)
+	adding.Mutations = []descpb.DescriptorMutation{{
+		Descriptor_: &descpb.DescriptorMutation_Index{Index: &idx},
+		State:       descpb.DescriptorMutation_WRITE_ONLY,
+		Direction:   descpb.DescriptorMutation_ADD,
+	}}
+	added := makeDesc(3)
+	added.Indexes = []descpb.IndexDescriptor{idx}
+
+	ready, err := d.filter([]streampb.StreamEvent_KV{
+		rowKV(1, 5), rowKV(2, 20), descKV(adding, 12), rowKV(3, 14), descKV(added, 15),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []hlc.Timestamp{ts(5)}, timestamps(ready))
+	require.Equal(t, ts(15), d.barrier)
+	_, ok := d.maybeReport()
+	require.False(t, ok, "the barrier is reported before the descriptors are resolved past it")
+
+	// Resolving the table span does not release any rows.
+	ready, err = d.forward([]jobspb.ResolvedSpan{{Span: tableSpan, Timestamp: ts(30)}})
+	require.NoError(t, err)
+	require.Empty(t, ready)
+	require.Equal(t, ts(10), d.resolved())
+
+	ready, err = d.forward([]jobspb.ResolvedSpan{{Span: descSpan, Timestamp: ts(16)}})
+	require.NoError(t, err)
+	require.Equal(t, []hlc.Timestamp{ts(14)}, timestamps(ready))
+	require.Equal(t, []hlc.Timestamp{ts(20)}, timestamps(d.pending))
+	barrier, ok := d.maybeReport()
+	require.True(t, ok)
+	require.Equal(t, ts(15), barrier)
+	_, ok = d.maybeReport()
+	require.False(t, ok, "the barrier is only reported once")
+
+	// The writer does not apply the rows after the barrier.
+	require.Equal(t, []hlc.Timestamp{ts(14)},
+		timestamps(filterAfterSchemaChangeBarrier([]streampb.StreamEvent_KV{rowKV(3, 14), rowKV(2, 20)}, barrier)))
+
+	// Once the barrier is recorded in the job, the restarted writer ignores the
+	// schema change at the barrier.
+	restarted := spec
+	restarted.SchemaChangeBarrier = barrier
+	d2, err := newSourceSchemaChangeDetector(restarted, configByTable, destTableBySrcID)
+	require.NoError(t, err)
+	defer d2.frontier.Release()
+	_, err = d2.filter([]streampb.StreamEvent_KV{descKV(adding, 12), descKV(added, 15)})
+	require.NoError(t, err)
+	_, err = d2.forward([]jobspb.ResolvedSpan{{Span: descSpan, Timestamp: ts(16)}})
+	require.NoError(t, err)
+	_, ok = d2.maybeReport()
+	require.False(t, ok)
+	require.Equal(t, barrier, d2.barrier)
+}
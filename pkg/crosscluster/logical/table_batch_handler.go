@@ -83,7 +83,9 @@ func tableHandlerSessionSettings(sd *sessiondata.SessionData) *sessiondata.Sessi
 	// TODO(jeffswenson): enable swap mutation once update swap merges
 	//sd.UseSwapMutations = true
 	sd.BufferedWritesEnabled = false
-	sd.OriginIDForLogicalDataReplication = 1
+	if sd.OriginIDForLogicalDataReplication == 0 {
+		sd.OriginIDForLogicalDataReplication = defaultOriginID
+	}
 	return sd
 }
 
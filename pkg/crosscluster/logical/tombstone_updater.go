@@ -10,6 +10,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -32,6 +33,9 @@ type tombstoneUpdater struct {
 	settings *cluster.Settings
 	descID   descpb.ID
 
+	// writeOptions tag every tombstone with the origin of the session.
+	writeOptions *kvpb.WriteOptions
+
 	// leased holds fields whose lifetimes are tied to a leased descriptor.
 	leased struct {
 		// descriptor is a leased descriptor. Callers should use getDeleter to
@@ -70,6 +74,9 @@ func newTombstoneUpdater(
 		descID:   descID,
 		sd:       sd,
 		settings: settings,
+		writeOptions: replicationWriteOptions(
+			sd.OriginIDForLogicalDataReplication, sd.LocalOriginIDForLogicalDataReplication,
+		),
 	}
 }
 
@@ -97,7 +104,7 @@ func (tu *tombstoneUpdater) updateTombstone(
 			// If updateTombstone is called in a transaction, create and run a batch
 			// in the transaction.
 			batch := txn.KV().NewBatch()
-			batch.Header.WriteOptions = originID1Options
+			batch.Header.WriteOptions = tu.writeOptions
 			if err := tu.addToBatch(ctx, txn.KV(), batch, mvccTimestamp, afterRow); err != nil {
 				return err
 			}
@@ -107,7 +114,7 @@ func (tu *tombstoneUpdater) updateTombstone(
 		// 1pc transaction.
 		return tu.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
 			batch := txn.NewBatch()
-			batch.Header.WriteOptions = originID1Options
+			batch.Header.WriteOptions = tu.writeOptions
 			if err := tu.addToBatch(ctx, txn, batch, mvccTimestamp, afterRow); err != nil {
 				return err
 			}
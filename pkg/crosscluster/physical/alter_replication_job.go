@@ -606,6 +606,9 @@ func alterTenantJobCutover(
 				cutoverTime, record.Timestamp)
 		}
 	}
+	if err := checkCascadingCutoverTime(ctx, txn, jobRegistry, ptp, tenInfo, cutoverTime); err != nil {
+		return hlc.Timestamp{}, err
+	}
 	if err := applyCutoverTime(ctx, job, txn, cutoverTime, replicatedTimeAtCutover); err != nil {
 		return hlc.Timestamp{}, err
 	}
@@ -613,6 +616,59 @@ func alterTenantJobCutover(
 	return cutoverTime, nil
 }
 
+// checkCascadingCutoverTime checks that cutting over a standby tenant that
+// serves as the source of cascading replication streams does not revert data
+// that a downstream standby has already reported as replicated. The protected
+// timestamp of each cascading producer job tracks the replicated time of its
+// consumer, and is never above this tenant's replicated time, so cutting over
+// to LATEST is always safe.
+func checkCascadingCutoverTime(
+	ctx context.Context,
+	txn isql.Txn,
+	jobRegistry *jobs.Registry,
+	ptp protectedts.Storage,
+	tenInfo *mtinfopb.TenantInfo,
+	cutoverTime hlc.Timestamp,
+) error {
+	for _, id := range tenInfo.PhysicalReplicationProducerJobIDs {
+		j, err := jobRegistry.LoadJobWithTxn(ctx, id, txn)
+		if err != nil {
+			if jobs.HasJobNotFoundError(err) {
+				continue
+			}
+			return err
+		}
+		details, ok := j.Details().(jobspb.StreamReplicationDetails)
+		if !ok || details.StandbyIngestionJobID != tenInfo.PhysicalReplicationConsumerJobID {
+			continue
+		}
+		if j.State() != jobs.StateRunning && j.State() != jobs.StatePaused {
+			continue
+		}
+		// A downstream standby that has already completed its own cutover no
+		// longer depends on this tenant's history.
+		progress := j.Progress()
+		if progress.GetStreamReplication().StreamIngestionStatus != jobspb.StreamReplicationProgress_NOT_FINISHED {
+			continue
+		}
+		record, err := ptp.GetRecord(ctx, details.ProtectedTimestampRecordID)
+		if err != nil {
+			if errors.Is(err, protectedts.ErrNotExists) {
+				continue
+			}
+			return err
+		}
+		if cutoverTime.Less(record.Timestamp) {
+			return errors.WithHintf(
+				errors.Newf("cutover time %s is before the time %s already replicated from tenant %q by cascading replication stream %d",
+					cutoverTime, record.Timestamp, tenInfo.Name, id),
+				"complete replication on the downstream virtual cluster first, or choose a cutover time at or after %s",
+				record.Timestamp)
+		}
+	}
+	return nil
+}
+
 // applyCutoverTime modifies the consumer job record with a cutover time and
 // unpauses the job if necessary.
 func applyCutoverTime(
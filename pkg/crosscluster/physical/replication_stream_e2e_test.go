@@ -626,6 +626,87 @@ func TestTenantStreamingCutoverOnSourceFailure(t *testing.T) {
 	jobutils.WaitForJobToSucceed(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
 }
 
+// TestTenantStreamingCascading tests a chain of standbys A -> B -> C, in which
+// the standby B is itself the source of the replication stream into C.
+func TestTenantStreamingCascading(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	skip.UnderRace(t, "multiple replication streams are too slow under race")
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	args.MultitenantSingleClusterNumNodes = 1
+	args.NoMetamorphicExternalConnection = true
+	c, cleanup := replicationtestutils.CreateMultiTenantStreamingCluster(ctx, t, args)
+	defer cleanup()
+
+	replicatedTime := func(ingestionJobID int) hlc.Timestamp {
+		progress := jobutils.GetJobProgress(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+		return replicationutils.ReplicatedTimeFromProgress(progress)
+	}
+
+	producerAB, ingestionAB := c.StartStreamReplication(ctx)
+	jobutils.WaitForJobToRun(c.T, c.SrcSysSQL, jobspb.JobID(producerAB))
+	jobutils.WaitForJobToRun(c.T, c.DestSysSQL, jobspb.JobID(ingestionAB))
+	c.WaitUntilStartTimeReached(jobspb.JobID(ingestionAB))
+	beforeCascade := replicatedTime(ingestionAB)
+
+	const tertiary = roachpb.TenantName("tertiary")
+	c.DestSysSQL.Exec(t, fmt.Sprintf("CREATE TENANT %s FROM REPLICATION OF %s ON '%s'",
+		tertiary, args.DestTenantName, c.SrcURL.String()))
+	producerBC, ingestionBC := replicationtestutils.GetStreamJobIds(t, ctx, c.DestSysSQL, tertiary)
+	jobutils.WaitForJobToRun(c.T, c.DestSysSQL, jobspb.JobID(producerBC))
+	jobutils.WaitForJobToRun(c.T, c.DestSysSQL, jobspb.JobID(ingestionBC))
+	replicationtestutils.WaitUntilStartTimeReached(t, c.DestSysSQL, jobspb.JobID(ingestionBC))
+	c.WaitUntilReplicatedTime(c.SrcSysServer.Clock().Now(), jobspb.JobID(ingestionAB))
+
+	// With B paused, C catches up to B's replicated time but never advances
+	// past it, even as new writes arrive in A.
+	c.DestSysSQL.Exec(t, fmt.Sprintf("PAUSE JOB %d", ingestionAB))
+	jobutils.WaitForJobToPause(t, c.DestSysSQL, jobspb.JobID(ingestionAB))
+	pausedAt := replicatedTime(ingestionAB)
+	c.SrcTenantSQL.Exec(t, "INSERT INTO d.t2 VALUES (3)")
+	testutils.SucceedsSoon(t, func() error {
+		if rt := replicatedTime(ingestionBC); rt.Less(pausedAt) {
+			return errors.Newf("cascading replicated time %s has not reached %s", rt, pausedAt)
+		}
+		return nil
+	})
+	for i := 0; i < 10; i++ {
+		c.SrcTenantSQL.Exec(t, "UPSERT INTO d.t2 VALUES (3)")
+		require.True(t, replicatedTime(ingestionBC).LessEq(pausedAt),
+			"cascading standby advanced past the replicated time of its source standby")
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// Cutting B over to a time before the time already replicated into C would
+	// revert data that C reported as replicated, so it is rejected.
+	testutils.SucceedsSoon(t, func() error {
+		pts := replicationtestutils.TestingGetPTSFromReplicationJob(
+			t, ctx, c.DestSysSQL, c.DestSysServer, jobspb.JobID(producerBC))
+		if pts.LessEq(beforeCascade) {
+			return errors.Newf("cascading producer protected timestamp %s has not advanced past %s", pts, beforeCascade)
+		}
+		return nil
+	})
+	c.DestSysSQL.ExpectErr(t, "is before the time .* already replicated from tenant",
+		`ALTER TENANT $1 COMPLETE REPLICATION TO SYSTEM TIME $2::string`,
+		args.DestTenantName, beforeCascade.AsOfSystemTime())
+
+	// Cutting B over to its latest replicated time succeeds and stops the
+	// cascading stream, leaving C at or below B's cutover time.
+	c.DestSysSQL.Exec(t, fmt.Sprintf("RESUME JOB %d", ingestionAB))
+	c.WaitUntilReplicatedTime(c.SrcSysServer.Clock().Now(), jobspb.JobID(ingestionAB))
+	var cutoverStr string
+	c.DestSysSQL.QueryRow(t, `ALTER TENANT $1 COMPLETE REPLICATION TO LATEST`,
+		args.DestTenantName).Scan(&cutoverStr)
+	cutoverTime := replicationtestutils.DecimalTimeToHLC(t, cutoverStr)
+	jobutils.WaitForJobToSucceed(t, c.DestSysSQL, jobspb.JobID(ingestionAB))
+	jobutils.WaitForJobToFail(t, c.DestSysSQL, jobspb.JobID(producerBC))
+	require.True(t, replicatedTime(ingestionBC).LessEq(cutoverTime),
+		"cascading standby advanced past the cutover time of its source standby")
+}
+
 func TestTenantStreamingImport(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
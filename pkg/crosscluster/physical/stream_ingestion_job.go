@@ -129,6 +129,9 @@ func completeIngestion(
 	cutoverTimestamp hlc.Timestamp,
 ) error {
 	details := ingestionJob.Details().(jobspb.StreamIngestionDetails)
+	if err := stopCascadingProducerJobs(ctx, execCtx.ExecCfg(), ingestionJob.ID(), details.DestinationTenantID); err != nil {
+		return err
+	}
 	log.Dev.Infof(ctx, "activating destination tenant %d", details.DestinationTenantID)
 	if err := activateTenant(ctx, execCtx, details, cutoverTimestamp); err != nil {
 		return err
@@ -183,6 +186,50 @@ func completeProducerJob(
 	}
 }
 
+// stopCascadingProducerJobs fails the producer jobs that stream the destination
+// tenant to a cascading standby. Cutting over reverts the tenant's history
+// above the cutover time, which a downstream standby that followed that
+// history cannot observe consistently. Failing the producer jobs stops those
+// streams, leaving each downstream standby at its last replicated time, which
+// the cutover time was validated to be at or after.
+func stopCascadingProducerJobs(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	ingestionJobID jobspb.JobID,
+	tenantID roachpb.TenantID,
+) error {
+	return execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		info, err := sql.GetTenantRecordByID(ctx, txn, tenantID, execCfg.Settings)
+		if err != nil {
+			return err
+		}
+		for _, producerJobID := range info.PhysicalReplicationProducerJobIDs {
+			if err := execCfg.JobRegistry.UpdateJobWithTxn(ctx, producerJobID, txn,
+				func(txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater) error {
+					if md.Payload.GetStreamReplication().StandbyIngestionJobID != ingestionJobID {
+						return nil
+					}
+					progress := md.Progress.GetStreamReplication()
+					if progress.StreamIngestionStatus != jobspb.StreamReplicationProgress_NOT_FINISHED {
+						return nil
+					}
+					log.Dev.Infof(ctx, "stopping cascading producer job %d", producerJobID)
+					progress.StreamIngestionStatus = jobspb.StreamReplicationProgress_FINISHED_UNSUCCESSFULLY
+					md.Progress.StatusMessage = "canceling this producer job as its source standby tenant " +
+						"completed replication"
+					ju.UpdateProgress(md.Progress)
+					return nil
+				}); err != nil {
+				if jobs.HasJobNotFoundError(err) {
+					continue
+				}
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // startPostCutoverRetentionJob begins a dummy producer job on the newly cutover
 // to tenant. This producer job will lay PTS over the whole tenant, enabling a
 // fast failback to the original source cluster.
@@ -0,0 +1,150 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package producer
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/crosscluster/replicationutils"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/multitenant/mtinfopb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// A standby tenant, i.e. one that is itself being populated by a physical
+// replication ingestion job, may serve as the source of another replication
+// stream. This allows a chain of standbys (primary -> standby -> tertiary)
+// without placing additional load on the primary.
+//
+// Data in a standby tenant is only guaranteed to be consistent at or below the
+// replicated time of its ingestion job: keys above that time may still be
+// missing writes that the ingestion job has not yet flushed. A producer reading
+// from a standby therefore never reports a span as resolved beyond the
+// standby's replicated time, which in turn guarantees that the replicated time
+// of the cascading standby never exceeds the replicated time of the standby it
+// follows.
+
+// loadStandbyReplicatedTime returns the replicated time of the ingestion job
+// populating the given standby tenant.
+func loadStandbyReplicatedTime(
+	ctx context.Context, registry *jobs.Registry, txn isql.Txn, tenantRecord *mtinfopb.TenantInfo,
+) (hlc.Timestamp, error) {
+	ingestionJob, err := registry.LoadJobWithTxn(ctx, tenantRecord.PhysicalReplicationConsumerJobID, txn)
+	if err != nil {
+		return hlc.Timestamp{}, err
+	}
+	progress := ingestionJob.Progress()
+	if _, ok := progress.Details.(*jobspb.Progress_StreamIngest); !ok {
+		return hlc.Timestamp{}, errors.AssertionFailedf(
+			"job %d populating tenant %q is not a stream ingestion job", ingestionJob.ID(), tenantRecord.Name)
+	}
+	replicatedTime := replicationutils.ReplicatedTimeFromProgress(&progress)
+	if replicatedTime.IsEmpty() {
+		return hlc.Timestamp{}, errors.Newf(
+			"cannot replicate from standby tenant %q: its replication job %d has not yet replicated a consistent time",
+			tenantRecord.Name, ingestionJob.ID())
+	}
+	return replicatedTime, nil
+}
+
+// standbySample records the replicated time of a standby's ingestion job
+// together with the local clock time at which it was observed.
+type standbySample struct {
+	observedAt     hlc.Timestamp
+	replicatedTime hlc.Timestamp
+}
+
+// standbyFrontier tracks the replicated time of the ingestion job populating
+// the standby tenant served by an eventStream.
+//
+// The replicated time read at local time L covers ingestion writes that were
+// all applied before L. Rangefeed checkpoints are in terms of the local clock,
+// so once a span's rangefeed frontier has reached L, every event covered by
+// the replicated time has been emitted for that span and the span may be
+// reported as resolved at that replicated time.
+type standbyFrontier struct {
+	jobID        jobspb.JobID
+	pollInterval time.Duration
+
+	lastPoll time.Time
+	samples  []standbySample
+}
+
+// maybeRefresh loads the replicated time of the standby's ingestion job if it
+// has not been loaded within the poll interval. Errors are logged rather than
+// returned: a stale sample only delays checkpoints.
+func (f *standbyFrontier) maybeRefresh(ctx context.Context, db isql.DB, clock *hlc.Clock) {
+	if timeutil.Since(f.lastPoll) < f.pollInterval {
+		return
+	}
+	f.lastPoll = timeutil.Now()
+	progress, err := jobs.LoadJobProgress(ctx, db, f.jobID)
+	if err != nil {
+		log.Dev.Warningf(ctx, "failed to load progress of standby ingestion job %d: %v", f.jobID, err)
+		return
+	}
+	if progress == nil {
+		return
+	}
+	if _, ok := progress.Details.(*jobspb.Progress_StreamIngest); !ok {
+		return
+	}
+	f.addSample(standbySample{
+		observedAt:     clock.Now(),
+		replicatedTime: replicationutils.ReplicatedTimeFromProgress(progress),
+	})
+}
+
+func (f *standbyFrontier) addSample(sample standbySample) {
+	if n := len(f.samples); n > 0 && sample.replicatedTime.LessEq(f.samples[n-1].replicatedTime) {
+		return
+	}
+	f.samples = append(f.samples, sample)
+}
+
+// capResolvedSpans rewrites the rangefeed resolved spans so that no span is
+// reported as resolved beyond the standby's replicated time, and discards
+// samples that are no longer needed.
+func (f *standbyFrontier) capResolvedSpans(spans []jobspb.ResolvedSpan) {
+	minFrontier := hlc.MaxTimestamp
+	for i := range spans {
+		minFrontier.Backward(spans[i].Timestamp)
+		spans[i].Timestamp = capStandbyTimestamp(spans[i].Timestamp, f.samples)
+	}
+	// Every span has advanced past the observation time of the newest sample
+	// at or below minFrontier, so older samples will never be used again.
+	if idx := latestSampleAt(f.samples, minFrontier); idx > 0 {
+		f.samples = append(f.samples[:0], f.samples[idx:]...)
+	}
+}
+
+// capStandbyTimestamp returns the timestamp at which a span whose rangefeed
+// frontier is at ts may be reported as resolved. It returns an empty
+// timestamp if no replicated time was observed at or below ts.
+func capStandbyTimestamp(ts hlc.Timestamp, samples []standbySample) hlc.Timestamp {
+	idx := latestSampleAt(samples, ts)
+	if idx < 0 {
+		return hlc.Timestamp{}
+	}
+	capped := samples[idx].replicatedTime
+	capped.Backward(ts)
+	return capped
+}
+
+// latestSampleAt returns the index of the newest sample observed at or below
+// ts, or -1 if there is none.
+func latestSampleAt(samples []standbySample, ts hlc.Timestamp) int {
+	return sort.Search(len(samples), func(i int) bool {
+		return ts.Less(samples[i].observedAt)
+	}) - 1
+}
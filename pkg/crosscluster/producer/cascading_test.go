@@ -0,0 +1,69 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package producer
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandbyFrontierCapsResolvedSpans(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ts := func(wall int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wall} }
+	spanA := roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("b")}
+	spanB := roachpb.Span{Key: roachpb.Key("b"), EndKey: roachpb.Key("c")}
+	resolved := func(a, b int64) []jobspb.ResolvedSpan {
+		return []jobspb.ResolvedSpan{{Span: spanA, Timestamp: ts(a)}, {Span: spanB, Timestamp: ts(b)}}
+	}
+	timestamps := func(spans []jobspb.ResolvedSpan) []hlc.Timestamp {
+		var out []hlc.Timestamp
+		for _, sp := range spans {
+			out = append(out, sp.Timestamp)
+		}
+		return out
+	}
+
+	f := &standbyFrontier{}
+
+	// Nothing may be reported as resolved before the standby's replicated time
+	// has been observed.
+	spans := resolved(100, 100)
+	f.capResolvedSpans(spans)
+	require.Equal(t, []hlc.Timestamp{{}, {}}, timestamps(spans))
+
+	f.addSample(standbySample{observedAt: ts(100), replicatedTime: ts(50)})
+	f.addSample(standbySample{observedAt: ts(200), replicatedTime: ts(150)})
+	// A sample that does not advance the replicated time is ignored.
+	f.addSample(standbySample{observedAt: ts(250), replicatedTime: ts(150)})
+	require.Len(t, f.samples, 2)
+
+	// Each span is capped at the replicated time observed before its rangefeed
+	// frontier, never exceeding the frontier itself.
+	spans = resolved(99, 120)
+	f.capResolvedSpans(spans)
+	require.Equal(t, []hlc.Timestamp{{}, ts(50)}, timestamps(spans))
+	require.Len(t, f.samples, 2)
+
+	spans = resolved(150, 300)
+	f.capResolvedSpans(spans)
+	require.Equal(t, []hlc.Timestamp{ts(50), ts(150)}, timestamps(spans))
+	require.Len(t, f.samples, 2)
+
+	// Once every span has passed the second observation, the first sample is
+	// discarded.
+	spans = resolved(210, 300)
+	f.capResolvedSpans(spans)
+	require.Equal(t, []hlc.Timestamp{ts(150), ts(150)}, timestamps(spans))
+	require.Equal(t, []standbySample{{observedAt: ts(200), replicatedTime: ts(150)}}, f.samples)
+}
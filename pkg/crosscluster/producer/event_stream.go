@@ -65,6 +65,10 @@ type eventStream struct {
 	seqNum uint64
 	debug  streampb.DebugProducerStatusHolder
 
+	// standby is set when the source tenant is itself a standby, in which case
+	// checkpoints are capped at the replicated time of its ingestion job.
+	standby *standbyFrontier
+
 	consumerReady atomic.Bool
 }
 
@@ -336,6 +340,10 @@ func (s *eventStream) sendCheckpoint(ctx context.Context, frontier rangefeed.Vis
 		spans = append(spans, jobspb.ResolvedSpan{Span: sp, Timestamp: ts})
 	}
 	s.lastCheckpointLen = len(spans)
+	if s.standby != nil {
+		s.standby.maybeRefresh(ctx, s.execCfg.InternalDB, s.execCfg.Clock)
+		s.standby.capResolvedSpans(spans)
+	}
 
 	s.seqNum++
 	err := s.sendFlush(ctx, &streampb.StreamEvent{StreamSeq: s.seqNum, Checkpoint: &streampb.StreamEvent_StreamCheckpoint{
@@ -481,6 +489,12 @@ func (s *eventStream) validateProducerJobAndSpec(ctx context.Context) (roachpb.T
 			}
 		}
 	}
+	if standbyJobID := sp.StreamReplication.StandbyIngestionJobID; standbyJobID != 0 {
+		s.standby = &standbyFrontier{
+			jobID:        standbyJobID,
+			pollInterval: s.spec.Config.MinCheckpointFrequency,
+		}
+	}
 	return sourceTenantID, nil
 }
 
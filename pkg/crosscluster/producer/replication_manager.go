@@ -287,6 +287,18 @@ func (r *replicationStreamManagerImpl) PlanLogicalReplication(
 	if err != nil {
 		return nil, err
 	}
+	if req.IncludeDescriptorSpans {
+		// Every partition streams the descriptors so that each consumer can hold
+		// back the rows written after a schema change until it has seen it.
+		descSpans := make([]roachpb.Span, 0, len(req.TableIDs))
+		for _, id := range req.TableIDs {
+			key := r.evalCtx.Codec.DescMetadataKey(uint32(id))
+			descSpans = append(descSpans, roachpb.Span{Key: key, EndKey: key.PrefixEnd()})
+		}
+		for _, partition := range spec.Partitions {
+			partition.SourcePartition.Spans = append(partition.SourcePartition.Spans, descSpans...)
+		}
+	}
 	spec.TableDescriptors = tableDescs
 	spec.TableSpans = spans
 	spec.TypeDescriptors = typeDescriptors
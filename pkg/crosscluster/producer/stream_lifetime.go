@@ -84,6 +84,19 @@ func StartReplicationProducerJob(
 		return streampb.ReplicationProducerSpec{}, errors.Errorf("kv.rangefeed.enabled must be true to start a replication job")
 	}
 
+	registry := execConfig.JobRegistry
+
+	// A standby tenant may itself serve as the source of a cascading stream, in
+	// which case the stream can only start at a time at which the standby is
+	// consistent.
+	var standbyReplicatedTime hlc.Timestamp
+	if tenantRecord.PhysicalReplicationConsumerJobID != 0 {
+		standbyReplicatedTime, err = loadStandbyReplicatedTime(ctx, registry, txn, tenantRecord)
+		if err != nil {
+			return streampb.ReplicationProducerSpec{}, err
+		}
+	}
+
 	var replicationStartTime hlc.Timestamp
 	if !req.ReplicationStartTime.IsEmpty() {
 		if tenantRecord.PreviousSourceTenant != nil {
@@ -104,16 +117,27 @@ func StartReplicationProducerJob(
 			}
 		}
 		replicationStartTime = req.ReplicationStartTime
+	} else if !standbyReplicatedTime.IsEmpty() {
+		replicationStartTime = standbyReplicatedTime
 	} else {
 		replicationStartTime = hlc.Timestamp{
 			WallTime: evalCtx.GetStmtTimestamp().UnixNano(),
 		}
 	}
+	if !standbyReplicatedTime.IsEmpty() && standbyReplicatedTime.Less(replicationStartTime) {
+		return streampb.ReplicationProducerSpec{}, errors.Errorf(
+			"cannot start replication from standby tenant %q at %s: it has only replicated up to %s",
+			tenantRecord.Name, replicationStartTime, standbyReplicatedTime)
+	}
 
-	registry := execConfig.JobRegistry
 	ptsID := uuid.MakeV4()
 
 	jr := makeProducerJobRecord(registry, tenantRecord, defaultExpirationWindow, evalCtx.SessionData().User(), ptsID, assumeSucceeded)
+	if tenantRecord.PhysicalReplicationConsumerJobID != 0 {
+		details := jr.Details.(jobspb.StreamReplicationDetails)
+		details.StandbyIngestionJobID = tenantRecord.PhysicalReplicationConsumerJobID
+		jr.Details = details
+	}
 	if _, err := registry.CreateAdoptableJobWithTxn(ctx, jr, jr.JobID, txn); err != nil {
 		return streampb.ReplicationProducerSpec{}, err
 	}
@@ -49,6 +49,9 @@ var (
 	_ Details = SqlActivityFlushDetails{}
 	_ Details = HotRangesLoggerDetails{}
 	_ Details = InspectDetails{}
+	_ Details = VerifyBackupDetails{}
+	_ Details = CloneTableDetails{}
+	_ Details = IncrementalViewRefreshDetails{}
 )
 
 // ProgressDetails is a marker interface for job progress details proto structs.
@@ -82,6 +85,9 @@ var (
 	_ ProgressDetails = SqlActivityFlushProgress{}
 	_ ProgressDetails = HotRangesLoggerProgress{}
 	_ ProgressDetails = InspectProgress{}
+	_ ProgressDetails = VerifyBackupProgress{}
+	_ ProgressDetails = CloneTableProgress{}
+	_ ProgressDetails = IncrementalViewRefreshProgress{}
 )
 
 // Type returns the payload's job type and panics if the type is invalid.
@@ -249,6 +255,12 @@ func DetailsType(d isPayload_Details) (Type, error) {
 		return TypeHotRangesLogger, nil
 	case *Payload_InspectDetails:
 		return TypeInspect, nil
+	case *Payload_VerifyBackupDetails:
+		return TypeVerifyBackup, nil
+	case *Payload_CloneTableDetails:
+		return TypeCloneTable, nil
+	case *Payload_IncrementalViewRefreshDetails:
+		return TypeIncrementalViewRefresh, nil
 	default:
 		return TypeUnspecified, errors.Newf("Payload.Type called on a payload with an unknown details type: %T", d)
 	}
@@ -305,6 +317,9 @@ var JobDetailsForEveryJobType = map[Type]Details{
 	TypeSQLActivityFlush:             SqlActivityFlushDetails{},
 	TypeHotRangesLogger:              HotRangesLoggerDetails{},
 	TypeInspect:                      InspectDetails{},
+	TypeVerifyBackup:                 VerifyBackupDetails{},
+	TypeCloneTable:                   CloneTableDetails{},
+	TypeIncrementalViewRefresh:       IncrementalViewRefreshDetails{},
 }
 
 // WrapProgressDetails wraps a ProgressDetails object in the protobuf wrapper
@@ -378,6 +393,12 @@ func WrapProgressDetails(details ProgressDetails) interface {
 		return &Progress_HotRangesLogger{HotRangesLogger: &d}
 	case InspectProgress:
 		return &Progress_Inspect{Inspect: &d}
+	case VerifyBackupProgress:
+		return &Progress_VerifyBackup{VerifyBackup: &d}
+	case CloneTableProgress:
+		return &Progress_CloneTable{CloneTable: &d}
+	case IncrementalViewRefreshProgress:
+		return &Progress_IncrementalViewRefresh{IncrementalViewRefresh: &d}
 	default:
 		panic(errors.AssertionFailedf("WrapProgressDetails: unknown progress type %T", d))
 	}
@@ -449,6 +470,12 @@ func (p *Payload) UnwrapDetails() Details {
 		return *d.HotRangesLoggerDetails
 	case *Payload_InspectDetails:
 		return *d.InspectDetails
+	case *Payload_VerifyBackupDetails:
+		return *d.VerifyBackupDetails
+	case *Payload_CloneTableDetails:
+		return *d.CloneTableDetails
+	case *Payload_IncrementalViewRefreshDetails:
+		return *d.IncrementalViewRefreshDetails
 	default:
 		return nil
 	}
@@ -520,6 +547,12 @@ func (p *Progress) UnwrapDetails() ProgressDetails {
 		return *d.HotRangesLogger
 	case *Progress_Inspect:
 		return d.Inspect
+	case *Progress_VerifyBackup:
+		return *d.VerifyBackup
+	case *Progress_CloneTable:
+		return *d.CloneTable
+	case *Progress_IncrementalViewRefresh:
+		return *d.IncrementalViewRefresh
 	default:
 		return nil
 	}
@@ -615,6 +648,12 @@ func WrapPayloadDetails(details Details) interface {
 		return &Payload_HotRangesLoggerDetails{HotRangesLoggerDetails: &d}
 	case InspectDetails:
 		return &Payload_InspectDetails{InspectDetails: &d}
+	case VerifyBackupDetails:
+		return &Payload_VerifyBackupDetails{VerifyBackupDetails: &d}
+	case CloneTableDetails:
+		return &Payload_CloneTableDetails{CloneTableDetails: &d}
+	case IncrementalViewRefreshDetails:
+		return &Payload_IncrementalViewRefreshDetails{IncrementalViewRefreshDetails: &d}
 	default:
 		panic(errors.AssertionFailedf("jobs.WrapPayloadDetails: unknown details type %T", d))
 	}
@@ -650,7 +689,7 @@ const (
 func (Type) SafeValue() {}
 
 // NumJobTypes is the number of jobs types.
-const NumJobTypes = 34
+const NumJobTypes = 37
 
 // ChangefeedDetailsMarshaler allows for dependency injection of
 // cloud.SanitizeExternalStorageURI to avoid the dependency from this
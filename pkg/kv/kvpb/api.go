@@ -2578,6 +2578,13 @@ func (writeOptions *WriteOptions) GetOriginID() uint32 {
 	return writeOptions.OriginID
 }
 
+func (writeOptions *WriteOptions) GetLocalOriginID() uint32 {
+	if writeOptions == nil {
+		return 0
+	}
+	return writeOptions.LocalOriginID
+}
+
 func (writeOptions *WriteOptions) GetOriginTimestamp() hlc.Timestamp {
 	if writeOptions == nil {
 		return hlc.Timestamp{}
@@ -91,6 +91,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rangeprober"
 	"github.com/cockroachdb/cockroach/pkg/sql/regions"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rolemembershipcache"
 	"github.com/cockroachdb/cockroach/pkg/sql/scheduledlogging"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scdeps"
@@ -1044,6 +1045,13 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		StatementHintsCache: hints.NewStatementHintsCache(
 			cfg.clock, cfg.rangeFeedFactory, cfg.stopper, codec, cfg.internalDB, cfg.Settings,
 		),
+		PlanBaselineEvolver: hints.NewPlanBaselineEvolver(
+			cfg.Settings, cfg.internalDB, cfg.stopper,
+		),
+		ResultCache: resultcache.New(
+			cfg.Settings, codec, cfg.rangeFeedFactory, cfg.stopper,
+			serverCacheMemoryMonitor.MakeBoundAccount(),
+		),
 		VecIndexManager:            vecIndexManager,
 		RowMetrics:                 &rowMetrics,
 		InternalRowMetrics:         &internalRowMetrics,
@@ -1089,6 +1097,8 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 	execCfg.FeatureFlagMetrics = featureflag.NewFeatureFlagMetrics()
 	cfg.registry.AddMetricStruct(execCfg.FeatureFlagMetrics)
 
+	cfg.registry.AddMetricStruct(execCfg.ResultCache.Metrics())
+
 	if gcJobTestingKnobs := cfg.TestingKnobs.GCJob; gcJobTestingKnobs != nil {
 		execCfg.GCJobTestingKnobs = gcJobTestingKnobs.(*sql.GCJobTestingKnobs)
 	} else {
@@ -0,0 +1,63 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+)
+
+// maxReoptimizations is the maximum number of times a statement can be
+// re-optimized due to cardinality misestimates. The last attempt always runs
+// without cardinality checks.
+const maxReoptimizations = 3
+
+var adaptiveReoptimizationMisestimateThreshold = settings.RegisterFloatSetting(
+	settings.ApplicationLevel,
+	"sql.optimizer.adaptive_reoptimization.misestimate_threshold",
+	"the factor by which the number of rows produced by the build side of a hash "+
+		"join must exceed the optimizer's estimate for the statement to be "+
+		"re-optimized when optimizer_use_adaptive_reoptimization is enabled",
+	1000,
+	settings.FloatWithMinimum(1),
+)
+
+var adaptiveReoptimizationMinRowCount = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.optimizer.adaptive_reoptimization.min_row_count",
+	"the minimum number of rows that the build side of a hash join must produce "+
+		"for the statement to be re-optimized when "+
+		"optimizer_use_adaptive_reoptimization is enabled",
+	10000,
+	settings.NonNegativeInt,
+)
+
+// makeCardinalityCheck returns the cardinality check for the build side of a
+// hash join with the given check ID and estimated row count. The check fails
+// once the input produces more rows than the estimate multiplied by the
+// misestimate threshold, and never before it produces the minimum row count.
+func makeCardinalityCheck(
+	sv *settings.Values, id int32, estimatedRowCount uint64,
+) execinfrapb.CardinalityCheck {
+	if id == 0 {
+		return execinfrapb.CardinalityCheck{}
+	}
+	threshold := adaptiveReoptimizationMisestimateThreshold.Get(sv)
+	maxRowCount := threshold * math.Max(float64(estimatedRowCount), 1)
+	if minRowCount := float64(adaptiveReoptimizationMinRowCount.Get(sv)); maxRowCount < minRowCount {
+		maxRowCount = minRowCount
+	}
+	if maxRowCount >= math.MaxUint64 {
+		maxRowCount = math.MaxUint64
+	}
+	return execinfrapb.CardinalityCheck{
+		ID:                id,
+		EstimatedRowCount: estimatedRowCount,
+		MaxRowCount:       uint64(maxRowCount),
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	gosql "database/sql"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAdaptiveReoptimizationResultsAlreadySent verifies that a statement that
+// fails a cardinality check is re-optimized and executed again when none of its
+// results have been sent to the client, and that it fails with an error
+// otherwise.
+func TestAdaptiveReoptimizationResultsAlreadySent(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	const query = "SELECT small.v, big.k FROM small JOIN big ON small.v = big.v"
+
+	// The push callback fails the first cardinality check of the first
+	// execution of the query once some rows have been pushed to the client.
+	var injected, executions atomic.Int64
+	srv, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+		Knobs: base.TestingKnobs{
+			SQLExecutor: &ExecutorTestingKnobs{
+				DistSQLReceiverPushCallbackFactory: func(_ context.Context, q string) func(rowenc.EncDatumRow, coldata.Batch, *execinfrapb.ProducerMetadata) (rowenc.EncDatumRow, coldata.Batch, *execinfrapb.ProducerMetadata) {
+					if q != query {
+						return nil
+					}
+					executions.Add(1)
+					var dataPushed bool
+					return func(row rowenc.EncDatumRow, batch coldata.Batch, meta *execinfrapb.ProducerMetadata) (rowenc.EncDatumRow, coldata.Batch, *execinfrapb.ProducerMetadata) {
+						if !dataPushed || injected.Load() != 0 {
+							dataPushed = dataPushed || row != nil || (batch != nil && batch.Length() > 0)
+							return row, batch, meta
+						}
+						injected.Store(1)
+						check := execinfrapb.CardinalityCheck{ID: 1, EstimatedRowCount: 1, MaxRowCount: 10}
+						return nil, nil, &execinfrapb.ProducerMetadata{
+							Err: execinfrapb.NewCardinalityMisestimateError(check, 1000 /* observedRowCount */),
+						}
+					}
+				},
+			},
+		},
+	})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+
+	r := sqlutils.MakeSQLRunner(sqlDB)
+	r.Exec(t, `CREATE TABLE small (k INT PRIMARY KEY, v INT)`)
+	r.Exec(t, `CREATE TABLE big (k INT PRIMARY KEY, v INT)`)
+	r.Exec(t, `INSERT INTO small VALUES (1, 1), (2, 2)`)
+	r.Exec(t, `INSERT INTO big SELECT i, i % 10 FROM generate_series(1, 1000) AS g(i)`)
+	// Inject statistics so that the plan for the query includes a cardinality
+	// check on the build side of the hash join.
+	r.Exec(t, `ALTER TABLE small INJECT STATISTICS '[
+		{"columns": ["v"], "created_at": "2026-01-01 00:00:00", "row_count": 2, "distinct_count": 2}
+	]'`)
+	r.Exec(t, `ALTER TABLE big INJECT STATISTICS '[
+		{"columns": ["v"], "created_at": "2026-01-01 00:00:00", "row_count": 1, "distinct_count": 1}
+	]'`)
+
+	openDB := func(resultsBufferSize string) *gosql.DB {
+		pgURL, cleanupFn := s.PGUrl(
+			t, serverutils.CertsDirPrefix(t.Name()), serverutils.User(username.RootUser),
+		)
+		t.Cleanup(cleanupFn)
+		q := pgURL.Query()
+		q.Add("results_buffer_size", resultsBufferSize)
+		pgURL.RawQuery = q.Encode()
+		db, err := gosql.Open("postgres", pgURL.String())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+		_, err = db.Exec("SET optimizer_use_adaptive_reoptimization = on")
+		require.NoError(t, err)
+		return db
+	}
+
+	countRows := func(db *gosql.DB) (int, error) {
+		rows, err := db.Query(query)
+		if err != nil {
+			return 0, err
+		}
+		defer rows.Close()
+		var n int
+		for rows.Next() {
+			n++
+		}
+		return n, rows.Err()
+	}
+
+	t.Run("results_buffered", func(t *testing.T) {
+		injected.Store(0)
+		executions.Store(0)
+
+		// With the default results buffer, no rows have been sent to the client
+		// when the check fails, so the statement is re-optimized and executed
+		// again.
+		n, err := countRows(openDB("16384"))
+		require.NoError(t, err)
+		require.Equal(t, 200, n)
+		require.Equal(t, int64(1), injected.Load())
+		require.Equal(t, int64(2), executions.Load())
+	})
+
+	t.Run("results_already_sent", func(t *testing.T) {
+		injected.Store(0)
+		executions.Store(0)
+
+		// Choose a small results_buffer_size so that rows are sent to the
+		// client before the check fails, and make sure the statement is not
+		// re-optimized.
+		_, err := countRows(openDB("4"))
+		require.Error(t, err)
+		require.ErrorContains(t, err, "cannot re-optimize since some results were already sent to the client")
+		require.Equal(t, int64(1), injected.Load())
+		require.Equal(t, int64(1), executions.Load())
+	})
+}
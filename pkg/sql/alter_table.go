@@ -868,6 +868,13 @@ func (n *alterTableNode) startExec(params runParams) error {
 		case *tree.AlterTableSetRLSMode:
 			return pgerror.New(pgcode.FeatureNotSupported,
 				"ALTER TABLE ... ROW LEVEL SECURITY is only implemented in the declarative schema changer")
+		case *tree.AlterTableAddSystemVersioning, *tree.AlterTableDropSystemVersioning:
+			if !params.p.IsActive(params.ctx, clusterversion.V26_1_SystemVersionedTables) {
+				return pgerror.New(pgcode.FeatureNotSupported,
+					"ALTER TABLE ... SYSTEM VERSIONING is not supported until the cluster is fully upgraded")
+			}
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"ALTER TABLE ... SYSTEM VERSIONING is only implemented in the declarative schema changer")
 		default:
 			return errors.AssertionFailedf("unsupported alter command: %T", cmd)
 		}
@@ -2510,7 +2517,8 @@ func extractRBRTableUsingConstraint(
 //   - The schema_locked table storage parameter is true, and this statement is
 //     cannot set schema_locked automatically via a whitelist.
 //   - The table is referenced by logical data replication jobs, and the statement
-//     is not in the allow list of LDR schema changes.
+//     is not in the allow list of LDR schema changes, unless the session allows
+//     schema changes on such tables.
 func (p *planner) checkSchemaChangeIsAllowed(
 	ctx context.Context, desc catalog.TableDescriptor, n tree.Statement,
 ) (ret error) {
@@ -2558,7 +2566,7 @@ func (p *planner) checkSchemaChangeIsAllowed(
 	if desc.IsSchemaLocked() && preventedBySchemaLocked {
 		return sqlerrors.NewSchemaChangeOnLockedTableErr(desc.GetName())
 	}
-	if len(desc.TableDesc().LDRJobIDs) > 0 {
+	if len(desc.TableDesc().LDRJobIDs) > 0 && !p.SessionData().AllowSchemaChangesOnLDRTables {
 		var virtualColNames []string
 		for _, col := range desc.NonDropColumns() {
 			if col.IsVirtual() {
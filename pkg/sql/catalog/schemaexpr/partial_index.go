@@ -110,6 +110,23 @@ func MakePartialIndexExpr(
 	return expr, nil
 }
 
+// MakeRowFilterExpr parses, resolves, and type-checks a boolean filter over the
+// given columns of a table, such as the row_filter option of a RESTORE. The
+// returned expression can be evaluated with a RowIndexedVarContainer built over
+// the same columns. It also returns the set of column IDs referenced in the
+// filter.
+func MakeRowFilterExpr(
+	ctx context.Context,
+	table catalog.TableDescriptor,
+	cols []catalog.Column,
+	filter string,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+) (tree.TypedExpr, catalog.TableColSet, error) {
+	h := makePartialIndexHelper(table, cols, evalCtx, semaCtx)
+	return h.makeBoolExpr(ctx, filter)
+}
+
 // MakePartialIndexExprs returns a map of predicate expressions for each
 // partial index in the input list of indexes, or nil if none of the indexes
 // are partial indexes. It also returns a set of all column IDs referenced in
@@ -184,7 +201,15 @@ func makePartialIndexHelper(
 func (pi partialIndexHelper) makePartialIndexExpr(
 	ctx context.Context, idx catalog.Index,
 ) (tree.TypedExpr, catalog.TableColSet, error) {
-	expr, err := parserutils.ParseExpr(idx.GetPredicate())
+	return pi.makeBoolExpr(ctx, idx.GetPredicate())
+}
+
+// makeBoolExpr turns a boolean expression over the helper's columns from a
+// string to a TypedExpr.
+func (pi partialIndexHelper) makeBoolExpr(
+	ctx context.Context, exprStr string,
+) (tree.TypedExpr, catalog.TableColSet, error) {
+	expr, err := parserutils.ParseExpr(exprStr)
 	if err != nil {
 		return nil, catalog.TableColSet{}, err
 	}
@@ -927,6 +927,11 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		return
 	}
 
+	if err := desc.validateSystemVersioning(); err != nil {
+		vea.Report(err)
+		return
+	}
+
 	if desc.IsVirtualTable() {
 		return
 	}
@@ -1438,6 +1443,27 @@ func (desc *wrapper) validateColumnFamilies(columnsByID map[descpb.ColumnID]cata
 	return nil
 }
 
+// validateSystemVersioning validates that the history table and row start
+// column of a system-versioned table are well-formed.
+func (desc *wrapper) validateSystemVersioning() error {
+	sv := desc.SystemVersioning
+	if sv == nil {
+		return nil
+	}
+	if sv.HistoryTableID == descpb.InvalidID || sv.HistoryTableID == desc.GetID() {
+		return errors.AssertionFailedf("invalid history table ID %d", sv.HistoryTableID)
+	}
+	col := catalog.FindColumnByID(desc, sv.RowStartColumnID)
+	if col == nil {
+		return errors.AssertionFailedf("row start column %d does not exist", sv.RowStartColumnID)
+	}
+	if !col.GetType().Identical(types.TimestampTZ) || col.IsNullable() {
+		return errors.AssertionFailedf(
+			"row start column %q must be a non-nullable TIMESTAMPTZ column", col.GetName())
+	}
+	return nil
+}
+
 // validateTriggers validates that triggers are well-formed.
 func (desc *wrapper) validateTriggers() error {
 	var triggerIDs intsets.Fast
@@ -198,16 +198,6 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 		return nil
 
 	case core.Windower != nil:
-		for _, wf := range core.Windower.WindowFns {
-			if wf.FilterColIdx != tree.NoColumnIdx {
-				return errWindowFunctionFilterClause
-			}
-			if wf.Func.AggregateFunc != nil {
-				if !colexecagg.IsAggOptimized(*wf.Func.AggregateFunc) {
-					return errDefaultAggregateWindowFunction
-				}
-			}
-		}
 		return nil
 
 	case core.LocalPlanNode != nil:
@@ -242,8 +232,6 @@ var (
 	errFilteringAggregation           = errors.New("filtering aggregation not supported")
 	errNonInnerHashJoinWithOnExpr     = errors.New("can't plan vectorized non-inner hash joins with ON expressions")
 	errNonInnerMergeJoinWithOnExpr    = errors.New("can't plan vectorized non-inner merge joins with ON expressions")
-	errWindowFunctionFilterClause     = errors.New("window functions with FILTER clause are not supported")
-	errDefaultAggregateWindowFunction = errors.New("default aggregate window functions not supported")
 	errStreamIngestionWrap            = errors.New("core.StreamIngestion{Data,Frontier} is not supported because of #55758")
 	// errCoreNotWorthWrapping is a generic error indicating that a processor
 	// core is not worth wrapping into a vectorized flow because this processor
@@ -922,6 +910,12 @@ func NewColOperator(
 						core.TableReader.LockingWaitPolicy == descpb.ScanLockingWaitPolicy_SKIP_LOCKED {
 						return false
 					}
+					// BERNOULLI sampling decides which rows to keep based on
+					// their keys, which the direct scans don't have access to.
+					if sample := core.TableReader.Sample; sample != nil &&
+						sample.Method == execinfrapb.TableSampleSpec_BERNOULLI {
+						return false
+					}
 					var prevRowPrefix []byte
 					for i, sp := range core.TableReader.Spans {
 						if len(sp.EndKey) == 0 {
@@ -1270,6 +1264,12 @@ func NewColOperator(
 				)
 				args.CloserRegistry.AddCloser(result.Root.(colexecop.Closer))
 			} else {
+				if check := core.HashJoiner.RightCardinalityCheck; check.Enabled() {
+					// The right input is fully consumed in order to build the hash
+					// table before the hash joiner produces any output, so a severe
+					// misestimate can be detected before any results are returned.
+					inputs[1].Root = colexecutils.NewCardinalityChecker(inputs[1].Root, check)
+				}
 				opName := redact.SafeString("hash-joiner")
 				hjArgs, hashJoinerMemMonitorName := makeNewHashJoinerArgs(
 					ctx,
@@ -1617,6 +1617,7 @@ func NewColOperator(
 					OutputColIdx:    outputColIdx,
 					PartitionColIdx: partitionColIdx,
 					PeersColIdx:     peersColIdx,
+					FilterColIdx:    int(wf.FilterColIdx),
 				}
 
 				// Some window functions always return an INT result, so we use
@@ -1736,9 +1737,11 @@ func NewColOperator(
 						var aggFnsAlloc *colexecagg.AggregateFuncsAlloc
 						if (aggType != execinfrapb.Min && aggType != execinfrapb.Max) ||
 							wf.Frame.Exclusion != execinfrapb.WindowerSpec_Frame_NO_EXCLUSION ||
+							wf.FilterColIdx != tree.NoColumnIdx ||
 							!colexecwindow.WindowFrameCanShrink(wf.Frame, &wf.Ordering) {
 							// Min and max window functions have specialized implementations
-							// when the frame can shrink and has a default exclusion clause.
+							// when the frame can shrink and has a default exclusion clause
+							// and no FILTER clause.
 							aggFnsAlloc, _, toClose, err = colexecagg.NewAggregateFuncsAlloc(
 								ctx, &aggArgs, aggregations, 1, /* initialAllocSize */
 								1 /* maxAllocSize */, colexecagg.WindowAggKind,
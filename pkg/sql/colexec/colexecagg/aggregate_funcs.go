@@ -276,9 +276,10 @@ func NewAggregateFuncsAlloc(
 		}
 	}
 	var inputArgsConverter *colconv.VecToDatumConverter
-	if len(vecIdxsToConvert) > 0 {
+	if len(vecIdxsToConvert) > 0 && aggKind != WindowAggKind {
 		// Only create the converter if we actually need to convert some vectors
-		// for the default aggregate functions.
+		// for the default aggregate functions. Default window aggregate
+		// functions convert their arguments themselves.
 		inputArgsConverter = colconv.NewVecToDatumConverter(len(args.InputTypes), vecIdxsToConvert, false /* willRelease */)
 	}
 	// allocs tracks all aggregateFuncAllocs for optimized aggregate functions
@@ -494,7 +495,10 @@ func NewAggregateFuncsAlloc(
 					len(aggFn.ColIdx), args.ConstArguments[i], args.OutputTypes[i], allocSize,
 				)
 			case WindowAggKind:
-				colexecerror.InternalError(errors.AssertionFailedf("default window aggregate not supported"))
+				funcAllocs[i] = newDefaultWindowAggAlloc(
+					ctx, args.Allocator, args.Constructors[i], args.EvalCtx,
+					len(aggFn.ColIdx), args.ConstArguments[i], args.OutputTypes[i], allocSize,
+				)
 			default:
 				colexecerror.InternalError(errors.AssertionFailedf("unexpected agg kind"))
 			}
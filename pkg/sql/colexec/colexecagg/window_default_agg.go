@@ -0,0 +1,200 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package colexecagg
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colconv"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execagg"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// defaultWindowAgg is the aggregate function used when an aggregate that
+// doesn't have an optimized implementation is executed as a window function.
+// It wraps the row-execution implementation of the aggregate.
+//
+// Unlike defaultHashAgg and defaultOrderedAgg, which rely on the aggregator to
+// convert the input batches to tree.Datums, defaultWindowAgg converts the
+// arguments itself, since the vectors given to Compute come from the buffer of
+// the window operator, and every call can refer to a different batch.
+type defaultWindowAgg struct {
+	unorderedAggregateFuncBase
+	fn              eval.AggregateFunc
+	ctx             context.Context
+	resultConverter func(tree.Datum) interface{}
+	// scratch is shared among all aggregate function instances created by the
+	// same alloc object.
+	scratch *defaultWindowAggScratch
+}
+
+type defaultWindowAggScratch struct {
+	// sel contains the indices of the tuples to convert.
+	sel []int
+	// args contains the converted values of each argument.
+	args      []tree.Datums
+	otherArgs []tree.Datum
+	da        tree.DatumAlloc
+}
+
+var _ AggregateFunc = &defaultWindowAgg{}
+
+func (a *defaultWindowAgg) Compute(
+	vecs []*coldata.Vec, inputIdxs []uint32, startIdx, endIdx int, sel []int,
+) {
+	n := endIdx - startIdx
+	s := a.scratch
+	s.sel = s.sel[:0]
+	if sel != nil {
+		s.sel = append(s.sel, sel[startIdx:endIdx]...)
+	} else {
+		for i := startIdx; i < endIdx; i++ {
+			s.sel = append(s.sel, i)
+		}
+	}
+	for j, colIdx := range inputIdxs {
+		if cap(s.args[j]) < n {
+			s.args[j] = make(tree.Datums, n)
+		} else {
+			s.args[j] = s.args[j][:n]
+		}
+		colconv.ColVecToDatumAndDeselect(s.args[j], vecs[colIdx], n, s.sel, &s.da)
+	}
+	// Note that we only need to account for the memory of the output vector
+	// (which the window operator does) and not for the intermediate results
+	// of aggregation since the aggregate function itself does the latter.
+	for i := 0; i < n; i++ {
+		// Note that the only function that takes no arguments is COUNT_ROWS, and
+		// it has an optimized implementation, so we don't need to check whether
+		// len(inputIdxs) is at least 1.
+		firstArg := s.args[0][i]
+		for j := 1; j < len(inputIdxs); j++ {
+			s.otherArgs[j-1] = s.args[j][i]
+		}
+		if err := a.fn.Add(a.ctx, firstArg, s.otherArgs...); err != nil {
+			colexecerror.ExpectedError(err)
+		}
+	}
+}
+
+func (a *defaultWindowAgg) Flush(outputIdx int) {
+	res, err := a.fn.Result()
+	if err != nil {
+		colexecerror.ExpectedError(err)
+	}
+	if res == tree.DNull {
+		a.nulls.SetNull(outputIdx)
+	} else {
+		coldata.SetValueAt(a.vec, a.resultConverter(res), outputIdx)
+	}
+}
+
+// Remove implements the slidingWindowAggregateFunc interface (see
+// window_aggregator_tmpl.go). This allows the default aggregate functions to be
+// used when the window frame only grows. For the case when the window frame can
+// shrink, the quadratic-scaling implementation is necessary.
+func (*defaultWindowAgg) Remove(
+	vecs []*coldata.Vec, inputIdxs []uint32, startIdx, endIdx int,
+) {
+	colexecerror.InternalError(
+		errors.AssertionFailedf("Remove called on defaultWindowAgg"),
+	)
+}
+
+func (a *defaultWindowAgg) Reset() {
+	a.fn.Reset(a.ctx)
+}
+
+func newDefaultWindowAggAlloc(
+	ctx context.Context,
+	allocator *colmem.Allocator,
+	constructor execagg.AggregateConstructor,
+	evalCtx *eval.Context,
+	numArguments int,
+	constArguments tree.Datums,
+	outputType *types.T,
+	allocSize int64,
+) *defaultWindowAggAlloc {
+	scratch := &defaultWindowAggScratch{args: make([]tree.Datums, numArguments)}
+	if numArguments > 1 {
+		scratch.otherArgs = make([]tree.Datum, numArguments-1)
+	}
+	return &defaultWindowAggAlloc{
+		aggAllocBase: aggAllocBase{
+			allocator: allocator,
+			allocSize: allocSize,
+		},
+		constructor:     constructor,
+		ctx:             ctx,
+		evalCtx:         evalCtx,
+		resultConverter: colconv.GetDatumToPhysicalFn(outputType),
+		scratch:         scratch,
+		arguments:       constArguments,
+	}
+}
+
+type defaultWindowAggAlloc struct {
+	aggAllocBase
+	aggFuncs []defaultWindowAgg
+
+	constructor     execagg.AggregateConstructor
+	ctx             context.Context
+	evalCtx         *eval.Context
+	resultConverter func(tree.Datum) interface{}
+	// scratch is the scratch space that is shared among all aggregate
+	// functions created by this alloc. Such sharing is acceptable since the
+	// window operators run in a single goroutine and they process functions
+	// one at a time.
+	scratch *defaultWindowAggScratch
+	// arguments is the list of constant (non-aggregated) arguments to the
+	// aggregate.
+	arguments tree.Datums
+	// returnedFns stores the references to all aggregate functions that have
+	// been returned by this alloc, since row-execution aggregate functions
+	// need to be closed.
+	returnedFns []*defaultWindowAgg
+}
+
+var _ aggregateFuncAlloc = &defaultWindowAggAlloc{}
+var _ colexecop.Closer = &defaultWindowAggAlloc{}
+
+const sizeOfDefaultWindowAgg = int64(unsafe.Sizeof(defaultWindowAgg{}))
+const defaultWindowAggSliceOverhead = int64(unsafe.Sizeof([]defaultWindowAgg{}))
+
+func (a *defaultWindowAggAlloc) newAggFunc() AggregateFunc {
+	if len(a.aggFuncs) == 0 {
+		a.allocator.AdjustMemoryUsage(defaultWindowAggSliceOverhead + sizeOfDefaultWindowAgg*a.allocSize)
+		a.aggFuncs = make([]defaultWindowAgg, a.allocSize)
+	}
+	f := &a.aggFuncs[0]
+	*f = defaultWindowAgg{
+		fn:              a.constructor(a.evalCtx, a.arguments),
+		ctx:             a.ctx,
+		resultConverter: a.resultConverter,
+		scratch:         a.scratch,
+	}
+	f.allocator = a.allocator
+	a.allocator.AdjustMemoryUsageAfterAllocation(f.fn.Size())
+	a.aggFuncs = a.aggFuncs[1:]
+	a.returnedFns = append(a.returnedFns, f)
+	return f
+}
+
+func (a *defaultWindowAggAlloc) Close(ctx context.Context) error {
+	for _, fn := range a.returnedFns {
+		fn.fn.Close(ctx)
+	}
+	a.returnedFns = nil
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package colexecutils
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+)
+
+// CardinalityChecker is a colexecop.Operator that counts the number of tuples
+// produced by its input, and panics with an
+// execinfrapb.CardinalityMisestimateError as soon as the count exceeds the
+// maximum of the given execinfrapb.CardinalityCheck. It is planned on inputs
+// that are fully consumed before their consumer produces any output (e.g. the
+// build side of a hash join), so that the statement can be re-optimized
+// without any results having been returned.
+type CardinalityChecker struct {
+	colexecop.OneInputHelper
+	colexecop.NonExplainable
+
+	check    execinfrapb.CardinalityCheck
+	rowCount uint64
+}
+
+var _ colexecop.Operator = &CardinalityChecker{}
+
+// NewCardinalityChecker creates a new CardinalityChecker.
+func NewCardinalityChecker(
+	input colexecop.Operator, check execinfrapb.CardinalityCheck,
+) *CardinalityChecker {
+	return &CardinalityChecker{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		check:          check,
+	}
+}
+
+// Next is part of colexecop.Operator interface.
+func (c *CardinalityChecker) Next() coldata.Batch {
+	batch := c.Input.Next()
+	c.rowCount += uint64(batch.Length())
+	if c.rowCount > c.check.MaxRowCount {
+		colexecerror.ExpectedError(execinfrapb.NewCardinalityMisestimateError(c.check, c.rowCount))
+	}
+	return batch
+}
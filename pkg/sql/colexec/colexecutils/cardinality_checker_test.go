@@ -0,0 +1,57 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package colexecutils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCardinalityChecker verifies that CardinalityChecker panics with a
+// CardinalityMisestimateError once its input exceeds the maximum row count.
+func TestCardinalityChecker(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	typs := []*types.T{types.Int}
+	batch := testAllocator.NewMemBatchWithMaxCapacity(typs)
+	batch.SetLength(batch.Capacity())
+	check := execinfrapb.CardinalityCheck{
+		ID:                1,
+		EstimatedRowCount: 1,
+		MaxRowCount:       uint64(2*batch.Length() + 1),
+	}
+	op := NewCardinalityChecker(colexecop.NewRepeatableBatchSource(testAllocator, batch, typs), check)
+	op.Init(ctx)
+
+	// The first two batches don't exceed the maximum.
+	for i := 0; i < 2; i++ {
+		require.NoError(t, colexecerror.CatchVectorizedRuntimeError(func() {
+			op.Next()
+		}))
+	}
+	err := colexecerror.CatchVectorizedRuntimeError(func() {
+		op.Next()
+	})
+	var misestimate *execinfrapb.CardinalityMisestimateError
+	require.True(t, errors.As(err, &misestimate))
+	require.Equal(t, check, misestimate.Misestimate.Check)
+	require.Equal(t, uint64(3*batch.Length()), misestimate.Misestimate.ObservedRowCount)
+
+	// The error survives an encoding round trip.
+	decoded := errors.DecodeError(ctx, errors.EncodeError(ctx, err))
+	require.True(t, errors.As(decoded, &misestimate))
+	require.Equal(t, check, misestimate.Misestimate.Check)
+}
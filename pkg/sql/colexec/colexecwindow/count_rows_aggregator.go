@@ -13,6 +13,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
 	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -27,7 +28,11 @@ func NewCountRowsOperator(
 	// store less columns than the queue.
 	bufferMemLimit := int64(float64(args.MemoryLimit) * 0.5)
 	mainMemLimit := args.MemoryLimit - bufferMemLimit
-	framer := newWindowFramer(args.EvalCtx, frame, ordering, args.InputTypes, args.PeersColIdx)
+	framer := maybeFilterWindowFramer(
+		newWindowFramer(args.EvalCtx, frame, ordering, args.InputTypes, args.PeersColIdx),
+		args.FilterColIdx,
+		args.BufferAllocator,
+	)
 	colsToStore := framer.getColsToStore(nil /* oldColsToStore */)
 	buffer := colexecutils.NewSpillingBuffer(
 		args.BufferAllocator, bufferMemLimit, args.QueueCfg, args.FdSemaphore,
@@ -41,6 +46,7 @@ func NewCountRowsOperator(
 		allocator:    args.MainAllocator,
 		outputColIdx: args.OutputColIdx,
 		framer:       framer,
+		filtered:     args.FilterColIdx != tree.NoColumnIdx,
 	}
 	return newBufferedWindowOperator(args, windower, types.Int, mainMemLimit)
 }
@@ -51,6 +57,13 @@ type countRowsWindowAggregator struct {
 	allocator    *colmem.Allocator
 	outputColIdx int
 	framer       windowFramer
+
+	// filtered is true if the function has a FILTER clause. In this case, the
+	// rows that pass the filter are counted incrementally in count using the
+	// sliding window intervals, so that only the rows entering or leaving the
+	// frame need to be checked against the filter.
+	filtered bool
+	count    int
 }
 
 var _ bufferedWindower = &countRowsWindowAggregator{}
@@ -58,6 +71,7 @@ var _ bufferedWindower = &countRowsWindowAggregator{}
 // transitionToProcessing implements the bufferedWindower interface.
 func (a *countRowsWindowAggregator) transitionToProcessing() {
 	a.framer.startPartition(a.Ctx, a.partitionSize, a.buffer)
+	a.count = 0
 }
 
 // startNewPartition implements the bufferedWindower interface.
@@ -93,8 +107,19 @@ func (a *countRowsWindowAggregator) processBatch(batch coldata.Batch, startIdx,
 		for i := startIdx; i < endIdx; i++ {
 			var cnt int
 			a.framer.next(a.Ctx)
-			for _, interval := range a.framer.frameIntervals() {
-				cnt += interval.end - interval.start
+			if a.filtered {
+				toAdd, toRemove := a.framer.slidingWindowIntervals()
+				for _, interval := range toRemove {
+					a.count -= interval.end - interval.start
+				}
+				for _, interval := range toAdd {
+					a.count += interval.end - interval.start
+				}
+				cnt = a.count
+			} else {
+				for _, interval := range a.framer.frameIntervals() {
+					cnt += interval.end - interval.start
+				}
 			}
 			//gcassert:bce
 			outCol[i] = int64(cnt)
@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
 	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -57,7 +58,11 @@ func NewWindowAggregatorOperator(
 	// columns than the queue.
 	bufferMemLimit := int64(float64(args.MemoryLimit) * 0.5)
 	mainMemLimit := args.MemoryLimit - bufferMemLimit
-	framer := newWindowFramer(args.EvalCtx, frame, ordering, args.InputTypes, args.PeersColIdx)
+	framer := maybeFilterWindowFramer(
+		newWindowFramer(args.EvalCtx, frame, ordering, args.InputTypes, args.PeersColIdx),
+		args.FilterColIdx,
+		args.BufferAllocator,
+	)
 	colsToStore := framer.getColsToStore(append([]int{}, argIdxs...))
 	buffer := colexecutils.NewSpillingBuffer(
 		args.BufferAllocator, bufferMemLimit, args.QueueCfg, args.FdSemaphore,
@@ -90,9 +95,11 @@ func NewWindowAggregatorOperator(
 			// In the case when the window frame for a given row does not necessarily
 			// include all rows from the previous frame, min and max require a
 			// specialized implementation that maintains a dequeue of seen values.
-			if frame.Exclusion != execinfrapb.WindowerSpec_Frame_NO_EXCLUSION {
+			if frame.Exclusion != execinfrapb.WindowerSpec_Frame_NO_EXCLUSION ||
+				args.FilterColIdx != tree.NoColumnIdx {
 				// TODO(drewk): extend the implementations to work with non-default
-				// exclusion. For now, we have to use the quadratic-time method.
+				// exclusion and the FILTER clause. For now, we have to use the
+				// quadratic-time method.
 				windower = &windowAggregator{windowAggregatorBase: base, agg: agg}
 			} else {
 				switch aggType {
@@ -125,7 +132,13 @@ func NewWindowAggregatorOperator(
 			}
 		}
 	default:
-		if slidingWindowAgg, ok := agg.(slidingWindowAggregateFunc); ok {
+		slidingWindowAgg, ok := agg.(slidingWindowAggregateFunc)
+		if ok && !colexecagg.IsAggOptimized(aggType) && WindowFrameCanShrink(frame, ordering) {
+			// The default aggregate functions can only be used in a
+			// sliding-window context if the window does not shrink.
+			ok = false
+		}
+		if ok {
 			windower = &slidingWindowAggregator{windowAggregatorBase: base, agg: slidingWindowAgg}
 		} else {
 			windower = &windowAggregator{windowAggregatorBase: base, agg: agg}
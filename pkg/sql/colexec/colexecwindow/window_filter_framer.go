@@ -0,0 +1,178 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package colexecwindow
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/memsize"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// maybeFilterWindowFramer wraps the given windowFramer so that only the rows
+// for which the boolean column at filterColIdx is true are included in the
+// window frame intervals. It is used to implement the FILTER clause of
+// aggregate window functions. If filterColIdx is tree.NoColumnIdx, the framer
+// is returned unchanged. The memory used by the intervals is accounted for by
+// the given allocator.
+func maybeFilterWindowFramer(
+	framer windowFramer, filterColIdx int, allocator *colmem.Allocator,
+) windowFramer {
+	if filterColIdx == tree.NoColumnIdx {
+		return framer
+	}
+	return &filteringWindowFramer{
+		windowFramer: framer,
+		filterColIdx: filterColIdx,
+		allocator:    allocator,
+	}
+}
+
+// windowIntervalSize is the in-memory size of a windowInterval in bytes.
+const windowIntervalSize = 2 * memsize.Int
+
+// filteringWindowFramer is a windowFramer that removes the rows that don't
+// pass the FILTER clause from the intervals returned by the wrapped framer.
+// Rows for which the filter column is false or NULL are skipped, which matches
+// the behavior of the row-execution windower.
+//
+// Only frameIntervals and slidingWindowIntervals take the filter into account,
+// since the FILTER clause is only allowed for aggregate window functions, and
+// those don't use frameFirstIdx, frameLastIdx and frameNthIdx (with the
+// exception of the min and max removable aggregators, which aren't used when
+// a filter is present).
+//
+// Since the set of rows that pass the filter doesn't depend on the frame, the
+// rows to add and remove for the sliding window optimization are those that
+// the wrapped framer adds and removes and that pass the filter. This way, the
+// filter column is only read for the rows that enter or leave the frame,
+// rather than for the whole frame of every row.
+type filteringWindowFramer struct {
+	windowFramer
+
+	// filterColIdx is the index of the filter column within the stored columns.
+	filterColIdx int
+	storedCols   *colexecutils.SpillingBuffer
+	allocator    *colmem.Allocator
+	// ctx is the context passed to the last call to next. It is used to read
+	// the filter column from storedCols.
+	ctx context.Context
+
+	intervals       []windowInterval
+	intervalsAreSet bool
+	toAdd           []windowInterval
+	toRemove        []windowInterval
+
+	// accountedFor is the number of bytes that are currently accounted for by
+	// the allocator for the intervals slices.
+	accountedFor int64
+}
+
+var _ windowFramer = &filteringWindowFramer{}
+
+// getColsToStore implements the windowFramer interface.
+func (f *filteringWindowFramer) getColsToStore(oldColsToStore []int) (colsToStore []int) {
+	colsToStore = f.windowFramer.getColsToStore(oldColsToStore)
+	for i := range colsToStore {
+		if colsToStore[i] == f.filterColIdx {
+			// The column is already present in colsToStore.
+			f.filterColIdx = i
+			return colsToStore
+		}
+	}
+	colsToStore = append(colsToStore, f.filterColIdx)
+	f.filterColIdx = len(colsToStore) - 1
+	return colsToStore
+}
+
+// startPartition implements the windowFramer interface.
+func (f *filteringWindowFramer) startPartition(
+	ctx context.Context, partitionSize int, storedCols *colexecutils.SpillingBuffer,
+) {
+	f.windowFramer.startPartition(ctx, partitionSize, storedCols)
+	f.storedCols = storedCols
+	f.intervals = f.intervals[:0]
+	f.intervalsAreSet = false
+	f.toAdd = f.toAdd[:0]
+	f.toRemove = f.toRemove[:0]
+}
+
+// next implements the windowFramer interface.
+func (f *filteringWindowFramer) next(ctx context.Context) {
+	f.windowFramer.next(ctx)
+	f.ctx = ctx
+	f.intervalsAreSet = false
+}
+
+// frameIntervals implements the windowFramer interface.
+func (f *filteringWindowFramer) frameIntervals() []windowInterval {
+	if f.intervalsAreSet {
+		return f.intervals
+	}
+	f.intervalsAreSet = true
+	f.intervals = f.filterIntervals(f.windowFramer.frameIntervals(), f.intervals[:0])
+	f.accountForIntervals()
+	return f.intervals
+}
+
+// slidingWindowIntervals implements the windowFramer interface.
+func (f *filteringWindowFramer) slidingWindowIntervals() (toAdd, toRemove []windowInterval) {
+	toAdd, toRemove = f.windowFramer.slidingWindowIntervals()
+	f.toAdd = f.filterIntervals(toAdd, f.toAdd[:0])
+	f.toRemove = f.filterIntervals(toRemove, f.toRemove[:0])
+	f.accountForIntervals()
+	return f.toAdd, f.toRemove
+}
+
+// filterIntervals appends to result the maximal intervals of rows within the
+// given intervals that pass the filter, and returns the updated slice.
+func (f *filteringWindowFramer) filterIntervals(
+	intervals, result []windowInterval,
+) []windowInterval {
+	for _, interval := range intervals {
+		// runStart is the start of the current run of rows that pass the filter,
+		// or -1 if there is no such run.
+		runStart := -1
+		idx := interval.start
+		for idx < interval.end {
+			vec, vecIdx, n := f.storedCols.GetVecWithTuple(f.ctx, f.filterColIdx, idx)
+			filterCol, nulls := vec.Bool(), vec.Nulls()
+			for ; vecIdx < n && idx < interval.end; vecIdx++ {
+				passes := filterCol[vecIdx] && !nulls.NullAt(vecIdx)
+				if passes && runStart == -1 {
+					runStart = idx
+				} else if !passes && runStart != -1 {
+					result = append(result, windowInterval{start: runStart, end: idx})
+					runStart = -1
+				}
+				idx++
+			}
+		}
+		if runStart != -1 {
+			result = append(result, windowInterval{start: runStart, end: interval.end})
+		}
+	}
+	return result
+}
+
+// accountForIntervals updates the memory account of the allocator to reflect
+// the current capacity of the intervals slices.
+func (f *filteringWindowFramer) accountForIntervals() {
+	size := windowIntervalSize * int64(cap(f.intervals)+cap(f.toAdd)+cap(f.toRemove))
+	if size != f.accountedFor {
+		f.allocator.AdjustMemoryUsageAfterAllocation(size - f.accountedFor)
+		f.accountedFor = size
+	}
+}
+
+// close implements the windowFramer interface.
+func (f *filteringWindowFramer) close() {
+	f.windowFramer.close()
+	f.allocator.ReleaseMemory(f.accountedFor)
+	*f = filteringWindowFramer{}
+}
@@ -36,12 +36,6 @@ type windowFnTestCase struct {
 	windowerSpec execinfrapb.WindowerSpec
 }
 
-func (tc *windowFnTestCase) init() {
-	for i := range tc.windowerSpec.WindowFns {
-		tc.windowerSpec.WindowFns[i].FilterColIdx = tree.NoColumnIdx
-	}
-}
-
 func TestWindowFunctions(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -88,8 +82,12 @@ func TestWindowFunctions(t *testing.T) {
 	// we only test a few representative aggregates.
 	sumFn := execinfrapb.AggregatorSpec_SUM
 	countFn := execinfrapb.AggregatorSpec_COUNT
+	countRowsFn := execinfrapb.AggregatorSpec_COUNT_ROWS
 	avgFn := execinfrapb.AggregatorSpec_AVG
 	maxFn := execinfrapb.AggregatorSpec_MAX
+	// bit_or doesn't have an optimized implementation, so it represents the
+	// default aggregate window functions.
+	bitOrFn := execinfrapb.AggregatorSpec_BIT_OR
 
 	for _, spillForced := range []bool{false, true} {
 		flowCtx.Cfg.TestingKnobs.ForceDiskSpill = spillForced
@@ -103,6 +101,7 @@ func TestWindowFunctions(t *testing.T) {
 					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &rowNumberFn},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -116,6 +115,7 @@ func TestWindowFunctions(t *testing.T) {
 					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &rankFn},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -129,6 +129,7 @@ func TestWindowFunctions(t *testing.T) {
 					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &denseRankFn},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -142,6 +143,7 @@ func TestWindowFunctions(t *testing.T) {
 					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &percentRankFn},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -155,6 +157,7 @@ func TestWindowFunctions(t *testing.T) {
 					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &cumeDistFn},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -169,6 +172,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &nTileFn},
 							ArgsIdxs:     []uint32{1},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -189,6 +193,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &lagFn},
 							ArgsIdxs:     []uint32{1, 2, 3},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 4,
 						},
 					},
@@ -209,6 +214,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &leadFn},
 							ArgsIdxs:     []uint32{1, 2, 3},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 4,
 						},
 					},
@@ -223,6 +229,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &firstValueFn},
 							ArgsIdxs:     []uint32{1},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -237,6 +244,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &lastValueFn},
 							ArgsIdxs:     []uint32{1},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -251,6 +259,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &nthValueFn},
 							ArgsIdxs:     []uint32{1, 2},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 3,
 						},
 					},
@@ -268,6 +277,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &sumFn},
 							ArgsIdxs:     []uint32{1},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -282,6 +292,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &countFn},
 							ArgsIdxs:     []uint32{1},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -300,6 +311,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &avgFn},
 							ArgsIdxs:     []uint32{1},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -314,6 +326,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &maxFn},
 							ArgsIdxs:     []uint32{1},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -329,6 +342,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &rowNumberFn},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -342,6 +356,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &rankFn},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -355,6 +370,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &denseRankFn},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -368,6 +384,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &percentRankFn},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -381,6 +398,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &cumeDistFn},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -395,6 +413,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &nTileFn},
 							ArgsIdxs:     []uint32{1},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -415,6 +434,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &lagFn},
 							ArgsIdxs:     []uint32{1, 2, 3},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}, {ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 4,
 						},
 					},
@@ -435,6 +455,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &leadFn},
 							ArgsIdxs:     []uint32{1, 2, 3},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}, {ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 4,
 						},
 					},
@@ -449,6 +470,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &firstValueFn},
 							ArgsIdxs:     []uint32{1},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -463,6 +485,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &lastValueFn},
 							ArgsIdxs:     []uint32{1},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -477,6 +500,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &nthValueFn},
 							ArgsIdxs:     []uint32{1, 2},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 3,
 						},
 					},
@@ -493,6 +517,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &sumFn},
 							ArgsIdxs:     []uint32{1},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -507,6 +532,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &countFn},
 							ArgsIdxs:     []uint32{1},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -524,6 +550,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &avgFn},
 							ArgsIdxs:     []uint32{1},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -538,11 +565,111 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &maxFn},
 							ArgsIdxs:     []uint32{1},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							FilterColIdx: tree.NoColumnIdx,
+							OutputColIdx: 2,
+						},
+					},
+				},
+			},
+			{
+				tuples:   colexectestutils.Tuples{{nil, 4}, {nil, 1}, {1, 2}, {1, nil}, {2, 8}, {3, 1}, {3, 16}},
+				expected: colexectestutils.Tuples{{nil, 4, 5}, {nil, 1, 5}, {1, 2, 7}, {1, nil, 7}, {2, 8, 15}, {3, 1, 31}, {3, 16, 31}},
+				windowerSpec: execinfrapb.WindowerSpec{
+					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
+						{
+							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &bitOrFn},
+							ArgsIdxs:     []uint32{1},
+							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
 				},
 			},
+			{
+				tuples:   colexectestutils.Tuples{{1, 7, true}, {2, 3, false}, {3, nil, true}, {4, -5, nil}, {5, 6, true}},
+				expected: colexectestutils.Tuples{{1, 7, true, 1}, {2, 3, false, 1}, {3, nil, true, 1}, {4, -5, nil, 1}, {5, 6, true, 2}},
+				windowerSpec: execinfrapb.WindowerSpec{
+					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
+						{
+							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &countFn},
+							ArgsIdxs:     []uint32{1},
+							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							FilterColIdx: 2,
+							OutputColIdx: 3,
+						},
+					},
+				},
+			},
+			{
+				tuples:   colexectestutils.Tuples{{1, 7, true}, {2, 3, false}, {3, nil, true}, {4, -5, nil}, {5, 6, true}},
+				expected: colexectestutils.Tuples{{1, 7, true, 7}, {2, 3, false, 7}, {3, nil, true, 7}, {4, -5, nil, 7}, {5, 6, true, 7}},
+				windowerSpec: execinfrapb.WindowerSpec{
+					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
+						{
+							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &bitOrFn},
+							ArgsIdxs:     []uint32{1},
+							FilterColIdx: 2,
+							OutputColIdx: 3,
+						},
+					},
+				},
+			},
+			{
+				tuples:   colexectestutils.Tuples{{1, 7, true}, {2, 3, false}, {3, nil, true}, {4, -5, nil}, {5, 6, true}},
+				expected: colexectestutils.Tuples{{1, 7, true, 7}, {2, 3, false, 7}, {3, nil, true, nil}, {4, -5, nil, 6}, {5, 6, true, 6}},
+				windowerSpec: execinfrapb.WindowerSpec{
+					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
+						{
+							Func:     execinfrapb.WindowerSpec_Func{AggregateFunc: &sumFn},
+							ArgsIdxs: []uint32{1},
+							Ordering: execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							Frame: &execinfrapb.WindowerSpec_Frame{
+								Mode: execinfrapb.WindowerSpec_Frame_ROWS,
+								Bounds: execinfrapb.WindowerSpec_Frame_Bounds{
+									Start: execinfrapb.WindowerSpec_Frame_Bound{
+										BoundType: execinfrapb.WindowerSpec_Frame_OFFSET_PRECEDING,
+										IntOffset: 1,
+									},
+									End: &execinfrapb.WindowerSpec_Frame_Bound{
+										BoundType: execinfrapb.WindowerSpec_Frame_OFFSET_FOLLOWING,
+										IntOffset: 1,
+									},
+								},
+							},
+							FilterColIdx: 2,
+							OutputColIdx: 3,
+						},
+					},
+				},
+			},
+			{
+				tuples:   colexectestutils.Tuples{{1, 7, true}, {2, 3, false}, {3, nil, true}, {4, -5, nil}, {5, 6, true}},
+				expected: colexectestutils.Tuples{{1, 7, true, 1}, {2, 3, false, 2}, {3, nil, true, 1}, {4, -5, nil, 2}, {5, 6, true, 1}},
+				windowerSpec: execinfrapb.WindowerSpec{
+					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
+						{
+							Func:     execinfrapb.WindowerSpec_Func{AggregateFunc: &countRowsFn},
+							Ordering: execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 0}}},
+							Frame: &execinfrapb.WindowerSpec_Frame{
+								Mode: execinfrapb.WindowerSpec_Frame_ROWS,
+								Bounds: execinfrapb.WindowerSpec_Frame_Bounds{
+									Start: execinfrapb.WindowerSpec_Frame_Bound{
+										BoundType: execinfrapb.WindowerSpec_Frame_OFFSET_PRECEDING,
+										IntOffset: 1,
+									},
+									End: &execinfrapb.WindowerSpec_Frame_Bound{
+										BoundType: execinfrapb.WindowerSpec_Frame_OFFSET_FOLLOWING,
+										IntOffset: 1,
+									},
+								},
+							},
+							FilterColIdx: 2,
+							OutputColIdx: 3,
+						},
+					},
+				},
+			},
 
 			// With both PARTITION BY and ORDER BY.
 			{
@@ -554,6 +681,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &rowNumberFn},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -568,6 +696,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &rankFn},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -582,6 +711,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &denseRankFn},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -596,6 +726,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &percentRankFn},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -610,6 +741,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &cumeDistFn},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -625,6 +757,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &nTileFn},
 							ArgsIdxs:     []uint32{2},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 3,
 						},
 					},
@@ -646,6 +779,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &lagFn},
 							ArgsIdxs:     []uint32{1, 2, 3},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 4,
 						},
 					},
@@ -667,6 +801,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &leadFn},
 							ArgsIdxs:     []uint32{1, 2, 3},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 4,
 						},
 					},
@@ -688,6 +823,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &firstValueFn},
 							ArgsIdxs:     []uint32{2},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 3,
 						},
 					},
@@ -709,6 +845,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &lastValueFn},
 							ArgsIdxs:     []uint32{2},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 3,
 						},
 					},
@@ -730,6 +867,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &nthValueFn},
 							ArgsIdxs:     []uint32{2, 3},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 4,
 						},
 					},
@@ -752,6 +890,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &sumFn},
 							ArgsIdxs:     []uint32{2},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 3,
 						},
 					},
@@ -773,6 +912,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &countFn},
 							ArgsIdxs:     []uint32{2},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 3,
 						},
 					},
@@ -796,6 +936,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &avgFn},
 							ArgsIdxs:     []uint32{2},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 3,
 						},
 					},
@@ -817,6 +958,7 @@ func TestWindowFunctions(t *testing.T) {
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &maxFn},
 							ArgsIdxs:     []uint32{2},
 							Ordering:     execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{{ColIdx: 1}}},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 3,
 						},
 					},
@@ -831,6 +973,7 @@ func TestWindowFunctions(t *testing.T) {
 					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &rowNumberFn},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -843,6 +986,7 @@ func TestWindowFunctions(t *testing.T) {
 					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &rankFn},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -855,6 +999,7 @@ func TestWindowFunctions(t *testing.T) {
 					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &denseRankFn},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -867,6 +1012,7 @@ func TestWindowFunctions(t *testing.T) {
 					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &percentRankFn},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -879,6 +1025,7 @@ func TestWindowFunctions(t *testing.T) {
 					WindowFns: []execinfrapb.WindowerSpec_WindowFn{
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &cumeDistFn},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -892,6 +1039,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &nTileFn},
 							ArgsIdxs:     []uint32{1},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -911,6 +1059,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &lagFn},
 							ArgsIdxs:     []uint32{0, 1, 2},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 3,
 						},
 					},
@@ -930,6 +1079,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &leadFn},
 							ArgsIdxs:     []uint32{0, 1, 2},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 3,
 						},
 					},
@@ -943,6 +1093,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &firstValueFn},
 							ArgsIdxs:     []uint32{0},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -956,6 +1107,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &lastValueFn},
 							ArgsIdxs:     []uint32{0},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -969,6 +1121,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{WindowFunc: &nthValueFn},
 							ArgsIdxs:     []uint32{0, 1},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 2,
 						},
 					},
@@ -985,6 +1138,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &sumFn},
 							ArgsIdxs:     []uint32{0},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -998,6 +1152,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &countFn},
 							ArgsIdxs:     []uint32{0},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -1015,6 +1170,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &avgFn},
 							ArgsIdxs:     []uint32{0},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -1028,6 +1184,7 @@ func TestWindowFunctions(t *testing.T) {
 						{
 							Func:         execinfrapb.WindowerSpec_Func{AggregateFunc: &maxFn},
 							ArgsIdxs:     []uint32{0},
+							FilterColIdx: tree.NoColumnIdx,
 							OutputColIdx: 1,
 						},
 					},
@@ -1037,11 +1194,13 @@ func TestWindowFunctions(t *testing.T) {
 			log.Dev.Infof(ctx, "spillForced=%t/%s", spillForced, tc.windowerSpec.WindowFns[0].Func.String())
 			var semsToCheck []semaphore.Semaphore
 			colexectestutils.RunTests(t, testAllocator, []colexectestutils.Tuples{tc.tuples}, tc.expected, colexectestutils.UnorderedVerifier, func(sources []colexecop.Operator) (colexecop.Operator, error) {
-				tc.init()
 				ct := make([]*types.T, len(tc.tuples[0]))
 				for i := range ct {
 					ct[i] = types.Int
 				}
+				if filterColIdx := tc.windowerSpec.WindowFns[0].FilterColIdx; filterColIdx != tree.NoColumnIdx {
+					ct[filterColIdx] = types.Bool
+				}
 				resultType := types.Int
 				fun := tc.windowerSpec.WindowFns[0].Func
 				if fun.WindowFunc != nil {
@@ -1162,6 +1321,7 @@ func BenchmarkWindowFunctions(b *testing.B) {
 			Input:           source,
 			InputTypes:      sourceTypes,
 			OutputColIdx:    outputIdx,
+			FilterColIdx:    tree.NoColumnIdx,
 			PartitionColIdx: partitionCol,
 			PeersColIdx:     peersCol,
 		}
@@ -35,6 +35,10 @@ type WindowArgs struct {
 	OutputColIdx    int
 	PartitionColIdx int
 	PeersColIdx     int
+	// FilterColIdx is the index of the boolean column that specifies the rows
+	// which pass the FILTER clause of an aggregate window function. It is
+	// tree.NoColumnIdx if there is no FILTER clause.
+	FilterColIdx int
 }
 
 // windowFnMaxNumArgs is a mapping from the window function to the maximum
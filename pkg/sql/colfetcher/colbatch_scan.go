@@ -157,6 +157,13 @@ func newColBatchScanBase(
 
 	s := colBatchScanBasePool.Get().(*colBatchScanBase)
 	s.Spans = spec.Spans
+	if sample := spec.Sample; sample != nil && sample.Method == execinfrapb.TableSampleSpec_SYSTEM {
+		// Only read the ranges that are part of the sample.
+		sampler := row.MakeSampler(sample.Fraction, sample.Seed)
+		if s.Spans, err = sampler.SampleSpans(ctx, flowCtx.Cfg.DistSender, s.Spans); err != nil {
+			return nil, nil, nil, err
+		}
+	}
 	if !flowCtx.Local {
 		// Make a copy of the spans so that we could get the misplanned ranges
 		// info.
@@ -350,6 +357,10 @@ func NewColBatchScan(
 		flowCtx.EvalCtx.TestingKnobs.ForceProductionValues,
 		spec.FetchSpec.External,
 	)
+	if sample := spec.Sample; sample != nil && sample.Method == execinfrapb.TableSampleSpec_BERNOULLI {
+		sampler := row.MakeSampler(sample.Fraction, sample.Seed)
+		kvFetcher.SetSampler(&sampler)
+	}
 	fetcher := cFetcherPool.Get().(*cFetcher)
 	shouldCollectStats := execstats.ShouldCollectStats(ctx, flowCtx.CollectStats)
 	fetcher.cFetcherArgs = cFetcherArgs{
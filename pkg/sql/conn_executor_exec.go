@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/contention"
 	"github.com/cockroachdb/cockroach/pkg/sql/contentionpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
 	"github.com/cockroachdb/cockroach/pkg/sql/hints"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
@@ -2699,12 +2700,76 @@ func (ex *connExecutor) dispatchReadCommittedStmtToExecutionEngine(
 // dispatchToExecutionEngine executes the statement, writes the result to res
 // and returns an event for the connection's state machine.
 //
+// If the optimizer_use_adaptive_reoptimization session setting is enabled, the
+// plan can include cardinality checks. If one of them fails because the
+// optimizer severely underestimated the number of rows produced by an
+// expression, the statement is re-optimized using the observed row count and
+// executed again. A savepoint is used to undo the effects of the failed
+// execution, in the same way as dispatchReadCommittedStmtToExecutionEngine.
+//
 // If an error is returned, the connection needs to stop processing queries.
 // Query execution errors are written to res; they are not returned; it is
 // expected that the caller will inspect res and react to query errors by
 // producing an appropriate state machine event.
 func (ex *connExecutor) dispatchToExecutionEngine(
 	ctx context.Context, planner *planner, res RestrictedCommandResult,
+) error {
+	for {
+		planner.allowCardinalityChecks = ex.sessionData().OptimizerUseAdaptiveReoptimization &&
+			len(planner.reoptimizations) < maxReoptimizations &&
+			ex.executorType != executorTypeInternal &&
+			getPausablePortalInfo(planner) == nil &&
+			planner.stmt.AST.StatementReturnType() == tree.Rows
+		if !planner.allowCardinalityChecks {
+			return ex.dispatchToExecutionEngineOnce(ctx, planner, res)
+		}
+
+		bufferPos := res.BufferedResultsLen()
+		savepoint, err := ex.state.mu.txn.CreateSavepoint(ctx)
+		if err != nil {
+			return err
+		}
+		if err := ex.dispatchToExecutionEngineOnce(ctx, planner, res); err != nil {
+			return err
+		}
+		var misestimateErr *execinfrapb.CardinalityMisestimateError
+		if res.Err() == nil || !errors.As(res.Err(), &misestimateErr) {
+			if res.Err() == nil {
+				if err := ex.state.mu.txn.ReleaseSavepoint(ctx, savepoint); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		check := misestimateErr.Misestimate.Check
+		if check.ID < 1 || int(check.ID) > len(planner.curPlan.cardinalityChecks) {
+			return errors.AssertionFailedf("unexpected cardinality check ID %d", check.ID)
+		}
+
+		// In order to re-optimize the statement, we need to clear any results
+		// and errors that were buffered, and rollback to the savepoint.
+		if ableToClear := res.TruncateBufferedResults(bufferPos); !ableToClear {
+			res.SetError(errors.Wrapf(
+				res.Err(), "cannot re-optimize since some results were already sent to the client",
+			))
+			return nil
+		}
+		res.SetError(nil)
+		if err := ex.state.mu.txn.RollbackToSavepoint(ctx, savepoint); err != nil {
+			return err
+		}
+		observation := planner.curPlan.cardinalityChecks[check.ID-1]
+		observation.ActualRowCount = float64(misestimateErr.Misestimate.ObservedRowCount)
+		planner.cardinalityFeedback.Add(observation)
+		planner.reoptimizations = append(planner.reoptimizations, misestimateErr)
+		log.VEventf(ctx, 2, "re-optimizing statement: %v", misestimateErr)
+	}
+}
+
+// dispatchToExecutionEngineOnce plans and executes the statement once. See
+// dispatchToExecutionEngine.
+func (ex *connExecutor) dispatchToExecutionEngineOnce(
+	ctx context.Context, planner *planner, res RestrictedCommandResult,
 ) (retErr error) {
 	defer func() {
 		if ppInfo := getPausablePortalInfo(planner); ppInfo != nil {
@@ -2924,9 +2989,11 @@ func (ex *connExecutor) dispatchToExecutionEngine(
 		distribute = FullDistribution
 	}
 	ex.sessionTracing.TraceExecStart(ctx, "distributed")
-	stats, err := ex.execWithDistSQLEngine(
-		ctx, planner, stmt.AST.StatementReturnType(), res, distribute, progAtomic, distSQLProhibitedErr,
-	)
+	stats, err := ex.execWithResultCache(ctx, planner, res, func(res RestrictedCommandResult) (topLevelQueryStats, error) {
+		return ex.execWithDistSQLEngine(
+			ctx, planner, stmt.AST.StatementReturnType(), res, distribute, progAtomic, distSQLProhibitedErr,
+		)
+	})
 	if ppInfo := getPausablePortalInfo(planner); ppInfo != nil {
 		// For pausable portals, we log the stats when closing the portal, so we need
 		// to aggregate the stats for all executions.
@@ -60,6 +60,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
 	"github.com/cockroachdb/cockroach/pkg/sql/contentionpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/hintpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
@@ -232,6 +233,7 @@ var crdbInternal = virtualSchema{
 		catconstants.CrdbInternalStoreLivenessSupportFrom:           crdbInternalStoreLivenessSupportFromTable,
 		catconstants.CrdbInternalStoreLivenessSupportFor:            crdbInternalStoreLivenessSupportForTable,
 		catconstants.CrdbInternalClusterInspectErrorsViewID:         crdbInternalClusterInspectErrorsView,
+		catconstants.CrdbInternalPlanBaselinesTableID:               crdbInternalPlanBaselinesTable,
 	},
 	validWithNoDatabaseContext: true,
 }
@@ -3832,6 +3834,14 @@ func showAlterStatement(
 		}
 	}
 
+	if stmt, err := showSystemVersioningStatement(tn, table, lCtx, contextName); err != nil {
+		return err
+	} else if stmt != "" {
+		if err := alterStmts.Append(tree.NewDString(stmt)); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -9721,3 +9731,67 @@ CREATE VIEW crdb_internal.cluster_inspect_errors AS
 	},
 	comment: `wrapper over system.inspect_errors`,
 }
+
+var crdbInternalPlanBaselinesTable = virtualSchemaTable{
+	comment: `plan baselines stored in system.statement_hints`,
+	schema: `
+CREATE TABLE crdb_internal.plan_baselines (
+  hint_id     INT NOT NULL,
+  fingerprint STRING NOT NULL,
+  plan_gist   STRING NOT NULL,
+  fixed       BOOL NOT NULL,
+  created_at  TIMESTAMPTZ NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) (retErr error) {
+		hasRoleOption, _, err := p.HasViewActivityOrViewActivityRedactedRole(ctx)
+		if err != nil {
+			return err
+		}
+		if !hasRoleOption {
+			return noViewActivityOrViewActivityRedactedRoleError(p.User())
+		}
+
+		const query = `
+SELECT "row_id", "fingerprint", "hint", "created_at"
+FROM system.statement_hints
+ORDER BY "row_id" ASC`
+		it, err := p.InternalSQLTxn().QueryIteratorEx(
+			ctx, "crdb-internal-plan-baselines-table", p.txn,
+			sessiondata.NodeUserSessionDataOverride, query,
+		)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			retErr = errors.CombineErrors(retErr, it.Close())
+		}()
+		for {
+			ok, err := it.Next(ctx)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+			r := it.Cur()
+			hint, err := hintpb.FromBytes([]byte(tree.MustBeDBytes(r[2])))
+			if err != nil {
+				// Skip hints that cannot be decoded, like the optimizer does.
+				continue
+			}
+			baseline := hint.PlanBaseline
+			if baseline == nil {
+				continue
+			}
+			if err := addRow(
+				r[0],
+				r[1],
+				tree.NewDString(baseline.PlanGist),
+				tree.MakeDBool(tree.DBool(baseline.Fixed)),
+				r[3],
+			); err != nil {
+				return err
+			}
+		}
+	},
+}
@@ -273,6 +273,20 @@ func (n *createTableNode) startExec(params runParams) error {
 	defer func(originalDefs tree.TableDefs) { n.n.Defs = originalDefs }(n.n.Defs)
 	n.n.Defs = defsCopy
 
+	if n.n.SystemVersioning {
+		if !params.p.IsActive(params.ctx, clusterversion.V26_1_SystemVersionedTables) {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"system-versioned tables are not supported until the cluster is fully upgraded")
+		}
+		if n.dbDesc.IsMultiRegion() {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"system-versioned tables are not supported in multi-region databases")
+		}
+		// Add the hidden column that stores the time at which each row version
+		// became current.
+		n.n.Defs = append(n.n.Defs, tree.SystemVersioningRowStartColumnDef())
+	}
+
 	for _, def := range n.n.Defs {
 		switch v := def.(type) {
 		case *tree.UniqueConstraintTableDef:
@@ -442,6 +456,12 @@ func (n *createTableNode) startExec(params runParams) error {
 				desc.State = descpb.DescriptorState_PUBLIC
 			}
 		}
+
+		if n.n.SystemVersioning {
+			if err := n.createHistoryTable(params, schema, desc, creationTime, privs); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Replace all UDF names with OIDs in check constraints and update back
@@ -628,6 +648,73 @@ func (n *createTableNode) startExec(params runParams) error {
 	return nil
 }
 
+// createHistoryTable creates the history table of a table that is created
+// WITH SYSTEM VERSIONING, and records it in the descriptor of the table. The
+// history table is named <table>_history and is created in the same schema as
+// the table. It has the visible columns of the table, followed by the row
+// start and row end columns, and a hidden rowid primary key.
+func (n *createTableNode) createHistoryTable(
+	params runParams,
+	schema catalog.SchemaDescriptor,
+	desc *tabledesc.Mutable,
+	creationTime hlc.Timestamp,
+	privs *catpb.PrivilegeDescriptor,
+) error {
+	rowStart := catalog.FindColumnByTreeName(desc, tree.SystemVersioningRowStartColName)
+	if rowStart == nil {
+		return errors.AssertionFailedf("system-versioned table %q has no %s column",
+			desc.GetName(), tree.SystemVersioningRowStartColName)
+	}
+	var defs tree.TableDefs
+	for _, col := range desc.VisibleColumns() {
+		defs = append(defs, &tree.ColumnTableDef{Name: col.ColName(), Type: col.GetType()})
+	}
+	defs = append(defs, tree.SystemVersioningHistoryColumnDefs()...)
+	histStmt := &tree.CreateTable{
+		Table:       n.n.Table,
+		Defs:        defs,
+		Persistence: n.n.Persistence,
+	}
+	histStmt.Table.ObjectName += tree.SystemVersioningHistoryTableSuffix
+	if _, err := getSchemaForCreateTable(params, n.dbDesc, histStmt.Persistence, &histStmt.Table,
+		tree.ResolveRequireTableDesc, false /* ifNotExists */); err != nil {
+		return err
+	}
+
+	id, err := params.extendedEvalCtx.DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return err
+	}
+	hist, err := newTableDesc(
+		params, histStmt, n.dbDesc, schema, id, creationTime, privs, nil, /* affected */
+	)
+	if err != nil {
+		return err
+	}
+	if err := params.p.createDescriptor(
+		params.ctx, hist, tree.AsStringWithFQNames(histStmt, params.Ann()),
+	); err != nil {
+		return err
+	}
+	if err := params.p.addBackRefsFromAllTypesInTable(params.ctx, hist); err != nil {
+		return err
+	}
+	if err := validateDescriptor(params.ctx, params.p, hist); err != nil {
+		return err
+	}
+	if err := params.p.logEvent(params.ctx, hist.ID, &eventpb.CreateTable{
+		TableName: histStmt.Table.FQString(),
+	}); err != nil {
+		return err
+	}
+
+	desc.SystemVersioning = &descpb.TableDescriptor_SystemVersioning{
+		HistoryTableID:   hist.GetID(),
+		RowStartColumnID: rowStart.GetID(),
+	}
+	return nil
+}
+
 func (*createTableNode) Next(runParams) (bool, error) { return false, nil }
 func (*createTableNode) Values() tree.Datums          { return tree.Datums{} }
 
@@ -10,6 +10,8 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/docs"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
@@ -78,6 +80,11 @@ func (n *createViewNode) startExec(params runParams) error {
 	}
 	createView := n.createView
 
+	if createView.Incremental && !createView.WithData {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"incremental materialized views cannot be created WITH NO DATA")
+	}
+
 	if !params.SessionData().AllowViewWithSecurityInvokerClause && createView.Options != nil && createView.Options.SecurityInvoker {
 		return pgerror.Newf(pgcode.FeatureNotSupported,
 			"security invoker views are not supported")
@@ -279,6 +286,28 @@ func (n *createViewNode) startExec(params runParams) error {
 					// should only be accessed after a REFRESH VIEW operation has been called
 					// on it.
 					desc.RefreshViewRequired = !createView.WithData
+					if createView.Incremental {
+						// The view is maintained by a job if its query is supported, and
+						// has to be refreshed otherwise.
+						if err := n.checkIncrementalView(); err != nil {
+							params.p.BufferClientNotice(params.ctx, pgnotice.Newf(
+								"materialized view %q cannot be maintained incrementally and must be refreshed with REFRESH MATERIALIZED VIEW: %v",
+								viewName, err,
+							))
+						} else {
+							desc.IncrementalRefreshJobID = params.p.extendedEvalCtx.QueueJob(&jobs.Record{
+								Description:   tree.AsStringWithFQNames(n.createView, params.Ann()),
+								Username:      params.p.User(),
+								DescriptorIDs: descpb.IDs{id},
+								Details:       jobspb.IncrementalViewRefreshDetails{ViewID: id},
+								Progress:      jobspb.IncrementalViewRefreshProgress{},
+							})
+							params.p.BufferClientNotice(params.ctx, pgnotice.Newf(
+								"materialized view %q is maintained incrementally by job %d",
+								viewName, desc.IncrementalRefreshJobID,
+							))
+						}
+					}
 					desc.State = descpb.DescriptorState_ADD
 					version := params.ExecCfg().Settings.Version.ActiveVersion(params.ctx)
 					if err := desc.AllocateIDs(params.ctx, version); err != nil {
@@ -411,6 +440,19 @@ func (*createViewNode) Next(runParams) (bool, error) { return false, nil }
 func (*createViewNode) Values() tree.Datums          { return tree.Datums{} }
 func (n *createViewNode) Close(ctx context.Context)  {}
 
+// checkIncrementalView returns an error explaining why the materialized view
+// being created cannot be maintained incrementally, if it cannot.
+func (n *createViewNode) checkIncrementalView() error {
+	if _, err := analyzeIncrementalViewQuery(n.viewQuery); err != nil {
+		return err
+	}
+	deps := make([]catalog.TableDescriptor, 0, len(n.planDeps))
+	for _, dep := range n.planDeps {
+		deps = append(deps, dep.desc)
+	}
+	return checkIncrementalViewTables(deps)
+}
+
 // makeViewTableDesc returns the table descriptor for a new view.
 //
 // It creates the descriptor directly in the PUBLIC state rather than
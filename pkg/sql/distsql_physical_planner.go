@@ -2134,6 +2134,7 @@ func initTableReaderSpecTemplate(
 		LockingStrength:                 n.lockingStrength,
 		LockingWaitPolicy:               n.lockingWaitPolicy,
 		LockingDurability:               n.lockingDurability,
+		Sample:                          n.sample,
 	}
 	if err := rowenc.InitIndexFetchSpec(&s.FetchSpec, codec, n.desc, n.index, colIDs); err != nil {
 		return nil, execinfrapb.PostProcessSpec{}, err
@@ -2350,7 +2351,11 @@ func (dsp *DistSQLPlanner) planTableReaders(
 		err                    error
 	)
 	if planCtx.isLocal {
-		spanPartitions, parallelizeLocal = dsp.maybeParallelizeLocalScans(ctx, planCtx, info)
+		var parallelPipelines bool
+		spanPartitions, parallelPipelines = dsp.maybePlanParallelLocalPipelines(ctx, planCtx, info)
+		if !parallelPipelines {
+			spanPartitions, parallelizeLocal = dsp.maybeParallelizeLocalScans(ctx, planCtx, info)
+		}
 	} else if info.post.Limit == 0 {
 		// No hard limit - plan all table readers where their data live. Note
 		// that we're ignoring soft limits for now since the TableReader will
@@ -2786,12 +2791,13 @@ func (dsp *DistSQLPlanner) planAggregators(
 
 	// We either have a local stage on each stream followed by a final stage, or
 	// just a final stage. We only use a local stage if:
-	//  - the previous stage is distributed on multiple nodes, and
+	//  - the previous stage is distributed on multiple nodes or consists of
+	//    parallel pipelines on a single node, and
 	//  - all aggregation functions support it, and
 	//  - no function is performing distinct aggregation.
 	//  TODO(radu): we could relax this by splitting the aggregation into two
 	//  different paths and joining on the results.
-	multiStage := prevStageNode == 0
+	multiStage := prevStageNode == 0 || len(p.ResultRouters) > 1
 	if multiStage {
 		for _, e := range info.aggregations {
 			if e.Distinct {
@@ -3200,9 +3206,10 @@ func (dsp *DistSQLPlanner) planAggregators(
 			}
 		}
 
-		// We have multiple streams, so we definitely have a processor planned
-		// on a remote node.
-		stageID := p.NewStage(true /* containsRemoteProcessor */, info.allowPartialDistribution)
+		// The final stage processors are planned on the nodes of the result
+		// routers, which might all be the gateway if the multiple streams are
+		// parallel pipelines of a local plan.
+		stageID := p.NewStage(p.IsLastStageDistributed(), info.allowPartialDistribution)
 
 		// We have one final stage processor for each result router. This is a
 		// somewhat arbitrary decision; we could have a different number of nodes
@@ -3925,6 +3932,13 @@ func (dsp *DistSQLPlanner) createPlanForJoin(
 		leftPlanDistribution:  leftPlan.GetLastStageDistribution(),
 		rightPlanDistribution: rightPlan.GetLastStageDistribution(),
 		finalizeLastStageCb:   planCtx.associateWithPlanNode(n),
+		rightCardinalityCheck: makeCardinalityCheck(
+			&dsp.st.SV, n.rightCardinalityCheckID, n.estimatedRightRowCount,
+		),
+	}
+	if n.partitionAligned {
+		info.leftPartitionedScan = partitionedScanNode(n.left)
+		info.rightPartitionedScan = partitionedScanNode(n.right)
 	}
 	return dsp.planJoiners(ctx, planCtx, &info, n.reqOrdering), nil
 }
@@ -3958,6 +3972,26 @@ func (dsp *DistSQLPlanner) planJoiners(
 	leftRouters := info.leftPlan.ResultRouters
 	rightRouters := info.rightPlan.ResultRouters
 
+	// If the inputs are co-partitioned, the join is performed separately for
+	// each set of partitions, on the nodes that read them.
+	if partitions, ok := dsp.planPartitionWiseJoin(
+		ctx, planCtx, info, p.Processors, leftRouters, rightRouters,
+	); ok {
+		// There are always multiple joiners, so the cardinality check is
+		// omitted (see below).
+		info.rightCardinalityCheck = execinfrapb.CardinalityCheck{}
+		p.AddPartitionWiseJoinStage(
+			ctx, partitions, info.makeCoreSpec(), info.post,
+			info.leftEqCols, info.rightEqCols,
+			info.leftPlan.GetResultTypes(), info.rightPlan.GetResultTypes(),
+			info.leftMergeOrd, info.rightMergeOrd,
+			info.joinResultTypes, info.finalizeLastStageCb,
+		)
+		p.PlanToStreamColMap = info.joinToStreamColMap
+		p.SetMergeOrdering(dsp.convertOrdering(reqOrdering, p.PlanToStreamColMap))
+		return p
+	}
+
 	// Instances where we will run the join processors.
 	var sqlInstances []base.SQLInstanceID
 	if numEq := len(info.leftEqCols); numEq != 0 {
@@ -3977,6 +4011,13 @@ func (dsp *DistSQLPlanner) planJoiners(
 		}
 	}
 
+	if len(sqlInstances) > 1 {
+		// With multiple joiners, one of them could output rows before another
+		// fails its cardinality check, after which the statement could no
+		// longer be re-optimized, so the check is omitted.
+		info.rightCardinalityCheck = execinfrapb.CardinalityCheck{}
+	}
+
 	p.AddJoinStage(
 		ctx, sqlInstances, info.makeCoreSpec(), info.post,
 		info.leftEqCols, info.rightEqCols,
@@ -4497,11 +4538,11 @@ func (dsp *DistSQLPlanner) addDistinctProcessors(
 
 	// Add distinct processors local to each existing current result processor.
 	plan.AddNoGroupingStage(distinctSpec, execinfrapb.PostProcessSpec{}, plan.GetResultTypes(), plan.MergeOrdering, finalizeLastStageCb)
-	if !plan.IsLastStageDistributed() {
+	if !plan.IsLastStageDistributed() && len(plan.ResultRouters) == 1 {
 		return
 	}
 
-	sqlInstanceIDs := getSQLInstanceIDsOfRouters(plan.ResultRouters, plan.Processors)
+	sqlInstanceIDs := getStageSQLInstanceIDs(plan.ResultRouters, plan.Processors)
 	plan.AddStageOnNodes(
 		ctx, sqlInstanceIDs, distinctSpec, execinfrapb.PostProcessSpec{},
 		distinctSpec.Distinct.DistinctColumns, plan.GetResultTypes(),
@@ -5234,14 +5275,15 @@ func (dsp *DistSQLPlanner) planExport(
 
 	var core execinfrapb.ProcessorCoreUnion
 	core.Exporter = &execinfrapb.ExportSpec{
-		Destination: planInfo.destination,
-		NamePattern: planInfo.fileNamePattern,
-		Format:      planInfo.format,
-		ChunkRows:   int64(planInfo.chunkRows),
-		ChunkSize:   planInfo.chunkSize,
-		ColNames:    planInfo.colNames,
-		HeaderRow:   planInfo.headerRow,
-		UserProto:   planCtx.planner.User().EncodeProto(),
+		Destination:   planInfo.destination,
+		NamePattern:   planInfo.fileNamePattern,
+		Format:        planInfo.format,
+		ChunkRows:     int64(planInfo.chunkRows),
+		ChunkSize:     planInfo.chunkSize,
+		ColNames:      planInfo.colNames,
+		HeaderRow:     planInfo.headerRow,
+		UserProto:     planCtx.planner.User().EncodeProto(),
+		CopyTableName: planInfo.copyTableName,
 	}
 
 	p.AddNoGroupingStage(
@@ -5274,8 +5316,12 @@ func logAndSanitizeExportDestination(ctx context.Context, dest string) error {
 // vectorized flow, we might get an error during the query execution because the
 // processors eagerly move into the draining state which will cancel the context
 // of parallel TableReaders which might "poison" the transaction.
+//
+// Scans with a required ordering are only considered if allowOrderedScans is
+// true, in which case their parallel pipelines are merged by an ordered
+// synchronizer (see maybePlanParallelLocalPipelines).
 func checkScanParallelizationIfLocal(
-	ctx context.Context, plan *planComponents,
+	ctx context.Context, plan *planComponents, allowOrderedScans bool,
 ) (prohibitParallelization, hasScanNodeToParallelize bool) {
 	if plan.main.planNode == nil || len(plan.cascades) != 0 ||
 		len(plan.checkPlans) != 0 || len(plan.triggers) != 0 {
@@ -5295,7 +5341,7 @@ func checkScanParallelizationIfLocal(
 			// explainPlanNode is a zeroInputPlanNode, so we have to manually
 			// recurse.
 			plan := n.plan.WrappedPlan.(*planComponents)
-			prohibit, has := checkScanParallelizationIfLocal(ctx, plan)
+			prohibit, has := checkScanParallelizationIfLocal(ctx, plan, allowOrderedScans)
 			prohibitParallelization = prohibitParallelization || prohibit
 			hasScanNodeToParallelize = hasScanNodeToParallelize || has
 			// Do not recurse.
@@ -5327,7 +5373,7 @@ func checkScanParallelizationIfLocal(
 				}
 			}
 		case *scanNode:
-			if len(n.reqOrdering) == 0 && n.parallelize {
+			if (len(n.reqOrdering) == 0 || allowOrderedScans) && n.parallelize {
 				hasScanNodeToParallelize = true
 			}
 			if n.fetchPlanningInfo.requiresMVCCDecoding() {
@@ -5425,6 +5471,7 @@ func (dsp *DistSQLPlanner) NewPlanningCtxWithOracle(
 		}
 		prohibitParallelization, hasScanNodeToParallelize := checkScanParallelizationIfLocal(
 			ctx, &planner.curPlan.planComponents,
+			evalCtx.SessionData().MaxLocalParallelism > 1, /* allowOrderedScans */
 		)
 		if prohibitParallelization || !hasScanNodeToParallelize {
 			return planCtx
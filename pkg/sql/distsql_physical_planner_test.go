@@ -19,6 +19,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/gossip"
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangecache"
@@ -48,6 +49,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/testutils/skip"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -1552,6 +1554,65 @@ func TestPartitionSpans(t *testing.T) {
 	}
 }
 
+// TestSplitSpanEvenly verifies that spans are split within ranges without
+// splitting the KVs of a single row across pieces.
+func TestSplitSpanEvenly(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const tableID, indexID, numFamilies = 104, 1, 3
+	codec := keys.SystemSQLCodec
+	indexPrefix := codec.IndexPrefix(tableID, indexID)
+	keyPrefixLength := len(indexPrefix)
+	rowPrefix := func(pk int64) roachpb.Key {
+		return encoding.EncodeVarintAscending(indexPrefix.Clone(), pk)
+	}
+
+	// findPiece returns the index of the piece containing the given key.
+	findPiece := func(pieces roachpb.Spans, key roachpb.Key) int {
+		for i, piece := range pieces {
+			if piece.ContainsKey(key) {
+				return i
+			}
+		}
+		t.Fatalf("key %s is not contained in any piece of %v", key, pieces)
+		return -1
+	}
+
+	t.Run("index", func(t *testing.T) {
+		span := roachpb.Span{Key: indexPrefix, EndKey: indexPrefix.PrefixEnd()}
+		pieces := splitSpanEvenly(span, 4, keyPrefixLength, 1 /* numKeyCols */)
+		require.Len(t, pieces, 4)
+		require.Equal(t, span.Key, pieces[0].Key)
+		require.Equal(t, span.EndKey, pieces[len(pieces)-1].EndKey)
+		for i := 1; i < len(pieces); i++ {
+			require.Equal(t, pieces[i-1].EndKey, pieces[i].Key)
+		}
+		// All KVs of every row must belong to the same piece.
+		for pk := int64(-1000); pk <= 100000; pk++ {
+			row := rowPrefix(pk)
+			expected := findPiece(pieces, keys.MakeFamilyKey(row.Clone(), 0))
+			for famID := uint32(1); famID < numFamilies; famID++ {
+				require.Equal(t, expected, findPiece(pieces, keys.MakeFamilyKey(row.Clone(), famID)), "pk %d", pk)
+			}
+		}
+	})
+
+	t.Run("single row", func(t *testing.T) {
+		// A span containing a single row cannot be split if the row has
+		// multiple KVs.
+		span := roachpb.Span{Key: rowPrefix(1000), EndKey: rowPrefix(1000).PrefixEnd()}
+		require.Len(t, splitSpanEvenly(span, 4, keyPrefixLength, 1 /* numKeyCols */), 1)
+		// If every row has a single KV, any split key is safe.
+		require.Len(t, splitSpanEvenly(span, 4, keyPrefixLength, 0 /* numKeyCols */), 4)
+	})
+
+	t.Run("point lookup", func(t *testing.T) {
+		span := roachpb.Span{Key: keys.MakeFamilyKey(rowPrefix(1), 0)}
+		require.Equal(t, roachpb.Spans{span}, splitSpanEvenly(span, 4, keyPrefixLength, 1 /* numKeyCols */))
+	})
+}
+
 // TestShouldPickGatewayNode is a unit test of the shouldPickGateway method.
 func TestShouldPickGatewayNode(t *testing.T) {
 	defer leaktest.AfterTest(t)()
@@ -1855,6 +1916,7 @@ func TestCheckScanParallelizationIfLocal(t *testing.T) {
 	scanToParallelize := &scanNode{parallelize: true}
 	for _, tc := range []struct {
 		plan                     planComponents
+		allowOrderedScans        bool
 		prohibitParallelization  bool
 		hasScanNodeToParallelize bool
 	}{
@@ -1868,6 +1930,13 @@ func TestCheckScanParallelizationIfLocal(t *testing.T) {
 			// scanNode.reqOrdering is not empty.
 			hasScanNodeToParallelize: false,
 		},
+		{
+			plan:              planComponents{main: planMaybePhysical{planNode: &scanNode{parallelize: true, reqOrdering: ReqOrdering{{}}}}},
+			allowOrderedScans: true,
+			// scanNode.reqOrdering is not empty, but its parallel pipelines
+			// can be merged by an ordered synchronizer.
+			hasScanNodeToParallelize: true,
+		},
 		{
 			plan:                     planComponents{main: planMaybePhysical{planNode: scanToParallelize}},
 			hasScanNodeToParallelize: true,
@@ -2017,7 +2086,7 @@ func TestCheckScanParallelizationIfLocal(t *testing.T) {
 			prohibitParallelization: true,
 		},
 	} {
-		prohibitParallelization, hasScanNodeToParallize := checkScanParallelizationIfLocal(context.Background(), &tc.plan)
+		prohibitParallelization, hasScanNodeToParallize := checkScanParallelizationIfLocal(context.Background(), &tc.plan, tc.allowOrderedScans)
 		require.Equal(t, tc.prohibitParallelization, prohibitParallelization)
 		require.Equal(t, tc.hasScanNodeToParallelize, hasScanNodeToParallize)
 	}
@@ -7,6 +7,7 @@ package sql
 
 import (
 	"context"
+	"slices"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
@@ -40,6 +41,14 @@ type joinPlanningInfo struct {
 	leftPlanDistribution, rightPlanDistribution physicalplan.PlanDistribution
 	allowPartialDistribution                    bool
 	finalizeLastStageCb                         func(*physicalplan.PhysicalPlan)
+	// rightCardinalityCheck is the cardinality check performed on the right
+	// input of a hash join. It is only used when planning a hash join.
+	rightCardinalityCheck execinfrapb.CardinalityCheck
+	// leftPartitionedScan and rightPartitionedScan, if set, are the scans of
+	// identically partitioned indexes that produce the rows of the left and
+	// right inputs. They are only set if the join can be planned
+	// partition-wise (see planPartitionWiseJoin).
+	leftPartitionedScan, rightPartitionedScan *scanNode
 }
 
 // makeCoreSpec creates a processor core for hash and merge joins based on the
@@ -56,12 +65,13 @@ func (info *joinPlanningInfo) makeCoreSpec() execinfrapb.ProcessorCoreUnion {
 	if len(info.leftMergeOrd.Columns) == 0 {
 		// There is no required ordering on the columns, so we plan a hash join.
 		core.HashJoiner = &execinfrapb.HashJoinerSpec{
-			LeftEqColumns:        info.leftEqCols,
-			RightEqColumns:       info.rightEqCols,
-			OnExpr:               info.onExpr,
-			Type:                 info.joinType,
-			LeftEqColumnsAreKey:  info.leftEqColsAreKey,
-			RightEqColumnsAreKey: info.rightEqColsAreKey,
+			LeftEqColumns:         info.leftEqCols,
+			RightEqColumns:        info.rightEqCols,
+			OnExpr:                info.onExpr,
+			Type:                  info.joinType,
+			LeftEqColumnsAreKey:   info.leftEqColsAreKey,
+			RightEqColumnsAreKey:  info.rightEqColsAreKey,
+			RightCardinalityCheck: info.rightCardinalityCheck,
 		}
 	} else {
 		core.MergeJoiner = &execinfrapb.MergeJoinerSpec{
@@ -220,5 +230,12 @@ func findJoinProcessorNodes(
 ) (instances []base.SQLInstanceID) {
 	// TODO(radu): for now we run a join processor on every node that produces
 	// data for either source. In the future we should be smarter here.
-	return getSQLInstanceIDsOfRouters(append(leftRouters, rightRouters...), processors)
+	instances = getSQLInstanceIDsOfRouters(append(leftRouters, rightRouters...), processors)
+	if n := max(len(leftRouters), len(rightRouters)); len(instances) == 1 && n > 1 {
+		// All inputs are parallel pipelines on a single node (see
+		// getStageSQLInstanceIDs), so we run a join processor for each
+		// pipeline.
+		instances = slices.Repeat(instances, n)
+	}
+	return instances
 }
@@ -0,0 +1,273 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"encoding/binary"
+	"slices"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
+)
+
+// maxLocalParallelism is the maximum value of the max_local_parallelism
+// session variable.
+const maxLocalParallelism = 64
+
+// maybePlanParallelLocalPipelines checks whether the scan of a local plan
+// should be split into multiple span partitions, each of which is processed by
+// a separate pipeline on the gateway.
+//
+// Unlike the parallel TableReaders planned by maybeParallelizeLocalScans, which
+// are merged into a single stream right away, the TableReaders planned here
+// remain separate result routers of the plan. As a result, the stages above
+// the scan (filters, projections, aggregations, joins, etc) are planned once
+// for each stream too, and the streams are only merged by a parallel unordered
+// or an ordered synchronizer once they cannot be processed independently
+// anymore.
+//
+// The spans are split at range boundaries, and contiguous ranges are assigned
+// to the same partition. Rows never straddle range boundaries, so this is safe
+// even if the table has multiple column families. If there are fewer ranges
+// than pipelines, which is common for small clusters where a table often fits
+// in a single range, the pieces are additionally split within the ranges (see
+// splitSpanEvenly).
+func (dsp *DistSQLPlanner) maybePlanParallelLocalPipelines(
+	ctx context.Context, planCtx *PlanningCtx, info *tableReaderPlanningInfo,
+) (spanPartitions []SpanPartition, ok bool) {
+	sd := planCtx.ExtendedEvalCtx.SessionData()
+	parallelism := int(sd.MaxLocalParallelism)
+	if parallelism <= 1 ||
+		!info.parallelize ||
+		!planCtx.parallelizeScansIfLocal ||
+		sd.VectorizeMode == sessiondatapb.VectorizeOff ||
+		info.post.Limit != 0 ||
+		planCtx.spanIter == nil { // This condition can only be true in tests.
+		return nil, false
+	}
+
+	// Break up the spans into the pieces that belong to each range.
+	ranges, err := splitSpansAtRangeBoundaries(ctx, planCtx, info.spans)
+	if err != nil {
+		log.VEventf(ctx, 2, "unable to plan parallel local pipelines: %v", err)
+		return nil, false
+	}
+	if len(ranges) < parallelism {
+		ranges = splitRangesEvenly(&info.spec.FetchSpec, ranges, parallelism)
+	}
+	if parallelism > len(ranges) {
+		parallelism = len(ranges)
+	}
+	if quota := int(dsp.parallelLocalScansSem.ApproximateQuota()); parallelism > quota+1 {
+		parallelism = quota + 1
+	}
+	// Try acquiring the quota for all additional pipelines, reducing the
+	// parallelism until the quota is available.
+	var alloc *quotapool.IntAlloc
+	for parallelism > 1 {
+		if alloc, err = dsp.parallelLocalScansSem.TryAcquire(ctx, uint64(parallelism-1)); err == nil {
+			break
+		}
+		parallelism--
+	}
+	if parallelism <= 1 {
+		return nil, false
+	}
+	planCtx.onFlowCleanup = append(planCtx.onFlowCleanup, alloc.Release)
+
+	// Assign contiguous ranges to each partition, so that each pipeline reads
+	// a contiguous part of the index.
+	spanPartitions = make([]SpanPartition, parallelism)
+	for i := range spanPartitions {
+		spanPartitions[i].SQLInstanceID = dsp.gatewaySQLInstanceID
+		start, end := i*len(ranges)/parallelism, (i+1)*len(ranges)/parallelism
+		for _, spans := range ranges[start:end] {
+			spanPartitions[i].Spans = append(spanPartitions[i].Spans, spans...)
+		}
+	}
+	return spanPartitions, true
+}
+
+// splitSpansAtRangeBoundaries returns the given spans grouped by the ranges
+// that contain them, in order. Spans that cross range boundaries are split
+// into a piece for each range.
+func splitSpansAtRangeBoundaries(
+	ctx context.Context, planCtx *PlanningCtx, spans roachpb.Spans,
+) (ranges []roachpb.Spans, _ error) {
+	it := planCtx.spanIter
+	var lastRangeID roachpb.RangeID
+	addPiece := func(rangeID roachpb.RangeID, piece roachpb.Span) {
+		if len(ranges) == 0 || rangeID != lastRangeID {
+			ranges = append(ranges, nil)
+			lastRangeID = rangeID
+		}
+		ranges[len(ranges)-1] = append(ranges[len(ranges)-1], piece)
+	}
+	for _, span := range spans {
+		rSpan, err := keys.SpanAddr(span)
+		if err != nil {
+			return nil, err
+		}
+		lastKey := rSpan.Key
+		for it.Seek(ctx, span, kvcoord.Ascending); ; it.Next(ctx) {
+			if !it.Valid() {
+				return nil, it.Error()
+			}
+			desc := it.Desc()
+			if len(span.EndKey) == 0 {
+				// This is a point lookup.
+				addPiece(desc.RangeID, span)
+				break
+			}
+			endKey := desc.EndKey
+			if rSpan.EndKey.Less(endKey) {
+				endKey = rSpan.EndKey
+			}
+			addPiece(desc.RangeID, roachpb.Span{Key: lastKey.AsRawKey(), EndKey: endKey.AsRawKey()})
+			if !endKey.Less(rSpan.EndKey) {
+				break
+			}
+			lastKey = endKey
+		}
+	}
+	return ranges, nil
+}
+
+// splitRangesEvenly splits the spans of the given ranges (as returned by
+// splitSpansAtRangeBoundaries) so that there are at least the given number of
+// pieces, if possible. Each returned element contains the pieces that should
+// be assigned to the same partition, in order.
+func splitRangesEvenly(
+	fetchSpec *fetchpb.IndexFetchSpec, ranges []roachpb.Spans, parallelism int,
+) []roachpb.Spans {
+	var numKeyCols int
+	switch {
+	case fetchSpec.MaxKeysPerRow == 1:
+		// Every row is stored in a single KV, so any key is a safe split key.
+	case !fetchSpec.IsSecondaryIndex:
+		// All key columns of the primary index are part of the key, so the split
+		// keys can be aligned to the row prefix.
+		numKeyCols = len(fetchSpec.KeyAndSuffixColumns)
+	default:
+		// The suffix columns of a unique secondary index are only sometimes part
+		// of the key, so we cannot determine the row prefix of a split key.
+		return ranges
+	}
+	piecesPerRange := (parallelism + len(ranges) - 1) / len(ranges)
+	result := make([]roachpb.Spans, 0, len(ranges)*piecesPerRange)
+	for _, spans := range ranges {
+		for _, span := range spans {
+			for _, piece := range splitSpanEvenly(
+				span, piecesPerRange, int(fetchSpec.KeyPrefixLength), numKeyCols,
+			) {
+				result = append(result, roachpb.Spans{piece})
+			}
+		}
+	}
+	return result
+}
+
+// splitSpanEvenly splits the given span into at most n pieces at keys that are
+// evenly spaced in the key space of the span. Note that this does not take the
+// distribution of the data into account, so the pieces might contain very
+// different numbers of rows.
+//
+// If numKeyCols is positive, each split key is truncated to the row prefix
+// that it starts with, if any, so that no row is split across two pieces. The
+// row prefix consists of the first keyPrefixLength bytes, which encode the
+// table and index IDs, followed by numKeyCols encoded key columns.
+func splitSpanEvenly(
+	span roachpb.Span, n int, keyPrefixLength, numKeyCols int,
+) (pieces roachpb.Spans) {
+	if n <= 1 || len(span.EndKey) == 0 {
+		return roachpb.Spans{span}
+	}
+	// Interpret the eight bytes following the common prefix of the start and
+	// end keys as integers, and interpolate between them.
+	commonPrefix := span.Key[:commonPrefixLen(span.Key, span.EndKey)]
+	readUint64 := func(key roachpb.Key) uint64 {
+		var buf [8]byte
+		copy(buf[:], key[len(commonPrefix):])
+		return binary.BigEndian.Uint64(buf[:])
+	}
+	start, end := readUint64(span.Key), readUint64(span.EndKey)
+	step := (end - start) / uint64(n)
+	if step == 0 {
+		return roachpb.Spans{span}
+	}
+	lastKey := span.Key
+	for i := 1; i < n; i++ {
+		splitKey := make(roachpb.Key, len(commonPrefix), len(commonPrefix)+8)
+		copy(splitKey, commonPrefix)
+		splitKey = binary.BigEndian.AppendUint64(splitKey, start+uint64(i)*step)
+		if numKeyCols > 0 {
+			splitKey = truncateToRowPrefix(splitKey, keyPrefixLength, numKeyCols)
+		}
+		if splitKey.Compare(lastKey) <= 0 || splitKey.Compare(span.EndKey) >= 0 {
+			continue
+		}
+		pieces = append(pieces, roachpb.Span{Key: lastKey, EndKey: splitKey})
+		lastKey = splitKey
+	}
+	return append(pieces, roachpb.Span{Key: lastKey, EndKey: span.EndKey})
+}
+
+// truncateToRowPrefix returns the row prefix of the given key if the key
+// starts with one (see splitSpanEvenly). Otherwise, the key is returned
+// unchanged, and no row prefix can be a prefix of the key since the encoded key
+// columns are self-delimiting. In both cases, the returned key does not fall
+// between the KVs of a single row.
+func truncateToRowPrefix(key roachpb.Key, keyPrefixLength, numKeyCols int) roachpb.Key {
+	if len(key) < keyPrefixLength {
+		return key
+	}
+	n := keyPrefixLength
+	for i := 0; i < numKeyCols; i++ {
+		l, err := encoding.PeekLength(key[n:])
+		if err != nil || n+l > len(key) {
+			return key
+		}
+		n += l
+	}
+	return key[:n]
+}
+
+// commonPrefixLen returns the length of the longest common prefix of a and b.
+func commonPrefixLen(a, b roachpb.Key) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+// getStageSQLInstanceIDs returns the instances on which the processors of a
+// stage consuming the given routers should be planned, one processor for each
+// returned instance. Usually, these are the distinct instances of the routers.
+// However, if all routers are on a single instance, which is the case for the
+// parallel pipelines of local plans (see maybePlanParallelLocalPipelines), the
+// instance is returned once for each router so that the stage remains
+// parallel.
+func getStageSQLInstanceIDs(
+	routers []physicalplan.ProcessorIdx, processors []physicalplan.Processor,
+) []base.SQLInstanceID {
+	sqlInstanceIDs := getSQLInstanceIDsOfRouters(routers, processors)
+	if len(sqlInstanceIDs) == 1 && len(routers) > 1 {
+		sqlInstanceIDs = slices.Repeat(sqlInstanceIDs, len(routers))
+	}
+	return sqlInstanceIDs
+}
@@ -0,0 +1,262 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"slices"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/distribution"
+	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+var partitionWiseJoinsEnabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.distsql.partition_wise_joins.enabled",
+	"if enabled, joins of co-partitioned tables on their partitioning columns "+
+		"are performed separately for each set of partitions, on the nodes that "+
+		"read them, instead of redistributing rows across all nodes",
+	true,
+)
+
+// remainderPartitionKey is the partition key of the rows that are not in any
+// partition with explicit values, i.e. that are in a DEFAULT partition or
+// not in any partition.
+const remainderPartitionKey = ""
+
+// partitionPrefix is the span of the index keys with the given values of the
+// partitioning columns.
+type partitionPrefix struct {
+	key  string
+	span roachpb.Span
+}
+
+// partitionedScanNode returns the scanNode that produces the rows of the given
+// planNode, if the planNode is a scanNode optionally wrapped in filterNodes and
+// renderNodes. This matches distribution.PartitionAligned.
+func partitionedScanNode(n planNode) *scanNode {
+	for {
+		switch t := n.(type) {
+		case *filterNode:
+			n = t.input
+		case *renderNode:
+			n = t.input
+		case *scanNode:
+			return t
+		default:
+			return nil
+		}
+	}
+}
+
+// makePartitionPrefixes returns the spans of the values of the PARTITION BY
+// LIST partitions of the given index, along with their partition keys (see
+// distribution.PartitionKey). It returns ok=false if the index is not
+// partitioned by list, or if the values do not all have the same number of
+// columns.
+func makePartitionPrefixes(
+	codec keys.SQLCodec, desc catalog.TableDescriptor, index catalog.Index,
+) (prefixes []partitionPrefix, ok bool, _ error) {
+	part := index.GetPartitioning()
+	if part.NumLists() == 0 {
+		return nil, false, nil
+	}
+	var a tree.DatumAlloc
+	numCols := -1
+	uniform := true
+	if err := part.ForEachList(func(_ string, values [][]byte, _ catalog.Partitioning) error {
+		for _, valueEncBuf := range values {
+			t, keyPrefix, err := rowenc.DecodePartitionTuple(
+				&a, codec, desc, index, part, valueEncBuf, nil, /* prefixDatums */
+			)
+			if err != nil {
+				return err
+			}
+			if len(t.Datums) == 0 {
+				// This is the DEFAULT value.
+				continue
+			}
+			if numCols == -1 {
+				numCols = len(t.Datums)
+			} else if numCols != len(t.Datums) {
+				uniform = false
+			}
+			prefixes = append(prefixes, partitionPrefix{
+				key:  distribution.PartitionKey(t.Datums),
+				span: roachpb.Span{Key: keyPrefix, EndKey: roachpb.Key(keyPrefix).PrefixEnd()},
+			})
+		}
+		return nil
+	}); err != nil {
+		return nil, false, err
+	}
+	if !uniform || len(prefixes) == 0 {
+		return nil, false, nil
+	}
+	return prefixes, true, nil
+}
+
+// spanPartitionKeys returns the keys of the partitions that overlap the given
+// span.
+func spanPartitionKeys(span roachpb.Span, prefixes []partitionPrefix) []string {
+	var partitionKeys []string
+	contained := false
+	for i := range prefixes {
+		if prefixes[i].span.Overlaps(span) {
+			partitionKeys = append(partitionKeys, prefixes[i].key)
+			contained = contained || prefixes[i].span.Contains(span)
+		}
+	}
+	if !contained {
+		partitionKeys = append(partitionKeys, remainderPartitionKey)
+	}
+	return partitionKeys
+}
+
+// planPartitionWiseJoin returns the partitions of a partition-wise join of the
+// given left and right routers, if the join can be planned partition-wise.
+//
+// A join can be planned partition-wise if the optimizer determined that its
+// inputs are scans of identically partitioned indexes and its equality columns
+// include the partitioning columns (see distribution.PartitionAligned), and if
+// the rows of each partition are read on a subset of the nodes that is
+// disjoint from the nodes that read other partitions. For example, this is the
+// case for joins of REGIONAL BY ROW tables on their region columns, where each
+// partition is read by the leaseholders in its region. Matching rows are then
+// always read by nodes of the same partition, so rows only need to be
+// distributed among those nodes, instead of across all regions.
+func (dsp *DistSQLPlanner) planPartitionWiseJoin(
+	ctx context.Context,
+	planCtx *PlanningCtx,
+	info *joinPlanningInfo,
+	processors []physicalplan.Processor,
+	leftRouters, rightRouters []physicalplan.ProcessorIdx,
+) (partitions []physicalplan.JoinPartition, ok bool) {
+	if info.leftPartitionedScan == nil || info.rightPartitionedScan == nil ||
+		len(info.leftEqCols) == 0 || planCtx.isLocal ||
+		!partitionWiseJoinsEnabled.Get(&dsp.st.SV) {
+		return nil, false
+	}
+	codec := planCtx.ExtendedEvalCtx.Codec
+	leftScan, rightScan := info.leftPartitionedScan, info.rightPartitionedScan
+	leftPrefixes, ok, err := makePartitionPrefixes(codec, leftScan.desc, leftScan.index)
+	if err != nil {
+		log.VEventf(ctx, 2, "unable to plan partition-wise join: %v", err)
+	}
+	if !ok {
+		return nil, false
+	}
+	rightPrefixes, ok, err := makePartitionPrefixes(codec, rightScan.desc, rightScan.index)
+	if err != nil {
+		log.VEventf(ctx, 2, "unable to plan partition-wise join: %v", err)
+	}
+	if !ok || len(leftPrefixes) != len(rightPrefixes) {
+		return nil, false
+	}
+	leftKeys := make(map[string]struct{}, len(leftPrefixes))
+	for i := range leftPrefixes {
+		leftKeys[leftPrefixes[i].key] = struct{}{}
+	}
+	for i := range rightPrefixes {
+		if _, ok := leftKeys[rightPrefixes[i].key]; !ok {
+			return nil, false
+		}
+	}
+
+	// Group the nodes that read the same partitions of either input, using a
+	// union-find over the nodes.
+	parent := make(map[base.SQLInstanceID]base.SQLInstanceID)
+	var find func(id base.SQLInstanceID) base.SQLInstanceID
+	find = func(id base.SQLInstanceID) base.SQLInstanceID {
+		if p, ok := parent[id]; ok && p != id {
+			parent[id] = find(p)
+			return parent[id]
+		}
+		parent[id] = id
+		return id
+	}
+	owners := make(map[string]base.SQLInstanceID)
+	addRouters := func(routers []physicalplan.ProcessorIdx, prefixes []partitionPrefix) bool {
+		for _, r := range routers {
+			proc := &processors[r]
+			tr := proc.Spec.Core.TableReader
+			if tr == nil {
+				// The rows of the router are not read directly from the table.
+				return false
+			}
+			id := find(proc.SQLInstanceID)
+			for _, span := range tr.Spans {
+				for _, key := range spanPartitionKeys(span, prefixes) {
+					if owner, ok := owners[key]; ok {
+						parent[find(owner)] = find(id)
+					} else {
+						owners[key] = id
+					}
+				}
+			}
+		}
+		return true
+	}
+	if !addRouters(leftRouters, leftPrefixes) || !addRouters(rightRouters, rightPrefixes) {
+		return nil, false
+	}
+
+	// Build a partition for each group of nodes.
+	partitionIdx := make(map[base.SQLInstanceID]int)
+	addToPartition := func(r physicalplan.ProcessorIdx) *physicalplan.JoinPartition {
+		id := processors[r].SQLInstanceID
+		root := find(id)
+		idx, ok := partitionIdx[root]
+		if !ok {
+			idx = len(partitions)
+			partitionIdx[root] = idx
+			partitions = append(partitions, physicalplan.JoinPartition{})
+		}
+		part := &partitions[idx]
+		if !slices.Contains(part.SQLInstanceIDs, id) {
+			part.SQLInstanceIDs = append(part.SQLInstanceIDs, id)
+		}
+		return part
+	}
+	for _, r := range leftRouters {
+		part := addToPartition(r)
+		part.LeftRouters = append(part.LeftRouters, r)
+	}
+	for _, r := range rightRouters {
+		part := addToPartition(r)
+		part.RightRouters = append(part.RightRouters, r)
+	}
+
+	// Join processors need rows from both inputs, so partitions that only read
+	// one of the inputs are merged into another partition. Merging partitions
+	// is always correct, since matching rows stay within the same partition.
+	var complete, incomplete []physicalplan.JoinPartition
+	for _, part := range partitions {
+		if len(part.LeftRouters) > 0 && len(part.RightRouters) > 0 {
+			complete = append(complete, part)
+		} else {
+			incomplete = append(incomplete, part)
+		}
+	}
+	if len(complete) < 2 {
+		// There is nothing to gain over a regular join.
+		return nil, false
+	}
+	for _, part := range incomplete {
+		complete[0].SQLInstanceIDs = append(complete[0].SQLInstanceIDs, part.SQLInstanceIDs...)
+		complete[0].LeftRouters = append(complete[0].LeftRouters, part.LeftRouters...)
+		complete[0].RightRouters = append(complete[0].RightRouters, part.RightRouters...)
+	}
+	return complete, true
+}
@@ -10,6 +10,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/settings"
@@ -671,6 +672,12 @@ func (dsp *DistSQLPlanner) createStatsPlan(
 		colIdxMap.Set(col.GetID(), i)
 	}
 
+	// Extended statistics are only collected for multi-column statistics, and
+	// only once all nodes are able to read them.
+	generateExtendedStats := stats.ExtendedStatisticsClusterMode.Get(&dsp.st.SV) &&
+		dsp.st.Version.IsActive(ctx, clusterversion.V26_1)
+	maxMCVs := uint32(stats.MaxExtendedStatisticsMCVs.Get(&dsp.st.SV))
+
 	var sketchSpecs, invSketchSpecs []execinfrapb.SketchSpec
 	sampledColumnIDs := make([]descpb.ColumnID, len(requestedCols))
 	for _, s := range reqStats {
@@ -715,6 +722,10 @@ func (dsp *DistSQLPlanner) createStatsPlan(
 			// descriptor to generate the inverted index entries.
 			invSketchSpecs = append(invSketchSpecs, spec)
 		} else {
+			if len(s.columns) > 1 && generateExtendedStats {
+				spec.GenerateExtendedStatistics = true
+				spec.MaxMostCommonValues = maxMCVs
+			}
 			sketchSpecs = append(sketchSpecs, spec)
 		}
 	}
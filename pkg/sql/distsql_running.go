@@ -2452,8 +2452,12 @@ func (dsp *DistSQLPlanner) PlanAndRunPostQueries(
 				continue
 			}
 
-			log.VEventf(ctx, 2, "executing cascade for constraint %s",
-				plan.cascades[cascadesIdx].FKConstraint.Name())
+			if hist := plan.cascades[cascadesIdx].HistoryTable; hist != nil {
+				log.VEventf(ctx, 2, "executing insert into history table %s", hist.Name())
+			} else {
+				log.VEventf(ctx, 2, "executing cascade for constraint %s",
+					plan.cascades[cascadesIdx].FKConstraint.Name())
+			}
 
 			// We place a sequence point before every cascade, so that each subsequent
 			// cascade can observe the writes by the previous step. However, The
@@ -316,6 +316,7 @@ func (e *distSQLSpecExecFactory) ConstructScan(
 	*trSpec = execinfrapb.TableReaderSpec{
 		Reverse:                         params.Reverse,
 		TableDescriptorModificationTime: tabDesc.GetModificationTime(),
+		Sample:                          makeTableSampleSpec(params.Sample),
 	}
 	if err := rowenc.InitIndexFetchSpec(&trSpec.FetchSpec, e.planner.ExecCfg().Codec, tabDesc, idx, columnIDs); err != nil {
 		return nil, err
@@ -532,7 +533,11 @@ func (e *distSQLSpecExecFactory) ConstructHashJoin(
 	leftEqColsAreKey, rightEqColsAreKey bool,
 	extraOnCond tree.TypedExpr,
 	estimatedLeftRowCount, estimatedRightRowCount uint64,
+	rightCardinalityCheckID int32,
+	partitionAligned bool,
 ) (exec.Node, error) {
+	// Cardinality checks and partition-wise joins are not supported by this
+	// factory, so rightCardinalityCheckID and partitionAligned are ignored.
 	return e.constructHashOrMergeJoin(
 		joinType, left, right, extraOnCond, leftEqCols, rightEqCols,
 		leftEqColsAreKey, rightEqColsAreKey,
@@ -549,7 +554,10 @@ func (e *distSQLSpecExecFactory) ConstructMergeJoin(
 	reqOrdering exec.OutputOrdering,
 	leftEqColsAreKey, rightEqColsAreKey bool,
 	estimatedLeftRowCount, estimatedRightRowCount uint64,
+	partitionAligned bool,
 ) (exec.Node, error) {
+	// Partition-wise joins are not supported by this factory, so
+	// partitionAligned is ignored.
 	leftEqCols, rightEqCols, mergeJoinOrdering, err := getEqualityIndicesAndMergeJoinOrdering(leftOrdering, rightOrdering)
 	if err != nil {
 		return nil, err
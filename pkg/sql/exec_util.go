@@ -81,6 +81,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
 	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rolemembershipcache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
@@ -1729,6 +1730,8 @@ type ExecutorConfig struct {
 	StatsRefresher      *stats.Refresher
 	QueryCache          *querycache.C
 	StatementHintsCache *hints.StatementHintsCache
+	PlanBaselineEvolver *hints.PlanBaselineEvolver
+	ResultCache         *resultcache.Cache
 	VecIndexManager     *vecindex.Manager
 
 	SchemaChangerMetrics *SchemaChangerMetrics
@@ -30,6 +30,10 @@ type BackupRestoreTestingKnobs struct {
 	// loaded/created on the resumption of a compaction job.
 	AfterLoadingCompactionManifestOnResume func(manifest *backuppb.BackupManifest)
 
+	// AfterTableFingerprints, if set, is called with the table fingerprints
+	// recorded by a backup before its manifest is written, and may modify them.
+	AfterTableFingerprints func(fingerprints []backuppb.BackupManifest_TableFingerprint)
+
 	// CaptureResolvedTableDescSpans allows for intercepting the spans which are
 	// resolved during backup planning, and will eventually be backed up during
 	// execution.
@@ -0,0 +1,82 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package execinfrapb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gogo/protobuf/proto"
+)
+
+// Enabled returns true if the check should be performed.
+func (c *CardinalityCheck) Enabled() bool {
+	return c.ID != 0
+}
+
+// CardinalityMisestimateError is returned when a CardinalityCheck fails, i.e.
+// when an input of a processor produced many more rows than the optimizer
+// estimated. The gateway can use it to re-optimize the statement.
+type CardinalityMisestimateError struct {
+	Misestimate CardinalityMisestimate
+}
+
+var _ error = (*CardinalityMisestimateError)(nil)
+var _ fmt.Formatter = (*CardinalityMisestimateError)(nil)
+var _ errors.SafeFormatter = (*CardinalityMisestimateError)(nil)
+
+// NewCardinalityMisestimateError returns a new CardinalityMisestimateError
+// for the given failed check.
+func NewCardinalityMisestimateError(
+	check CardinalityCheck, observedRowCount uint64,
+) *CardinalityMisestimateError {
+	return &CardinalityMisestimateError{
+		Misestimate: CardinalityMisestimate{
+			Check:            check,
+			ObservedRowCount: observedRowCount,
+		},
+	}
+}
+
+// Error implements the error interface.
+func (e *CardinalityMisestimateError) Error() string { return fmt.Sprintf("%v", e) }
+
+// Format implements the fmt.Formatter interface.
+func (e *CardinalityMisestimateError) Format(s fmt.State, verb rune) { errors.FormatError(e, s, verb) }
+
+// SafeFormatError implements the errors.SafeFormatter interface.
+func (e *CardinalityMisestimateError) SafeFormatError(p errors.Printer) (next error) {
+	p.Printf("cardinality misestimate: estimated %d rows, observed at least %d rows",
+		e.Misestimate.Check.EstimatedRowCount, e.Misestimate.ObservedRowCount)
+	return nil
+}
+
+func encodeCardinalityMisestimateError(
+	_ context.Context, err error,
+) (msgPrefix string, safe []string, details proto.Message) {
+	e := err.(*CardinalityMisestimateError)
+	return e.Error(), nil, &e.Misestimate
+}
+
+func decodeCardinalityMisestimateError(
+	_ context.Context, _ string, _ []string, payload proto.Message,
+) error {
+	m, ok := payload.(*CardinalityMisestimate)
+	if !ok {
+		// If this ever happens, this means some version of the library changed
+		// the payload type. In this case, give up and let DecodeError use the
+		// opaque type.
+		return nil
+	}
+	return &CardinalityMisestimateError{Misestimate: *m}
+}
+
+func init() {
+	pKey := errors.GetTypeKey((*CardinalityMisestimateError)(nil))
+	errors.RegisterLeafEncoder(pKey, encodeCardinalityMisestimateError)
+	errors.RegisterLeafDecoder(pKey, decodeCardinalityMisestimateError)
+}
@@ -223,6 +223,12 @@ func (tr *TableReaderSpec) summary() (string, []string) {
 		))
 	}
 
+	if tr.Sample != nil {
+		details = append(details, fmt.Sprintf(
+			"Sample: %s (%.6g%%)", tr.Sample.Method, tr.Sample.Fraction*100,
+		))
+	}
+
 	return "TableReader", details
 }
 
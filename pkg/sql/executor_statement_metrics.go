@@ -230,6 +230,8 @@ func (ex *connExecutor) recordStatementSummary(
 		ex.statsCollector.RecordStatement(ctx, b.Build())
 	}
 
+	planner.recordPlanBaselineExecution(ctx, stmtErr, ex.statsCollector.RunLatency())
+
 	// Record statement execution statistics if span is recorded and no error was
 	// encountered while collecting query-level statistics.
 	if queryLevelStatsOk {
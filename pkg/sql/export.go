@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/featureflag"
@@ -18,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
@@ -50,6 +52,9 @@ type exportPlanningInfo struct {
 	colNames            []string
 	headerRow           bool
 	finalizeLastStageCb func(*physicalplan.PhysicalPlan) // will be nil in the spec factory
+	// copyTableName is the table that the COPY statements of the sql file
+	// format copy rows into.
+	copyTableName string
 }
 
 func (e *exportNode) startExec(params runParams) error {
@@ -76,6 +81,7 @@ const (
 	exportOptionFileName    = "filename"
 	exportOptionCompression = "compression"
 	exportOptionHeaderRow   = "header_row"
+	exportOptionTableName   = "table_name"
 
 	exportChunkSizeDefault = int64(32 << 20) // 32 MB
 	exportChunkRowsDefault = 100000
@@ -86,6 +92,7 @@ const (
 	exportSnappyCodec     = "snappy"
 	csvSuffix             = "csv"
 	parquetSuffix         = "parquet"
+	sqlSuffix             = "sql"
 )
 
 var exportOptionExpectValues = map[string]exprutil.KVStringOptValidate{
@@ -96,6 +103,7 @@ var exportOptionExpectValues = map[string]exprutil.KVStringOptValidate{
 	exportOptionCompression: exprutil.KVStringOptRequireValue,
 	exportOptionChunkSize:   exprutil.KVStringOptRequireValue,
 	exportOptionHeaderRow:   exprutil.KVStringOptRequireNoValue,
+	exportOptionTableName:   exprutil.KVStringOptRequireValue,
 }
 
 // featureExportEnabled is used to enable and disable the EXPORT feature.
@@ -159,7 +167,7 @@ func buildExportPlanningInfo(
 		return nil, errors.Errorf("EXPORT cannot be used inside a multi-statement transaction")
 	}
 
-	if fileSuffix != csvSuffix && fileSuffix != parquetSuffix {
+	if fileSuffix != csvSuffix && fileSuffix != parquetSuffix && fileSuffix != sqlSuffix {
 		return nil, errors.Errorf("unsupported export format: %q", fileSuffix)
 	}
 
@@ -242,6 +250,39 @@ func buildExportPlanningInfo(
 		}
 		format.Format = roachpb.IOFileFormat_Parquet
 		format.Parquet = parquetOpts
+	case sqlSuffix:
+		// The sql file format writes COPY statements in the text format of
+		// COPY, so that the files can be run by psql.
+		copyOpts := roachpb.PgCopyOptions{Delimiter: '\t', Null: `\N`}
+		if override, ok := optVals[exportOptionDelimiter]; ok {
+			delimiter, err := util.GetSingleRune(override)
+			if err != nil || delimiter > unicode.MaxASCII {
+				return nil, pgerror.New(pgcode.InvalidParameterValue, "invalid delimiter")
+			}
+			copyOpts.Delimiter = int32(delimiter)
+		}
+		if override, ok := optVals[exportOptionNullAs]; ok {
+			copyOpts.Null = override
+		}
+		format.Format = roachpb.IOFileFormat_PgCopy
+		format.PgCopy = copyOpts
+	}
+
+	var copyTableName string
+	if name, ok := optVals[exportOptionTableName]; ok {
+		if fileSuffix != sqlSuffix {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"%s is only supported for sql file format", exportOptionTableName)
+		}
+		tn, err := parser.ParseQualifiedTableName(name)
+		if err != nil {
+			return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+				"invalid %s", exportOptionTableName)
+		}
+		copyTableName = tree.AsString(tn)
+	} else if fileSuffix == sqlSuffix {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s is required for sql file format", exportOptionTableName)
 	}
 
 	chunkRows := exportChunkRowsDefault
@@ -301,5 +342,6 @@ func buildExportPlanningInfo(
 		chunkSize:       chunkSize,
 		colNames:        colNames,
 		headerRow:       headerRow,
+		copyTableName:   copyTableName,
 	}, nil
 }
@@ -0,0 +1,326 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execopnode"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/unique"
+	"github.com/cockroachdb/errors"
+)
+
+// copyNullDefault and copyDelimiterDefault are the defaults of the text format
+// of COPY, which is what the sql file format writes rows in.
+const (
+	copyNullDefault      = `\N`
+	copyDelimiterDefault = '\t'
+)
+
+// sqlWriter is the EXPORT processor for the sql file format. Each file it
+// writes is a self-contained COPY ... FROM STDIN statement followed by its
+// rows in the text format of COPY, which can be run by psql or cockroach sql
+// against either Postgres or CockroachDB.
+type sqlWriter struct {
+	execinfra.ProcessorBase
+
+	spec       execinfrapb.ExportSpec
+	input      execinfra.RowSource
+	inputTypes []*types.T
+	uniqueID   int64
+
+	buf        bytes.Buffer
+	compressor *gzip.Writer
+	w          io.Writer
+	f          *tree.FmtCtx
+	copyHeader string
+	delimiter  byte
+	nullsAs    string
+
+	runningState exportState
+	chunk        int
+	rows         int64
+
+	alloc tree.DatumAlloc
+}
+
+var _ execinfra.RowSourcedProcessor = &sqlWriter{}
+var _ execopnode.OpNode = &sqlWriter{}
+
+// NewSQLWriterProcessor returns the EXPORT processor for the sql file format.
+func NewSQLWriterProcessor(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec execinfrapb.ExportSpec,
+	post *execinfrapb.PostProcessSpec,
+	input execinfra.RowSource,
+) (execinfra.Processor, error) {
+	if spec.CopyTableName == "" {
+		return nil, errors.AssertionFailedf("sql export requires a table name")
+	}
+	sp := &sqlWriter{
+		spec:       spec,
+		input:      input,
+		inputTypes: input.OutputTypes(),
+		uniqueID:   unique.GenerateUniqueInt(unique.ProcessUniqueID(flowCtx.EvalCtx.NodeID.SQLInstanceID())),
+		f:          flowCtx.EvalCtx.FmtCtx(tree.FmtPgwireText),
+		copyHeader: makeCopyHeader(spec.CopyTableName, spec.ColNames),
+		delimiter:  copyDelimiterDefault,
+		nullsAs:    copyNullDefault,
+	}
+	if spec.Format.PgCopy.Delimiter != 0 {
+		sp.delimiter = byte(spec.Format.PgCopy.Delimiter)
+	}
+	if spec.Format.PgCopy.Null != "" {
+		sp.nullsAs = spec.Format.PgCopy.Null
+	}
+	sp.w = &sp.buf
+	if spec.Format.Compression == roachpb.IOFileFormat_Gzip {
+		sp.compressor = gzip.NewWriter(&sp.buf)
+		sp.w = sp.compressor
+	}
+	if err := sp.Init(
+		ctx, sp, post, colinfo.ExportColumnTypes, flowCtx, processorID, nil, /* memMonitor */
+		execinfra.ProcStateOpts{
+			InputsToDrain: []execinfra.RowSource{sp.input},
+		},
+	); err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+// makeCopyHeader returns the COPY statement that each file starts with.
+// tableName is expected to already be quoted as needed.
+func makeCopyHeader(tableName string, colNames []string) string {
+	var b bytes.Buffer
+	b.WriteString("COPY ")
+	b.WriteString(tableName)
+	b.WriteString(" (")
+	for i, name := range colNames {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		lexbase.EncodeRestrictedSQLIdent(&b, name, lexbase.EncNoFlags)
+	}
+	b.WriteString(") FROM STDIN;\n")
+	return b.String()
+}
+
+func (sp *sqlWriter) Start(ctx context.Context) {
+	ctx = sp.StartInternal(ctx, "sqlWriter")
+	sp.input.Start(ctx)
+	sp.runningState = exportNewChunk
+}
+
+func (sp *sqlWriter) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	for sp.State == execinfra.StateRunning {
+		switch sp.runningState {
+		case exportNewChunk:
+			sp.buf.Reset()
+			if sp.compressor != nil {
+				sp.compressor.Reset(&sp.buf)
+			}
+			sp.rows = 0
+			sp.runningState = exportContinueChunk
+			continue
+
+		case exportContinueChunk:
+			if int64(sp.buf.Len()) >= sp.spec.ChunkSize {
+				sp.runningState = exportFlushChunk
+				continue
+			}
+			if sp.spec.ChunkRows > 0 && sp.rows >= sp.spec.ChunkRows {
+				sp.runningState = exportFlushChunk
+				continue
+			}
+			row, meta := sp.input.Next()
+			if meta != nil {
+				return nil, meta
+			}
+			if row == nil {
+				if sp.rows > 0 {
+					sp.runningState = exportFlushChunk
+				} else {
+					sp.runningState = exportDone
+				}
+				continue
+			}
+			if sp.rows == 0 {
+				if _, err := io.WriteString(sp.w, sp.copyHeader); err != nil {
+					sp.MoveToDraining(err)
+					return nil, sp.DrainHelper()
+				}
+			}
+			sp.rows++
+			if err := sp.writeRow(row); err != nil {
+				sp.MoveToDraining(err)
+				return nil, sp.DrainHelper()
+			}
+
+		case exportFlushChunk:
+			// Terminate the COPY data of this file.
+			if _, err := io.WriteString(sp.w, "\\.\n"); err != nil {
+				sp.MoveToDraining(err)
+				return nil, sp.DrainHelper()
+			}
+			row, err := sp.exportFile()
+			if err != nil {
+				sp.MoveToDraining(err)
+				return nil, sp.DrainHelper()
+			}
+			sp.runningState = exportNewChunk
+			if outRow := sp.ProcessRowHelper(row); outRow != nil {
+				return outRow, nil
+			}
+
+		case exportDone:
+			sp.MoveToDraining(nil /* err */)
+
+		default:
+			log.Dev.Fatalf(sp.Ctx(), "unsupported state: %d", sp.runningState)
+		}
+	}
+
+	return nil, sp.DrainHelper()
+}
+
+// writeRow writes a single row in the text format of COPY.
+func (sp *sqlWriter) writeRow(row rowenc.EncDatumRow) error {
+	for i, ed := range row {
+		if i > 0 {
+			if _, err := sp.w.Write([]byte{sp.delimiter}); err != nil {
+				return err
+			}
+		}
+		if ed.IsNull() {
+			if _, err := io.WriteString(sp.w, sp.nullsAs); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := ed.EnsureDecoded(sp.inputTypes[i], &sp.alloc); err != nil {
+			return err
+		}
+		sp.f.FormatNode(ed.Datum)
+		if err := encodeCopyField(sp.w, sp.f.Bytes(), sp.delimiter); err != nil {
+			return err
+		}
+		sp.f.Reset()
+	}
+	_, err := sp.w.Write([]byte{'\n'})
+	return err
+}
+
+// copyEscapes maps the bytes that must be escaped in a field of the text
+// format of COPY to the character following the backslash.
+var copyEscapes = map[byte]byte{
+	'\\': '\\',
+	'\b': 'b',
+	'\f': 'f',
+	'\n': 'n',
+	'\r': 'r',
+	'\t': 't',
+	'\v': 'v',
+}
+
+// encodeCopyField writes a single field escaped for the text format of COPY.
+func encodeCopyField(w io.Writer, in []byte, delimiter byte) error {
+	lastIndex := 0
+	for i, c := range in {
+		escape, ok := copyEscapes[c]
+		if !ok && c != delimiter {
+			continue
+		}
+		if !ok {
+			escape = delimiter
+		}
+		if _, err := w.Write(in[lastIndex:i]); err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte{'\\', escape}); err != nil {
+			return err
+		}
+		lastIndex = i + 1
+	}
+	_, err := w.Write(in[lastIndex:])
+	return err
+}
+
+func (sp *sqlWriter) exportFile() (rowenc.EncDatumRow, error) {
+	conf, err := cloud.ExternalStorageConfFromURI(sp.spec.Destination, sp.spec.User())
+	if err != nil {
+		return nil, err
+	}
+	es, err := sp.FlowCtx.Cfg.ExternalStorage(sp.Ctx(), conf)
+	if err != nil {
+		return nil, err
+	}
+	defer es.Close()
+
+	part := fmt.Sprintf("n%d.%d", sp.uniqueID, sp.chunk)
+	sp.chunk++
+	fileName := strings.Replace(sp.spec.NamePattern, exportFilePatternPart, part, -1)
+	if sp.compressor != nil {
+		// Close the compressor to flush the gzip footer.
+		if err := sp.compressor.Close(); err != nil {
+			return nil, errors.Wrapf(err, "failed to close exporting writer")
+		}
+		fileName += ".gz"
+	}
+
+	size := sp.buf.Len()
+	if err := cloud.WriteFile(sp.Ctx(), es, fileName, bytes.NewReader(sp.buf.Bytes())); err != nil {
+		return nil, err
+	}
+	return rowenc.EncDatumRow{
+		rowenc.DatumToEncDatumUnsafe(types.String, tree.NewDString(fileName)),
+		rowenc.DatumToEncDatumUnsafe(types.Int, tree.NewDInt(tree.DInt(sp.rows))),
+		rowenc.DatumToEncDatumUnsafe(types.Int, tree.NewDInt(tree.DInt(size))),
+	}, nil
+}
+
+func (sp *sqlWriter) ConsumerClosed() {
+	if sp.InternalClose() {
+		if sp.compressor != nil {
+			_ = sp.compressor.Close()
+		}
+		sp.f.Close()
+	}
+}
+
+func (sp *sqlWriter) ChildCount(verbose bool) int {
+	if _, ok := sp.input.(execopnode.OpNode); ok {
+		return 1
+	}
+	return 0
+}
+
+func (sp *sqlWriter) Child(nth int, verbose bool) execopnode.OpNode {
+	if nth == 0 {
+		if n, ok := sp.input.(execopnode.OpNode); ok {
+			return n
+		}
+		panic("input to sqlWriter is not an execopnode.OpNode")
+	}
+	panic(errors.AssertionFailedf("invalid index %d", nth))
+}
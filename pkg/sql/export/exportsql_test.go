@@ -0,0 +1,67 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package export_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSQL(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	dir, cleanupDir := testutils.TempDir(t)
+	defer cleanupDir()
+
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{ExternalIODir: dir})
+	defer srv.Stopper().Stop(context.Background())
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	sqlDB.Exec(t, `CREATE TABLE foo (i INT PRIMARY KEY, "S s" STRING, b BYTES, a INT[])`)
+	sqlDB.Exec(t, `INSERT INTO foo VALUES
+		(1, e'tab\tnew\nline', b'hi', ARRAY[1, NULL]),
+		(2, e'back\\slash|pipe', NULL, NULL)`)
+
+	t.Run("defaults", func(t *testing.T) {
+		sqlDB.Exec(t, `EXPORT INTO SQL 'nodelocal://1/sql' WITH table_name = 'public.foo' FROM SELECT * FROM foo ORDER BY i`)
+		content := readFileByGlob(t, filepath.Join(dir, "sql", "export*-n*.0.sql"))
+
+		expected := `COPY public.foo (i, "S s", b, a) FROM STDIN;
+1	tab\tnew\nline	\\x6869	{1,NULL}
+2	back\\slash|pipe	\N	\N
+\.
+`
+		require.Equal(t, expected, string(content))
+	})
+
+	t.Run("delimiter and nullas", func(t *testing.T) {
+		sqlDB.Exec(t, `EXPORT INTO SQL 'nodelocal://1/sql_opts' WITH table_name = '"Foo"', delimiter = '|', nullas = 'NULL' FROM SELECT i, "S s" FROM foo ORDER BY i`)
+		content := readFileByGlob(t, filepath.Join(dir, "sql_opts", "export*-n*.0.sql"))
+
+		expected := `COPY "Foo" (i, "S s") FROM STDIN;
+1|tab\tnew\nline
+2|back\\slash\|pipe
+\.
+`
+		require.Equal(t, expected, string(content))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		sqlDB.ExpectErr(t, `table_name is required for sql file format`,
+			`EXPORT INTO SQL 'nodelocal://1/sql_err' FROM SELECT * FROM foo`)
+		sqlDB.ExpectErr(t, `table_name is only supported for sql file format`,
+			`EXPORT INTO CSV 'nodelocal://1/sql_err' WITH table_name = 'foo' FROM SELECT * FROM foo`)
+	})
+}
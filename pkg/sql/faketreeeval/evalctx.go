@@ -603,6 +603,25 @@ func (ep *DummyEvalPlanner) InsertStatementHint(
 	return 0, nil
 }
 
+// CreatePlanBaseline is part of the eval.Planner interface.
+func (ep *DummyEvalPlanner) CreatePlanBaseline(
+	ctx context.Context, statementFingerprint string, planGist string, fixed bool,
+) (int64, error) {
+	return 0, nil
+}
+
+// SetPlanBaselineFixed is part of the eval.Planner interface.
+func (ep *DummyEvalPlanner) SetPlanBaselineFixed(
+	ctx context.Context, hintID int64, fixed bool,
+) (bool, error) {
+	return false, nil
+}
+
+// DropPlanBaseline is part of the eval.Planner interface.
+func (ep *DummyEvalPlanner) DropPlanBaseline(ctx context.Context, hintID int64) (bool, error) {
+	return false, nil
+}
+
 // UsingHintInjection is part of the eval.Planner interface.
 func (ep *DummyEvalPlanner) UsingHintInjection() bool {
 	return false
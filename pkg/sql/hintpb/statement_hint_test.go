@@ -39,4 +39,7 @@ func TestFromToBytes(t *testing.T) {
 	}
 	testRT(&InjectHints{})
 	testRT(&InjectHints{DonorSQL: "SELECT * FROM t"})
+	testRT(&PlanBaseline{})
+	testRT(&PlanBaseline{PlanGist: "AgHUAQIAAwAAAAYG", Fixed: true})
+	testRT(&ResultCache{})
 }
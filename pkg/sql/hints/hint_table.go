@@ -153,6 +153,62 @@ func InsertHintIntoDB(
 	return int64(tree.MustBeDInt(row[0])), nil
 }
 
+// GetHintFromDB reads the statement hint with the given hint ID from the
+// system.statement_hints table. It returns ok=false if there is no such hint.
+func GetHintFromDB(
+	ctx context.Context, txn isql.Txn, hintID int64,
+) (fingerprint string, hint hintpb.StatementHintUnion, ok bool, _ error) {
+	const opName = "get-statement-hint"
+	const getHintStmt = `SELECT "fingerprint", "hint" FROM system.statement_hints WHERE "row_id" = $1`
+	row, err := txn.QueryRowEx(
+		ctx, opName, txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		getHintStmt, hintID,
+	)
+	if err != nil || row == nil {
+		return "", hintpb.StatementHintUnion{}, false, err
+	}
+	fingerprint = string(tree.MustBeDString(row[0]))
+	hint, err = hintpb.FromBytes([]byte(tree.MustBeDBytes(row[1])))
+	if err != nil {
+		return "", hintpb.StatementHintUnion{}, false, err
+	}
+	return fingerprint, hint, true, nil
+}
+
+// UpdateHintInDB replaces the statement hint with the given hint ID in the
+// system.statement_hints table. It returns false if there is no such hint.
+//
+// Note that the hint ID does not change, so cached plans for the statement are
+// not invalidated. Callers that change how statements are planned should delete
+// the hint and insert a new one instead.
+func UpdateHintInDB(
+	ctx context.Context, txn isql.Txn, hintID int64, hint hintpb.StatementHintUnion,
+) (bool, error) {
+	const opName = "update-statement-hint"
+	hintBytes, err := hintpb.ToBytes(hint)
+	if err != nil {
+		return false, err
+	}
+	const updateStmt = `UPDATE system.statement_hints SET "hint" = $2 WHERE "row_id" = $1`
+	n, err := txn.ExecEx(
+		ctx, opName, txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		updateStmt, hintID, hintBytes,
+	)
+	return n > 0, err
+}
+
+// DeleteHintFromDB deletes the statement hint with the given hint ID from the
+// system.statement_hints table. It returns false if there is no such hint.
+func DeleteHintFromDB(ctx context.Context, txn isql.Txn, hintID int64) (bool, error) {
+	const opName = "delete-statement-hint"
+	const deleteStmt = `DELETE FROM system.statement_hints WHERE "row_id" = $1`
+	n, err := txn.ExecEx(
+		ctx, opName, txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		deleteStmt, hintID,
+	)
+	return n > 0, err
+}
+
 // Size returns an estimate of the memory usage of the Hint in bytes.
 func (hint *Hint) Size() int64 {
 	res := int64(unsafe.Sizeof(*hint))
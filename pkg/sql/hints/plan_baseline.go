@@ -0,0 +1,310 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package hints
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/hintpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/logtags"
+)
+
+// PlanBaselineEvolutionEnabled controls whether plan baselines are evolved
+// automatically when a different plan is proven to be faster.
+var PlanBaselineEvolutionEnabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.plan_baselines.evolution.enabled",
+	"when true, plan baselines that are not fixed are replaced by alternative "+
+		"plans that are proven to be faster by sampled executions",
+	true,
+)
+
+var planBaselineEvolutionSampleRate = settings.RegisterFloatSetting(
+	settings.ApplicationLevel,
+	"sql.plan_baselines.evolution.sample_rate",
+	"the probability that an execution of a statement with a plan baseline is "+
+		"planned without the baseline in order to sample an alternative plan",
+	0.01,
+	settings.Fraction,
+)
+
+var planBaselineEvolutionMinSamples = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.plan_baselines.evolution.min_samples",
+	"the minimum number of executions of both the baseline plan and an "+
+		"alternative plan before the alternative plan can replace the baseline",
+	10,
+	settings.PositiveInt,
+)
+
+var planBaselineEvolutionMinImprovement = settings.RegisterFloatSetting(
+	settings.ApplicationLevel,
+	"sql.plan_baselines.evolution.min_improvement",
+	"the minimum fraction by which the mean latency of an alternative plan must "+
+		"be lower than the mean latency of the baseline plan for the alternative "+
+		"plan to replace the baseline",
+	0.2,
+	settings.Fraction,
+)
+
+const (
+	// maxTrackedPlanBaselines is the maximum number of plan baselines for which
+	// a PlanBaselineEvolver tracks execution latencies.
+	maxTrackedPlanBaselines = 1024
+
+	// maxPlanBaselineCandidates is the maximum number of alternative plans for
+	// which a PlanBaselineEvolver tracks execution latencies per baseline.
+	maxPlanBaselineCandidates = 4
+)
+
+// PlanBaselineEvolver tracks the execution latencies of statements with plan
+// baselines on this node, and replaces a baseline in the
+// system.statement_hints table when an alternative plan is proven to be faster
+// than the baseline plan.
+//
+// Alternative plans are sampled by occasionally planning a statement without
+// its baseline (see ShouldExplore). A plan replaces the baseline once both have
+// been executed at least sql.plan_baselines.evolution.min_samples times and the
+// mean latency of the plan is lower than the mean latency of the baseline by at
+// least sql.plan_baselines.evolution.min_improvement. Fixed baselines are never
+// evolved.
+type PlanBaselineEvolver struct {
+	st      *cluster.Settings
+	db      isql.DB
+	stopper *stop.Stopper
+
+	mu struct {
+		syncutil.Mutex
+
+		// baselines contains the latencies observed for each plan baseline,
+		// keyed by hint ID.
+		baselines map[int64]*planBaselineLatencies
+	}
+}
+
+// planBaselineLatencies contains the latencies observed for the baseline plan
+// and for alternative plans of a plan baseline.
+type planBaselineLatencies struct {
+	// gist is the plan gist of the baseline.
+	gist string
+
+	baseline   latencySamples
+	candidates map[string]*latencySamples
+
+	// evolving is set once a faster plan has been found and the baseline is
+	// being replaced.
+	evolving bool
+}
+
+// latencySamples accumulates execution latencies of a plan.
+type latencySamples struct {
+	count int64
+	total time.Duration
+}
+
+func (s *latencySamples) add(latency time.Duration) {
+	s.count++
+	s.total += latency
+}
+
+func (s *latencySamples) mean() time.Duration {
+	if s.count == 0 {
+		return 0
+	}
+	return s.total / time.Duration(s.count)
+}
+
+// NewPlanBaselineEvolver creates a new PlanBaselineEvolver.
+func NewPlanBaselineEvolver(
+	st *cluster.Settings, db isql.DB, stopper *stop.Stopper,
+) *PlanBaselineEvolver {
+	e := &PlanBaselineEvolver{st: st, db: db, stopper: stopper}
+	e.mu.baselines = make(map[int64]*planBaselineLatencies)
+	return e
+}
+
+// ShouldExplore returns true if the current execution of a statement with the
+// given plan baseline should be planned without the baseline, in order to
+// sample an alternative plan.
+func (e *PlanBaselineEvolver) ShouldExplore(baseline *hintpb.PlanBaseline) bool {
+	if baseline.Fixed || !PlanBaselineEvolutionEnabled.Get(&e.st.SV) {
+		return false
+	}
+	return rand.Float64() < planBaselineEvolutionSampleRate.Get(&e.st.SV)
+}
+
+// RecordExecution records the latency of an execution of a statement with the
+// plan baseline with the given hint ID. gist is the plan gist of the plan that
+// was executed, which may or may not match the baseline. If the execution
+// proves that an alternative plan is faster than the baseline plan, the
+// baseline is replaced asynchronously.
+func (e *PlanBaselineEvolver) RecordExecution(
+	ctx context.Context,
+	hintID int64,
+	baseline *hintpb.PlanBaseline,
+	gist string,
+	latency time.Duration,
+) {
+	if baseline.Fixed || gist == "" || !PlanBaselineEvolutionEnabled.Get(&e.st.SV) {
+		return
+	}
+	newGist := e.recordExecution(hintID, baseline.PlanGist, gist, latency)
+	if newGist == "" {
+		return
+	}
+
+	// Replace the baseline in the background, so that the statement does not
+	// have to wait for it.
+	const opName = "evolve-plan-baseline"
+	bgCtx := logtags.WithTags(context.Background(), logtags.FromContext(ctx))
+	bgCtx, cancel := e.stopper.WithCancelOnQuiesce(bgCtx)
+	if err := e.stopper.RunAsyncTask(bgCtx, opName, func(ctx context.Context) {
+		defer cancel()
+		defer e.forget(hintID)
+		var newHintID int64
+		var ok bool
+		err := e.db.Txn(ctx, func(ctx context.Context, txn isql.Txn) (err error) {
+			newHintID, ok, err = EvolvePlanBaselineInDB(ctx, txn, hintID, baseline.PlanGist, newGist)
+			return err
+		})
+		if err != nil {
+			log.Dev.Warningf(ctx, "failed to evolve plan baseline %d: %v", hintID, err)
+			return
+		}
+		if ok {
+			log.Dev.Infof(ctx, "evolved plan baseline %d to plan %s as plan baseline %d", hintID, newGist, newHintID)
+		}
+	}); err != nil {
+		cancel()
+		e.forget(hintID)
+	}
+}
+
+// recordExecution adds the latency to the samples of the given plan baseline.
+// It returns the gist of the plan that should replace the baseline plan, if
+// there is one.
+func (e *PlanBaselineEvolver) recordExecution(
+	hintID int64, baselineGist, gist string, latency time.Duration,
+) (newGist string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	l := e.mu.baselines[hintID]
+	if l == nil || l.gist != baselineGist {
+		if l == nil && len(e.mu.baselines) >= maxTrackedPlanBaselines {
+			return ""
+		}
+		l = &planBaselineLatencies{gist: baselineGist}
+		e.mu.baselines[hintID] = l
+	}
+	if l.evolving {
+		return ""
+	}
+	if gist == baselineGist {
+		l.baseline.add(latency)
+	} else {
+		c := l.candidates[gist]
+		if c == nil {
+			if len(l.candidates) >= maxPlanBaselineCandidates {
+				return ""
+			}
+			if l.candidates == nil {
+				l.candidates = make(map[string]*latencySamples)
+			}
+			c = &latencySamples{}
+			l.candidates[gist] = c
+		}
+		c.add(latency)
+	}
+
+	minSamples := planBaselineEvolutionMinSamples.Get(&e.st.SV)
+	if l.baseline.count < minSamples {
+		return ""
+	}
+	minImprovement := planBaselineEvolutionMinImprovement.Get(&e.st.SV)
+	threshold := time.Duration(float64(l.baseline.mean()) * (1 - minImprovement))
+	var best time.Duration
+	for candidateGist, c := range l.candidates {
+		if c.count < minSamples || c.mean() >= threshold {
+			continue
+		}
+		if newGist == "" || c.mean() < best {
+			newGist, best = candidateGist, c.mean()
+		}
+	}
+	if newGist != "" {
+		l.evolving = true
+	}
+	return newGist
+}
+
+// forget discards the latencies observed for the given plan baseline.
+func (e *PlanBaselineEvolver) forget(hintID int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.mu.baselines, hintID)
+}
+
+// EvolvePlanBaselineInDB replaces the plan baseline with the given hint ID with
+// a new plan baseline for the same statement fingerprint using the given plan
+// gist. The new baseline gets a new hint ID, so that cached plans for the
+// statement are invalidated. It returns ok=false without making changes if the
+// baseline no longer exists, is fixed, or no longer has the given old plan
+// gist.
+func EvolvePlanBaselineInDB(
+	ctx context.Context, txn isql.Txn, hintID int64, oldGist, newGist string,
+) (newHintID int64, ok bool, _ error) {
+	fingerprint, hint, ok, err := GetHintFromDB(ctx, txn, hintID)
+	if err != nil || !ok {
+		return 0, false, err
+	}
+	baseline := hint.PlanBaseline
+	if baseline == nil || baseline.Fixed || baseline.PlanGist != oldGist {
+		return 0, false, nil
+	}
+	if _, err := DeleteHintFromDB(ctx, txn, hintID); err != nil {
+		return 0, false, err
+	}
+	var newHint hintpb.StatementHintUnion
+	newHint.SetValue(&hintpb.PlanBaseline{PlanGist: newGist})
+	newHintID, err = InsertHintIntoDB(ctx, txn, fingerprint, newHint)
+	if err != nil {
+		return 0, false, err
+	}
+	return newHintID, true, nil
+}
+
+// SetPlanBaselineFixedInDB sets whether the plan baseline with the given hint
+// ID is fixed, i.e., whether it is excluded from automatic evolution. It
+// returns false if there is no plan baseline with the given hint ID.
+func SetPlanBaselineFixedInDB(
+	ctx context.Context, txn isql.Txn, hintID int64, fixed bool,
+) (bool, error) {
+	_, hint, ok, err := GetHintFromDB(ctx, txn, hintID)
+	if err != nil || !ok || hint.PlanBaseline == nil {
+		return false, err
+	}
+	hint.PlanBaseline.Fixed = fixed
+	return UpdateHintInDB(ctx, txn, hintID, hint)
+}
+
+// DeletePlanBaselineFromDB deletes the plan baseline with the given hint ID
+// from the system.statement_hints table. It returns false if there is no plan
+// baseline with the given hint ID.
+func DeletePlanBaselineFromDB(ctx context.Context, txn isql.Txn, hintID int64) (bool, error) {
+	_, hint, ok, err := GetHintFromDB(ctx, txn, hintID)
+	if err != nil || !ok || hint.PlanBaseline == nil {
+		return false, err
+	}
+	return DeleteHintFromDB(ctx, txn, hintID)
+}
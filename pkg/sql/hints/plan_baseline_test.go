@@ -0,0 +1,60 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package hints
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanBaselineEvolverRecordExecution(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	planBaselineEvolutionMinSamples.Override(ctx, &st.SV, 3)
+	planBaselineEvolutionMinImprovement.Override(ctx, &st.SV, 0.5)
+	e := NewPlanBaselineEvolver(st, nil /* db */, nil /* stopper */)
+
+	const hintID = 1
+	const baseline, slow, fast = "baseline", "slow", "fast"
+	record := func(gist string, latency time.Duration) string {
+		return e.recordExecution(hintID, baseline, gist, latency)
+	}
+
+	// Candidates are not considered until the baseline has enough samples.
+	for i := 0; i < 3; i++ {
+		require.Empty(t, record(fast, time.Millisecond))
+	}
+	for i := 0; i < 2; i++ {
+		require.Empty(t, record(baseline, 10*time.Millisecond))
+	}
+
+	// Add samples for a candidate that is faster than the baseline, but not by
+	// enough to replace it.
+	for i := 0; i < 3; i++ {
+		require.Empty(t, record(slow, 8*time.Millisecond))
+	}
+
+	// Once the baseline has enough samples, the faster candidate replaces it.
+	require.Equal(t, fast, record(baseline, 10*time.Millisecond))
+
+	// Further executions are ignored while the baseline is being replaced.
+	require.Empty(t, record(fast, time.Millisecond))
+
+	// Once the baseline has been replaced, samples are collected for the new
+	// baseline from scratch.
+	e.forget(hintID)
+	require.Empty(t, e.recordExecution(hintID, fast, fast, time.Millisecond))
+	require.Equal(t, int64(1), e.mu.baselines[hintID].baseline.count)
+}
@@ -0,0 +1,402 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	exportDatabaseOptChunkRows = "chunk_rows"
+	exportDatabaseOptChunkSize = "chunk_size"
+
+	// exportDatabaseFormat is the only file format of EXPORT DATABASE.
+	exportDatabaseFormat = "SQL"
+	// exportDatabaseDumpFile is the name of the file that replays the dump.
+	exportDatabaseDumpFile = "dump.sql"
+	// exportDatabaseDataDir is the directory, relative to the destination, that
+	// the COPY files of the tables are written under.
+	exportDatabaseDataDir = "data"
+)
+
+var exportDatabaseOptionExpectValues = map[string]exprutil.KVStringOptValidate{
+	exportDatabaseOptChunkRows: exprutil.KVStringOptRequireValue,
+	exportDatabaseOptChunkSize: exprutil.KVStringOptRequireValue,
+}
+
+func exportDatabaseTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	exportStmt, ok := stmt.(*tree.ExportDatabase)
+	if !ok {
+		return false, nil, nil
+	}
+	if err := exprutil.TypeCheck(
+		ctx, "EXPORT DATABASE", p.SemaCtx(),
+		exprutil.Strings{exportStmt.File},
+		exprutil.KVOptions{
+			KVOptions: exportStmt.Options, Validation: exportDatabaseOptionExpectValues,
+		},
+	); err != nil {
+		return false, nil, err
+	}
+	return true, colinfo.ExportColumns, nil
+}
+
+// exportDatabasePlanHook implements sql.PlanHookFn.
+//
+// EXPORT DATABASE writes a logical dump of a database as of a single
+// timestamp: a dump.sql file containing the DDL of the database's schemas,
+// types, tables, views, sequences, routines and triggers, as produced by the
+// SHOW CREATE ALL builtins, which includes (\ir) the files holding the data of
+// each table as COPY ... FROM STDIN statements. The data of each table is
+// written by a distributed EXPORT INTO SQL, so it is spread across the nodes
+// of the cluster. The dump can be replayed by running dump.sql with psql or
+// cockroach sql from the destination directory.
+func exportDatabasePlanHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	exportStmt, ok := stmt.(*tree.ExportDatabase)
+	if !ok {
+		return nil, nil, false, nil
+	}
+
+	if exportStmt.FileFormat != exportDatabaseFormat {
+		return nil, nil, false, pgerror.Newf(pgcode.FeatureNotSupported,
+			"unsupported EXPORT DATABASE format %q; only %s is supported",
+			exportStmt.FileFormat, exportDatabaseFormat)
+	}
+	if !p.ExtendedEvalContext().TxnIsSingleStmt {
+		return nil, nil, false, errors.Errorf(
+			"EXPORT DATABASE cannot be used inside a multi-statement transaction")
+	}
+
+	exprEval := p.ExprEvaluator("EXPORT DATABASE")
+	dest, err := exprEval.String(ctx, exportStmt.File)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	opts, err := exprEval.KVOptions(ctx, exportStmt.Options, exportDatabaseOptionExpectValues)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
+		if err := sql.CheckDestinationPrivileges(ctx, p, []string{dest}); err != nil {
+			return err
+		}
+
+		asOf := p.ExecCfg().Clock.Now()
+		if exportStmt.AsOf.Expr != nil {
+			aost, err := p.EvalAsOfTimestamp(ctx, exportStmt.AsOf)
+			if err != nil {
+				return err
+			}
+			asOf = aost.Timestamp
+		}
+
+		e := &databaseExporter{
+			p:        p,
+			ie:       p.ExecCfg().InternalDB.Executor(),
+			override: sessiondata.InternalExecutorOverride{User: p.User()},
+			dbName:   exportStmt.Database,
+			db:       tree.AsString(&exportStmt.Database),
+			dest:     dest,
+			opts:     opts,
+			asOf:     asOf,
+		}
+		return e.export(ctx, resultsCh)
+	}
+	return fn, colinfo.ExportColumns, false, nil
+}
+
+// databaseExporter writes the logical dump of a single database.
+type databaseExporter struct {
+	p        sql.PlanHookState
+	ie       isql.Executor
+	override sessiondata.InternalExecutorOverride
+	dbName   tree.Name
+	// db is dbName quoted for use in the text of queries.
+	db   string
+	dest string
+	opts map[string]string
+	asOf hlc.Timestamp
+}
+
+// exportedTable is a table whose rows are part of the dump.
+type exportedTable struct {
+	// name is the schema-qualified name of the table, as used by the DDL of the
+	// dump.
+	name tree.TableName
+	id   int64
+	// cols are the columns whose values are dumped, which excludes computed
+	// and hidden columns.
+	cols tree.NameList
+}
+
+func (e *databaseExporter) export(ctx context.Context, resultsCh chan<- tree.Datums) error {
+	schemas, err := e.showCreateAll(ctx, "schemas")
+	if err != nil {
+		return err
+	}
+	types, err := e.showCreateAll(ctx, "types")
+	if err != nil {
+		return err
+	}
+	tables, err := e.showCreateAll(ctx, "tables")
+	if err != nil {
+		return err
+	}
+	routines, err := e.showCreateAll(ctx, "routines")
+	if err != nil {
+		return err
+	}
+	triggers, err := e.showCreateAll(ctx, "triggers")
+	if err != nil {
+		return err
+	}
+
+	// The statements creating tables, views and sequences come before the
+	// statements adding and validating foreign keys, which are only run after
+	// the data has been loaded.
+	preData, postData := tables, []string(nil)
+	for i, stmt := range tables {
+		if strings.HasPrefix(stmt, "ALTER TABLE") {
+			preData, postData = tables[:i], tables[i:]
+			break
+		}
+	}
+
+	var dump bytes.Buffer
+	var numStmts int64
+	writeStmts := func(stmts []string) {
+		for _, stmt := range stmts {
+			dump.WriteString(stmt)
+			dump.WriteString("\n")
+			numStmts++
+		}
+	}
+	fmt.Fprintf(&dump, "-- Logical dump of database %s as of %s.\n",
+		e.db, e.asOf.AsOfSystemTime())
+	dump.WriteString("-- Run this file from its directory in an empty database.\n")
+	for _, stmt := range schemas {
+		// The public schema already exists in every database.
+		if stmt == "CREATE SCHEMA public;" {
+			continue
+		}
+		writeStmts([]string{stmt})
+	}
+	writeStmts(types)
+	writeStmts(preData)
+	writeStmts(routines)
+
+	exported, err := e.exportedTables(ctx)
+	if err != nil {
+		return err
+	}
+	for _, table := range exported {
+		files, err := e.exportTable(ctx, table, resultsCh)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			fmt.Fprintf(&dump, "\\ir %s\n", file)
+		}
+	}
+
+	setvals, err := e.sequenceValues(ctx)
+	if err != nil {
+		return err
+	}
+	writeStmts(setvals)
+	writeStmts(postData)
+	writeStmts(triggers)
+
+	store, err := e.p.ExecCfg().DistSQLSrv.ExternalStorageFromURI(ctx, e.dest, e.p.User())
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	size := dump.Len()
+	if err := cloud.WriteFile(ctx, store, exportDatabaseDumpFile, &dump); err != nil {
+		return errors.Wrapf(err, "writing %s", exportDatabaseDumpFile)
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case resultsCh <- tree.Datums{
+		tree.NewDString(exportDatabaseDumpFile),
+		tree.NewDInt(tree.DInt(numStmts)),
+		tree.NewDInt(tree.DInt(size)),
+	}:
+	}
+	return nil
+}
+
+// asOfClause returns the AS OF SYSTEM TIME clause that every query of the
+// export reads at, which makes the dump consistent.
+func (e *databaseExporter) asOfClause() string {
+	return fmt.Sprintf("AS OF SYSTEM TIME %s", e.asOf.AsOfSystemTime())
+}
+
+// showCreateAll returns the statements produced by the
+// crdb_internal.show_create_all_<objects> builtin for the database.
+func (e *databaseExporter) showCreateAll(ctx context.Context, objects string) ([]string, error) {
+	rows, err := e.ie.QueryBufferedEx(ctx, "export-database-show-create-all", nil, /* txn */
+		e.override,
+		fmt.Sprintf(`SELECT crdb_internal.show_create_all_%s($1) %s`, objects, e.asOfClause()),
+		string(e.dbName),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s of database %s", objects, e.db)
+	}
+	stmts := make([]string, 0, len(rows))
+	for _, row := range rows {
+		stmts = append(stmts, string(tree.MustBeDString(row[0])))
+	}
+	return stmts, nil
+}
+
+// exportedTables returns the tables of the database whose rows are dumped.
+func (e *databaseExporter) exportedTables(ctx context.Context) ([]exportedTable, error) {
+	rows, err := e.ie.QueryBufferedEx(ctx, "export-database-tables", nil, /* txn */
+		e.override,
+		fmt.Sprintf(`
+SELECT t.table_schema, t.table_name, c.column_name, n.table_id
+  FROM %[1]s.information_schema.tables AS t
+  JOIN %[1]s.information_schema.columns AS c
+    ON c.table_schema = t.table_schema AND c.table_name = t.table_name
+  JOIN %[1]s.crdb_internal.tables AS n
+    ON n.schema_name = t.table_schema AND n.name = t.table_name
+       AND n.database_name = t.table_catalog
+ WHERE t.table_type = 'BASE TABLE'
+   AND c.is_generated = 'NEVER' AND c.is_hidden = 'NO'
+ ORDER BY n.table_id, c.ordinal_position
+%[2]s`, e.db, e.asOfClause()),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing tables of database %s", e.db)
+	}
+	var tables []exportedTable
+	for _, row := range rows {
+		id := int64(tree.MustBeDInt(row[3]))
+		if len(tables) == 0 || tables[len(tables)-1].id != id {
+			tables = append(tables, exportedTable{
+				name: tree.MakeTableNameWithSchema(
+					"", tree.Name(tree.MustBeDString(row[0])), tree.Name(tree.MustBeDString(row[1])),
+				),
+				id: id,
+			})
+		}
+		table := &tables[len(tables)-1]
+		table.cols = append(table.cols, tree.Name(tree.MustBeDString(row[2])))
+	}
+	return tables, nil
+}
+
+// exportTable writes the rows of a table as COPY files under the data
+// directory of the destination, and returns the paths of the files relative
+// to the destination.
+func (e *databaseExporter) exportTable(
+	ctx context.Context, table exportedTable, resultsCh chan<- tree.Datums,
+) ([]string, error) {
+	dir := fmt.Sprintf("%s/%d", exportDatabaseDataDir, table.id)
+	uri, err := url.Parse(e.dest)
+	if err != nil {
+		return nil, err
+	}
+	uri.Path = path.Join(uri.Path, dir)
+	tableDest := uri.String()
+	// Reference the table through the database being exported, while the COPY
+	// statements use the schema-qualified name of the dump's DDL.
+	source := table.name
+	source.CatalogName = e.dbName
+	source.ExplicitCatalog = true
+	withOpts := fmt.Sprintf("table_name = %s", lexbase.EscapeSQLString(tree.AsString(&table.name)))
+	for _, opt := range []string{exportDatabaseOptChunkRows, exportDatabaseOptChunkSize} {
+		if v, ok := e.opts[opt]; ok {
+			withOpts += fmt.Sprintf(", %s = %s", opt, lexbase.EscapeSQLString(v))
+		}
+	}
+	rows, err := e.ie.QueryBufferedEx(ctx, "export-database-table", nil, /* txn */
+		e.override,
+		fmt.Sprintf(`EXPORT INTO SQL $1 WITH %s FROM SELECT %s FROM %s %s`,
+			withOpts, tree.AsString(&table.cols), &source, e.asOfClause()),
+		tableDest,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "exporting table %s", &table.name)
+	}
+	files := make([]string, 0, len(rows))
+	for _, row := range rows {
+		file := dir + "/" + string(tree.MustBeDString(row[0]))
+		files = append(files, file)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case resultsCh <- tree.Datums{tree.NewDString(file), row[1], row[2]}:
+		}
+	}
+	return files, nil
+}
+
+// sequenceValues returns the statements that restore the values of the
+// sequences of the database.
+func (e *databaseExporter) sequenceValues(ctx context.Context) ([]string, error) {
+	rows, err := e.ie.QueryBufferedEx(ctx, "export-database-sequences", nil, /* txn */
+		e.override,
+		fmt.Sprintf(`
+SELECT sequence_schema, sequence_name
+  FROM %s.information_schema.sequences
+ ORDER BY sequence_schema, sequence_name
+%s`, e.db, e.asOfClause()),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing sequences of database %s", e.db)
+	}
+	stmts := make([]string, 0, len(rows))
+	for _, row := range rows {
+		name := tree.MakeTableNameWithSchema(
+			"", tree.Name(tree.MustBeDString(row[0])), tree.Name(tree.MustBeDString(row[1])),
+		)
+		source := name
+		source.CatalogName = e.dbName
+		source.ExplicitCatalog = true
+		valRow, err := e.ie.QueryRowEx(ctx, "export-database-sequence-value", nil, /* txn */
+			e.override,
+			fmt.Sprintf(`SELECT last_value, is_called FROM %s %s`, &source, e.asOfClause()),
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading value of sequence %s", &name)
+		}
+		stmts = append(stmts, fmt.Sprintf("SELECT setval(%s, %d, %t);",
+			lexbase.EscapeSQLString(tree.AsString(&name)),
+			int64(tree.MustBeDInt(valRow[0])), bool(tree.MustBeDBool(valRow[1]))))
+	}
+	return stmts, nil
+}
+
+func init() {
+	sql.AddPlanHook("export database", exportDatabasePlanHook, exportDatabaseTypeCheck)
+}
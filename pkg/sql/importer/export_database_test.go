@@ -0,0 +1,144 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package importer_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// replaySQLDump runs the dump.sql file of an EXPORT DATABASE the way psql
+// would, loading the data of each included file with COPY ... FROM STDIN.
+func replaySQLDump(t *testing.T, ctx context.Context, conn *pgx.Conn, dumpDir string) {
+	dump, err := os.ReadFile(filepath.Join(dumpDir, "dump.sql"))
+	require.NoError(t, err)
+
+	var stmts strings.Builder
+	flush := func() {
+		if stmts.Len() == 0 {
+			return
+		}
+		_, err := conn.Exec(ctx, stmts.String())
+		require.NoError(t, err, "%s", stmts.String())
+		stmts.Reset()
+	}
+	for _, line := range strings.Split(string(dump), "\n") {
+		file, ok := strings.CutPrefix(line, `\ir `)
+		if !ok {
+			stmts.WriteString(line)
+			stmts.WriteString("\n")
+			continue
+		}
+		flush()
+		contents, err := os.ReadFile(filepath.Join(dumpDir, file))
+		require.NoError(t, err)
+		copyStmt, data, ok := strings.Cut(string(contents), "\n")
+		require.True(t, ok)
+		data, ok = strings.CutSuffix(data, "\\.\n")
+		require.True(t, ok, "%s is missing the end of data marker", file)
+		_, err = conn.PgConn().CopyFrom(ctx, strings.NewReader(data), copyStmt)
+		require.NoError(t, err, "%s", copyStmt)
+	}
+	flush()
+}
+
+func TestExportDatabaseSQL(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{ExternalIODir: dir})
+	defer srv.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	sqlDB.Exec(t, `
+CREATE DATABASE d;
+USE d;
+CREATE SCHEMA s;
+CREATE TYPE s.status AS ENUM ('open', 'closed');
+CREATE SEQUENCE s.ids;
+CREATE TABLE s.parent (id INT PRIMARY KEY DEFAULT nextval('s.ids'), name STRING, status s.status);
+CREATE TABLE child (
+  id INT PRIMARY KEY,
+  parent_id INT REFERENCES s.parent (id),
+  note STRING,
+  note_len INT AS (length(note)) STORED
+);
+CREATE TABLE no_pk (v JSONB);
+CREATE VIEW s.open_parents AS SELECT id, name FROM s.parent WHERE status = 'open';
+INSERT INTO s.parent (name, status) VALUES ('a', 'open'), (e'tab\there', 'closed'), (NULL, NULL);
+INSERT INTO child VALUES
+  (1, 1, e'line\nbreak'),
+  (2, 1, e'back\\slash'),
+  (3, 2, NULL),
+  (4, NULL, '\N');
+INSERT INTO no_pk VALUES ('{"a": [1, "x\ty"]}'), (NULL);
+`)
+	var asOf string
+	sqlDB.QueryRow(t, `SELECT cluster_logical_timestamp()`).Scan(&asOf)
+
+	// Changes after the timestamp of the export are not part of the dump.
+	sqlDB.Exec(t, `INSERT INTO s.parent (name) VALUES ('later')`)
+	sqlDB.Exec(t, `DELETE FROM child WHERE id = 1`)
+
+	rows := sqlDB.QueryStr(t, `EXPORT DATABASE d INTO SQL 'nodelocal://1/dump' WITH chunk_rows = '2' AS OF SYSTEM TIME `+asOf)
+	require.Equal(t, "dump.sql", rows[len(rows)-1][0])
+	var dataFiles int
+	for _, row := range rows[:len(rows)-1] {
+		require.True(t, strings.HasPrefix(row[0], "data/"), row[0])
+		dataFiles++
+	}
+	// s.parent and child have 3 and 4 rows, written 2 rows per file, and no_pk
+	// has 2 rows.
+	require.Equal(t, 5, dataFiles)
+
+	sqlDB.Exec(t, `CREATE DATABASE d2`)
+	pgURL, cleanupURL := srv.ApplicationLayer().PGUrl(t, serverutils.DBName("d2"))
+	defer cleanupURL()
+	conn, err := pgx.Connect(ctx, pgURL.String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+	replaySQLDump(t, ctx, conn, filepath.Join(dir, "dump"))
+
+	for _, query := range []string{
+		`SELECT * FROM %s.s.parent %s ORDER BY id`,
+		`SELECT * FROM %s.public.child %s ORDER BY id`,
+		`SELECT * FROM %s.public.no_pk %s ORDER BY v`,
+		`SELECT * FROM %s.s.open_parents %s ORDER BY id`,
+	} {
+		sqlDB.CheckQueryResults(t,
+			fmt.Sprintf(query, "d2", ""),
+			sqlDB.QueryStr(t, fmt.Sprintf(query, "d", "AS OF SYSTEM TIME "+asOf)),
+		)
+	}
+
+	// The sequence continues from its value at the time of the export, and the
+	// foreign key was restored.
+	sqlDB.CheckQueryResults(t, `SELECT nextval('d2.s.ids')`, [][]string{{"4"}})
+	sqlDB.ExpectErr(t, `violates foreign key constraint`,
+		`INSERT INTO d2.public.child VALUES (5, 100, 'x')`)
+
+	sqlDB.ExpectErr(t, `unsupported EXPORT DATABASE format "CSV"`,
+		`EXPORT DATABASE d INTO CSV 'nodelocal://1/csv'`)
+	sqlDB.ExpectErr(t, `invalid option "compression"`,
+		`EXPORT DATABASE d INTO SQL 'nodelocal://1/gz' WITH compression = 'gzip'`)
+}
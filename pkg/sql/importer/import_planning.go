@@ -660,6 +660,14 @@ func importPlanHook(
 			return errors.Newf("cannot run an import on table %s which is apart of a Logical Data Replication stream", table)
 		}
 
+		// IMPORT INTO may overwrite existing rows, which would bypass the history
+		// table of a system-versioned table.
+		if found.SystemVersioning != nil {
+			return errors.WithHint(pgerror.Newf(pgcode.FeatureNotSupported,
+				"IMPORT INTO is not supported for system-versioned table %s", table),
+				"remove system versioning with ALTER TABLE ... DROP SYSTEM VERSIONING before importing")
+		}
+
 		// Import into an RLS table is blocked, unless this is the admin. It is
 		// allowed for admins since they are exempt from RLS policies and have
 		// unrestricted read/write access.
@@ -1957,6 +1957,32 @@ INSERT INTO foo VALUES (1);
 	sqlDB.Exec(t, fmt.Sprintf(`IMPORT INTO feature_flags (a, b) CSV DATA (%s)`, testFiles.files[0]))
 }
 
+func TestImportIntoSystemVersionedTable(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{ExternalIODir: t.TempDir()})
+	defer srv.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	sqlDB.Exec(t, `CREATE TABLE sv (a INT8 PRIMARY KEY, b STRING) WITH SYSTEM VERSIONING`)
+	sqlDB.Exec(t, `INSERT INTO sv VALUES (1, 'old')`)
+	var filename string
+	sqlDB.QueryRow(t, `WITH cte AS (EXPORT INTO CSV 'nodelocal://1/sv' FROM SELECT 1, 'new')
+SELECT filename FROM cte`).Scan(&filename)
+	importStmt := fmt.Sprintf(`IMPORT INTO sv (a, b) CSV DATA ('nodelocal://1/sv/%s')`, filename)
+
+	// Importing would overwrite the existing row without recording its old
+	// version in the history table.
+	sqlDB.ExpectErr(t, `IMPORT INTO is not supported for system-versioned table`, importStmt)
+	sqlDB.CheckQueryResults(t, `SELECT a, b FROM sv`, [][]string{{"1", "old"}})
+
+	sqlDB.Exec(t, `ALTER TABLE sv DROP SYSTEM VERSIONING`)
+	sqlDB.Exec(t, importStmt)
+	sqlDB.CheckQueryResults(t, `SELECT a, b FROM sv`, [][]string{{"1", "new"}})
+}
+
 func TestImportObjectLevelRBAC(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -0,0 +1,451 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// incrementalViewAggregates are the aggregate functions supported in the
+// query of an incremental materialized view.
+var incrementalViewAggregates = map[string]bool{
+	"count":      true,
+	"count_rows": true,
+	"max":        true,
+	"min":        true,
+	"sum":        true,
+	"sum_int":    true,
+}
+
+// incrementalViewPlan describes how the changes to the base tables of a
+// materialized view created WITH (incremental) are applied to the view.
+//
+// The rows of the view affected by a set of changed rows of the base tables
+// are found by evaluating the view query restricted to the changed rows, both
+// before and after the changes. For a view that groups, every affected group
+// is recomputed from the base tables. For a view that does not, the rows
+// derived from the changed rows before the changes are replaced by the rows
+// derived from them after the changes.
+type incrementalViewPlan struct {
+	// tables are the references to base tables in the FROM clause of the view
+	// query, in order. A table that is referenced more than once has an entry
+	// per reference.
+	tables []incrementalViewTableRef
+	// from and where are the FROM clause and the filter of the view query.
+	from, where string
+	// exprs are the output expressions of the view query.
+	exprs []string
+	// grouped is set if the view query has a GROUP BY clause.
+	grouped bool
+	// keyColumns are the ordinals of the output columns that hold the grouping
+	// expressions of a grouped view query.
+	keyColumns []int
+	// query is the view query.
+	query string
+}
+
+// incrementalViewTableRef is a reference to a base table in the FROM clause of
+// the query of an incremental materialized view.
+type incrementalViewTableRef struct {
+	name tree.TableName
+	// ref qualifies the columns of the table in the view query: the alias of
+	// the table or its name.
+	ref string
+}
+
+// analyzeIncrementalViewQuery returns the plan to maintain a materialized view
+// with the given query incrementally, or an error explaining why the view
+// cannot be maintained incrementally.
+//
+// The supported queries are a single SELECT over base tables combined with
+// inner joins, with filters, projections and, optionally, a GROUP BY clause
+// whose expressions are output columns of the view and whose aggregates are
+// SUM, COUNT, MIN or MAX. Every function must be immutable so that the rows of
+// the view can be recomputed at any time.
+func analyzeIncrementalViewQuery(query string) (*incrementalViewPlan, error) {
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok {
+		return nil, errors.New("the view query must be a SELECT statement")
+	}
+	if sel.With != nil || sel.OrderBy != nil || sel.Limit != nil || sel.Locking != nil {
+		return nil, errors.New("WITH, ORDER BY, LIMIT and locking clauses are not supported")
+	}
+	sc, ok := sel.Select.(*tree.SelectClause)
+	if !ok || sc.TableSelect {
+		return nil, errors.New("set operations and VALUES are not supported")
+	}
+	if sc.Distinct || sc.DistinctOn != nil {
+		return nil, errors.New("DISTINCT is not supported")
+	}
+	if sc.Window != nil {
+		return nil, errors.New("window functions are not supported")
+	}
+	if len(sc.From.Tables) == 0 {
+		return nil, errors.New("the view query must read from a table")
+	}
+
+	p := &incrementalViewPlan{query: query}
+	var v incrementalViewExprVisitor
+	for _, t := range sc.From.Tables {
+		if err := p.addTables(t, &v); err != nil {
+			return nil, err
+		}
+	}
+	p.from = tree.AsStringWithFlags(&sc.From.Tables, tree.FmtParsable)
+	p.where = "true"
+	if sc.Where != nil {
+		if err := v.check(sc.Where.Expr); err != nil {
+			return nil, err
+		}
+		p.where = tree.AsStringWithFlags(sc.Where.Expr, tree.FmtParsable)
+	}
+	for _, e := range sc.GroupBy {
+		if err := v.check(e); err != nil {
+			return nil, err
+		}
+	}
+	if sc.Having != nil {
+		if err := v.check(sc.Having.Expr); err != nil {
+			return nil, err
+		}
+	}
+	for _, e := range sc.Exprs {
+		if vn, ok := e.Expr.(tree.VarName); ok {
+			switch vn.(type) {
+			case tree.UnqualifiedStar, *tree.AllColumnsSelector, *tree.TupleStar:
+				return nil, errors.New("* in the select list is not supported")
+			}
+		}
+		if err := v.check(e.Expr); err != nil {
+			return nil, err
+		}
+		p.exprs = append(p.exprs, tree.AsStringWithFlags(e.Expr, tree.FmtParsable))
+	}
+
+	p.grouped = len(sc.GroupBy) > 0
+	if !p.grouped {
+		if v.aggregates {
+			return nil, errors.New("aggregations without GROUP BY are not supported")
+		}
+		return p, nil
+	}
+	for _, g := range sc.GroupBy {
+		col := -1
+		if ord, ok := g.(*tree.NumVal); ok {
+			if i, err := ord.AsInt64(); err == nil && i >= 1 && int(i) <= len(p.exprs) {
+				col = int(i) - 1
+			}
+		} else {
+			expr := tree.AsStringWithFlags(g, tree.FmtParsable)
+			for i := range p.exprs {
+				if p.exprs[i] == expr {
+					col = i
+					break
+				}
+			}
+		}
+		if col < 0 {
+			return nil, errors.Newf(
+				"GROUP BY expression %s must be an output column of the view", tree.AsString(g),
+			)
+		}
+		p.keyColumns = append(p.keyColumns, col)
+	}
+	return p, nil
+}
+
+// addTables adds the base tables referenced by a table expression of the FROM
+// clause to the plan.
+func (p *incrementalViewPlan) addTables(expr tree.TableExpr, v *incrementalViewExprVisitor) error {
+	switch t := expr.(type) {
+	case *tree.AliasedTableExpr:
+		name, ok := t.Expr.(*tree.TableName)
+		if !ok || t.Ordinality || t.Lateral || len(t.As.Cols) > 0 {
+			return errors.New("only tables without column aliases may appear in the FROM clause")
+		}
+		ref := incrementalViewTableRef{name: *name, ref: name.String()}
+		if t.As.Alias != "" {
+			ref.ref = t.As.Alias.String()
+		}
+		p.tables = append(p.tables, ref)
+		return nil
+	case *tree.JoinTableExpr:
+		if t.JoinType != "" && t.JoinType != tree.AstInner && t.JoinType != tree.AstCross {
+			return errors.Newf("%s joins are not supported", t.JoinType)
+		}
+		if on, ok := t.Cond.(*tree.OnJoinCond); ok {
+			if err := v.check(on.Expr); err != nil {
+				return err
+			}
+		}
+		if err := p.addTables(t.Left, v); err != nil {
+			return err
+		}
+		return p.addTables(t.Right, v)
+	case *tree.ParenTableExpr:
+		return p.addTables(t.Expr, v)
+	default:
+		return errors.New("only tables may appear in the FROM clause")
+	}
+}
+
+// incrementalViewExprVisitor checks that the expressions of the query of an
+// incremental materialized view evaluate to the same result whenever they are
+// recomputed, and records whether they contain aggregate functions.
+type incrementalViewExprVisitor struct {
+	aggregates bool
+	err        error
+}
+
+var _ tree.Visitor = &incrementalViewExprVisitor{}
+
+func (v *incrementalViewExprVisitor) check(expr tree.Expr) error {
+	tree.WalkExprConst(v, expr)
+	return v.err
+}
+
+// VisitPre is part of the tree.Visitor interface.
+func (v *incrementalViewExprVisitor) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if v.err != nil {
+		return false, expr
+	}
+	switch t := expr.(type) {
+	case *tree.Subquery:
+		v.err = errors.New("subqueries are not supported")
+	case *tree.FuncExpr:
+		if t.WindowDef != nil {
+			v.err = errors.New("window functions are not supported")
+			break
+		}
+		name := strings.ToLower(t.Func.String())
+		props, overloads := builtinsregistry.GetBuiltinProperties(name)
+		if props == nil {
+			v.err = errors.Newf("function %s is not supported", name)
+			break
+		}
+		aggregate, immutable := false, true
+		for i := range overloads {
+			aggregate = aggregate || overloads[i].Class == tree.AggregateClass
+			immutable = immutable && overloads[i].Volatility <= volatility.Immutable
+		}
+		switch {
+		case aggregate && !incrementalViewAggregates[name]:
+			v.err = errors.Newf("aggregate function %s is not supported", name)
+		case aggregate:
+			v.aggregates = true
+		case !immutable:
+			v.err = errors.Newf("function %s is not immutable", name)
+		}
+	}
+	return v.err == nil, expr
+}
+
+// VisitPost is part of the tree.Visitor interface.
+func (v *incrementalViewExprVisitor) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// checkIncrementalViewTables returns an error if the tables the query of an
+// incremental materialized view depends on cannot be maintained
+// incrementally.
+func checkIncrementalViewTables(deps []catalog.TableDescriptor) error {
+	for _, desc := range deps {
+		if !desc.IsPhysicalTable() || desc.IsSequence() || desc.MaterializedView() {
+			return errors.Newf("%q is not a table", desc.GetName())
+		}
+		if _, err := makeIncrementalViewTable(desc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// incrementalViewTable is a base table of an incremental materialized view
+// with the primary key columns which identify its changed rows.
+type incrementalViewTable struct {
+	desc    catalog.TableDescriptor
+	keyCols []catalog.Column
+	dirs    []catenumpb.IndexColumn_Direction
+}
+
+// makeIncrementalViewTable returns the primary key of a base table of an
+// incremental materialized view.
+func makeIncrementalViewTable(desc catalog.TableDescriptor) (*incrementalViewTable, error) {
+	idx := desc.GetPrimaryIndex()
+	t := &incrementalViewTable{desc: desc}
+	for i := 0; i < idx.NumKeyColumns(); i++ {
+		col, err := catalog.MustFindColumnByID(desc, idx.GetKeyColumnID(i))
+		if err != nil {
+			return nil, err
+		}
+		if col.GetType().Family() == types.CollatedStringFamily {
+			return nil, errors.Newf(
+				"the primary key of %q has collated string column %q", desc.GetName(), col.GetName(),
+			)
+		}
+		t.keyCols = append(t.keyCols, col)
+		t.dirs = append(t.dirs, idx.GetKeyColumnDirection(i))
+	}
+	return t, nil
+}
+
+// incrementalViewArgs accumulates the placeholder arguments of a statement.
+type incrementalViewArgs []interface{}
+
+// add appends a tuple of placeholders for the datums to the statement being
+// built.
+func (a *incrementalViewArgs) add(b *strings.Builder, row tree.Datums) {
+	b.WriteByte('(')
+	for i, d := range row {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		*a = append(*a, d)
+		fmt.Fprintf(b, "$%d", len(*a))
+	}
+	b.WriteByte(')')
+}
+
+// changedRowsFilter returns a filter over the FROM clause of the view query
+// which selects the combinations of rows that involve a changed row of a base
+// table. tables are the base tables of the references of the plan, in order,
+// and changed are the primary keys of the changed rows of each table.
+func (p *incrementalViewPlan) changedRowsFilter(
+	tables []*incrementalViewTable, changed map[descpb.ID][]tree.Datums, args *incrementalViewArgs,
+) string {
+	var b strings.Builder
+	for i, ref := range p.tables {
+		rows := changed[tables[i].desc.GetID()]
+		if len(rows) == 0 {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(" OR ")
+		}
+		b.WriteByte('(')
+		for j, col := range tables[i].keyCols {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%s.%s", ref.ref, tree.NameString(col.GetName()))
+		}
+		b.WriteString(") IN (")
+		for j, row := range rows {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			args.add(&b, row)
+		}
+		b.WriteByte(')')
+	}
+	if b.Len() == 0 {
+		return "false"
+	}
+	return b.String()
+}
+
+// affectedRowsQuery returns the query which evaluates the view query as of the
+// given time restricted to the combinations of rows that involve a changed row
+// of a base table. For a grouped view, the query returns the distinct keys of
+// the affected groups. Otherwise, it returns the rows of the view derived from
+// the changed rows, each followed by the primary keys of the rows of the base
+// tables it is derived from.
+func (p *incrementalViewPlan) affectedRowsQuery(
+	asOf hlc.Timestamp, tables []*incrementalViewTable, changed map[descpb.ID][]tree.Datums,
+) (string, incrementalViewArgs) {
+	var args incrementalViewArgs
+	filter := p.changedRowsFilter(tables, changed, &args)
+	var cols []string
+	if p.grouped {
+		for _, c := range p.keyColumns {
+			cols = append(cols, p.exprs[c])
+		}
+	} else {
+		cols = append(cols, p.exprs...)
+		for i, ref := range p.tables {
+			for _, col := range tables[i].keyCols {
+				cols = append(cols, fmt.Sprintf("%s.%s", ref.ref, tree.NameString(col.GetName())))
+			}
+		}
+	}
+	distinct := ""
+	if p.grouped {
+		distinct = "DISTINCT "
+	}
+	return fmt.Sprintf(
+		"SELECT %s%s FROM %s AS OF SYSTEM TIME '%s' WHERE (%s) AND (%s)",
+		distinct, strings.Join(cols, ", "), p.from, asOf.AsOfSystemTime(), p.where, filter,
+	), args
+}
+
+// groupsFilter returns a filter which selects the rows whose columns, qualified
+// by ref, match one of the given keys.
+func groupsFilter(ref string, cols []string, keys []tree.Datums, args *incrementalViewArgs) string {
+	var b strings.Builder
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteString(" OR ")
+		}
+		b.WriteByte('(')
+		for j, d := range key {
+			if j > 0 {
+				b.WriteString(" AND ")
+			}
+			*args = append(*args, d)
+			fmt.Fprintf(&b, "%s.%s IS NOT DISTINCT FROM $%d", ref, cols[j], len(*args))
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// groupsQuery returns the query which recomputes the rows of a grouped view
+// for the groups with the given keys as of the given time.
+func (p *incrementalViewPlan) groupsQuery(
+	asOf hlc.Timestamp, keys []tree.Datums,
+) (string, incrementalViewArgs) {
+	names := make([]string, len(p.exprs))
+	for i := range names {
+		names[i] = fmt.Sprintf("c%d", i)
+	}
+	keyNames := make([]string, len(p.keyColumns))
+	for i, c := range p.keyColumns {
+		keyNames[i] = names[c]
+	}
+	var args incrementalViewArgs
+	filter := groupsFilter("q", keyNames, keys, &args)
+	return fmt.Sprintf(
+		"SELECT * FROM (%s) AS q (%s) AS OF SYSTEM TIME '%s' WHERE %s",
+		p.query, strings.Join(names, ", "), asOf.AsOfSystemTime(), filter,
+	), args
+}
+
+// incrementalViewDatumsKey returns a string which identifies the values of a
+// row.
+func incrementalViewDatumsKey(row tree.Datums) string {
+	var b strings.Builder
+	for i, d := range row {
+		if i > 0 {
+			b.WriteByte(0)
+		}
+		b.WriteString(tree.AsStringWithFlags(d, tree.FmtParsable))
+	}
+	return b.String()
+}
@@ -0,0 +1,825 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobsprotectedts"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+var incrementalViewFlushInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.materialized_view.incremental.flush_interval",
+	"the interval at which the changes to the base tables of incremental materialized views are applied to the views",
+	10*time.Second,
+	settings.PositiveDuration,
+)
+
+var incrementalViewBufferSize = settings.RegisterByteSizeSetting(
+	settings.ApplicationLevel,
+	"sql.materialized_view.incremental.buffer_size",
+	"the maximum memory used to buffer the changes to the base tables of an incremental "+
+		"materialized view between flushes; the changes are flushed early once it is reached",
+	64<<20, /* 64 MiB */
+	settings.PositiveInt,
+)
+
+// incrementalViewBatchSize is the maximum number of rows referenced by a
+// single statement of an incremental view refresh job.
+const incrementalViewBatchSize = 100
+
+// incrementalViewChange is a change to a key of a base table of an incremental
+// materialized view.
+type incrementalViewChange struct {
+	key roachpb.Key
+	ts  hlc.Timestamp
+}
+
+const incrementalViewChangeOverhead = int64(unsafe.Sizeof(incrementalViewChange{}))
+
+func (c incrementalViewChange) memUsage() int64 {
+	return incrementalViewChangeOverhead + int64(cap(c.key))
+}
+
+// incrementalViewBuffer buffers the changes read from the rangefeed on the base
+// tables of an incremental materialized view until they are applied to the
+// view.
+type incrementalViewBuffer struct {
+	// fullCh is signaled once the buffer is full.
+	fullCh chan struct{}
+	mu     struct {
+		syncutil.Mutex
+		acc     mon.BoundAccount
+		changes []incrementalViewChange
+		// frontier is the timestamp up to which all changes have been buffered.
+		frontier hlc.Timestamp
+		// full is set once a change could not be buffered within the memory
+		// budget. Later changes are dropped and the frontier no longer advances,
+		// so the changes up to the frontier can still be applied, after which the
+		// rangefeed is restarted from the frontier.
+		full bool
+		err  error
+	}
+}
+
+func newIncrementalViewBuffer(acc mon.BoundAccount) *incrementalViewBuffer {
+	b := &incrementalViewBuffer{fullCh: make(chan struct{}, 1)}
+	b.mu.acc = acc
+	return b
+}
+
+func (b *incrementalViewBuffer) close(ctx context.Context) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.mu.changes = nil
+	b.mu.acc.Close(ctx)
+}
+
+func (b *incrementalViewBuffer) onValue(ctx context.Context, value *kvpb.RangeFeedValue) {
+	b.add(ctx, incrementalViewChange{key: value.Key, ts: value.Value.Timestamp}, nil)
+}
+
+func (b *incrementalViewBuffer) onSSTable(
+	ctx context.Context, sst *kvpb.RangeFeedSSTable, registeredSpan roachpb.Span,
+) {
+	span := registeredSpan.Intersect(sst.Span)
+	iter, err := storage.NewMemSSTIterator(sst.Data, false /* verify */, storage.IterOptions{
+		KeyTypes:   storage.IterKeyTypePointsAndRanges,
+		LowerBound: span.Key,
+		UpperBound: span.EndKey,
+	})
+	if err != nil {
+		b.add(ctx, incrementalViewChange{}, err)
+		return
+	}
+	defer iter.Close()
+	for iter.SeekGE(storage.MVCCKey{Key: span.Key}); ; iter.Next() {
+		if ok, err := iter.Valid(); err != nil {
+			b.add(ctx, incrementalViewChange{}, err)
+			return
+		} else if !ok {
+			return
+		}
+		if _, hasRange := iter.HasPointAndRange(); hasRange {
+			b.add(ctx, incrementalViewChange{}, errors.Newf(
+				"incremental materialized views do not support MVCC range tombstones in ingested SSTs in %s", span,
+			))
+			return
+		}
+		key := iter.UnsafeKey().Clone()
+		b.add(ctx, incrementalViewChange{key: key.Key, ts: key.Timestamp}, nil)
+	}
+}
+
+func (b *incrementalViewBuffer) onDeleteRange(
+	ctx context.Context, value *kvpb.RangeFeedDeleteRange,
+) {
+	b.add(ctx, incrementalViewChange{}, errors.Newf(
+		"incremental materialized views do not support MVCC range tombstones, found one in %s at %s",
+		value.Span, value.Timestamp,
+	))
+}
+
+func (b *incrementalViewBuffer) onInternalError(ctx context.Context, err error) {
+	b.add(ctx, incrementalViewChange{}, err)
+}
+
+func (b *incrementalViewBuffer) onFrontierAdvance(ctx context.Context, ts hlc.Timestamp) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.mu.full {
+		return
+	}
+	b.mu.frontier.Forward(ts)
+}
+
+// add buffers a change, or records the error which stops the job if err is
+// set.
+func (b *incrementalViewBuffer) add(ctx context.Context, c incrementalViewChange, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err != nil {
+		if b.mu.err == nil {
+			b.mu.err = err
+		}
+		return
+	}
+	if b.mu.full {
+		return
+	}
+	if err := b.mu.acc.Grow(ctx, c.memUsage()); err != nil {
+		// Every change that is dropped is above the frontier, as the frontier
+		// stops advancing here.
+		b.mu.full = true
+		select {
+		case b.fullCh <- struct{}{}:
+		default:
+		}
+		return
+	}
+	b.mu.changes = append(b.mu.changes, c)
+}
+
+// take removes and returns the buffered changes at or below the frontier, as
+// well as the frontier and whether the buffer is full.
+func (b *incrementalViewBuffer) take(
+	ctx context.Context,
+) (_ []incrementalViewChange, _ hlc.Timestamp, full bool, _ error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.mu.err != nil {
+		return nil, hlc.Timestamp{}, false, b.mu.err
+	}
+	var taken, kept []incrementalViewChange
+	var takenBytes int64
+	for _, c := range b.mu.changes {
+		if c.ts.LessEq(b.mu.frontier) {
+			taken = append(taken, c)
+			takenBytes += c.memUsage()
+			continue
+		}
+		kept = append(kept, c)
+	}
+	b.mu.changes = kept
+	b.mu.acc.Shrink(ctx, takenBytes)
+	return taken, b.mu.frontier, b.mu.full, nil
+}
+
+// incrementalView is an incremental materialized view as loaded by the job
+// which maintains it.
+type incrementalView struct {
+	desc catalog.TableDescriptor
+	plan *incrementalViewPlan
+	// tables are the base tables of the table references of the plan, in
+	// order.
+	tables []*incrementalViewTable
+	// byID are the base tables by ID.
+	byID map[descpb.ID]*incrementalViewTable
+	// columns are the names of the visible columns of the view.
+	columns []string
+}
+
+// loadIncrementalView loads an incremental materialized view and its base
+// tables. It returns nil if the view has been dropped. The base tables are not
+// loaded while the view is being created.
+func loadIncrementalView(
+	ctx context.Context, execCfg *ExecutorConfig, viewID descpb.ID,
+) (*incrementalView, error) {
+	var v *incrementalView
+	if err := execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		v = nil
+		desc, err := txn.Descriptors().ByIDWithoutLeased(txn.KV()).Get().Table(ctx, viewID)
+		if errors.Is(err, catalog.ErrDescriptorNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if desc.Dropped() {
+			return nil
+		}
+		v = &incrementalView{desc: desc, byID: make(map[descpb.ID]*incrementalViewTable)}
+		if desc.Adding() {
+			return nil
+		}
+		if v.plan, err = analyzeIncrementalViewQuery(desc.GetViewQuery()); err != nil {
+			return err
+		}
+		g := txn.Descriptors().ByNameWithLeased(txn.KV()).Get()
+		for _, ref := range v.plan.tables {
+			db, err := g.Database(ctx, ref.name.Catalog())
+			if err != nil {
+				return err
+			}
+			sc, err := g.Schema(ctx, db, ref.name.Schema())
+			if err != nil {
+				return err
+			}
+			tbl, err := g.Table(ctx, db, sc, ref.name.Object())
+			if err != nil {
+				return err
+			}
+			t, ok := v.byID[tbl.GetID()]
+			if !ok {
+				if t, err = makeIncrementalViewTable(tbl); err != nil {
+					return err
+				}
+				v.byID[tbl.GetID()] = t
+			}
+			v.tables = append(v.tables, t)
+		}
+		for _, col := range desc.VisibleColumns() {
+			v.columns = append(v.columns, tree.NameString(col.GetName()))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// spans returns the spans of the primary indexes of the base tables of the
+// view.
+func (v *incrementalView) spans(codec keys.SQLCodec) []roachpb.Span {
+	var spans []roachpb.Span
+	for _, t := range v.byID {
+		spans = append(spans, t.desc.PrimaryIndexSpan(codec))
+	}
+	return spans
+}
+
+// primaryIndexesChanged returns whether the primary index of a base table of
+// the view differs from the one of other.
+func (v *incrementalView) primaryIndexesChanged(other *incrementalView) bool {
+	for id, t := range v.byID {
+		if o, ok := other.byID[id]; !ok || o.desc.GetPrimaryIndexID() != t.desc.GetPrimaryIndexID() {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeChanges returns the distinct primary keys of the rows of each base
+// table with a change.
+func (v *incrementalView) decodeChanges(
+	codec keys.SQLCodec, changes []incrementalViewChange,
+) (map[descpb.ID][]tree.Datums, error) {
+	changed := make(map[descpb.ID][]tree.Datums)
+	seen := make(map[descpb.ID]map[string]struct{})
+	var alloc tree.DatumAlloc
+	for _, c := range changes {
+		_, tableID, indexID, err := codec.DecodeIndexPrefix(c.key)
+		if err != nil {
+			return nil, err
+		}
+		t, ok := v.byID[descpb.ID(tableID)]
+		if !ok || descpb.IndexID(indexID) != t.desc.GetPrimaryIndexID() {
+			continue
+		}
+		vals := make([]rowenc.EncDatum, len(t.keyCols))
+		if _, err := rowenc.DecodeIndexKey(codec, vals, t.dirs, c.key); err != nil {
+			return nil, err
+		}
+		row := make(tree.Datums, len(vals))
+		for i := range vals {
+			if err := vals[i].EnsureDecoded(t.keyCols[i].GetType(), &alloc); err != nil {
+				return nil, err
+			}
+			row[i] = vals[i].Datum
+		}
+		if seen[t.desc.GetID()] == nil {
+			seen[t.desc.GetID()] = make(map[string]struct{})
+		}
+		key := incrementalViewDatumsKey(row)
+		if _, ok := seen[t.desc.GetID()][key]; ok {
+			continue
+		}
+		seen[t.desc.GetID()][key] = struct{}{}
+		changed[t.desc.GetID()] = append(changed[t.desc.GetID()], row)
+	}
+	return changed, nil
+}
+
+// incrementalViewBatches splits the changed rows of the base tables into
+// batches of at most incrementalViewBatchSize rows.
+func incrementalViewBatches(changed map[descpb.ID][]tree.Datums) []map[descpb.ID][]tree.Datums {
+	var batches []map[descpb.ID][]tree.Datums
+	cur, n := make(map[descpb.ID][]tree.Datums), 0
+	for id, rows := range changed {
+		for _, row := range rows {
+			if n == incrementalViewBatchSize {
+				batches = append(batches, cur)
+				cur, n = make(map[descpb.ID][]tree.Datums), 0
+			}
+			cur[id] = append(cur[id], row)
+			n++
+		}
+	}
+	if n > 0 {
+		batches = append(batches, cur)
+	}
+	return batches
+}
+
+// incrementalViewWrite is a statement which writes to the backing table of an
+// incremental materialized view.
+type incrementalViewWrite struct {
+	stmt string
+	args incrementalViewArgs
+}
+
+// computeWrites returns the statements which apply the changes to the rows of
+// the base tables between from and to to the view.
+func (v *incrementalView) computeWrites(
+	ctx context.Context, ie isql.Executor, from, to hlc.Timestamp, changed map[descpb.ID][]tree.Datums,
+) ([]incrementalViewWrite, error) {
+	query := func(stmt string, args incrementalViewArgs) ([]tree.Datums, error) {
+		return ie.QueryBufferedEx(
+			ctx, "incremental-view-read", nil, /* txn */
+			sessiondata.NodeUserSessionDataOverride, stmt, args...,
+		)
+	}
+	if v.plan.grouped {
+		return v.computeGroupWrites(query, from, to, changed)
+	}
+
+	// The rows of the view derived from the changed rows before and after the
+	// changes, keyed by the primary keys of the rows of the base tables they
+	// are derived from.
+	numExprs := len(v.plan.exprs)
+	before, after := make(map[string]tree.Datums), make(map[string]tree.Datums)
+	for _, batch := range incrementalViewBatches(changed) {
+		for _, side := range []struct {
+			ts   hlc.Timestamp
+			rows map[string]tree.Datums
+		}{{from, before}, {to, after}} {
+			rows, err := query(v.plan.affectedRowsQuery(side.ts, v.tables, batch))
+			if err != nil {
+				return nil, err
+			}
+			for _, row := range rows {
+				side.rows[incrementalViewDatumsKey(row[numExprs:])] = row[:numExprs]
+			}
+		}
+	}
+	type netRow struct {
+		row tree.Datums
+		n   int
+	}
+	net := make(map[string]*netRow)
+	adjust := func(row tree.Datums, delta int) {
+		key := incrementalViewDatumsKey(row)
+		if net[key] == nil {
+			net[key] = &netRow{row: row}
+		}
+		net[key].n += delta
+	}
+	for k, row := range before {
+		adjust(row, -1)
+		if newRow, ok := after[k]; ok {
+			adjust(newRow, 1)
+			delete(after, k)
+		}
+	}
+	for _, row := range after {
+		adjust(row, 1)
+	}
+
+	var writes []incrementalViewWrite
+	var inserts []tree.Datums
+	for _, r := range net {
+		for i := 0; i < r.n; i++ {
+			inserts = append(inserts, r.row)
+		}
+		if r.n >= 0 {
+			continue
+		}
+		var w incrementalViewWrite
+		w.stmt = fmt.Sprintf("DELETE FROM [%d AS v] WHERE %s LIMIT %d",
+			v.desc.GetID(), groupsFilter("v", v.columns, []tree.Datums{r.row}, &w.args), -r.n)
+		writes = append(writes, w)
+	}
+	return append(writes, v.insertWrites(inserts)...), nil
+}
+
+// computeGroupWrites returns the statements which recompute the groups of a
+// grouped view that contain a row of a base table changed between from and
+// to.
+func (v *incrementalView) computeGroupWrites(
+	query func(string, incrementalViewArgs) ([]tree.Datums, error),
+	from, to hlc.Timestamp,
+	changed map[descpb.ID][]tree.Datums,
+) ([]incrementalViewWrite, error) {
+	groups := make(map[string]tree.Datums)
+	for _, batch := range incrementalViewBatches(changed) {
+		for _, ts := range []hlc.Timestamp{from, to} {
+			rows, err := query(v.plan.affectedRowsQuery(ts, v.tables, batch))
+			if err != nil {
+				return nil, err
+			}
+			for _, row := range rows {
+				groups[incrementalViewDatumsKey(row)] = row
+			}
+		}
+	}
+	keys := make([]tree.Datums, 0, len(groups))
+	for _, key := range groups {
+		keys = append(keys, key)
+	}
+	keyColumns := make([]string, len(v.plan.keyColumns))
+	for i, c := range v.plan.keyColumns {
+		keyColumns[i] = v.columns[c]
+	}
+
+	var writes []incrementalViewWrite
+	var inserts []tree.Datums
+	for len(keys) > 0 {
+		batch := keys[:min(len(keys), incrementalViewBatchSize)]
+		keys = keys[len(batch):]
+		rows, err := query(v.plan.groupsQuery(to, batch))
+		if err != nil {
+			return nil, err
+		}
+		inserts = append(inserts, rows...)
+		var w incrementalViewWrite
+		w.stmt = fmt.Sprintf("DELETE FROM [%d AS v] WHERE %s",
+			v.desc.GetID(), groupsFilter("v", keyColumns, batch, &w.args))
+		writes = append(writes, w)
+	}
+	return append(writes, v.insertWrites(inserts)...), nil
+}
+
+// insertWrites returns the statements which insert the rows into the view.
+func (v *incrementalView) insertWrites(rows []tree.Datums) []incrementalViewWrite {
+	var writes []incrementalViewWrite
+	for len(rows) > 0 {
+		batch := rows[:min(len(rows), incrementalViewBatchSize)]
+		rows = rows[len(batch):]
+		var w incrementalViewWrite
+		var b strings.Builder
+		fmt.Fprintf(&b, "INSERT INTO [%d AS v] (%s) VALUES ",
+			v.desc.GetID(), strings.Join(v.columns, ", "))
+		for i, row := range batch {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			w.args.add(&b, row)
+		}
+		w.stmt = b.String()
+		writes = append(writes, w)
+	}
+	return writes
+}
+
+// incrementalViewRefreshResumer implements the job which keeps a materialized
+// view created WITH (incremental) up to date. It consumes a rangefeed on the
+// primary indexes of the base tables of the view and periodically applies the
+// changes up to the rangefeed frontier to the view in a transaction which also
+// advances the high-water mark of the job, which is the time as of which the
+// view reflects its base tables.
+type incrementalViewRefreshResumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = &incrementalViewRefreshResumer{}
+
+// Resume is part of the jobs.Resumer interface.
+func (r *incrementalViewRefreshResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	execCfg := execCtx.(JobExecContext).ExecCfg()
+	viewID := r.job.Details().(jobspb.IncrementalViewRefreshDetails).ViewID
+
+	// Wait for the view to be backfilled.
+	var timer timeutil.Timer
+	defer timer.Stop()
+	var v *incrementalView
+	for {
+		var err error
+		if v, err = loadIncrementalView(ctx, execCfg, viewID); err != nil {
+			return err
+		}
+		if v == nil {
+			return r.releaseProtectedTimestamp(ctx, execCfg)
+		}
+		if !v.desc.Adding() {
+			break
+		}
+		timer.Reset(incrementalViewFlushInterval.Get(&execCfg.Settings.SV))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	progress := r.job.Progress()
+	asOf := v.desc.GetCreateAsOfTime()
+	if hw := progress.GetHighWater(); hw != nil && hw.IsSet() {
+		asOf = *hw
+	}
+	if progress.GetIncrementalViewRefresh().ProtectedTimestampRecord == nil {
+		if err := r.protect(ctx, execCfg, v, asOf); err != nil {
+			return err
+		}
+	}
+
+	bufMon := mon.NewMonitorInheritWithLimit(
+		mon.MakeName("incremental-view-buffer"), incrementalViewBufferSize.Get(&execCfg.Settings.SV),
+		execCfg.RootMemoryMonitor, false, /* longLiving */
+	)
+	bufMon.StartNoReserved(ctx, execCfg.RootMemoryMonitor)
+	defer bufMon.Stop(ctx)
+
+	for {
+		rf, buf, err := r.startRangeFeed(ctx, execCfg, v, asOf, bufMon.MakeBoundAccount())
+		if err != nil {
+			return err
+		}
+		next, err := r.maintain(ctx, execCfg, v, buf, &asOf)
+		rf.Close()
+		buf.close(ctx)
+		if err != nil || next == nil {
+			return err
+		}
+		// The rangefeed is restarted from the time as of which the view is up to
+		// date.
+		v = next
+	}
+}
+
+// maintain applies the changes read by the rangefeed to the view until the view
+// is dropped, in which case it returns nil, or until the rangefeed needs to be
+// restarted, in which case it returns the view to restart it on. The rangefeed
+// is restarted on the new primary indexes when the primary index of a base
+// table changes, and once the buffer fills up.
+func (r *incrementalViewRefreshResumer) maintain(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	v *incrementalView,
+	buf *incrementalViewBuffer,
+	asOf *hlc.Timestamp,
+) (*incrementalView, error) {
+	var timer timeutil.Timer
+	defer timer.Stop()
+	for {
+		timer.Reset(incrementalViewFlushInterval.Get(&execCfg.Settings.SV))
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		case <-buf.fullCh:
+		}
+		changes, frontier, full, err := buf.take(ctx)
+		if err != nil {
+			return nil, jobs.MarkAsPermanentJobError(err)
+		}
+		if frontier.LessEq(*asOf) {
+			if full {
+				return nil, jobs.MarkAsPermanentJobError(errors.WithHintf(errors.Newf(
+					"changes to the base tables of materialized view %d after %s exceed the buffer size",
+					v.desc.GetID(), *asOf,
+				), "increase %s", incrementalViewBufferSize.Name()))
+			}
+			continue
+		}
+		next, err := loadIncrementalView(ctx, execCfg, v.desc.GetID())
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			return nil, r.releaseProtectedTimestamp(ctx, execCfg)
+		}
+		if next.primaryIndexesChanged(v) {
+			log.Dev.Infof(ctx, "restarting rangefeed of incremental materialized view %d after a primary key change",
+				v.desc.GetID())
+			return next, nil
+		}
+		v = next
+		if err := r.apply(ctx, execCfg, v, *asOf, frontier, changes); err != nil {
+			return nil, err
+		}
+		*asOf = frontier
+		if full {
+			log.Dev.Infof(ctx, "restarting rangefeed of incremental materialized view %d after its buffer filled up",
+				v.desc.GetID())
+			return v, nil
+		}
+	}
+}
+
+// startRangeFeed starts a rangefeed on the primary indexes of the base tables
+// of the view from the given time.
+func (r *incrementalViewRefreshResumer) startRangeFeed(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	v *incrementalView,
+	asOf hlc.Timestamp,
+	acc mon.BoundAccount,
+) (*rangefeed.RangeFeed, *incrementalViewBuffer, error) {
+	buf := newIncrementalViewBuffer(acc)
+	rf, err := execCfg.RangeFeedFactory.RangeFeed(ctx,
+		fmt.Sprintf("incremental-view-%d", v.desc.GetID()), v.spans(execCfg.Codec), asOf, buf.onValue,
+		rangefeed.WithOnFrontierAdvance(buf.onFrontierAdvance),
+		rangefeed.WithOnSSTable(buf.onSSTable),
+		rangefeed.WithOnDeleteRange(buf.onDeleteRange),
+		rangefeed.WithOnInternalError(buf.onInternalError),
+	)
+	if err != nil {
+		buf.close(ctx)
+		return nil, nil, err
+	}
+	return rf, buf, nil
+}
+
+// apply applies the changes to the base tables of the view between from and to
+// to the view, and advances the high-water mark of the job and the protected
+// timestamp of the base tables to the time.
+func (r *incrementalViewRefreshResumer) apply(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	v *incrementalView,
+	from, to hlc.Timestamp,
+	changes []incrementalViewChange,
+) error {
+	changed, err := v.decodeChanges(execCfg.Codec, changes)
+	if err != nil {
+		return err
+	}
+	var writes []incrementalViewWrite
+	if len(changed) > 0 {
+		if writes, err = v.computeWrites(ctx, execCfg.InternalDB.Executor(), from, to, changed); err != nil {
+			return err
+		}
+	}
+	progress := r.job.Progress()
+	ptsID := progress.GetIncrementalViewRefresh().ProtectedTimestampRecord
+	return execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		override := sessiondata.NodeUserSessionDataOverride
+		override.AllowMaterializedViewMutations = true
+		for _, w := range writes {
+			if _, err := txn.ExecEx(
+				ctx, "incremental-view-write", txn.KV(), override, w.stmt, w.args...,
+			); err != nil {
+				return err
+			}
+		}
+		if err := execCfg.ProtectedTimestampProvider.WithTxn(txn).UpdateTimestamp(ctx, *ptsID, to); err != nil {
+			return err
+		}
+		return r.job.WithTxn(txn).Update(ctx, func(
+			txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+		) error {
+			if err := md.CheckRunningOrReverting(); err != nil {
+				return err
+			}
+			md.Progress.Progress = &jobspb.Progress_HighWater{HighWater: &to}
+			ju.UpdateProgress(md.Progress)
+			return nil
+		})
+	})
+}
+
+// protect protects the history of the base tables of the view after the given
+// time from garbage collection.
+func (r *incrementalViewRefreshResumer) protect(
+	ctx context.Context, execCfg *ExecutorConfig, v *incrementalView, asOf hlc.Timestamp,
+) error {
+	var ids descpb.IDs
+	for id := range v.byID {
+		ids = append(ids, id)
+	}
+	ptsID := uuid.MakeV4()
+	return execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		if err := execCfg.ProtectedTimestampProvider.WithTxn(txn).Protect(ctx,
+			jobsprotectedts.MakeRecord(ptsID, int64(r.job.ID()), asOf, nil, /* deprecatedSpans */
+				jobsprotectedts.Jobs, ptpb.MakeSchemaObjectsTarget(ids)),
+		); err != nil {
+			return err
+		}
+		return r.job.WithTxn(txn).Update(ctx, func(
+			txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+		) error {
+			if err := md.CheckRunningOrReverting(); err != nil {
+				return err
+			}
+			md.Progress.GetIncrementalViewRefresh().ProtectedTimestampRecord = &ptsID
+			ju.UpdateProgress(md.Progress)
+			return nil
+		})
+	})
+}
+
+// releaseProtectedTimestamp releases the protected timestamp record of the
+// job, if any.
+func (r *incrementalViewRefreshResumer) releaseProtectedTimestamp(
+	ctx context.Context, execCfg *ExecutorConfig,
+) error {
+	progress := r.job.Progress()
+	ptsID := progress.GetIncrementalViewRefresh().ProtectedTimestampRecord
+	if ptsID == nil {
+		return nil
+	}
+	return execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		err := execCfg.ProtectedTimestampProvider.WithTxn(txn).Release(ctx, *ptsID)
+		// In case that a retry happens, the record might have been released.
+		if errors.Is(err, protectedts.ErrNotExists) {
+			return nil
+		}
+		return err
+	})
+}
+
+// OnFailOrCancel is part of the jobs.Resumer interface. The view is left as
+// of the high-water mark of the job, and is no longer maintained
+// incrementally, so that it can be brought up to date with REFRESH
+// MATERIALIZED VIEW.
+func (r *incrementalViewRefreshResumer) OnFailOrCancel(
+	ctx context.Context, execCtx interface{}, _ error,
+) error {
+	execCfg := execCtx.(JobExecContext).ExecCfg()
+	if err := r.releaseProtectedTimestamp(ctx, execCfg); err != nil {
+		return err
+	}
+	viewID := r.job.Details().(jobspb.IncrementalViewRefreshDetails).ViewID
+	return DescsTxn(ctx, execCfg, func(ctx context.Context, txn isql.Txn, col *descs.Collection) error {
+		desc, err := col.MutableByID(txn.KV()).Table(ctx, viewID)
+		if err != nil {
+			// There is nothing to clean up if the view has been dropped.
+			if errors.Is(err, catalog.ErrDescriptorNotFound) || catalog.HasInactiveDescriptorError(err) {
+				return nil
+			}
+			return err
+		}
+		if desc.IncrementalRefreshJobID != r.job.ID() {
+			return nil
+		}
+		desc.IncrementalRefreshJobID = 0
+		return col.WriteDesc(ctx, false /* kvTrace */, desc, txn.KV())
+	})
+}
+
+// CollectProfile is part of the jobs.Resumer interface.
+func (r *incrementalViewRefreshResumer) CollectProfile(context.Context, interface{}) error {
+	return nil
+}
+
+func init() {
+	jobs.RegisterConstructor(
+		jobspb.TypeIncrementalViewRefresh,
+		func(job *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+			return &incrementalViewRefreshResumer{job: job}
+		},
+		jobs.UsesTenantCostControl,
+	)
+}
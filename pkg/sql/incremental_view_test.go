@@ -0,0 +1,223 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeIncrementalViewQuery(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, tc := range []struct {
+		query      string
+		refs       []string
+		grouped    bool
+		keyColumns []int
+		err        string
+	}{
+		{
+			query: "SELECT a, b + 1 FROM d.public.t WHERE b > 1",
+			refs:  []string{"d.public.t"},
+		},
+		{
+			query: "SELECT t.a, u.c FROM d.public.t JOIN d.public.u AS u ON t.a = u.a, d.public.w",
+			refs:  []string{"d.public.t", "u", "d.public.w"},
+		},
+		{
+			query:      "SELECT k, sum(v), count(*), min(v), max(v) FROM d.public.t GROUP BY k",
+			refs:       []string{"d.public.t"},
+			grouped:    true,
+			keyColumns: []int{0},
+		},
+		{
+			query:      "SELECT max(v), j, k FROM d.public.t GROUP BY k, 2 HAVING count(*) > 1",
+			refs:       []string{"d.public.t"},
+			grouped:    true,
+			keyColumns: []int{2, 1},
+		},
+		{query: "SELECT a FROM d.public.t LEFT JOIN d.public.u USING (a)", err: "LEFT joins are not supported"},
+		{query: "SELECT k, avg(v) FROM d.public.t GROUP BY k", err: "aggregate function avg is not supported"},
+		{query: "SELECT a, random() FROM d.public.t", err: "function random is not immutable"},
+		{query: "SELECT a FROM d.public.t WHERE a IN (SELECT a FROM d.public.u)", err: "subqueries are not supported"},
+		{query: "SELECT DISTINCT a FROM d.public.t", err: "DISTINCT is not supported"},
+		{query: "SELECT a FROM d.public.t ORDER BY a", err: "ORDER BY"},
+		{query: "SELECT a FROM d.public.t UNION SELECT a FROM d.public.u", err: "set operations"},
+		{query: "SELECT sum(v) FROM d.public.t GROUP BY k", err: "GROUP BY expression k must be an output column"},
+		{query: "SELECT count(*) FROM d.public.t", err: "aggregations without GROUP BY"},
+		{query: "SELECT * FROM d.public.t", err: "* in the select list"},
+		{query: "SELECT a FROM generate_series(1, 3) AS g (a)", err: "only tables"},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			p, err := analyzeIncrementalViewQuery(tc.query)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			var refs []string
+			for _, ref := range p.tables {
+				refs = append(refs, ref.ref)
+			}
+			require.Equal(t, tc.refs, refs)
+			require.Equal(t, tc.grouped, p.grouped)
+			require.Equal(t, tc.keyColumns, p.keyColumns)
+		})
+	}
+}
+
+func TestIncrementalMaterializedView(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	for _, l := range []serverutils.ApplicationLayerInterface{s.ApplicationLayer(), s.SystemLayer()} {
+		kvserver.RangefeedEnabled.Override(ctx, &l.ClusterSettings().SV, true)
+	}
+	incrementalViewFlushInterval.Override(ctx, &s.ApplicationLayer().ClusterSettings().SV, 10*time.Millisecond)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	sqlDB.Exec(t, "CREATE TABLE customers (id INT PRIMARY KEY, region STRING)")
+	sqlDB.Exec(t, "CREATE TABLE orders (id INT PRIMARY KEY, customer INT, amount INT)")
+	sqlDB.Exec(t, "INSERT INTO customers VALUES (1, 'east'), (2, 'west')")
+	sqlDB.Exec(t, "INSERT INTO orders VALUES (1, 1, 10), (2, 1, 20), (3, 2, 5)")
+	sqlDB.Exec(t, `CREATE MATERIALIZED VIEW totals WITH (incremental) AS
+SELECT c.region, sum(o.amount) AS total, count(*) AS n, max(o.amount) AS biggest
+FROM orders AS o JOIN customers AS c ON o.customer = c.id
+GROUP BY c.region`)
+	sqlDB.Exec(t, "CREATE MATERIALIZED VIEW big WITH (incremental) AS SELECT id, amount FROM orders WHERE amount >= 10")
+
+	sqlDB.Exec(t, "INSERT INTO orders VALUES (4, 2, 50)")
+	sqlDB.Exec(t, "UPDATE orders SET amount = 1 WHERE id = 1")
+	sqlDB.Exec(t, "DELETE FROM orders WHERE id = 2")
+	sqlDB.Exec(t, "UPDATE customers SET region = 'north' WHERE id = 1")
+
+	sqlDB.CheckQueryResultsRetry(t, "SELECT * FROM totals ORDER BY region", [][]string{
+		{"north", "1", "1", "1"},
+		{"west", "55", "2", "50"},
+	})
+	sqlDB.CheckQueryResultsRetry(t, "SELECT * FROM big ORDER BY id", [][]string{
+		{"4", "50"},
+	})
+
+	// The time as of which a view is up to date is the high-water mark of its
+	// job.
+	var jobID int
+	sqlDB.QueryRow(t, `SELECT job_id FROM [SHOW JOBS]
+WHERE job_type = 'INCREMENTAL VIEW REFRESH' AND description LIKE '%big%'`).Scan(&jobID)
+	var hasHighWater bool
+	sqlDB.QueryRow(t,
+		"SELECT high_water_timestamp IS NOT NULL FROM [SHOW JOBS] WHERE job_id = $1", jobID,
+	).Scan(&hasHighWater)
+	require.True(t, hasHighWater)
+
+	sqlDB.ExpectErr(t, "is maintained incrementally", "REFRESH MATERIALIZED VIEW totals")
+	sqlDB.ExpectErr(t, `cannot mutate materialized view "totals"`, "DELETE FROM totals")
+	require.Contains(t,
+		sqlDB.QueryStr(t, "SHOW CREATE totals")[0][1], "WITH ( incremental ) AS",
+	)
+
+	// Views with unsupported queries are refreshed like other materialized
+	// views.
+	sqlDB.Exec(t, "CREATE MATERIALIZED VIEW regions WITH (incremental) AS SELECT DISTINCT region FROM customers")
+	sqlDB.Exec(t, "REFRESH MATERIALIZED VIEW regions")
+	sqlDB.ExpectErr(t, "cannot be created WITH NO DATA",
+		"CREATE MATERIALIZED VIEW nodata WITH (incremental) AS SELECT id FROM orders WITH NO DATA")
+
+	// A view whose job is canceled is no longer maintained incrementally, and
+	// can be refreshed.
+	var totalsJobID int
+	sqlDB.QueryRow(t, `SELECT job_id FROM [SHOW JOBS]
+WHERE job_type = 'INCREMENTAL VIEW REFRESH' AND description LIKE '%totals%'`).Scan(&totalsJobID)
+	sqlDB.Exec(t, "CANCEL JOB $1", totalsJobID)
+	sqlDB.CheckQueryResultsRetry(t,
+		fmt.Sprintf("SELECT status FROM [SHOW JOBS] WHERE job_id = %d", totalsJobID),
+		[][]string{{"canceled"}},
+	)
+	sqlDB.Exec(t, "INSERT INTO orders VALUES (5, 2, 100)")
+	sqlDB.Exec(t, "REFRESH MATERIALIZED VIEW totals")
+	sqlDB.CheckQueryResults(t, "SELECT * FROM totals ORDER BY region", [][]string{
+		{"north", "1", "1", "1"},
+		{"west", "155", "3", "100"},
+	})
+	require.NotContains(t, sqlDB.QueryStr(t, "SHOW CREATE totals")[0][1], "incremental")
+
+	// The job stops once its view is dropped.
+	sqlDB.Exec(t, "DROP MATERIALIZED VIEW big")
+	sqlDB.CheckQueryResultsRetry(t,
+		fmt.Sprintf("SELECT status FROM [SHOW JOBS] WHERE job_id = %d", jobID),
+		[][]string{{"succeeded"}},
+	)
+}
+
+func TestIncrementalViewBuffer(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	monitor := mon.NewMonitor(mon.Options{
+		Name:     mon.MakeName("test_monitor"),
+		Settings: cluster.MakeTestingClusterSettings(),
+	})
+	monitor.Start(ctx, nil, mon.NewStandaloneBudget(64<<10))
+	defer monitor.Stop(ctx)
+	buf := newIncrementalViewBuffer(monitor.MakeBoundAccount())
+
+	ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+	value := func(key roachpb.Key, wallTime int64) *kvpb.RangeFeedValue {
+		return &kvpb.RangeFeedValue{Key: key, Value: roachpb.Value{Timestamp: ts(wallTime)}}
+	}
+
+	// Changes are taken once the frontier passes them.
+	buf.onValue(ctx, value(roachpb.Key("a"), 1))
+	buf.onValue(ctx, value(roachpb.Key("b"), 3))
+	buf.onFrontierAdvance(ctx, ts(2))
+	changes, frontier, full, err := buf.take(ctx)
+	require.NoError(t, err)
+	require.False(t, full)
+	require.Equal(t, ts(2), frontier)
+	require.Equal(t, []incrementalViewChange{{key: roachpb.Key("a"), ts: ts(1)}}, changes)
+
+	// Once the buffer is full, later changes are dropped and the frontier stops
+	// advancing, so the changes up to the frontier can still be applied.
+	buf.onFrontierAdvance(ctx, ts(3))
+	for filled := false; !filled; {
+		buf.onValue(ctx, value(make(roachpb.Key, 1<<10), 4))
+		select {
+		case <-buf.fullCh:
+			filled = true
+		default:
+		}
+	}
+	buf.onFrontierAdvance(ctx, ts(5))
+	changes, frontier, full, err = buf.take(ctx)
+	require.NoError(t, err)
+	require.True(t, full)
+	require.Equal(t, ts(3), frontier)
+	require.Equal(t, []incrementalViewChange{{key: roachpb.Key("b"), ts: ts(3)}}, changes)
+
+	buf.close(ctx)
+	require.Zero(t, monitor.AllocBytes())
+}
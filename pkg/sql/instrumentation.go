@@ -217,6 +217,14 @@ type instrumentationHelper struct {
 	// retryStmtCount is the number of times the statement was retried.
 	retryStmtCount uint64
 
+	// reoptimizations records the failed cardinality checks that caused the
+	// statement to be re-optimized.
+	reoptimizations []*execinfrapb.CardinalityMisestimateError
+
+	// planBaselineStatus describes how the plan baseline for the statement was
+	// used, if it has one.
+	planBaselineStatus string
+
 	// joinTypeCounts records the number of times each type of logical join was
 	// used in the query, up to 255.
 	joinTypeCounts [execbuilder.NumRecordedJoinTypes]uint8
@@ -664,6 +672,8 @@ func (ih *instrumentationHelper) Finish(
 	}
 
 	ih.retryStmtCount = uint64(p.autoRetryStmtCounter)
+	ih.reoptimizations = p.reoptimizations
+	ih.planBaselineStatus = p.planBaselineStatus(ih.planGist.String())
 
 	// Record the statement information that we've collected.
 	// Note that in case of implicit transactions, the trace contains the auto-commit too.
@@ -867,10 +877,14 @@ func (ih *instrumentationHelper) emitExplainAnalyzePlanToOutputBuilder(
 	ob.AddVectorized(ih.vectorized)
 	ob.AddPlanType(ih.generic, ih.optimized)
 	ob.AddStmtHintCount(ih.stmtHintsCount)
+	ob.AddPlanBaseline(ih.planBaselineStatus)
 	ob.AddRetryCount("transaction", ih.retryCount)
 	ob.AddRetryTime("transaction", phaseTimes.GetTransactionRetryLatency())
 	ob.AddRetryCount("statement", ih.retryStmtCount)
 	ob.AddRetryTime("statement", phaseTimes.GetStatementRetryLatency())
+	for _, r := range ih.reoptimizations {
+		ob.AddReoptimization(r.Misestimate.Check.EstimatedRowCount, r.Misestimate.ObservedRowCount)
+	}
 
 	if queryStats != nil {
 		if queryStats.KVRowsRead != 0 {
@@ -947,9 +947,18 @@ func applyOverrides(o sessiondata.InternalExecutorOverride, sd *sessiondata.Sess
 	if o.OriginIDForLogicalDataReplication != 0 {
 		sd.OriginIDForLogicalDataReplication = o.OriginIDForLogicalDataReplication
 	}
+	if o.LocalOriginIDForLogicalDataReplication != 0 {
+		sd.LocalOriginIDForLogicalDataReplication = o.LocalOriginIDForLogicalDataReplication
+	}
 	if o.OriginTimestampForLogicalDataReplication.IsSet() {
 		sd.OriginTimestampForLogicalDataReplication = o.OriginTimestampForLogicalDataReplication
 	}
+	if o.AllowSchemaChangesOnLDRTables {
+		sd.AllowSchemaChangesOnLDRTables = true
+	}
+	if o.AllowMaterializedViewMutations {
+		sd.AllowMaterializedViewMutations = true
+	}
 	if o.PlanCacheMode != nil {
 		sd.PlanCacheMode = *o.PlanCacheMode
 	}
@@ -39,6 +39,17 @@ type joinNode struct {
 	// estimatedRightRowCount, when set, is the estimated number of rows that
 	// the right input will produce.
 	estimatedRightRowCount uint64
+
+	// rightCardinalityCheckID, when non-zero, identifies the cardinality check
+	// performed on the right input of a hash join. See
+	// execinfrapb.CardinalityCheck.
+	rightCardinalityCheckID int32
+
+	// partitionAligned is set if the inputs are scans of identically
+	// partitioned indexes and the equality columns include the partitioning
+	// columns, in which case the join can be planned partition-wise. See
+	// distribution.PartitionAligned.
+	partitionAligned bool
 }
 
 func (p *planner) makeJoinNode(
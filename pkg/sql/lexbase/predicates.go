@@ -49,6 +49,8 @@ var lookaheadKeywords = []string{
 	"tenant",
 	"set",
 	"for",
+	"system_time",
+	"versioning",
 }
 
 // reservedOrLookaheadKeywords are the reserved keywords plus those keywords for
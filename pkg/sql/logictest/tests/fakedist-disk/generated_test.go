@@ -2180,6 +2180,13 @@ func TestLogic_system_namespace(
 	runLogicTest(t, "system_namespace")
 }
 
+func TestLogic_system_versioning(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "system_versioning")
+}
+
 func TestLogic_table(
 	t *testing.T,
 ) {
@@ -2187,6 +2194,13 @@ func TestLogic_table(
 	runLogicTest(t, "table")
 }
 
+func TestLogic_tablesample(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "tablesample")
+}
+
 func TestLogic_target_names(
 	t *testing.T,
 ) {
@@ -2208,6 +2208,13 @@ func TestLogic_system_namespace(
 	runLogicTest(t, "system_namespace")
 }
 
+func TestLogic_system_versioning(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "system_versioning")
+}
+
 func TestLogic_table(
 	t *testing.T,
 ) {
@@ -2215,6 +2222,13 @@ func TestLogic_table(
 	runLogicTest(t, "table")
 }
 
+func TestLogic_tablesample(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "tablesample")
+}
+
 func TestLogic_target_names(
 	t *testing.T,
 ) {
@@ -2173,6 +2173,13 @@ func TestLogic_table(
 	runLogicTest(t, "table")
 }
 
+func TestLogic_tablesample(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "tablesample")
+}
+
 func TestLogic_target_names(
 	t *testing.T,
 ) {
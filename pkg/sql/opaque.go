@@ -462,12 +462,16 @@ func init() {
 		&tree.Backup{},
 		&tree.ShowBackup{},
 		&tree.Restore{},
+		&tree.CloneTable{},
 		&tree.CreateChangefeed{},
 		&tree.ScheduledChangefeed{},
 		&tree.Import{},
 		&tree.ScheduledBackup{},
+		&tree.ScheduledVerifyBackup{},
+		&tree.VerifyBackup{},
 		&tree.CreateTenantFromReplication{},
 		&tree.CreateLogicalReplicationStream{},
+		&tree.ReplayDeadLetterQueue{},
 		&tree.CheckExternalConnection{},
 	} {
 		typ := optbuilder.OpaqueReadOnly
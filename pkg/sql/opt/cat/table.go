@@ -205,6 +205,13 @@ type Table interface {
 
 	// Policies returns all the policies defined for this table.
 	Policies() *Policies
+
+	// SystemVersioning returns the ID of the history table and the ordinal of
+	// the row start column if this is a system-versioned table. The history
+	// table stores the previous versions of the rows of the table, and the row
+	// start column stores the time at which each row version became current.
+	// If the table is not system-versioned, ok is false.
+	SystemVersioning() (historyTable StableID, rowStartOrd int, ok bool)
 }
 
 // CheckConstraint represents a check constraint on a table. Check constraints
@@ -271,6 +278,19 @@ type TableStatistic interface {
 	// inverted index histograms, this will always return types.Bytes.
 	HistogramType() *types.T
 
+	// MostCommonValues returns the most common combinations of non-NULL values
+	// on the columns of a multi-column statistic, in order of decreasing
+	// frequency. It is only used for multi-column stats (i.e., when
+	// ColumnCount() > 1). See MultiColumnValue for more details.
+	MostCommonValues() []MultiColumnValue
+
+	// DependencyDegree returns the fraction of rows (without NULL values) for
+	// which the value of the last column of a multi-column statistic is
+	// functionally determined by the values of the preceding columns. It is
+	// zero for single-column stats, and for multi-column stats which were
+	// collected without extended statistics.
+	DependencyDegree() float64
+
 	// IsPartial returns true if this statistic was collected with USING EXTREMES
 	// or with a WHERE clause.
 	IsPartial() bool
@@ -307,6 +327,17 @@ type HistogramBucket struct {
 	UpperBound tree.Datum
 }
 
+// MultiColumnValue contains a combination of values on the columns of a
+// multi-column statistic, and the estimated number of rows with those values.
+type MultiColumnValue struct {
+	// NumEq is the estimated number of rows with values equal to Values.
+	NumEq float64
+
+	// Values contains the value of each column of the statistic, in the same
+	// order as the columns of the statistic.
+	Values tree.Datums
+}
+
 // ForeignKeyConstraint represents a foreign key constraint. A foreign key
 // constraint has an origin (or referencing) side and a referenced side. For
 // example:
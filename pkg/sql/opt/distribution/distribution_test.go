@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
@@ -18,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testcat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 )
 
 func TestBuildProvided(t *testing.T) {
@@ -194,3 +196,59 @@ func TestGetDistributions(t *testing.T) {
 		})
 	}
 }
+
+func TestPartitionAligned(t *testing.T) {
+	catalog := testcat.New()
+	for _, ddl := range []string{
+		`CREATE TABLE ab (a INT, b INT, PRIMARY KEY (a, b)) PARTITION BY LIST (a) (
+			PARTITION p1 VALUES IN (1), PARTITION p2 VALUES IN (2, 3)
+		)`,
+		`CREATE TABLE cd (c INT, d INT, PRIMARY KEY (c, d)) PARTITION BY LIST (c) (
+			PARTITION p1 VALUES IN (1, 2), PARTITION p2 VALUES IN (3)
+		)`,
+		`CREATE TABLE ef (e INT, f INT, PRIMARY KEY (e, f)) PARTITION BY LIST (e) (
+			PARTITION p1 VALUES IN (1), PARTITION p2 VALUES IN (4)
+		)`,
+		`CREATE TABLE gh (g INT, h INT, PRIMARY KEY (g, h))`,
+	} {
+		if _, err := catalog.ExecuteDDL(ddl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var md opt.Metadata
+	md.Init()
+	scan := func(name string) (*memo.ScanExpr, opt.ColumnID, opt.ColumnID) {
+		tn := tree.NewUnqualifiedTableName(tree.Name(name))
+		tab := md.AddTable(catalog.Table(tn), tn)
+		return &memo.ScanExpr{ScanPrivate: memo.ScanPrivate{Table: tab}}, tab.ColumnID(0), tab.ColumnID(1)
+	}
+	ab, a, b := scan("ab")
+	cd, c, d := scan("cd")
+	ef, e, _ := scan("ef")
+	gh, g, _ := scan("gh")
+
+	testCases := []struct {
+		left, right     memo.RelExpr
+		leftEq, rightEq opt.ColList
+		expected        bool
+	}{
+		// The partitions of ab and cd have the same values, even though they are
+		// grouped differently.
+		{ab, cd, opt.ColList{a}, opt.ColList{c}, true},
+		{ab, cd, opt.ColList{b, a}, opt.ColList{d, c}, true},
+		{&memo.SelectExpr{Input: ab}, &memo.ProjectExpr{Input: cd}, opt.ColList{a}, opt.ColList{c}, true},
+		// The partitioning columns are not equated.
+		{ab, cd, opt.ColList{b}, opt.ColList{d}, false},
+		{ab, cd, opt.ColList{a, b}, opt.ColList{d, c}, false},
+		// The partitions have different values.
+		{ab, ef, opt.ColList{a}, opt.ColList{e}, false},
+		// One of the tables is not partitioned.
+		{ab, gh, opt.ColList{a}, opt.ColList{g}, false},
+		{&memo.SortExpr{Input: ab}, cd, opt.ColList{a}, opt.ColList{c}, false},
+	}
+	for i, tc := range testCases {
+		if actual := PartitionAligned(&md, tc.left, tc.right, tc.leftEq, tc.rightEq); actual != tc.expected {
+			t.Errorf("%d: expected %t, got %t", i, tc.expected, actual)
+		}
+	}
+}
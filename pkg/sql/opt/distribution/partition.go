@@ -0,0 +1,126 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package distribution
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// PartitionAligned returns true if the given join inputs are scans of indexes
+// that are partitioned identically by PARTITION BY LIST (e.g., the indexes of
+// REGIONAL BY ROW tables in the same database), and the given equality columns
+// equate each partitioning column of the left index with the corresponding
+// partitioning column of the right index. In that case, rows from the two
+// inputs can only match if they are in partitions with the same values, so
+// the join can be performed separately for each set of partitions, local to
+// the nodes that hold them.
+func PartitionAligned(
+	md *opt.Metadata, left, right memo.RelExpr, leftEq, rightEq opt.ColList,
+) bool {
+	leftScan, ok := partitionedScan(left)
+	if !ok {
+		return false
+	}
+	rightScan, ok := partitionedScan(right)
+	if !ok {
+		return false
+	}
+	leftIndex := md.Table(leftScan.Table).Index(leftScan.Index)
+	rightIndex := md.Table(rightScan.Table).Index(rightScan.Index)
+	leftKeys, numCols, ok := partitionKeys(leftIndex)
+	if !ok {
+		return false
+	}
+	rightKeys, rightNumCols, ok := partitionKeys(rightIndex)
+	if !ok || numCols != rightNumCols || len(leftKeys) != len(rightKeys) {
+		return false
+	}
+	for i := range leftKeys {
+		if leftKeys[i] != rightKeys[i] {
+			return false
+		}
+	}
+
+	// Each partitioning column must be equated with the corresponding
+	// partitioning column of the other input.
+	for i := 0; i < numCols; i++ {
+		leftCol := leftScan.Table.IndexColumnID(leftIndex, i)
+		rightCol := rightScan.Table.IndexColumnID(rightIndex, i)
+		if !md.ColumnMeta(leftCol).Type.Identical(md.ColumnMeta(rightCol).Type) {
+			return false
+		}
+		found := false
+		for j := range leftEq {
+			if leftEq[j] == leftCol && rightEq[j] == rightCol {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// PartitionKey returns a string that uniquely identifies the given values of
+// the partitioning columns of a PARTITION BY LIST partition.
+func PartitionKey(values tree.Datums) string {
+	var b strings.Builder
+	for i, d := range values {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(tree.AsStringWithFlags(d, tree.FmtSerializable))
+	}
+	return b.String()
+}
+
+// partitionedScan returns the scan that produces the rows of the given
+// expression, if the expression is a scan optionally wrapped in selections
+// and projections.
+func partitionedScan(e memo.RelExpr) (*memo.ScanExpr, bool) {
+	for {
+		switch t := e.(type) {
+		case *memo.SelectExpr:
+			e = t.Input
+		case *memo.ProjectExpr:
+			e = t.Input
+		case *memo.ScanExpr:
+			return t, true
+		default:
+			return nil, false
+		}
+	}
+}
+
+// partitionKeys returns the sorted keys of all the values of the PARTITION BY
+// LIST partitions of the given index, along with the number of partitioning
+// columns. It returns ok=false if the index is not partitioned, or if the
+// values of its partitions do not all specify every partitioning column.
+func partitionKeys(index cat.Index) (keys []string, numCols int, ok bool) {
+	for i, n := 0, index.PartitionCount(); i < n; i++ {
+		for _, values := range index.Partition(i).PartitionByListPrefixes() {
+			if numCols == 0 {
+				numCols = len(values)
+			} else if len(values) != numCols {
+				return nil, 0, false
+			}
+			keys = append(keys, PartitionKey(values))
+		}
+	}
+	if len(keys) == 0 {
+		return nil, 0, false
+	}
+	sort.Strings(keys)
+	return keys, numCols, true
+}
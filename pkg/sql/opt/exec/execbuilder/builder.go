@@ -134,6 +134,17 @@ type Builder struct {
 	// are planned as nested routines, and therefore it is useful to apply TCO.
 	tailCalls map[opt.ScalarExpr]struct{}
 
+	// cardinalityChecksEnabled is true if the builder should add cardinality
+	// checks to the build side of hash joins. See EnableCardinalityChecks.
+	cardinalityChecksEnabled bool
+
+	// cardinalityCheckBlockers is non-zero while building expressions which
+	// can produce output rows before all of their inputs have been fully
+	// consumed (e.g., UNION ALL). Cardinality checks are not added within such
+	// expressions, because the statement could no longer be re-optimized once
+	// rows have been returned to the client.
+	cardinalityCheckBlockers int
+
 	// -- output --
 
 	// flags tracks various properties of the plan accumulated while building.
@@ -192,6 +203,12 @@ type Builder struct {
 
 	// IndexesUsed list the indexes used in query with the format tableID@indexID.
 	IndexesUsed
+
+	// CardinalityChecks contains an entry for each cardinality check added to
+	// the plan. The check with ID i corresponds to CardinalityChecks[i-1]. The
+	// entries identify the memo groups of the checked expressions, so that the
+	// observed row counts can be fed back to the optimizer.
+	CardinalityChecks []memo.CardinalityObservation
 }
 
 // IndexesUsed is a list of indexes used in a query.
@@ -292,6 +309,15 @@ func (b *Builder) DisableTelemetry() {
 	b.disableTelemetry = true
 }
 
+// EnableCardinalityChecks causes the builder to add cardinality checks to the
+// build side of hash joins, when the build side has statistics available. A
+// failed check causes execution to stop with a cardinality misestimate error,
+// allowing the statement to be re-optimized with the observed row count. It
+// must only be enabled for statements which can safely be re-executed.
+func (b *Builder) EnableCardinalityChecks() {
+	b.cardinalityChecksEnabled = true
+}
+
 // Build constructs the execution node tree and returns its root node if no
 // error occurred.
 func (b *Builder) Build() (_ exec.Plan, err error) {
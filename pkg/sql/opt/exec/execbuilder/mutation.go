@@ -464,7 +464,8 @@ func (b *Builder) buildUpdate(upd *memo.UpdateExpr) (_ execPlan, outputCols colO
 	}
 
 	allowAutoCommit := b.allowAutoCommit && len(upd.UniqueChecks) == 0 &&
-		len(upd.FKChecks) == 0 && len(upd.FKCascades) == 0 && upd.AfterTriggers == nil
+		len(upd.FKChecks) == 0 && len(upd.FKCascades) == 0 && upd.AfterTriggers == nil &&
+		upd.HistoryInsert == nil
 	var node exec.Node
 	if upd.Swap {
 		if !checkOrds.Empty() || len(upd.UniqueWithTombstoneIndexes) != 0 {
@@ -510,6 +511,10 @@ func (b *Builder) buildUpdate(upd *memo.UpdateExpr) (_ execPlan, outputCols colO
 		return execPlan{}, colOrdMap{}, err
 	}
 
+	if err := b.buildHistoryInsert(upd.WithID, upd.HistoryInsert); err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+
 	if err := b.buildAfterTriggers(upd.WithID, upd.AfterTriggers); err != nil {
 		return execPlan{}, colOrdMap{}, err
 	}
@@ -572,7 +577,8 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (_ execPlan, outputCols colO
 		ups.UniqueWithTombstoneIndexes,
 		lockedIndexes,
 		b.allowAutoCommit && len(ups.UniqueChecks) == 0 &&
-			len(ups.FKChecks) == 0 && len(ups.FKCascades) == 0 && ups.AfterTriggers == nil,
+			len(ups.FKChecks) == 0 && len(ups.FKCascades) == 0 && ups.AfterTriggers == nil &&
+			ups.HistoryInsert == nil,
 	)
 	if err != nil {
 		return execPlan{}, colOrdMap{}, err
@@ -590,6 +596,10 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (_ execPlan, outputCols colO
 		return execPlan{}, colOrdMap{}, err
 	}
 
+	if err := b.buildHistoryInsert(ups.WithID, ups.HistoryInsert); err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+
 	if err := b.buildAfterTriggers(ups.WithID, ups.AfterTriggers); err != nil {
 		return execPlan{}, colOrdMap{}, err
 	}
@@ -643,7 +653,7 @@ func (b *Builder) buildDelete(del *memo.DeleteExpr) (_ execPlan, outputCols colO
 	}
 
 	allowAutoCommit := b.allowAutoCommit && len(del.FKChecks) == 0 &&
-		len(del.FKCascades) == 0 && del.AfterTriggers == nil
+		len(del.FKCascades) == 0 && del.AfterTriggers == nil && del.HistoryInsert == nil
 	var node exec.Node
 	if del.Swap {
 		node, err = b.factory.ConstructDeleteSwap(
@@ -678,6 +688,10 @@ func (b *Builder) buildDelete(del *memo.DeleteExpr) (_ execPlan, outputCols colO
 		return execPlan{}, colOrdMap{}, err
 	}
 
+	if err := b.buildHistoryInsert(del.WithID, del.HistoryInsert); err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+
 	if err := b.buildAfterTriggers(del.WithID, del.AfterTriggers); err != nil {
 		return execPlan{}, colOrdMap{}, err
 	}
@@ -1164,6 +1178,18 @@ func (b *Builder) buildFKCascades(withID opt.WithID, cascades memo.FKCascades) e
 	return nil
 }
 
+func (b *Builder) buildHistoryInsert(withID opt.WithID, hist *memo.HistoryInsert) error {
+	if hist == nil {
+		return nil
+	}
+	hb, err := makePostQueryBuilder(b, withID)
+	if err != nil {
+		return err
+	}
+	b.cascades = append(b.cascades, hb.setupHistoryInsert(hist))
+	return nil
+}
+
 func (b *Builder) buildAfterTriggers(withID opt.WithID, triggers *memo.AfterTriggers) error {
 	if triggers == nil {
 		return nil
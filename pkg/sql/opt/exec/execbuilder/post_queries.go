@@ -172,6 +172,30 @@ func (cb *postQueryBuilder) setupCascade(cascade *memo.FKCascade) exec.PostQuery
 	}
 }
 
+// setupHistoryInsert fills in an exec.PostQuery struct for the insertion into
+// the history table of a system-versioned table.
+func (cb *postQueryBuilder) setupHistoryInsert(hist *memo.HistoryInsert) exec.PostQuery {
+	return exec.PostQuery{
+		HistoryTable: hist.HistoryTable,
+		Buffer:       cb.mutationBuffer,
+		PlanFn: func(
+			ctx context.Context,
+			semaCtx *tree.SemaContext,
+			evalCtx *eval.Context,
+			execFactory exec.Factory,
+			bufferRef exec.Node,
+			numBufferedRows int,
+			allowAutoCommit bool,
+		) (exec.Plan, error) {
+			const actionName redact.SafeString = "history insert"
+			return cb.planPostQuery(
+				ctx, semaCtx, evalCtx, execFactory, bufferRef, numBufferedRows, allowAutoCommit,
+				hist.Builder, actionName,
+			)
+		},
+	}
+}
+
 // setupTriggers fills in an exec.PostQuery struct for the given triggers.
 func (cb *postQueryBuilder) setupTriggers(triggers *memo.AfterTriggers) exec.PostQuery {
 	return exec.PostQuery{
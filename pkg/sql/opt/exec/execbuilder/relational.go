@@ -733,6 +733,11 @@ func (b *Builder) scanParams(
 	}
 
 	softLimit := uint64(reqProps.LimitHintInt64())
+	if scan.Sample.IsSet() {
+		// The limit hint counts sampled rows, which may be a small fraction of
+		// the rows that are read, so it must not limit the KV batches.
+		softLimit = 0
+	}
 	hardLimit := scan.HardLimit.RowCount()
 	maxResults, maxResultsOk := b.indexConstraintMaxResults(scan, relProps)
 
@@ -808,6 +813,7 @@ func (b *Builder) scanParams(
 		Reverse:            reverse,
 		Parallelize:        parallelize,
 		Locking:            locking,
+		Sample:             scan.Sample,
 		EstimatedRowCount:  rowCount,
 		StatsCreatedAt:     statsCreatedAt,
 		LocalityOptimized:  scan.LocalityOptimized,
@@ -826,7 +832,9 @@ func (b *Builder) buildScan(scan *memo.ScanExpr) (_ execPlan, outputCols colOrdM
 		return execPlan{}, colOrdMap{}, fmt.Errorf("could not produce a query plan conforming to the FORCE_ZIGZAG hint")
 	}
 
-	isUnfiltered := scan.IsUnfiltered(md)
+	// A BERNOULLI sample reads every row of the table, so it is treated as a
+	// full scan even though it does not return every row.
+	isUnfiltered := scan.IsUnfiltered(md) || scan.Sample.Method == tree.TableSampleBernoulli
 	if scan.Flags.NoFullScan {
 		// Normally a full scan of a partial index would be allowed with the
 		// NO_FULL_SCAN hint (isUnfiltered is false for partial indexes), but if the
@@ -1538,6 +1546,18 @@ func (b *Builder) buildHashJoin(join memo.RelExpr) (_ execPlan, outputCols colOr
 	if rightExpr.Relational().Statistics().Available {
 		rightRowCount = uint64(rightExpr.Relational().Statistics().RowCount)
 	}
+	var rightCardinalityCheckID int32
+	if b.cardinalityChecksEnabled && b.cardinalityCheckBlockers == 0 && !isCrossJoin &&
+		rightExpr.Relational().Statistics().Available {
+		// The hash joiner consumes its entire right input before producing any
+		// rows, so the right input can be checked.
+		b.CardinalityChecks = append(b.CardinalityChecks, memo.CardinalityObservation{
+			Fingerprint: memo.CardinalityFingerprint(b.ctx, b.mem, rightExpr),
+		})
+		rightCardinalityCheckID = int32(len(b.CardinalityChecks))
+	}
+	partitionAligned := !isCrossJoin &&
+		distribution.PartitionAligned(b.mem.Metadata(), leftExpr, rightExpr, leftEq, rightEq)
 
 	b.recordJoinType(joinType)
 	if isCrossJoin {
@@ -1553,6 +1573,8 @@ func (b *Builder) buildHashJoin(join memo.RelExpr) (_ execPlan, outputCols colOr
 		leftEqColsAreKey, rightEqColsAreKey,
 		onExpr,
 		leftRowCount, rightRowCount,
+		rightCardinalityCheckID,
+		partitionAligned,
 	)
 	if err != nil {
 		return execPlan{}, colOrdMap{}, err
@@ -1647,6 +1669,14 @@ func (b *Builder) buildMergeJoin(
 	if rightExpr.Relational().Statistics().Available {
 		rightRowCount = uint64(rightExpr.Relational().Statistics().RowCount)
 	}
+	leftEqCols := make(opt.ColList, len(leftEq))
+	rightEqCols := make(opt.ColList, len(rightEq))
+	for i := range leftEq {
+		leftEqCols[i], rightEqCols[i] = leftEq[i].ID(), rightEq[i].ID()
+	}
+	partitionAligned := distribution.PartitionAligned(
+		b.mem.Metadata(), leftExpr, rightExpr, leftEqCols, rightEqCols,
+	)
 	b.recordJoinType(joinType)
 	b.recordJoinAlgorithm(exec.MergeJoin)
 	var ep execPlan
@@ -1657,6 +1687,7 @@ func (b *Builder) buildMergeJoin(
 		leftOrd, rightOrd, reqOrd,
 		leftEqColsAreKey, rightEqColsAreKey,
 		leftRowCount, rightRowCount,
+		partitionAligned,
 	)
 	if err != nil {
 		return execPlan{}, colOrdMap{}, err
@@ -1990,6 +2021,11 @@ func (b *Builder) buildGroupByInput(
 }
 
 func (b *Builder) buildSetOp(set memo.RelExpr) (_ execPlan, outputCols colOrdMap, err error) {
+	// Set operations can produce rows from one input before the other input
+	// has been consumed, so no cardinality checks are added within them.
+	b.cardinalityCheckBlockers++
+	defer func() { b.cardinalityCheckBlockers-- }()
+
 	leftExpr := set.Child(0).(memo.RelExpr)
 	left, leftCols, err := b.buildRelational(leftExpr)
 	if err != nil {
@@ -3511,6 +3547,11 @@ func (b *Builder) buildWith(with *memo.WithExpr) (_ execPlan, outputCols colOrdM
 func (b *Builder) buildRecursiveCTE(
 	rec *memo.RecursiveCTEExpr,
 ) (_ execPlan, outputCols colOrdMap, err error) {
+	// The rows of the initial query are produced before the recursive query is
+	// executed, so no cardinality checks are added within a recursive CTE.
+	b.cardinalityCheckBlockers++
+	defer func() { b.cardinalityCheckBlockers-- }()
+
 	initial, initialCols, err := b.buildRelational(rec.Initial)
 	if err != nil {
 		return execPlan{}, colOrdMap{}, err
@@ -171,6 +171,19 @@ func emitInternal(
 		return nil
 	}
 	for _, cascade := range plan.Cascades {
+		if cascade.HistoryTable != nil {
+			ob.EnterMetaNode("history-insert")
+			ob.Attr("table", cascade.HistoryTable.Name())
+			historyPlan, err := cascade.GetExplainPlan(ctx, createPostQueryPlanIfMissing)
+			if err != nil {
+				return err
+			}
+			if err = emitPostQuery(cascade, historyPlan, false /* alreadyEmitted */); err != nil {
+				return err
+			}
+			ob.LeaveNode()
+			continue
+		}
 		ob.EnterMetaNode("fk-cascade")
 		ob.Attr("fk", cascade.FKConstraint.Name())
 		if visitedFKsByCascades == nil {
@@ -727,6 +740,9 @@ func (e *emitter) emitNodeAttributes(ctx context.Context, evalCtx *eval.Context,
 		} else if a.Params.HardLimit == -1 {
 			ob.Attr("limit", "")
 		}
+		if a.Params.Sample.IsSet() {
+			ob.Attr("sample", a.Params.Sample.String())
+		}
 
 		if a.Params.Parallelize {
 			ob.VAttr("parallel", "")
@@ -325,6 +325,15 @@ func (ob *OutputBuilder) AddStmtHintCount(hintCount uint64) {
 	ob.AddTopLevelField("statement hints count", string(humanizeutil.Count(hintCount)))
 }
 
+// AddPlanBaseline adds a top-level field describing how the plan baseline for
+// the query was used. Cannot be called while inside a node.
+func (ob *OutputBuilder) AddPlanBaseline(status string) {
+	if status == "" {
+		return
+	}
+	ob.AddTopLevelField("plan baseline", status)
+}
+
 // AddPlanningTime adds a top-level planning time field. Cannot be called
 // while inside a node.
 func (ob *OutputBuilder) AddPlanningTime(delta time.Duration) {
@@ -390,6 +399,20 @@ func (ob *OutputBuilder) AddRetryCount(retryScope string, count uint64) {
 	}
 }
 
+// AddReoptimization adds a top-level field for a re-optimization of the
+// statement caused by a cardinality misestimate. Cannot be called while inside
+// a node.
+func (ob *OutputBuilder) AddReoptimization(estimatedRowCount, observedRowCount uint64) {
+	ob.AddFlakyTopLevelField(
+		DeflakeVolatile,
+		"adaptive re-optimization",
+		fmt.Sprintf(
+			"hash join input estimated at %s rows, observed at least %s rows",
+			humanizeutil.Count(estimatedRowCount), humanizeutil.Count(observedRowCount),
+		),
+	)
+}
+
 // AddRetryTime adds a top-level statement retry time field. Cannot be called
 // while inside a node.
 func (ob *OutputBuilder) AddRetryTime(retryScope string, delta time.Duration) {
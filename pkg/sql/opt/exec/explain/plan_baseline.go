@@ -0,0 +1,88 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package explain
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
+)
+
+// DecodePlanGistToBaseline decodes the given plan gist and summarizes the
+// indexes and join algorithms used by the plan as a memo.PlanBaseline. Tables
+// and indexes that cannot be resolved in the catalog (e.g., because they have
+// been dropped) are ignored.
+func DecodePlanGistToBaseline(
+	gist string, catalog cat.Catalog,
+) (_ *memo.PlanBaseline, retErr error) {
+	defer errorutil.MaybeCatchPanic(&retErr, nil /* errCallback */)
+
+	plan, err := DecodePlanGistToPlan(gist, catalog)
+	if err != nil {
+		return nil, err
+	}
+	b := &memo.PlanBaseline{Gist: gist}
+	addPlanToBaseline(b, plan.Root)
+	for i := range plan.Subqueries {
+		if n, ok := plan.Subqueries[i].Root.(*Node); ok {
+			addPlanToBaseline(b, n)
+		}
+	}
+	for _, n := range plan.Checks {
+		addPlanToBaseline(b, n)
+	}
+	return b, nil
+}
+
+// addPlanToBaseline adds the indexes and join algorithms used by the given
+// node and its descendants to the baseline.
+func addPlanToBaseline(b *memo.PlanBaseline, n *Node) {
+	if n == nil {
+		return
+	}
+	switch n.op {
+	case scanOp:
+		a := n.args.(*scanArgs)
+		addIndexToBaseline(b, a.Table, a.Index)
+
+	case hashJoinOp:
+		b.JoinAlgorithms |= memo.HashJoinAlgorithm
+
+	case mergeJoinOp:
+		b.JoinAlgorithms |= memo.MergeJoinAlgorithm
+
+	case lookupJoinOp:
+		a := n.args.(*lookupJoinArgs)
+		b.JoinAlgorithms |= memo.LookupJoinAlgorithm
+		addIndexToBaseline(b, a.Table, a.Index)
+
+	case invertedJoinOp:
+		a := n.args.(*invertedJoinArgs)
+		b.JoinAlgorithms |= memo.InvertedJoinAlgorithm
+		addIndexToBaseline(b, a.Table, a.Index)
+
+	case zigzagJoinOp:
+		a := n.args.(*zigzagJoinArgs)
+		b.JoinAlgorithms |= memo.ZigzagJoinAlgorithm
+		addIndexToBaseline(b, a.LeftTable, a.LeftIndex)
+		addIndexToBaseline(b, a.RightTable, a.RightIndex)
+	}
+	for _, child := range n.children {
+		addPlanToBaseline(b, child)
+	}
+}
+
+// addIndexToBaseline records that the baseline reads the given index, unless
+// the table or index could not be resolved when the gist was decoded.
+func addIndexToBaseline(b *memo.PlanBaseline, table cat.Table, index cat.Index) {
+	if _, ok := table.(*unknownTable); ok || table == nil {
+		return
+	}
+	if _, ok := index.(*unknownIndex); ok || index == nil {
+		return
+	}
+	b.AddIndex(table.ID(), index.Ordinal())
+}
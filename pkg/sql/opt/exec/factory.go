@@ -141,6 +141,9 @@ type ScanParams struct {
 	// Row-level locking properties.
 	Locking opt.Locking
 
+	// If set, the scan only returns a pseudo-random sample of its rows.
+	Sample opt.TableSample
+
 	// EstimatedRowCount, if set, is the estimated number of rows that will be
 	// scanned, rounded up.
 	EstimatedRowCount uint64
@@ -311,13 +314,19 @@ type ApplyJoinRightSideForExplainFn func(redactableValues bool) string
 // triggered if this buffer is not empty.
 type PostQuery struct {
 	// FKConstraint is used for logging and EXPLAIN purposes. It is nil if this
-	// PostQuery describes a set of AFTER triggers.
+	// PostQuery describes a set of AFTER triggers or a history insert.
 	FKConstraint cat.ForeignKeyConstraint
 
 	// Triggers is used for logging and EXPLAIN purposes. It is nil if this
-	// PostQuery describes a foreign-key cascade action.
+	// PostQuery describes a foreign-key cascade action or a history insert.
 	Triggers []cat.Trigger
 
+	// HistoryTable is used for logging and EXPLAIN purposes. It is set only if
+	// this PostQuery describes the insertion of the previous versions of
+	// updated or deleted rows into the history table of a system-versioned
+	// table. Such post-queries are executed along with the cascades.
+	HistoryTable cat.Table
+
 	// Buffer is the Node returned by ConstructBuffer which stores the input to
 	// the mutation. It is nil if the cascade does not require a buffer.
 	Buffer Node
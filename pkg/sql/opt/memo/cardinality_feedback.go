@@ -0,0 +1,115 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package memo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+)
+
+// CardinalityObservation records the number of rows that an expression
+// actually produced during execution.
+type CardinalityObservation struct {
+	// Fingerprint identifies the memo group of the expression. See
+	// CardinalityFingerprint.
+	Fingerprint string
+
+	// ActualRowCount is the number of rows the expression produced during
+	// execution. It may be a lower bound, since execution can be stopped as soon
+	// as a misestimate is detected.
+	ActualRowCount float64
+}
+
+// CardinalityFeedback is a set of cardinality observations collected while
+// executing previous plans for the current statement. It is used by the
+// statistics builder to correct the row count estimates of memo groups when
+// the statement is re-optimized.
+type CardinalityFeedback struct {
+	observations []CardinalityObservation
+}
+
+// Add adds an observation to the feedback. If there is already an observation
+// for the same memo group, the larger actual row count is kept.
+func (f *CardinalityFeedback) Add(o CardinalityObservation) {
+	for i := range f.observations {
+		existing := &f.observations[i]
+		if existing.Fingerprint == o.Fingerprint {
+			if o.ActualRowCount > existing.ActualRowCount {
+				existing.ActualRowCount = o.ActualRowCount
+			}
+			return
+		}
+	}
+	f.observations = append(f.observations, o)
+}
+
+// Empty returns true if the feedback contains no observations.
+func (f *CardinalityFeedback) Empty() bool {
+	return len(f.observations) == 0
+}
+
+// lookup returns the actual row count observed for the memo group with the
+// given fingerprint, if there is one.
+func (f *CardinalityFeedback) lookup(fingerprint string) (float64, bool) {
+	for i := range f.observations {
+		if o := &f.observations[i]; o.Fingerprint == fingerprint {
+			return o.ActualRowCount, true
+		}
+	}
+	return 0, false
+}
+
+// CardinalityFingerprint returns a string that identifies the memo group of the
+// given relational expression across optimizations of the same statement.
+//
+// The fingerprint is built from the first expression of the group and,
+// recursively, the first expressions of the groups of its relational
+// descendants. The first expression of a group is the one that was used to
+// build its logical properties, so it is the same whether the fingerprint is
+// computed while the memo is being built, or after optimization has replaced
+// the children of expressions with the lowest cost expressions of their
+// groups. Unlike the estimated row count of the group, the fingerprint does
+// not depend on statistics, so it still identifies the group once cardinality
+// feedback has changed the estimates.
+func CardinalityFingerprint(ctx context.Context, mem *Memo, e RelExpr) string {
+	f := MakeExprFmtCtx(
+		ctx, ExprFmtHideAll&^ExprFmtHideColumns, false /* redactableValues */, mem, nil, /* catalog */
+	)
+	var format func(e opt.Expr)
+	format = func(e opt.Expr) {
+		f.Buffer.WriteByte('(')
+		if rel, ok := e.(RelExpr); ok {
+			rel = rel.FirstExpr()
+			e = rel
+			fmt.Fprintf(f.Buffer, "%s %s", rel.Op(), rel.Relational().OutputCols)
+			if scan, ok := rel.Private().(*ScanPrivate); ok {
+				fmt.Fprintf(f.Buffer, " %d@%d", scan.Table, scan.Index)
+				if scan.Constraint != nil {
+					fmt.Fprintf(f.Buffer, " %s", scan.Constraint)
+				}
+				if scan.InvertedConstraint != nil {
+					fmt.Fprintf(f.Buffer, " %v", scan.InvertedConstraint)
+				}
+				if scan.HardLimit != 0 {
+					fmt.Fprintf(f.Buffer, " %s", scan.HardLimit)
+				}
+			}
+		} else {
+			f.Buffer.WriteString(e.Op().String())
+			if scalar, ok := e.(opt.ScalarExpr); ok {
+				f.formatScalarPrivate(scalar)
+			}
+		}
+		for i, n := 0, e.ChildCount(); i < n; i++ {
+			format(e.Child(i))
+		}
+		f.Buffer.WriteByte(')')
+	}
+	format(e)
+	return f.Buffer.String()
+}
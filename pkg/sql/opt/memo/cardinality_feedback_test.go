@@ -0,0 +1,41 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package memo
+
+import "testing"
+
+func TestCardinalityFeedback(t *testing.T) {
+	var f CardinalityFeedback
+	if !f.Empty() {
+		t.Fatal("expected empty feedback")
+	}
+
+	const ab, c = "(scan (1,2) 1@0)", "(scan (3) 2@0)"
+	f.Add(CardinalityObservation{Fingerprint: ab, ActualRowCount: 20000})
+	f.Add(CardinalityObservation{Fingerprint: c, ActualRowCount: 50000})
+	if f.Empty() {
+		t.Fatal("expected non-empty feedback")
+	}
+
+	// A smaller observation for the same memo group is ignored, while a larger
+	// one replaces the existing observation.
+	f.Add(CardinalityObservation{Fingerprint: ab, ActualRowCount: 15000})
+	if actual, ok := f.lookup(ab); !ok || actual != 20000 {
+		t.Errorf("expected 20000 rows, got %v (ok=%t)", actual, ok)
+	}
+	f.Add(CardinalityObservation{Fingerprint: ab, ActualRowCount: 30000})
+	if actual, ok := f.lookup(ab); !ok || actual != 30000 {
+		t.Errorf("expected 30000 rows, got %v (ok=%t)", actual, ok)
+	}
+	if actual, ok := f.lookup(c); !ok || actual != 50000 {
+		t.Errorf("expected 50000 rows, got %v (ok=%t)", actual, ok)
+	}
+
+	// Observations only apply to memo groups with the same fingerprint.
+	if _, ok := f.lookup("(scan (1) 1@0)"); ok {
+		t.Error("unexpected observation for a different memo group")
+	}
+}
@@ -36,7 +36,7 @@ type Cost struct {
 // member will have a lower cost.
 var MaxCost = Cost{
 	C:         math.Inf(+1),
-	Penalties: HugeCostPenalty | FullScanPenalty | UnboundedCardinalityPenalty,
+	Penalties: HugeCostPenalty | FullScanPenalty | PlanBaselinePenalty | UnboundedCardinalityPenalty,
 }
 
 // Less returns true if this cost is lower than the given cost.
@@ -105,6 +105,13 @@ const (
 	// plan is possible.
 	FullScanPenalty
 
+	// PlanBaselinePenalty is true if the operator or any of its descendants
+	// deviate from the plan baseline for the statement, for example by scanning
+	// an index or using a join algorithm that the baseline plan does not use.
+	// This causes the optimizer to choose a plan that matches the baseline if
+	// one is possible. See PlanBaseline.
+	PlanBaselinePenalty
+
 	// UnboundedCardinalityPenalty is true if the operator or any of its
 	// descendants have no guaranteed upperbound on the number of rows that they
 	// can produce. See props.AnyCardinality.
@@ -121,8 +128,9 @@ const (
 // Where:
 //
 //	<Cost> is the floating point cost value.
-//	<Penalties> contains "H", "F", or "U" for HugeCostPenalty, FullScanPenalty,
-//	  and UnboundedCardinalityPenalty, respectively.
+//	<Penalties> contains "H", "F", "B", or "U" for HugeCostPenalty,
+//	  FullScanPenalty, PlanBaselinePenalty, and UnboundedCardinalityPenalty,
+//	  respectively.
 //	<aux> contains the number of full scans and unbounded reads.
 //
 // For example, the summary "1.23:HF:5f6u" indicates a cost of 1.23 with the
@@ -137,6 +145,9 @@ func (c Cost) Summary() string {
 	if c.Penalties&FullScanPenalty != 0 {
 		sb.WriteByte('F')
 	}
+	if c.Penalties&PlanBaselinePenalty != 0 {
+		sb.WriteByte('B')
+	}
 	if c.Penalties&UnboundedCardinalityPenalty != 0 {
 		sb.WriteByte('U')
 	}
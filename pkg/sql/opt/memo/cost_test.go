@@ -38,6 +38,9 @@ func TestCostLess(t *testing.T) {
 		{Cost{C: 1.0, Penalties: HugeCostPenalty}, MaxCost, true},
 		{Cost{C: 2.0}, Cost{C: 1.0, Penalties: UnboundedCardinalityPenalty}, true},
 		{Cost{C: 1.0, Penalties: UnboundedCardinalityPenalty}, Cost{C: 2.0}, false},
+		{Cost{C: 2.0}, Cost{C: 1.0, Penalties: PlanBaselinePenalty}, true},
+		{Cost{C: 1.0, Penalties: PlanBaselinePenalty}, Cost{C: 2.0, Penalties: FullScanPenalty}, true},
+		{Cost{C: 1.0, Penalties: UnboundedCardinalityPenalty}, Cost{C: 2.0, Penalties: PlanBaselinePenalty}, true},
 		// Auxiliary information should not affect the comparison.
 		{Cost{C: 1.0, aux: testAux{0, 0}}, Cost{C: 1.0, aux: testAux{1, 1}}, false},
 		{Cost{C: 1.0, aux: testAux{1, 1}}, Cost{C: 1.0, aux: testAux{0, 0}}, false},
@@ -81,7 +84,9 @@ func TestCostSummary(t *testing.T) {
 		{Cost{C: 1.23456}, "1.23456::0f0u"},
 		{Cost{C: 1.23, Penalties: HugeCostPenalty}, "1.23:H:0f0u"},
 		{Cost{C: 1.23, Penalties: FullScanPenalty}, "1.23:F:0f0u"},
+		{Cost{C: 1.23, Penalties: PlanBaselinePenalty}, "1.23:B:0f0u"},
 		{Cost{C: 1.23, Penalties: UnboundedCardinalityPenalty}, "1.23:U:0f0u"},
+		{Cost{C: 1.23, Penalties: HugeCostPenalty | PlanBaselinePenalty | UnboundedCardinalityPenalty}, "1.23:HBU:0f0u"},
 		{Cost{C: 1.23, Penalties: HugeCostPenalty | FullScanPenalty | UnboundedCardinalityPenalty}, "1.23:HFU:0f0u"},
 		{Cost{C: 1.23, Penalties: HugeCostPenalty | FullScanPenalty | UnboundedCardinalityPenalty}, "1.23:HFU:0f0u"},
 		{Cost{C: 1.23, aux: testAux{5, 0}}, "1.23::5f0u"},
@@ -868,12 +868,14 @@ func (f *WindowFrame) String() string {
 // IsCanonical returns true if the ScanPrivate indicates an original unaltered
 // primary index Scan operator (i.e. unconstrained and not limited).
 // s.InvertedConstraint is implicitly nil because a primary index cannot
-// be inverted.
+// be inverted. Sampled scans are never canonical, since scanning a different
+// index or different spans would change which rows are sampled.
 func (s *ScanPrivate) IsCanonical() bool {
 	return s.Index == cat.PrimaryIndex &&
 		s.Constraint == nil &&
 		s.HardLimit == 0 &&
-		!s.LocalityOptimized
+		!s.LocalityOptimized &&
+		!s.Sample.IsSet()
 }
 
 // IsUnfiltered returns true if the ScanPrivate will produce all rows in the
@@ -883,7 +885,8 @@ func (s *ScanPrivate) IsUnfiltered(md *opt.Metadata) bool {
 		s.InvertedConstraint == nil &&
 		s.HardLimit == 0 &&
 		s.PartialIndexPredicate(md) == nil &&
-		s.Locking.WaitPolicy != tree.LockWaitSkipLocked
+		s.Locking.WaitPolicy != tree.LockWaitSkipLocked &&
+		!s.Sample.IsSet()
 }
 
 // IsFullIndexScan returns true if the ScanPrivate will produce all rows in the
@@ -1405,6 +1408,22 @@ type AfterTriggers struct {
 	WithID opt.WithID
 }
 
+// HistoryInsert stores metadata necessary for building the insertion of the
+// previous versions of updated or deleted rows into the history table of a
+// system-versioned table. The insertion is built as needed, after the original
+// query is executed.
+type HistoryInsert struct {
+	HistoryTable cat.Table
+
+	// Builder is an object that can be used as the "optbuilder" for the
+	// insertion into the history table.
+	Builder PostQueryBuilder
+
+	// WithID identifies the buffer for the mutation input in the original
+	// expression tree. It is always nonzero.
+	WithID opt.WithID
+}
+
 // PostQueryBuilder is an interface used to construct either a cascading query
 // for a specific FK relation, or an AFTER trigger action. For example: if we
 // are deleting rows from a parent table, after deleting the rows from the
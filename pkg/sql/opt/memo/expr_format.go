@@ -493,6 +493,9 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 		if private.HardLimit.IsSet() {
 			tp.Childf("limit: %s", private.HardLimit)
 		}
+		if private.Sample.IsSet() {
+			tp.Childf("sample: %s", private.Sample)
+		}
 
 		if private.shouldPrintFlags(md, f.HasFlags(ExprFmtHideNotVisibleIndexInfo)) {
 			var b strings.Builder
@@ -928,6 +931,9 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			if cost.Penalties&HugeCostPenalty != 0 {
 				b.WriteString(" huge-cost-penalty")
 			}
+			if cost.Penalties&PlanBaselinePenalty != 0 {
+				b.WriteString(" plan-baseline-penalty")
+			}
 			if cost.Penalties&UnboundedCardinalityPenalty != 0 {
 				b.WriteString(" unbounded-cardinality")
 			}
@@ -1693,6 +1699,9 @@ func (f *ExprFmtCtx) formatMutationCommon(tp treeprinter.Node, p *MutationPrivat
 			c.Child(p.AfterTriggers.Triggers[i].Name().Normalize())
 		}
 	}
+	if p.HistoryInsert != nil {
+		tp.Childf("history: %s", p.HistoryInsert.HistoryTable.Name())
+	}
 }
 
 // formatBeforeTriggers displays the names of BEFORE triggers that will be
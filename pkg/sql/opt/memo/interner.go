@@ -542,6 +542,12 @@ func (h *hasher) HashAfterTriggers(val *AfterTriggers) {
 	}
 }
 
+func (h *hasher) HashHistoryInsert(val *HistoryInsert) {
+	if val != nil {
+		h.HashUint64(uint64(reflect.ValueOf(val.Builder).Pointer()))
+	}
+}
+
 func (h *hasher) HashExplainOptions(val tree.ExplainOptions) {
 	h.HashUint64(uint64(val.Mode))
 	hash := h.hash
@@ -660,6 +666,13 @@ func (h *hasher) HashLocking(val opt.Locking) {
 	h.HashByte(byte(val.WaitPolicy))
 }
 
+func (h *hasher) HashTableSample(val opt.TableSample) {
+	h.HashByte(byte(val.Method))
+	h.HashFloat64(val.Fraction)
+	h.HashUint64(val.Seed)
+	h.HashBool(val.Repeatable)
+}
+
 func (h *hasher) HashInvertedSpans(val inverted.Spans) {
 	for i := range val {
 		span := &val[i]
@@ -1035,6 +1048,17 @@ func (h *hasher) IsAfterTriggersEqual(l, r *AfterTriggers) bool {
 	return l.Builder == r.Builder
 }
 
+func (h *hasher) IsHistoryInsertEqual(l, r *HistoryInsert) bool {
+	if l == r {
+		return true
+	}
+	if l == nil || r == nil {
+		return false
+	}
+	// It's sufficient to compare the PostQueryBuilder instances.
+	return l.Builder == r.Builder
+}
+
 func (h *hasher) IsExplainOptionsEqual(l, r tree.ExplainOptions) bool {
 	return l == r
 }
@@ -1125,6 +1149,10 @@ func (h *hasher) IsLockingEqual(l, r opt.Locking) bool {
 	return l == r
 }
 
+func (h *hasher) IsTableSampleEqual(l, r opt.TableSample) bool {
+	return l == r
+}
+
 func (h *hasher) IsInvertedSpansEqual(l, r inverted.Spans) bool {
 	return l.Equals(r)
 }
@@ -592,6 +592,30 @@ func TestInterner(t *testing.T) {
 			},
 		}},
 
+		{hashFn: in.hasher.HashTableSample, eqFn: in.hasher.IsTableSampleEqual, variations: []testVariation{
+			{val1: opt.TableSample{}, val2: opt.TableSample{}, equal: true},
+			{
+				val1:  opt.TableSample{},
+				val2:  opt.TableSample{Method: tree.TableSampleBernoulli, Fraction: 0.1},
+				equal: false,
+			},
+			{
+				val1:  opt.TableSample{Method: tree.TableSampleBernoulli, Fraction: 0.1},
+				val2:  opt.TableSample{Method: tree.TableSampleSystem, Fraction: 0.1},
+				equal: false,
+			},
+			{
+				val1:  opt.TableSample{Method: tree.TableSampleSystem, Fraction: 0.1, Seed: 1, Repeatable: true},
+				val2:  opt.TableSample{Method: tree.TableSampleSystem, Fraction: 0.1, Seed: 2, Repeatable: true},
+				equal: false,
+			},
+			{
+				val1:  opt.TableSample{Method: tree.TableSampleSystem, Fraction: 0.5, Seed: 1, Repeatable: true},
+				val2:  opt.TableSample{Method: tree.TableSampleSystem, Fraction: 0.5, Seed: 1, Repeatable: true},
+				equal: true,
+			},
+		}},
+
 		{hashFn: in.hasher.HashFastPathUniqueChecksExpr, eqFn: in.hasher.IsFastPathUniqueChecksExprEqual, variations: []testVariation{
 			{
 				val1:  FastPathUniqueChecksExpr{FastPathUniqueChecksItem{Check: scanNode}},
@@ -881,6 +905,12 @@ func TestInterner(t *testing.T) {
 			{val1: &AfterTriggers{Builder: postQueryBuilder1}, val2: &AfterTriggers{Builder: postQueryBuilder1}, equal: true},
 			{val1: &AfterTriggers{Builder: postQueryBuilder1}, val2: &AfterTriggers{Builder: postQueryBuilder2}, equal: false},
 		}},
+
+		{hashFn: in.hasher.HashHistoryInsert, eqFn: in.hasher.IsHistoryInsertEqual, variations: []testVariation{
+			{val1: (*HistoryInsert)(nil), val2: (*HistoryInsert)(nil), equal: true},
+			{val1: &HistoryInsert{Builder: postQueryBuilder1}, val2: &HistoryInsert{Builder: postQueryBuilder1}, equal: true},
+			{val1: &HistoryInsert{Builder: postQueryBuilder1}, val2: &HistoryInsert{Builder: postQueryBuilder2}, equal: false},
+		}},
 	}
 
 	computeHashValue := func(hashFn reflect.Value, val interface{}) internHash {
@@ -70,6 +70,16 @@ func (b *logicalPropsBuilder) clear() {
 	b.sb.clear()
 }
 
+// applyCardinalityFeedback corrects the row count estimate of a new memo group
+// once its logical properties have been built, if the memo has cardinality
+// feedback for the group.
+func (b *logicalPropsBuilder) applyCardinalityFeedback(e RelExpr, rel *props.Relational) {
+	if b.disableStats || b.mem.cardinalityFeedback.Empty() {
+		return
+	}
+	b.sb.applyCardinalityFeedback(e, rel)
+}
+
 func (b *logicalPropsBuilder) buildScanProps(scan *ScanExpr, rel *props.Relational) {
 	md := b.mem.Metadata()
 	hardLimit := scan.HardLimit.RowCount()
@@ -195,6 +205,15 @@ func (b *logicalPropsBuilder) buildScanProps(scan *ScanExpr, rel *props.Relation
 		// extra safety.
 		rel.Cardinality = rel.Cardinality.AsLowAs(0)
 	}
+	if scan.Sample.IsSet() {
+		// TABLESAMPLE acts like a filter, and a zero percent sample returns no
+		// rows at all.
+		if scan.Sample.Fraction == 0 {
+			rel.Cardinality = props.ZeroCardinality
+		} else {
+			rel.Cardinality = rel.Cardinality.AsLowAs(0)
+		}
+	}
 
 	// Statistics
 	// ----------
@@ -217,6 +217,7 @@ type Memo struct {
 	useMaxFrequencySelectivity                 bool
 	usingHintInjection                         bool
 	useSwapMutations                           bool
+	useExtendedStatistics                      bool
 
 	// txnIsoLevel is the isolation level under which the plan was created. This
 	// affects the planning of some locking operations, so it must be included in
@@ -246,6 +247,16 @@ type Memo struct {
 	// to clamp selectivity estimates to a lower bound.
 	optimizationStats OptimizationStats
 
+	// cardinalityFeedback contains the row counts observed while executing
+	// previous plans for the statement. It is used to correct row count
+	// estimates when the statement is re-optimized. A memo built with feedback
+	// must not be reused for other executions of the statement.
+	cardinalityFeedback CardinalityFeedback
+
+	// planBaseline, if non-nil, is the plan baseline for the statement. The
+	// coster penalizes expressions that deviate from it.
+	planBaseline *PlanBaseline
+
 	// WARNING: if you add more members, add initialization code in Init (if
 	// reusing allocated data structures is desired).
 }
@@ -334,6 +345,7 @@ func (m *Memo) Init(ctx context.Context, evalCtx *eval.Context) {
 		useMaxFrequencySelectivity:                 evalCtx.SessionData().OptimizerUseMaxFrequencySelectivity,
 		usingHintInjection:                         evalCtx.Planner != nil && evalCtx.Planner.UsingHintInjection(),
 		useSwapMutations:                           evalCtx.SessionData().UseSwapMutations,
+		useExtendedStatistics:                      evalCtx.SessionData().OptimizerUseExtendedStatistics,
 		txnIsoLevel:                                evalCtx.TxnIsoLevel,
 	}
 	m.metadata.Init()
@@ -358,6 +370,24 @@ func (m *Memo) ResetLogProps(ctx context.Context, evalCtx *eval.Context) {
 	m.logPropsBuilder.init(ctx, evalCtx, m)
 }
 
+// SetCardinalityFeedback sets the cardinality feedback used to correct row
+// count estimates. It must be called before the memo is built.
+func (m *Memo) SetCardinalityFeedback(feedback CardinalityFeedback) {
+	m.cardinalityFeedback = feedback
+}
+
+// SetPlanBaseline sets the plan baseline that the optimizer should prefer. It
+// must be called before the memo is optimized.
+func (m *Memo) SetPlanBaseline(baseline *PlanBaseline) {
+	m.planBaseline = baseline
+}
+
+// PlanBaseline returns the plan baseline for the statement, or nil if there is
+// none.
+func (m *Memo) PlanBaseline() *PlanBaseline {
+	return m.planBaseline
+}
+
 // NotifyOnNewGroup sets a callback function which is invoked each time we
 // create a new memo group.
 func (m *Memo) NotifyOnNewGroup(fn func(opt.Expr)) {
@@ -515,6 +545,7 @@ func (m *Memo) IsStale(
 		m.useMaxFrequencySelectivity != evalCtx.SessionData().OptimizerUseMaxFrequencySelectivity ||
 		m.usingHintInjection != (evalCtx.Planner != nil && evalCtx.Planner.UsingHintInjection()) ||
 		m.useSwapMutations != evalCtx.SessionData().UseSwapMutations ||
+		m.useExtendedStatistics != evalCtx.SessionData().OptimizerUseExtendedStatistics ||
 		m.txnIsoLevel != evalCtx.TxnIsoLevel {
 		return true, nil
 	}
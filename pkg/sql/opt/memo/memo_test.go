@@ -516,6 +516,12 @@ func TestMemoIsStale(t *testing.T) {
 	evalCtx.SessionData().OptimizerUseMaxFrequencySelectivity = false
 	notStale()
 
+	// Stale optimizer_use_extended_statistics.
+	evalCtx.SessionData().OptimizerUseExtendedStatistics = true
+	stale()
+	evalCtx.SessionData().OptimizerUseExtendedStatistics = false
+	notStale()
+
 	// Stale optimizer_prove_implication_with_virtual_computed_columns.
 	evalCtx.SessionData().OptimizerProveImplicationWithVirtualComputedColumns = true
 	stale()
@@ -0,0 +1,99 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package memo
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+)
+
+// JoinAlgorithmSet is a set of join algorithms used by a plan.
+type JoinAlgorithmSet uint8
+
+const (
+	// HashJoinAlgorithm is set if the plan contains a hash or cross join.
+	HashJoinAlgorithm JoinAlgorithmSet = 1 << iota
+	// MergeJoinAlgorithm is set if the plan contains a merge join.
+	MergeJoinAlgorithm
+	// LookupJoinAlgorithm is set if the plan contains a lookup join.
+	LookupJoinAlgorithm
+	// InvertedJoinAlgorithm is set if the plan contains an inverted join.
+	InvertedJoinAlgorithm
+	// ZigzagJoinAlgorithm is set if the plan contains a zigzag join.
+	ZigzagJoinAlgorithm
+)
+
+// PlanBaseline summarizes the accepted plan for a statement fingerprint (see
+// hintpb.PlanBaseline). It records the indexes and join algorithms that the
+// baseline plan uses. The coster adds PlanBaselinePenalty to any expression
+// that deviates from the baseline, so that the optimizer chooses a plan
+// matching the baseline if one is possible, and otherwise falls back to the
+// lowest cost plan.
+type PlanBaseline struct {
+	// Gist is the plan gist of the baseline plan.
+	Gist string
+
+	// Indexes maps each table read by the baseline plan to the set of index
+	// ordinals of the table that the baseline plan reads. Tables that are not
+	// in the map are not constrained by the baseline.
+	Indexes map[cat.StableID]intsets.Fast
+
+	// JoinAlgorithms is the set of join algorithms used by the baseline plan.
+	JoinAlgorithms JoinAlgorithmSet
+}
+
+// AddIndex records that the baseline plan reads the given index of the table.
+func (b *PlanBaseline) AddIndex(table cat.StableID, index cat.IndexOrdinal) {
+	if b.Indexes == nil {
+		b.Indexes = make(map[cat.StableID]intsets.Fast)
+	}
+	indexes := b.Indexes[table]
+	indexes.Add(index)
+	b.Indexes[table] = indexes
+}
+
+// Deviates returns true if the given expression does not match the baseline
+// plan, either because it reads an index of a table in the baseline that the
+// baseline plan does not read, or because it uses a join algorithm that the
+// baseline plan does not use. Only the expression itself is checked, not its
+// inputs.
+func (b *PlanBaseline) Deviates(md *opt.Metadata, e RelExpr) bool {
+	switch t := e.(type) {
+	case *ScanExpr:
+		return b.deviatesIndex(md, t.Table, t.Index)
+
+	case *InnerJoinExpr, *LeftJoinExpr, *RightJoinExpr, *FullJoinExpr,
+		*SemiJoinExpr, *AntiJoinExpr:
+		return b.JoinAlgorithms&HashJoinAlgorithm == 0
+
+	case *MergeJoinExpr:
+		return b.JoinAlgorithms&MergeJoinAlgorithm == 0
+
+	case *LookupJoinExpr:
+		return b.JoinAlgorithms&LookupJoinAlgorithm == 0 ||
+			b.deviatesIndex(md, t.Table, t.Index)
+
+	case *InvertedJoinExpr:
+		return b.JoinAlgorithms&InvertedJoinAlgorithm == 0 ||
+			b.deviatesIndex(md, t.Table, t.Index)
+
+	case *ZigzagJoinExpr:
+		return b.JoinAlgorithms&ZigzagJoinAlgorithm == 0 ||
+			b.deviatesIndex(md, t.LeftTable, t.LeftIndex) ||
+			b.deviatesIndex(md, t.RightTable, t.RightIndex)
+	}
+	return false
+}
+
+// deviatesIndex returns true if the baseline plan reads the given table but
+// not the given index of the table.
+func (b *PlanBaseline) deviatesIndex(
+	md *opt.Metadata, tabID opt.TableID, index cat.IndexOrdinal,
+) bool {
+	indexes, ok := b.Indexes[md.Table(tabID).ID()]
+	return ok && !indexes.Contains(index)
+}
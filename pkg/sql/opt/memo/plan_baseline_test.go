@@ -0,0 +1,66 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package memo
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testcat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+func TestPlanBaselineDeviates(t *testing.T) {
+	catalog := testcat.New()
+	if _, err := catalog.ExecuteDDL(
+		"CREATE TABLE abc (a INT PRIMARY KEY, b INT, c INT, INDEX (b), INDEX (c))",
+	); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := catalog.ExecuteDDL("CREATE TABLE xy (x INT PRIMARY KEY, y INT, INDEX (y))"); err != nil {
+		t.Fatal(err)
+	}
+	var md opt.Metadata
+	md.Init()
+	abcName := tree.NewUnqualifiedTableName("abc")
+	abcTab := catalog.Table(abcName)
+	abc := md.AddTable(abcTab, abcName)
+	xyName := tree.NewUnqualifiedTableName("xy")
+	xy := md.AddTable(catalog.Table(xyName), xyName)
+
+	// The baseline scans the primary index and the index on b of abc, and uses
+	// lookup joins.
+	var b PlanBaseline
+	b.AddIndex(abcTab.ID(), 0)
+	b.AddIndex(abcTab.ID(), 1)
+	b.JoinAlgorithms = LookupJoinAlgorithm
+
+	testCases := []struct {
+		e        RelExpr
+		expected bool
+	}{
+		{&ScanExpr{ScanPrivate: ScanPrivate{Table: abc, Index: 0}}, false},
+		{&ScanExpr{ScanPrivate: ScanPrivate{Table: abc, Index: 1}}, false},
+		{&ScanExpr{ScanPrivate: ScanPrivate{Table: abc, Index: 2}}, true},
+		// Tables that are not in the baseline are not constrained.
+		{&ScanExpr{ScanPrivate: ScanPrivate{Table: xy, Index: 1}}, false},
+		{&LookupJoinExpr{LookupJoinPrivate: LookupJoinPrivate{Table: abc, Index: 1}}, false},
+		{&LookupJoinExpr{LookupJoinPrivate: LookupJoinPrivate{Table: abc, Index: 2}}, true},
+		{&InnerJoinExpr{}, true},
+		{&MergeJoinExpr{}, true},
+		{&SelectExpr{}, false},
+	}
+	for i, tc := range testCases {
+		if actual := b.Deviates(&md, tc.e); actual != tc.expected {
+			t.Errorf("%d: expected Deviates(%s) to be %t", i, tc.e.Op(), tc.expected)
+		}
+	}
+
+	b.JoinAlgorithms |= HashJoinAlgorithm
+	if b.Deviates(&md, &InnerJoinExpr{}) {
+		t.Error("expected hash join to match the baseline")
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
@@ -781,6 +782,9 @@ func (sb *statisticsBuilder) makeTableStatistics(tabID opt.TableID) *props.Stati
 					resolution := histogramPessimisticThreshold * stats.RowCount
 					colStat.Histogram.Init(sb.evalCtx, col, stat.Histogram(), resolution)
 				}
+				if cols.Len() > 1 && sb.evalCtx.SessionData().OptimizerUseExtendedStatistics {
+					colStat.Extended = makeExtendedStatistic(tabID, stat, stats.RowCount)
+				}
 
 				// Make sure the distinct count is at least 1, for the same reason as
 				// the row count above.
@@ -887,8 +891,12 @@ func (sb *statisticsBuilder) buildScan(scan *ScanExpr, relProps *props.Relationa
 
 	// If the constraints and pred are nil, then this scan is an unconstrained
 	// scan on a non-partial index. The stats of the scan are the same as the
-	// underlying table stats.
+	// underlying table stats, scaled down by the fraction of the table that is
+	// sampled, if any.
 	if scan.Constraint == nil && scan.InvertedConstraint == nil && pred == nil {
+		if scan.Sample.IsSet() {
+			s.ApplySelectivity(props.MakeSelectivity(scan.Sample.Fraction))
+		}
 		sb.finalizeFromCardinality(relProps)
 		return
 	}
@@ -1091,6 +1099,14 @@ func (sb *statisticsBuilder) constrainScan(
 	// Calculate row count and selectivity
 	// -----------------------------------
 	corr := sb.correlationFromMultiColDistinctCounts(constrainedCols, scan, s)
+	if sb.shouldUseExtendedStatistics(constrainedCols) {
+		constCols := sb.constColsFromScanConstraint(constraint, pred)
+		if extCorr, ok := sb.correlationFromExtendedStatistics(
+			constrainedCols, histCols, opt.ColSet{}, constCols, scan, s,
+		); ok {
+			corr = extCorr
+		}
+	}
 	s.ApplySelectivity(sb.selectivityFromConstrainedCols(constrainedCols, histCols, opt.ColSet{}, scan, s, corr))
 	s.ApplySelectivity(sb.selectivityFromUnappliedConjuncts(unapplied))
 	s.ApplySelectivity(sb.selectivityFromNullsRemoved(scan, notNullCols, constrainedCols))
@@ -1107,6 +1123,11 @@ func (sb *statisticsBuilder) colStatScan(colSet opt.ColSet, scan *ScanExpr) *pro
 		colStat.Histogram = inputColStat.Histogram
 	}
 
+	// Extended statistics are only valid if the scan does not filter any rows.
+	if s.Selectivity == props.OneSelectivity {
+		colStat.Extended = inputColStat.Extended
+	}
+
 	if s.Selectivity != props.OneSelectivity {
 		tableStats := sb.makeTableStatistics(scan.Table)
 		colStat.ApplySelectivity(s.Selectivity, tableStats.RowCount)
@@ -3160,6 +3181,27 @@ func (sb *statisticsBuilder) finalizeFromCardinality(relProps *props.Relational)
 	}
 }
 
+// applyCardinalityFeedback replaces the estimated row count of the given new
+// memo group with the row count observed for the group during a previous
+// execution of the statement, if the observed row count is larger. See
+// CardinalityFeedback.
+func (sb *statisticsBuilder) applyCardinalityFeedback(e RelExpr, relProps *props.Relational) {
+	s := relProps.Statistics()
+	if !s.Available || s.RowCount <= 0 {
+		return
+	}
+	actual, ok := sb.mem.cardinalityFeedback.lookup(CardinalityFingerprint(sb.ctx, sb.mem, e))
+	if !ok || actual <= s.RowCount {
+		return
+	}
+	s.Selectivity = props.MakeSelectivity(s.Selectivity.AsFloat() * actual / s.RowCount)
+	s.RowCount = actual
+
+	// Clamp the observed row count to the cardinality of the group, and update
+	// the column statistics accordingly.
+	sb.finalizeFromCardinality(relProps)
+}
+
 func (sb *statisticsBuilder) finalizeFromRowCountAndDistinctCounts(
 	colStat *props.ColumnStatistic, s *props.Statistics,
 ) {
@@ -3447,6 +3489,14 @@ func (sb *statisticsBuilder) filterRelExpr(
 	// Calculate row count and selectivity
 	// -----------------------------------
 	corr := sb.correlationFromMultiColDistinctCounts(constrainedCols, e, s)
+	if sb.shouldUseExtendedStatistics(constrainedCols) {
+		constCols := sb.constColsFromFilters(filters)
+		if extCorr, ok := sb.correlationFromExtendedStatistics(
+			constrainedCols, histCols, maxFreqCols, constCols, e, s,
+		); ok {
+			corr = extCorr
+		}
+	}
 	s.ApplySelectivity(sb.selectivityFromConstrainedCols(constrainedCols, histCols, maxFreqCols, e, s, corr))
 	s.ApplySelectivity(sb.selectivityFromEquivalencies(equivReps, &relProps.FuncDeps, e, s))
 	s.ApplySelectivity(sb.selectivityFromUnappliedConjuncts(unapplied))
@@ -4456,6 +4506,224 @@ func (sb *statisticsBuilder) correlationFromMultiColDistinctCounts(
 	return (selectivity.AsFloat() - lowerBound.AsFloat()) / (upperBound.AsFloat() - lowerBound.AsFloat())
 }
 
+// makeExtendedStatistic returns the extended statistics of the given
+// multi-column table statistic, or nil if it has none. The counts of the most
+// common values are scaled to the given row count.
+func makeExtendedStatistic(
+	tabID opt.TableID, stat cat.TableStatistic, rowCount float64,
+) *props.ExtendedStatistic {
+	mcvs := stat.MostCommonValues()
+	degree := stat.DependencyDegree()
+	if len(mcvs) == 0 && degree == 0 {
+		return nil
+	}
+	ext := &props.ExtendedStatistic{
+		Cols:             make(opt.ColList, stat.ColumnCount()),
+		DependencyDegree: degree,
+	}
+	for i := range ext.Cols {
+		ext.Cols[i] = tabID.ColumnID(stat.ColumnOrdinal(i))
+	}
+	if len(mcvs) > 0 && stat.RowCount() > 0 {
+		scale := rowCount / float64(stat.RowCount())
+		ext.MostCommonValues = make([]cat.MultiColumnValue, len(mcvs))
+		for i := range mcvs {
+			ext.MostCommonValues[i] = cat.MultiColumnValue{
+				NumEq:  mcvs[i].NumEq * scale,
+				Values: mcvs[i].Values,
+			}
+		}
+	}
+	return ext
+}
+
+// constColValues contains the columns held constant by a filter or an index
+// constraint, and their values.
+type constColValues struct {
+	cols   opt.ColSet
+	values map[opt.ColumnID]tree.Datum
+}
+
+// shouldUseExtendedStatistics returns true if extended statistics could be
+// used to estimate the correlation between the given constrained columns.
+func (sb *statisticsBuilder) shouldUseExtendedStatistics(constrainedCols opt.ColSet) bool {
+	return constrainedCols.Len() > 1 &&
+		sb.evalCtx.SessionData().OptimizerUseMultiColStats &&
+		sb.evalCtx.SessionData().OptimizerUseExtendedStatistics
+}
+
+// constColsFromFilters returns the columns held constant by the given filters,
+// and their values.
+func (sb *statisticsBuilder) constColsFromFilters(filters FiltersExpr) constColValues {
+	var res constColValues
+	res.cols = ExtractConstColumns(sb.ctx, filters, sb.evalCtx)
+	res.values = make(map[opt.ColumnID]tree.Datum, res.cols.Len())
+	for col, ok := res.cols.Next(0); ok; col, ok = res.cols.Next(col + 1) {
+		res.values[col] = ExtractValueForConstColumn(sb.ctx, filters, sb.evalCtx, col)
+	}
+	return res
+}
+
+// constColsFromScanConstraint returns the columns held constant by the given
+// index constraint and partial index predicate, and their values.
+func (sb *statisticsBuilder) constColsFromScanConstraint(
+	c *constraint.Constraint, pred FiltersExpr,
+) constColValues {
+	res := sb.constColsFromFilters(pred)
+	if c == nil {
+		return res
+	}
+	cs := constraint.SingleConstraint(c)
+	cols := cs.ExtractConstCols(sb.ctx, sb.evalCtx)
+	for col, ok := cols.Next(0); ok; col, ok = cols.Next(col + 1) {
+		if !res.cols.Contains(col) {
+			res.cols.Add(col)
+			res.values[col] = cs.ExtractValueForConstCol(sb.ctx, sb.evalCtx, col)
+		}
+	}
+	return res
+}
+
+// correlationFromExtendedStatistics returns the correlation between the given
+// set of constrained columns, as indicated by the extended statistics of a
+// multi-column statistic on a subset of the columns held constant (see
+// props.ExtendedStatistic). If there is no such statistic, ok is false.
+//
+// The selectivity of the constant predicates on the columns of the statistic
+// is estimated as the frequency of the constant values, if they are one of the
+// most common values. Otherwise, it is estimated from the dependency degree d
+// of the last column on the preceding columns as:
+//
+//	sel(prefix) * (d + (1 - d) * sel(last))
+//
+// which is capped by the frequency of the least common of the most common
+// values. The selectivity of the remaining constrained columns is assumed to
+// be independent. Similar to correlationFromMultiColDistinctCounts, the
+// resulting selectivity mc is converted into a correlation:
+//
+//	corr = (mc - lb) / (ub - lb)
+//
+// where lb and ub are the selectivities of the constrained columns if they were
+// completely independent and completely correlated, respectively.
+func (sb *statisticsBuilder) correlationFromExtendedStatistics(
+	constrainedCols, histCols, maxFreqCols opt.ColSet,
+	constCols constColValues,
+	e RelExpr,
+	s *props.Statistics,
+) (correlation float64, ok bool) {
+	ext, inputRowCount := sb.findExtendedStatistic(constCols.cols.Intersection(constrainedCols), e)
+	if ext == nil || inputRowCount <= 0 {
+		return 0, false
+	}
+	values := make(tree.Datums, len(ext.Cols))
+	for i, col := range ext.Cols {
+		values[i] = constCols.values[col]
+		if values[i] == nil || values[i] == tree.DNull {
+			// The most common values and dependency degree do not account for NULL
+			// values.
+			return 0, false
+		}
+	}
+
+	// selectivityOf returns the selectivity of the predicates on the given
+	// columns, assuming that they are independent.
+	selectivityOf := func(cols opt.ColSet) props.Selectivity {
+		return sb.selectivityFromConstrainedCols(
+			cols, histCols.Intersection(cols), maxFreqCols.Intersection(cols), e, s, 0, /* correlation */
+		)
+	}
+
+	// Estimate the selectivity of the predicates on the statistic's columns.
+	var extSelectivity props.Selectivity
+	found := false
+	minNumEq := math.MaxFloat64
+	for i := range ext.MostCommonValues {
+		mcv := &ext.MostCommonValues[i]
+		minNumEq = min(minNumEq, mcv.NumEq)
+		if found || !sb.datumsEqual(mcv.Values, values) {
+			continue
+		}
+		extSelectivity = props.MakeSelectivityFromFraction(mcv.NumEq, inputRowCount)
+		found = true
+	}
+	if !found {
+		last := len(ext.Cols) - 1
+		d := ext.DependencyDegree
+		extSelectivity = selectivityOf(ext.Cols[:last].ToSet())
+		lastSelectivity := selectivityOf(opt.MakeColSet(ext.Cols[last]))
+		extSelectivity.Multiply(props.MakeSelectivity(d + (1-d)*lastSelectivity.AsFloat()))
+		if len(ext.MostCommonValues) > 0 {
+			extSelectivity = props.MinSelectivity(
+				extSelectivity, props.MakeSelectivityFromFraction(minNumEq, inputRowCount),
+			)
+		}
+	}
+
+	// Convert the selectivity into a correlation.
+	mc := extSelectivity
+	mc.Multiply(selectivityOf(constrainedCols.Difference(ext.Cols.ToSet())))
+	lb := sb.selectivityFromConstrainedCols(constrainedCols, histCols, maxFreqCols, e, s, 0 /* correlation */)
+	ub := sb.selectivityFromConstrainedCols(constrainedCols, histCols, maxFreqCols, e, s, 1 /* correlation */)
+	if ub.AsFloat() <= lb.AsFloat() {
+		return 0, true
+	}
+	correlation = (mc.AsFloat() - lb.AsFloat()) / (ub.AsFloat() - lb.AsFloat())
+	return min(max(correlation, 0), 1), true
+}
+
+// findExtendedStatistic returns the extended statistics of the multi-column
+// table statistic with the most columns that are a subset of the given
+// columns, along with the row count of e's input. Extended statistics are only
+// returned if e's input is unfiltered table data.
+func (sb *statisticsBuilder) findExtendedStatistic(
+	cols opt.ColSet, e RelExpr,
+) (_ *props.ExtendedStatistic, inputRowCount float64) {
+	if cols.Len() < 2 {
+		return nil, 0
+	}
+	var best *props.ColumnStatistic
+	var seenTables intsets.Fast
+	for col, ok := cols.Next(0); ok; col, ok = cols.Next(col + 1) {
+		tabID := sb.md.ColumnMeta(col).Table
+		if tabID == 0 || seenTables.Contains(int(tabID)) {
+			continue
+		}
+		seenTables.Add(int(tabID))
+		tableStats := sb.makeTableStatistics(tabID)
+		for i, n := 0, tableStats.ColStats.Count(); i < n; i++ {
+			colStat := tableStats.ColStats.Get(i)
+			if colStat.Extended == nil || !colStat.Cols.SubsetOf(cols) {
+				continue
+			}
+			if best == nil || colStat.Cols.Len() > best.Cols.Len() {
+				best = colStat
+			}
+		}
+	}
+	if best == nil {
+		return nil, 0
+	}
+	inputColStat, inputStats := sb.colStatFromInput(best.Cols, e)
+	if inputColStat.Extended == nil {
+		return nil, 0
+	}
+	return inputColStat.Extended, inputStats.RowCount
+}
+
+// datumsEqual returns true if the given datums are pairwise equal.
+func (sb *statisticsBuilder) datumsEqual(a, b tree.Datums) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		cmp, err := a[i].Compare(sb.ctx, sb.evalCtx, b[i])
+		if err != nil || cmp != 0 {
+			return false
+		}
+	}
+	return true
+}
+
 // correlationFromMultiColDistinctCountsForJoin is similar to
 // correlationFromMultiColDistinctCounts, but used for join expressions.
 func (sb *statisticsBuilder) correlationFromMultiColDistinctCountsForJoin(
@@ -258,6 +258,12 @@ func (c *CustomFuncs) CanUseSwapMutation(op opt.Operator, private *memo.Mutation
 		return false
 	}
 
+	// Neither are insertions into the history table of a system-versioned
+	// table.
+	if private.HistoryInsert != nil {
+		return false
+	}
+
 	md := c.mem.Metadata()
 	table := md.Table(private.Table)
 
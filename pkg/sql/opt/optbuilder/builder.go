@@ -206,6 +206,11 @@ type Builder struct {
 	// skipUnsafeInternalsCheck is used to skip the check that the
 	// planner is not used for unsafe internal statements.
 	skipUnsafeInternalsCheck bool
+
+	// tableSample is the TABLESAMPLE clause of the table that is currently
+	// being built, if any. It is set while building an aliased table expression
+	// and consumed by buildScan.
+	tableSample *opt.TableSample
 }
 
 // New creates a new Builder structure initialized with the given
@@ -108,6 +108,8 @@ func (mb *mutationBuilder) buildDelete(returning *tree.ReturningExprs) {
 
 	mb.buildRowLevelAfterTriggers(opt.DeleteOp)
 
+	mb.buildHistoryInsert(opt.DeleteOp)
+
 	// Project partial index DEL boolean columns.
 	mb.projectPartialIndexDelCols()
 
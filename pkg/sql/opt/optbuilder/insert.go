@@ -427,6 +427,8 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 //  6. There are no UPDATE triggers on the target table.
 //  7. Row-level security is disabled for the table. RLS may need to check for
 //     policy violations on the old rows, so we always need to fetch them.
+//  8. The table is not system-versioned. The old versions of updated rows are
+//     copied into the history table.
 //
 // TODO(andyk): The fast path is currently only enabled when the UPSERT alias
 // is explicitly selected by the user. It's possible to fast path some queries
@@ -438,6 +440,10 @@ func (mb *mutationBuilder) needExistingRows() bool {
 		return true
 	}
 
+	if _, _, ok := mb.tab.SystemVersioning(); ok {
+		return true
+	}
+
 	// If there are any implicit partitioning columns in the primary index,
 	// these columns will need to be fetched.
 	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
@@ -819,6 +825,8 @@ func (mb *mutationBuilder) buildInsert(
 
 	mb.buildRowLevelAfterTriggers(opt.InsertOp)
 
+	mb.buildHistoryInsert(opt.InsertOp)
+
 	private := mb.makeMutationPrivate(returning != nil, vectorInsert)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fastPathUniqueChecks, mb.fkChecks, private,
@@ -1042,6 +1050,8 @@ func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
 
 	mb.buildRowLevelAfterTriggers(opt.InsertOp)
 
+	mb.buildHistoryInsert(opt.InsertOp)
+
 	private := mb.makeMutationPrivate(returning != nil, false /* vectorInsert */)
 	mb.outScope.expr = mb.b.factory.ConstructUpsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
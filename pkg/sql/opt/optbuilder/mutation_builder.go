@@ -157,9 +157,10 @@ type mutationBuilder struct {
 	vectorIndexPutQuantizedVecColIDs opt.OptionalColList
 
 	// triggerColIDs is the set of column IDs used to project the OLD and NEW rows
-	// for row-level AFTER triggers, and possibly also contains the canary column.
-	// It is only populated if the mutation statement has row-level AFTER
-	// triggers.
+	// for row-level AFTER triggers and the old rows for history table inserts,
+	// and possibly also contains the canary column. It is only populated if the
+	// mutation statement has row-level AFTER triggers or the target table is
+	// system-versioned.
 	//
 	// NOTE: triggerColIDs may contain columns both contained and not contained in
 	// the lists above.
@@ -217,6 +218,10 @@ type mutationBuilder struct {
 	// afterTriggers contains AFTER triggers; see buildRowLevelAfterTriggers.
 	afterTriggers *memo.AfterTriggers
 
+	// historyInsert inserts the old versions of modified rows into the history
+	// table of a system-versioned table; see buildHistoryInsert.
+	historyInsert *memo.HistoryInsert
+
 	// withID is nonzero if we need to buffer the input for FK or uniqueness
 	// checks.
 	withID opt.WithID
@@ -596,6 +601,13 @@ func (mb *mutationBuilder) addTargetCol(ord int) {
 		panic(schemaexpr.CannotWriteToComputedColError(string(tabCol.ColName())))
 	}
 
+	// The row start column of a system-versioned table is maintained by the
+	// system.
+	if _, rowStartOrd, ok := mb.tab.SystemVersioning(); ok && ord == rowStartOrd {
+		panic(pgerror.Newf(pgcode.GeneratedAlways,
+			"cannot write to row start column %q of system-versioned table", tabCol.ColName()))
+	}
+
 	// Ensure that the name list does not contain duplicates.
 	colID := mb.tabID.ColumnID(ord)
 	if mb.targetColSet.Contains(colID) {
@@ -1789,14 +1801,15 @@ func (mb *mutationBuilder) makeMutationPrivate(
 		TriggerCols:                    mb.triggerColIDs,
 		FKCascades:                     mb.cascades,
 		AfterTriggers:                  mb.afterTriggers,
+		HistoryInsert:                  mb.historyInsert,
 		UniqueWithTombstoneIndexes:     mb.uniqueWithTombstoneIndexes.Ordered(),
 		VectorInsert:                   vectorInsert,
 	}
 
-	// If we didn't actually plan any checks, cascades, or triggers, don't buffer
-	// the input.
+	// If we didn't actually plan any checks, cascades, triggers, or history
+	// inserts, don't buffer the input.
 	if len(mb.uniqueChecks) > 0 || len(mb.fkChecks) > 0 ||
-		len(mb.cascades) > 0 || mb.afterTriggers != nil {
+		len(mb.cascades) > 0 || mb.afterTriggers != nil || mb.historyInsert != nil {
 		private.WithID = mb.withID
 	}
 
@@ -8,6 +8,7 @@ package optbuilder
 import (
 	"context"
 	"fmt"
+	"math"
 
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
@@ -86,7 +87,14 @@ func (b *Builder) buildDataSource(
 			lockCtx.withoutTargets()
 		}
 
-		outScope = b.buildDataSource(source.Expr, indexFlags, lockCtx, inScope)
+		if source.SystemTime != nil {
+			outScope = b.buildSystemTimeSource(source, indexFlags, lockCtx, inScope)
+		} else {
+			if source.TableSample != nil {
+				b.tableSample = b.buildTableSample(source)
+			}
+			outScope = b.buildDataSource(source.Expr, indexFlags, lockCtx, inScope)
+		}
 
 		if source.Ordinality {
 			outScope = b.buildWithOrdinality(outScope)
@@ -105,6 +113,7 @@ func (b *Builder) buildDataSource(
 
 		// CTEs take precedence over other data sources.
 		if cte := inScope.resolveCTE(tn); cte != nil {
+			b.errorOnTableSample()
 			lockCtx.locking.ignoreLockingForCTE()
 			outScope = inScope.push()
 			inCols := make(opt.ColList, len(cte.cols), len(cte.cols)+len(inScope.ordering))
@@ -169,9 +178,11 @@ func (b *Builder) buildDataSource(
 			)
 
 		case cat.Sequence:
+			b.errorOnTableSample()
 			return b.buildSequenceSelect(t, &resName, inScope)
 
 		case cat.View:
+			b.errorOnTableSample()
 			return b.buildView(t, &resName, lockCtx, inScope)
 
 		default:
@@ -279,6 +290,7 @@ func (b *Builder) buildDataSource(
 		case cat.Table:
 			outScope = b.buildScanFromTableRef(t, source, indexFlags, lockCtx.locking, inScope)
 		case cat.View:
+			b.errorOnTableSample()
 			if source.Columns != nil {
 				panic(pgerror.Newf(pgcode.FeatureNotSupported,
 					"cannot specify an explicit column list when accessing a view by reference"))
@@ -287,6 +299,7 @@ func (b *Builder) buildDataSource(
 
 			outScope = b.buildView(t, &tn, lockCtx, inScope)
 		case cat.Sequence:
+			b.errorOnTableSample()
 			tn := tree.MakeUnqualifiedTableName(t.Name())
 			// Any explicitly listed columns are ignored.
 			outScope = b.buildSequenceSelect(t, &tn, inScope)
@@ -301,6 +314,81 @@ func (b *Builder) buildDataSource(
 	}
 }
 
+// buildTableSample type-checks and evaluates the arguments of the TABLESAMPLE
+// clause of the given table expression. The arguments must be constants, since
+// they are evaluated once, at planning time.
+func (b *Builder) buildTableSample(source *tree.AliasedTableExpr) *opt.TableSample {
+	switch source.Expr.(type) {
+	case *tree.TableName, *tree.TableRef:
+	default:
+		panic(errTableSampleNonTable)
+	}
+	if source.IndexFlags != nil {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"index flags cannot be used with TABLESAMPLE"))
+	}
+
+	sample := &opt.TableSample{Method: source.TableSample.Method}
+	percent := b.evalTableSampleArg(source.TableSample.Percent)
+	if percent == tree.DNull {
+		panic(pgerror.New(pgcode.InvalidTablesampleArgument,
+			"TABLESAMPLE parameter cannot be null"))
+	}
+	p := float64(tree.MustBeDFloat(percent))
+	if !(p >= 0 && p <= 100) {
+		panic(pgerror.New(pgcode.InvalidTablesampleArgument,
+			"sample percentage must be between 0 and 100"))
+	}
+	sample.Fraction = p / 100
+
+	if source.TableSample.Repeatable != nil {
+		seed := b.evalTableSampleArg(source.TableSample.Repeatable)
+		if seed == tree.DNull {
+			panic(pgerror.New(pgcode.InvalidTablesampleRepeat,
+				"TABLESAMPLE REPEATABLE parameter cannot be null"))
+		}
+		sample.Seed = math.Float64bits(float64(tree.MustBeDFloat(seed)))
+		sample.Repeatable = true
+	}
+	return sample
+}
+
+// evalTableSampleArg type-checks the given TABLESAMPLE argument as a FLOAT8 and
+// evaluates it.
+func (b *Builder) evalTableSampleArg(expr tree.Expr) tree.Datum {
+	if tree.ContainsVars(expr) {
+		panic(errTableSampleNonConst)
+	}
+	texpr, err := tree.TypeCheckAndRequire(b.ctx, expr, b.semaCtx, types.Float, "TABLESAMPLE")
+	if err != nil {
+		panic(err)
+	}
+	if !eval.IsConst(b.evalCtx, texpr) {
+		panic(errTableSampleNonConst)
+	}
+	d, err := eval.Expr(b.ctx, b.evalCtx, texpr)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+var (
+	errTableSampleNonTable = pgerror.New(pgcode.WrongObjectType,
+		"TABLESAMPLE clause can only be applied to tables and materialized views")
+	errTableSampleNonConst = pgerror.New(pgcode.FeatureNotSupported,
+		"TABLESAMPLE arguments must be constant")
+)
+
+// errorOnTableSample panics if a TABLESAMPLE clause applies to the data source
+// that is being built, which is not a table.
+func (b *Builder) errorOnTableSample() {
+	if b.tableSample != nil {
+		b.tableSample = nil
+		panic(errTableSampleNonTable)
+	}
+}
+
 // buildView parses the view query text and builds it as a Select expression.
 func (b *Builder) buildView(
 	view cat.View, viewName *tree.TableName, lockCtx lockingContext, inScope *scope,
@@ -598,6 +686,7 @@ func (b *Builder) buildScan(
 			panic(pgerror.Newf(pgcode.Syntax,
 				"index flags not allowed with virtual tables"))
 		}
+		b.errorOnTableSample()
 		if locking.isSet() {
 			panic(pgerror.Newf(pgcode.Syntax,
 				"%s not allowed with virtual tables", locking.get().Strength))
@@ -638,6 +727,10 @@ func (b *Builder) buildScan(
 	}
 
 	private := memo.ScanPrivate{Table: tabID, Cols: scanColIDs}
+	if b.tableSample != nil {
+		private.Sample = *b.tableSample
+		b.tableSample = nil
+	}
 	if indexFlags != nil {
 		private.Flags.NoIndexJoin = indexFlags.NoIndexJoin
 		private.Flags.NoZigzagJoin = indexFlags.NoZigzagJoin
@@ -0,0 +1,424 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+var errSystemTimeNonTable = pgerror.New(pgcode.WrongObjectType,
+	"FOR SYSTEM_TIME can only be used with system-versioned tables")
+
+// resolveHistoryTable returns the history table with the given ID, which
+// belongs to the system-versioned table tab.
+func (b *Builder) resolveHistoryTable(tab cat.Table, id cat.StableID) cat.Table {
+	hist := resolveTable(b.ctx, b.catalog, id)
+	if hist == nil {
+		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"history table of %q is being created", tab.Name()))
+	}
+	return hist
+}
+
+// historyTableColumnOrd returns the ordinal of the column with the given name
+// in the history table, or panics if it does not exist.
+func historyTableColumnOrd(hist cat.Table, name tree.Name) int {
+	ord := findPublicTableColumnByName(hist, name)
+	if ord == -1 {
+		panic(pgerror.Newf(pgcode.UndefinedColumn,
+			"history table %q has no column %q", hist.Name(), name))
+	}
+	return ord
+}
+
+// isHistoryColumn returns true if the given column of a system-versioned table
+// is copied into the history table: all visible columns and the row start
+// column.
+func isHistoryColumn(tab cat.Table, ord, rowStartOrd int) bool {
+	col := tab.Column(ord)
+	if col.Kind() != cat.Ordinary {
+		return false
+	}
+	return col.Visibility() == cat.Visible || ord == rowStartOrd
+}
+
+// buildHistoryInsert plans the insertion of the old versions of the rows that
+// are modified by the mutation into the history table, if the target table is
+// system-versioned. The old version of each row is stamped with the
+// transaction timestamp as its row end time. Like AFTER triggers, the insertion
+// is planned as a post-query that reads from the buffered mutation input.
+//
+// Rows that were inserted by the mutation (for UPSERT and INSERT ON CONFLICT)
+// have no old version, so they are filtered out using the canary column. Plain
+// INSERT statements never write history rows.
+func (mb *mutationBuilder) buildHistoryInsert(mutation opt.Operator) {
+	histID, rowStartOrd, ok := mb.tab.SystemVersioning()
+	if !ok || (mutation == opt.InsertOp && mb.canaryColID == 0) {
+		return
+	}
+	hist := mb.b.resolveHistoryTable(mb.tab, histID)
+
+	// The history table is maintained on behalf of the user, so no privileges
+	// on it are required. Still, add a dependency so that the memo is
+	// invalidated if the history table changes.
+	mb.md.AddDependency(opt.DepByID(histID), hist, 0 /* priv */)
+
+	mb.ensureWithID()
+
+	hb := &historyInsertBuilder{
+		historyTable:   hist,
+		stmtTreeInitFn: mb.b.stmtTree.GetInitFnForPostQuery(),
+		rowStartIdx:    -1,
+		rowEndOrd:      historyTableColumnOrd(hist, tree.SystemVersioningRowEndColName),
+		canaryCol:      mb.canaryColID,
+	}
+	for i, n := 0, hist.ColumnCount(); i < n; i++ {
+		if i == hb.rowEndOrd {
+			continue
+		}
+		histCol := hist.Column(i)
+		if histCol.Kind() != cat.Ordinary || histCol.IsComputed() {
+			continue
+		}
+		ord := findPublicTableColumnByName(mb.tab, histCol.ColName())
+		if ord == -1 || !isHistoryColumn(mb.tab, ord, rowStartOrd) {
+			continue
+		}
+		col := mb.fetchColIDs[ord]
+		if col == 0 {
+			panic(errors.AssertionFailedf("expected fetch column for %q", histCol.ColName()))
+		}
+		if ord == rowStartOrd {
+			hb.rowStartIdx = len(hb.fetchCols)
+		}
+		mb.triggerColIDs.Add(col)
+		hb.fetchCols = append(hb.fetchCols, col)
+		hb.historyOrds = append(hb.historyOrds, i)
+	}
+	if hb.rowStartIdx == -1 {
+		panic(pgerror.Newf(pgcode.UndefinedColumn, "history table %q has no column %q",
+			hist.Name(), tree.SystemVersioningRowStartColName))
+	}
+	if mb.canaryColID != 0 {
+		mb.triggerColIDs.Add(mb.canaryColID)
+	}
+	if mb.historyInsert != nil {
+		panic(errors.AssertionFailedf("historyInsert already set"))
+	}
+	mb.historyInsert = &memo.HistoryInsert{
+		HistoryTable: hist,
+		Builder:      hb,
+		WithID:       mb.withID,
+	}
+}
+
+// historyInsertBuilder is a memo.PostQueryBuilder implementation that inserts
+// the old versions of the rows modified by a mutation of a system-versioned
+// table into its history table.
+//
+// See testdata/system_versioning for some examples.
+type historyInsertBuilder struct {
+	historyTable cat.Table
+
+	// stmtTreeInitFn returns a statementTree that tracks the mutations in
+	// ancestor statements. It may be unset if there are no ancestor statements.
+	stmtTreeInitFn func() statementTree
+
+	// fetchCols is the list of columns from the mutation input that correspond
+	// to the old values of the modified rows. The columns must be remapped to
+	// the new memo when the insert is built. historyOrds contains the ordinal of
+	// the history table column corresponding to each entry of fetchCols.
+	fetchCols   opt.ColList
+	historyOrds []int
+
+	// rowStartIdx is the index into fetchCols of the row start column.
+	rowStartIdx int
+
+	// rowEndOrd is the ordinal of the row end column in the history table.
+	rowEndOrd int
+
+	// canaryCol is set for UPSERT and INSERT with ON CONFLICT. It is NULL to
+	// indicate an inserted row, and non-NULL to indicate an updated row.
+	canaryCol opt.ColumnID
+}
+
+var _ memo.PostQueryBuilder = &historyInsertBuilder{}
+
+// Build is part of the memo.PostQueryBuilder interface.
+func (hb *historyInsertBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	colMap opt.ColMap,
+) (_ memo.RelExpr, err error) {
+	return buildTriggerCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, hb.stmtTreeInitFn,
+		func(b *Builder) memo.RelExpr {
+			f := b.factory
+			md := f.Metadata()
+
+			// Map the columns from the original memo to the new one using colMap.
+			inCols := hb.fetchCols.RemapColumns(colMap)
+			outCols := make(opt.ColList, len(inCols), len(inCols)+1)
+			histScope := b.allocScope()
+			for i, col := range inCols {
+				colMeta := md.ColumnMeta(col)
+				name := scopeColName("").WithMetadataName(colMeta.Alias)
+				outCols[i] = b.synthesizeColumn(
+					histScope, name, colMeta.Type, nil /* expr */, nil, /* scalar */
+				).id
+			}
+			var outCanaryCol opt.ColumnID
+			if hb.canaryCol != 0 {
+				inCanaryCol, ok := colMap.Get(int(hb.canaryCol))
+				if !ok {
+					panic(errors.AssertionFailedf("column %d not in mapping %s\n",
+						hb.canaryCol, colMap.String()))
+				}
+				colType := md.ColumnMeta(opt.ColumnID(inCanaryCol)).Type
+				name := scopeColName("").WithMetadataName("canary")
+				outCanaryCol = b.synthesizeColumn(
+					histScope, name, colType, nil /* expr */, nil, /* scalar */
+				).id
+				inCols = append(inCols, opt.ColumnID(inCanaryCol))
+				outCols = append(outCols, outCanaryCol)
+			}
+			md.AddWithBinding(binding, f.ConstructFakeRel(&memo.FakeRelPrivate{
+				Props: bindingProps,
+			}))
+			histScope.expr = f.ConstructWithScan(&memo.WithScanPrivate{
+				With:    binding,
+				InCols:  inCols,
+				OutCols: outCols,
+				ID:      md.NextUniqueID(),
+			})
+
+			// Project the row end time of the old row versions.
+			texpr := histScope.resolveAndRequireType(tree.SystemVersioningTimestampExpr, types.TimestampTZ)
+			rowEnd := b.buildScalar(texpr, histScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+			rowEndCol := b.projectColWithMetadataName(
+				histScope, string(tree.SystemVersioningRowEndColName), types.TimestampTZ, rowEnd,
+			)
+
+			// Row versions that became current in this transaction are not
+			// visible to any other transaction, so they are not recorded. Neither
+			// are rows inserted by an UPSERT, which have no old version.
+			filters := memo.FiltersExpr{f.ConstructFiltersItem(f.ConstructLt(
+				f.ConstructVariable(outCols[hb.rowStartIdx]), f.ConstructVariable(rowEndCol),
+			))}
+			if outCanaryCol != 0 {
+				filters = append(filters, f.ConstructFiltersItem(
+					f.ConstructIsNot(f.ConstructVariable(outCanaryCol), memo.NullSingleton),
+				))
+			}
+			histScope.expr = f.ConstructSelect(histScope.expr, filters)
+
+			// Insert the old row versions into the history table.
+			b.checkMultipleMutations(hb.historyTable, simpleInsert)
+			var mb mutationBuilder
+			mb.init(b, "insert", hb.historyTable, tree.MakeUnqualifiedTableName(hb.historyTable.Name()))
+			for _, ord := range hb.historyOrds {
+				mb.addTargetCol(ord)
+			}
+			mb.addTargetCol(hb.rowEndOrd)
+			mb.outScope = histScope
+			for i, ord := range hb.historyOrds {
+				mb.insertColIDs[ord] = outCols[i]
+			}
+			mb.insertColIDs[hb.rowEndOrd] = rowEndCol
+			mb.addAssignmentCasts(mb.insertColIDs)
+			mb.inputForInsertExpr = mb.outScope.expr
+			mb.addSynthesizedColsForInsert()
+			mb.insertExpr = mb.outScope.expr
+			mb.buildRowLevelBeforeTriggers(tree.TriggerEventInsert, true /* cascade */)
+			mb.buildInsert(nil /* returning */, false /* vectorInsert */, false /* hasOnConflict */)
+			return mb.outScope.expr
+		})
+}
+
+// buildSystemTimeSource builds a reference to a system-versioned table with a
+// FOR SYSTEM_TIME clause. The current and history tables are combined with a
+// UNION ALL, and the row versions are filtered by their validity period:
+//
+//	FOR SYSTEM_TIME AS OF t          valid_from <= t AND valid_to > t
+//	FOR SYSTEM_TIME FROM a TO b      valid_from < b AND valid_to > a
+//	FOR SYSTEM_TIME BETWEEN a AND b  valid_from <= b AND valid_to > a
+//	FOR SYSTEM_TIME ALL              no filter
+//
+// The valid_to column of current rows is NULL, and is treated as infinity. The
+// valid_from and valid_to columns are hidden columns of the resulting scope.
+func (b *Builder) buildSystemTimeSource(
+	source *tree.AliasedTableExpr,
+	indexFlags *tree.IndexFlags,
+	lockCtx lockingContext,
+	inScope *scope,
+) (outScope *scope) {
+	tn, ok := source.Expr.(*tree.TableName)
+	if !ok || inScope.resolveCTE(tn) != nil {
+		panic(errSystemTimeNonTable)
+	}
+	if source.TableSample != nil {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"FOR SYSTEM_TIME cannot be used with TABLESAMPLE"))
+	}
+	lockCtx.filter(tn.ObjectName)
+	if lockCtx.locking.isSet() {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s cannot be used with FOR SYSTEM_TIME", lockCtx.locking.get().Strength))
+	}
+
+	ds, _, resName := b.resolveDataSource(tn, privilege.SELECT)
+	tab, ok := ds.(cat.Table)
+	if !ok {
+		panic(errSystemTimeNonTable)
+	}
+	histID, rowStartOrd, ok := tab.SystemVersioning()
+	if !ok {
+		panic(errSystemTimeNonTable)
+	}
+	hist := b.resolveHistoryTable(tab, histID)
+	b.checkPrivilege(opt.DepByID(histID), hist, privilege.SELECT)
+	histName, err := b.catalog.FullyQualifiedName(b.ctx, hist)
+	if err != nil {
+		panic(err)
+	}
+
+	// Scan the current and history tables.
+	tabMeta := b.addTable(tab, &resName)
+	policyCommandScope, locking := b.prepForTableScan(noRowLocking, tabMeta)
+	curScope := b.buildScan(
+		tabMeta, tableOrdinals(tab, columnKinds{}), indexFlags, locking, inScope,
+		false /* disableNotVisibleIndex */, policyCommandScope,
+	)
+	histMeta := b.addTable(hist, &histName)
+	policyCommandScope, locking = b.prepForTableScan(noRowLocking, histMeta)
+	histScope := b.buildScan(
+		histMeta, tableOrdinals(hist, columnKinds{}), nil /* indexFlags */, locking, inScope,
+		false /* disableNotVisibleIndex */, policyCommandScope,
+	)
+	colByOrd := func(s *scope, ord int) opt.ColumnID {
+		for i := range s.cols {
+			if s.cols[i].tableOrdinal == ord {
+				return s.cols[i].id
+			}
+		}
+		panic(errors.AssertionFailedf("column %d not found in scan", ord))
+	}
+
+	// Match the columns of the two tables by name. Columns that were added to
+	// the current table after a row version was archived are NULL in the
+	// history table.
+	f := b.factory
+	md := f.Metadata()
+	var curProjections, histProjections memo.ProjectionsExpr
+	nullCol := func(projections *memo.ProjectionsExpr, name string, typ *types.T) opt.ColumnID {
+		col := md.AddColumn(name, typ)
+		*projections = append(*projections, f.ConstructProjectionsItem(f.ConstructNull(typ), col))
+		return col
+	}
+	outScope = inScope.push()
+	var leftCols, rightCols, outCols opt.ColList
+	addOutCol := func(name tree.Name, typ *types.T, visible bool, left, right opt.ColumnID) *scopeColumn {
+		col := b.synthesizeColumn(outScope, scopeColName(name), typ, nil /* expr */, nil /* scalar */)
+		col.table = *tn
+		if !visible {
+			col.visibility = accessibleByName
+		}
+		leftCols = append(leftCols, left)
+		rightCols = append(rightCols, right)
+		outCols = append(outCols, col.id)
+		return col
+	}
+	var rowStart opt.ColumnID
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		if !isHistoryColumn(tab, i, rowStartOrd) {
+			continue
+		}
+		col := tab.Column(i)
+		histOrd := findPublicTableColumnByName(hist, col.ColName())
+		if i == rowStartOrd {
+			histOrd = historyTableColumnOrd(hist, col.ColName())
+		}
+		var right opt.ColumnID
+		if histOrd != -1 {
+			if histTyp := hist.Column(histOrd).DatumType(); !histTyp.Identical(col.DatumType()) {
+				panic(pgerror.Newf(pgcode.DatatypeMismatch,
+					"column %q of history table %q has type %s, expected %s",
+					col.ColName(), hist.Name(), histTyp.SQLString(), col.DatumType().SQLString()))
+			}
+			right = colByOrd(histScope, histOrd)
+		} else {
+			right = nullCol(&histProjections, string(col.ColName()), col.DatumType())
+		}
+		outCol := addOutCol(col.ColName(), col.DatumType(), i != rowStartOrd, colByOrd(curScope, i), right)
+		if i == rowStartOrd {
+			rowStart = outCol.id
+		}
+	}
+	rowEnd := addOutCol(
+		tree.SystemVersioningRowEndColName, types.TimestampTZ, false, /* visible */
+		nullCol(&curProjections, string(tree.SystemVersioningRowEndColName), types.TimestampTZ),
+		colByOrd(histScope, historyTableColumnOrd(hist, tree.SystemVersioningRowEndColName)),
+	).id
+
+	left := f.ConstructProject(curScope.expr, curProjections, curScope.colSet())
+	right := histScope.expr
+	if len(histProjections) > 0 {
+		right = f.ConstructProject(right, histProjections, histScope.colSet())
+	}
+	outScope.expr = f.ConstructUnionAll(left, right, &memo.SetPrivate{
+		LeftCols:  leftCols,
+		RightCols: rightCols,
+		OutCols:   outCols,
+	})
+
+	// Filter the row versions by their validity period.
+	buildTimestamp := func(expr tree.Expr) opt.ScalarExpr {
+		argScope := b.allocScope()
+		texpr := argScope.resolveAndRequireType(expr, types.TimestampTZ)
+		return b.buildScalar(texpr, argScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	}
+	endsAfter := func(ts opt.ScalarExpr) opt.ScalarExpr {
+		return f.ConstructOr(
+			f.ConstructIs(f.ConstructVariable(rowEnd), memo.NullSingleton),
+			f.ConstructGt(f.ConstructVariable(rowEnd), ts),
+		)
+	}
+	var filter opt.ScalarExpr
+	switch clause := source.SystemTime; clause.Kind {
+	case tree.SystemTimeAsOf:
+		ts := buildTimestamp(clause.From)
+		filter = f.ConstructAnd(f.ConstructLe(f.ConstructVariable(rowStart), ts), endsAfter(ts))
+	case tree.SystemTimeFromTo:
+		from, to := buildTimestamp(clause.From), buildTimestamp(clause.To)
+		filter = f.ConstructAnd(f.ConstructLt(f.ConstructVariable(rowStart), to), endsAfter(from))
+	case tree.SystemTimeBetween:
+		from, to := buildTimestamp(clause.From), buildTimestamp(clause.To)
+		filter = f.ConstructAnd(f.ConstructLe(f.ConstructVariable(rowStart), to), endsAfter(from))
+	case tree.SystemTimeAll:
+	default:
+		panic(errors.AssertionFailedf("unexpected FOR SYSTEM_TIME kind %d", clause.Kind))
+	}
+	if filter != nil {
+		outScope.expr = f.ConstructSelect(outScope.expr, memo.FiltersExpr{f.ConstructFiltersItem(filter)})
+	}
+	return outScope
+}
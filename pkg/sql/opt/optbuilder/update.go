@@ -413,6 +413,8 @@ func (mb *mutationBuilder) buildUpdate(
 
 	mb.buildRowLevelAfterTriggers(opt.UpdateOp)
 
+	mb.buildHistoryInsert(opt.UpdateOp)
+
 	private := mb.makeMutationPrivate(returning != nil, false /* vectorInsert */)
 	for _, col := range mb.extraAccessibleCols {
 		if col.id != 0 {
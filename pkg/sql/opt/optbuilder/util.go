@@ -616,9 +616,14 @@ func (b *Builder) resolveTableForMutation(
 		alias = *outerAlias
 	}
 
-	// We can't mutate materialized views.
+	// We can't mutate materialized views, except for the job that maintains an
+	// incremental materialized view. Its plans depend on the session, so they
+	// must not be reused by other sessions.
 	if tab.IsMaterializedView() {
-		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
+		if !b.evalCtx.SessionData().AllowMaterializedViewMutations {
+			panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
+		}
+		b.DisableMemoReuse = true
 	}
 
 	return tab, depName, alias, columns
@@ -611,6 +611,7 @@ func (g *exprsGen) genMemoizeFuncs() {
 		if !define.Tags.Contains("Scalar") {
 			fmt.Fprintf(g.w, "  m.logPropsBuilder.build%sProps(e, &grp.rel)\n", define.Name)
 			fmt.Fprintf(g.w, "  grp.rel.Populated = true\n")
+			fmt.Fprintf(g.w, "  m.logPropsBuilder.applyCardinalityFeedback(e, &grp.rel)\n")
 		}
 		fmt.Fprintf(g.w, "    m.memEstimate += size\n")
 		fmt.Fprintf(g.w, "    m.CheckExpr(e)\n")
@@ -203,6 +203,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"WindowFrame":          {fullName: "memo.WindowFrame", passByVal: true},
 		"FKCascades":           {fullName: "memo.FKCascades", passByVal: true},
 		"AfterTriggers":        {fullName: "memo.AfterTriggers", isPointer: true},
+		"HistoryInsert":        {fullName: "memo.HistoryInsert", isPointer: true},
 		"ExplainOptions":       {fullName: "tree.ExplainOptions", passByVal: true},
 		"StatementReturnType":  {fullName: "tree.StatementReturnType", passByVal: true},
 		"StatementType":        {fullName: "tree.StatementType", passByVal: true},
@@ -243,6 +244,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"SchemaTypeDeps":       {fullName: "opt.SchemaTypeDeps", passByVal: true},
 		"SchemaFunctionDeps":   {fullName: "opt.SchemaFunctionDeps", passByVal: true},
 		"Locking":              {fullName: "opt.Locking", passByVal: true},
+		"TableSample":          {fullName: "opt.TableSample", passByVal: true},
 		"CTEMaterializeClause": {fullName: "tree.CTEMaterializeClause", passByVal: true},
 		"SpanExpression":       {fullName: "inverted.SpanExpression", isPointer: true, usePointerIntern: true},
 		"InvertedSpans":        {fullName: "inverted.Spans", passByVal: true},
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/olekukonko/tablewriter"
 )
@@ -236,6 +237,31 @@ type ColumnStatistic struct {
 	// the approximate distribution of values for that column, represented
 	// by a slice of histogram buckets.
 	Histogram *Histogram
+
+	// Extended is only used when the size of Cols is greater than one, and
+	// only for statistics of unfiltered table data. It contains the most
+	// common values and the dependency degree of the columns. It is not
+	// modified once it has been set.
+	Extended *ExtendedStatistic
+}
+
+// ExtendedStatistic contains the extended statistics of a multi-column table
+// statistic. See cat.TableStatistic for more details.
+type ExtendedStatistic struct {
+	// Cols lists the columns of the statistic, in the same order as the values
+	// of each of the MostCommonValues. The last column is the dependent column
+	// of DependencyDegree.
+	Cols opt.ColList
+
+	// MostCommonValues contains the most common combinations of non-NULL
+	// values on Cols, in order of decreasing frequency. The counts are scaled
+	// to the row count of the table statistics.
+	MostCommonValues []cat.MultiColumnValue
+
+	// DependencyDegree is the fraction of rows (without NULL values) for which
+	// the value of the last column is functionally determined by the values of
+	// the preceding columns.
+	DependencyDegree float64
 }
 
 // ApplySelectivity updates the distinct count, null count, and histogram
@@ -249,6 +275,11 @@ func (c *ColumnStatistic) ApplySelectivity(selectivity Selectivity, inputRows fl
 		c.Histogram = c.Histogram.ApplySelectivity(selectivity)
 	}
 
+	// Extended statistics only describe unfiltered table data.
+	if selectivity != OneSelectivity {
+		c.Extended = nil
+	}
+
 	if selectivity == OneSelectivity || c.DistinctCount == 0 {
 		return
 	}
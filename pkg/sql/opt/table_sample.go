@@ -0,0 +1,53 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package opt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// TableSample represents the TABLESAMPLE clause of a scan, which restricts the
+// scan to a pseudo-random subset of the rows in the table. The zero value
+// indicates that the scan is not sampled.
+type TableSample struct {
+	// Method is the sampling method. BERNOULLI independently selects each row
+	// with probability Fraction, while SYSTEM selects whole blocks of rows (one
+	// or more ranges) with probability Fraction.
+	Method tree.TableSampleMethod
+
+	// Fraction is the probability, between 0 and 1, with which each row or block
+	// is selected.
+	Fraction float64
+
+	// Seed is the seed used to select rows or blocks. It is only meaningful when
+	// Repeatable is true; otherwise a new seed is chosen for each execution.
+	Seed uint64
+
+	// Repeatable is true if the query specified REPEATABLE, in which case the
+	// same seed selects the same sample as long as the table is not modified.
+	Repeatable bool
+}
+
+// IsSet returns true if the scan is sampled.
+func (s TableSample) IsSet() bool {
+	return s.Method != 0
+}
+
+// String returns a human-readable representation of the sample, for use in
+// memo formatting.
+func (s TableSample) String() string {
+	if !s.IsSet() {
+		return ""
+	}
+	res := fmt.Sprintf("%s (%.6g%%)", strings.ToLower(s.Method.String()), s.Fraction*100)
+	if s.Repeatable {
+		res += fmt.Sprintf(" repeatable (%d)", s.Seed)
+	}
+	return res
+}
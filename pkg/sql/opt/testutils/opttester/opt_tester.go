@@ -355,8 +355,8 @@ func New(catalog cat.Catalog, sqlStr string) *OptTester {
 //
 //   - build-post-queries [flags]
 //
-//     Builds a query and then recursively builds cascading queries and AFTER
-//     triggers. Outputs all unoptimized plans. NOTE: the column IDs in the
+//     Builds a query and then recursively builds cascading queries, history
+//     table inserts, and AFTER triggers. Outputs all unoptimized plans. NOTE: the column IDs in the
 //     displayed plan will be higher than those used during execution, because
 //     the execution plan is built with a different memo.
 //
@@ -2542,6 +2542,29 @@ func (ot *OptTester) PostQueries(optimize bool) (string, error) {
 					return err
 				}
 			}
+			if h := p.HistoryInsert; h != nil {
+				// We use the same memo to build the history insert.
+				hist, err := h.Builder.Build(
+					context.Background(),
+					&ot.semaCtx,
+					&ot.evalCtx,
+					ot.catalog,
+					o.Factory(),
+					h.WithID,
+					inputRel,
+					colMap,
+				)
+				if err != nil {
+					return errors.Wrap(err, "error building history insert")
+				}
+				n := tp.Child("history-insert")
+				if err = formatExpr(n, "history-insert", hist); err != nil {
+					return err
+				}
+				if err = buildPostQueries(hist, n, level+1); err != nil {
+					return err
+				}
+			}
 			if t := p.AfterTriggers; t != nil {
 				// We use the same memo to build the triggers. This makes the entire
 				// tree easier to read (e.g. the column IDs won't overlap).
@@ -25,6 +25,8 @@ import (
 //   - INJECT STATISTICS: imports table statistics from a JSON object.
 //   - ADD CONSTRAINT FOREIGN KEY: add a foreign key reference.
 //   - {ENABLE | DISABLE} ROW LEVEL SECURITY: enables or disables RLS policies for the table.
+//   - {ADD | DROP} SYSTEM VERSIONING: enables or disables system versioning for
+//     the table. The table must already have the row start column.
 func (tc *Catalog) AlterTable(stmt *tree.AlterTable) {
 	tn := stmt.Table.ToTableName()
 	// Update the table name to include catalog and schema if not provided.
@@ -39,6 +41,12 @@ func (tc *Catalog) AlterTable(stmt *tree.AlterTable) {
 		case *tree.AlterTableSetRLSMode:
 			toggleRLSMode(tab, t.Mode)
 
+		case *tree.AlterTableAddSystemVersioning:
+			tc.addSystemVersioning(tab, t)
+
+		case *tree.AlterTableDropSystemVersioning:
+			tab.setSystemVersioning(0 /* historyTableID */, 0 /* rowStartOrd */)
+
 		case *tree.AlterTableAddConstraint:
 			switch d := t.ConstraintDef.(type) {
 			case *tree.ForeignKeyConstraintTableDef:
@@ -114,6 +122,27 @@ func injectTableStats(tt *Table, statsExpr tree.Expr, tc *Catalog) {
 	sort.Sort(tt.Stats)
 }
 
+// addSystemVersioning marks the table as system-versioned, using either the
+// given history table or the table named <table>_history.
+func (tc *Catalog) addSystemVersioning(tt *Table, cmd *tree.AlterTableAddSystemVersioning) {
+	var histName tree.TableName
+	if cmd.HistoryTable != nil {
+		histName = cmd.HistoryTable.ToTableName()
+	} else {
+		histName = tt.TabName
+		histName.ObjectName += tree.SystemVersioningHistoryTableSuffix
+	}
+	tc.qualifyTableName(&histName)
+	hist := tc.Table(&histName)
+	for i := range tt.Columns {
+		if tt.Columns[i].ColName() == tree.SystemVersioningRowStartColName {
+			tt.setSystemVersioning(hist.TabID, i)
+			return
+		}
+	}
+	panic(errors.AssertionFailedf("table %s has no %s column", tt.TabName.ObjectName, tree.SystemVersioningRowStartColName))
+}
+
 // toggleRLSMode will change the row-level security enabled field in the table.
 func toggleRLSMode(tt *Table, mode tree.TableRLSMode) {
 	switch mode {
@@ -157,6 +157,11 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 
 	}
 
+	// Add the hidden row start column of a system-versioned table.
+	if stmt.SystemVersioning {
+		stmt.Defs = append(stmt.Defs, tree.SystemVersioningRowStartColumnDef())
+	}
+
 	// Find the PK columns.
 	pkCols := make(map[tree.Name]struct{})
 	for _, def := range stmt.Defs {
@@ -444,9 +449,36 @@ OuterLoop:
 	// Add the new table to the catalog.
 	tc.AddTable(tab)
 
+	if stmt.SystemVersioning {
+		tc.createHistoryTable(tab)
+	}
+
 	return tab
 }
 
+// createHistoryTable creates the history table of the given system-versioned
+// table, which has the visible columns of the table followed by the row start
+// and row end columns, and marks the table as system-versioned.
+func (tc *Catalog) createHistoryTable(tab *Table) {
+	var defs tree.TableDefs
+	rowStartOrd := -1
+	for i := range tab.Columns {
+		col := &tab.Columns[i]
+		if col.ColName() == tree.SystemVersioningRowStartColName {
+			rowStartOrd = i
+		}
+		if col.Kind() != cat.Ordinary || col.Visibility() != cat.Visible {
+			continue
+		}
+		defs = append(defs, &tree.ColumnTableDef{Name: col.ColName(), Type: col.DatumType()})
+	}
+	defs = append(defs, tree.SystemVersioningHistoryColumnDefs()...)
+	name := tab.TabName
+	name.ObjectName += tree.SystemVersioningHistoryTableSuffix
+	hist := tc.CreateTable(&tree.CreateTable{Table: name, Defs: defs})
+	tab.setSystemVersioning(hist.TabID, rowStartOrd)
+}
+
 func (tc *Catalog) createVirtualTable(stmt *tree.CreateTable) *Table {
 	tab := &Table{
 		TabID:     tc.nextStableID(),
@@ -922,6 +922,12 @@ type Table struct {
 	rlsForced    bool
 	policies     cat.Policies
 	nextPolicyID descpb.PolicyID
+
+	// historyTableID is the ID of the history table if this is a
+	// system-versioned table, or zero otherwise. rowStartOrd is the ordinal of
+	// the row start column of a system-versioned table.
+	historyTableID cat.StableID
+	rowStartOrd    int
 }
 
 var _ cat.Table = &Table{}
@@ -1237,6 +1243,21 @@ func (tt *Table) Policies() *cat.Policies {
 	return &tt.policies
 }
 
+// SystemVersioning is part of the cat.Table interface.
+func (tt *Table) SystemVersioning() (historyTable cat.StableID, rowStartOrd int, ok bool) {
+	if tt.historyTableID == 0 {
+		return 0, 0, false
+	}
+	return tt.historyTableID, tt.rowStartOrd, true
+}
+
+// setSystemVersioning marks the table as system-versioned, with the given
+// history table and row start column.
+func (tt *Table) setSystemVersioning(historyTableID cat.StableID, rowStartOrd int) {
+	tt.historyTableID = historyTableID
+	tt.rowStartOrd = rowStartOrd
+}
+
 // findPolicyByName will lookup the policy by its name. It returns it's policy
 // type and index within that policy type slice so that callers can do removal
 // if needed.
@@ -1574,6 +1595,7 @@ type TableStat struct {
 	evalCtx       *eval.Context
 	histogram     []cat.HistogramBucket
 	histogramType *types.T
+	mcvs          []cat.MultiColumnValue
 	tc            *Catalog
 }
 
@@ -1688,6 +1710,55 @@ func (ts *TableStat) HistogramType() *types.T {
 	return ts.histogramType
 }
 
+// MostCommonValues is part of the cat.TableStatistic interface.
+func (ts *TableStat) MostCommonValues() []cat.MultiColumnValue {
+	if ts.mcvs != nil || len(ts.js.MostCommonValues) == 0 {
+		return ts.mcvs
+	}
+	evalCtx := ts.evalCtx
+	if evalCtx == nil {
+		evalCtxVal := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+		evalCtx = &evalCtxVal
+	}
+	colTypes := make([]*types.T, len(ts.js.ExtendedColumnTypes))
+	for i, typStr := range ts.js.ExtendedColumnTypes {
+		colTypeRef, err := parser.GetTypeFromValidSQLSyntax(typStr)
+		if err != nil {
+			panic(err)
+		}
+		colTypes[i], err = tree.ResolveType(context.Background(), colTypeRef, ts.tc)
+		if err != nil {
+			panic(err)
+		}
+	}
+	ts.mcvs = make([]cat.MultiColumnValue, len(ts.js.MostCommonValues))
+	for i := range ts.js.MostCommonValues {
+		mcv := &ts.js.MostCommonValues[i]
+		if len(mcv.Values) != len(colTypes) {
+			panic(errors.AssertionFailedf(
+				"expected %d most common values, found %d", len(colTypes), len(mcv.Values),
+			))
+		}
+		values := make(tree.Datums, len(mcv.Values))
+		for j := range mcv.Values {
+			datum, err := rowenc.ParseDatumStringAs(
+				context.Background(), colTypes[j], mcv.Values[j], evalCtx, nil, /* semaCtx */
+			)
+			if err != nil {
+				panic(err)
+			}
+			values[j] = datum
+		}
+		ts.mcvs[i] = cat.MultiColumnValue{NumEq: float64(mcv.NumEq), Values: values}
+	}
+	return ts.mcvs
+}
+
+// DependencyDegree is part of the cat.TableStatistic interface.
+func (ts *TableStat) DependencyDegree() float64 {
+	return ts.js.DependencyDegree
+}
+
 // IsPartial is part of the cat.TableStatistic interface.
 func (ts *TableStat) IsPartial() bool {
 	return ts.js.IsPartial()
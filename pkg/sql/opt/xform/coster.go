@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
@@ -656,6 +657,12 @@ func (c *coster) ComputeCost(candidate memo.RelExpr, required *physical.Required
 		}
 	}
 
+	// Add a penalty if the expression deviates from the plan baseline for the
+	// statement, so that a plan matching the baseline is chosen if possible.
+	if baseline := c.mem.PlanBaseline(); baseline != nil && baseline.Deviates(c.mem.Metadata(), candidate) {
+		cost.Penalties |= memo.PlanBaselinePenalty
+	}
+
 	if !cost.Less(memo.MaxCost) {
 		// Optsteps uses MaxCost to suppress nodes in the memo. When a node with
 		// MaxCost is added to the memo, it can lead to an obscure crash with an
@@ -837,6 +844,12 @@ func (c *coster) computeScanCost(scan *memo.ScanExpr, required *physical.Require
 		}
 	}
 
+	// A BERNOULLI sample reads every row of the table, even though it only
+	// returns a fraction of them.
+	if scan.Sample.Method == tree.TableSampleBernoulli && scan.Sample.Fraction > 0 {
+		rowCount /= scan.Sample.Fraction
+	}
+
 	// Add the IO cost of retrieving and the CPU cost of emitting the rows. The
 	// row cost depends on the size of the columns scanned.
 	perRowCost := c.rowScanCost(scan.Table, scan.Index, scan.Cols)
@@ -1634,6 +1634,19 @@ func (ot *optTable) Policies() *cat.Policies {
 	return &ot.policies
 }
 
+// SystemVersioning is part of the cat.Table interface.
+func (ot *optTable) SystemVersioning() (historyTable cat.StableID, rowStartOrd int, ok bool) {
+	sv := ot.desc.TableDesc().SystemVersioning
+	if sv == nil {
+		return 0, 0, false
+	}
+	ord, err := ot.LookupColumnOrdinal(sv.RowStartColumnID)
+	if err != nil {
+		return 0, 0, false
+	}
+	return cat.StableID(sv.HistoryTableID), ord, true
+}
+
 // LookupColumnOrdinal returns the ordinal of the column with the given ID. A
 // cache makes the lookup O(1).
 func (ot *optTable) LookupColumnOrdinal(colID descpb.ColumnID) (int, error) {
@@ -2091,6 +2104,27 @@ func (os *optTableStat) init(
 		}
 	}
 
+	// Verify that the column types of the extended statistics match the table
+	// column types.
+	if h := stat.HistogramData; h.HasExtendedStatistics() {
+		if len(h.ColumnTypes) != len(os.columnOrdinals) {
+			log.Dev.Warningf(ctx,
+				"skipping stat %d due to mismatched number of extended statistics column types",
+				stat.StatisticID,
+			)
+			return false, nil
+		}
+		for i, ord := range os.columnOrdinals {
+			if col := tab.getCol(ord); !h.ColumnTypes[i].Equivalent(col.GetType()) {
+				log.Dev.Warningf(ctx,
+					"skipping stat %d due to failed type check of extended statistics on column %s",
+					stat.StatisticID, col.GetName(),
+				)
+				return false, nil
+			}
+		}
+	}
+
 	return true, nil
 }
 
@@ -2153,6 +2187,19 @@ func (os *optTableStat) HistogramType() *types.T {
 	return os.stat.HistogramData.ColumnType
 }
 
+// MostCommonValues is part of the cat.TableStatistic interface.
+func (os *optTableStat) MostCommonValues() []cat.MultiColumnValue {
+	return os.stat.MostCommonValues
+}
+
+// DependencyDegree is part of the cat.TableStatistic interface.
+func (os *optTableStat) DependencyDegree() float64 {
+	if !os.stat.HistogramData.HasExtendedStatistics() {
+		return 0
+	}
+	return os.stat.HistogramData.DependencyDegree
+}
+
 // IsPartial is part of the cat.TableStatistic interface.
 func (os *optTableStat) IsPartial() bool {
 	return os.stat.IsPartial()
@@ -2776,6 +2823,9 @@ func (ot *optVirtualTable) IsRowLevelSecurityForced() bool { return false }
 // Policies is part of the cat.Table interface.
 func (ot *optVirtualTable) Policies() *cat.Policies { return nil }
 
+// SystemVersioning is part of the cat.Table interface.
+func (ot *optVirtualTable) SystemVersioning() (cat.StableID, int, bool) { return 0, 0, false }
+
 // optVirtualIndex is a dummy implementation of cat.Index for the indexes
 // reported by a virtual table. The index assumes that table column 0 is a dummy
 // PK column.
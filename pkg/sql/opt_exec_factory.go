@@ -11,6 +11,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"math/rand"
 	"net/url"
 	"strings"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
@@ -158,6 +160,7 @@ func (ef *execFactory) ConstructScan(
 	scan.lockingWaitPolicy = descpb.ToScanLockingWaitPolicy(params.Locking.WaitPolicy)
 	scan.lockingDurability = descpb.ToScanLockingDurability(params.Locking.Durability)
 	scan.localityOptimized = params.LocalityOptimized
+	scan.sample = makeTableSampleSpec(params.Sample)
 	if !ef.isExplain && !ef.planner.SessionData().Internal {
 		idxUsageKey := roachpb.IndexUsageKey{
 			TableID: roachpb.TableID(tabDesc.GetID()),
@@ -169,6 +172,27 @@ func (ef *execFactory) ConstructScan(
 	return scan, nil
 }
 
+// makeTableSampleSpec returns the TableSampleSpec for the given TABLESAMPLE
+// clause, or nil if the scan is not sampled. Unless the sample is REPEATABLE, a
+// new seed is chosen each time the plan is built.
+func makeTableSampleSpec(sample opt.TableSample) *execinfrapb.TableSampleSpec {
+	if !sample.IsSet() {
+		return nil
+	}
+	spec := &execinfrapb.TableSampleSpec{
+		Method:   execinfrapb.TableSampleSpec_BERNOULLI,
+		Fraction: sample.Fraction,
+		Seed:     sample.Seed,
+	}
+	if sample.Method == tree.TableSampleSystem {
+		spec.Method = execinfrapb.TableSampleSpec_SYSTEM
+	}
+	if !sample.Repeatable {
+		spec.Seed = rand.Uint64()
+	}
+	return spec
+}
+
 func generateScanSpans(
 	ctx context.Context,
 	evalCtx *eval.Context,
@@ -391,6 +415,8 @@ func (ef *execFactory) ConstructHashJoin(
 	leftEqColsAreKey, rightEqColsAreKey bool,
 	extraOnCond tree.TypedExpr,
 	estimatedLeftRowCount, estimatedRightRowCount uint64,
+	rightCardinalityCheckID int32,
+	partitionAligned bool,
 ) (exec.Node, error) {
 	p := ef.planner
 	leftPlan := left.(planNode)
@@ -413,7 +439,10 @@ func (ef *execFactory) ConstructHashJoin(
 	pred.leftEqKey = leftEqColsAreKey
 	pred.rightEqKey = rightEqColsAreKey
 
-	return p.makeJoinNode(leftPlan, rightPlan, pred, estimatedLeftRowCount, estimatedRightRowCount), nil
+	n := p.makeJoinNode(leftPlan, rightPlan, pred, estimatedLeftRowCount, estimatedRightRowCount)
+	n.rightCardinalityCheckID = rightCardinalityCheckID
+	n.partitionAligned = partitionAligned
+	return n, nil
 }
 
 // ConstructApplyJoin is part of the exec.Factory interface.
@@ -440,6 +469,7 @@ func (ef *execFactory) ConstructMergeJoin(
 	reqOrdering exec.OutputOrdering,
 	leftEqColsAreKey, rightEqColsAreKey bool,
 	estimatedLeftRowCount, estimatedRightRowCount uint64,
+	partitionAligned bool,
 ) (exec.Node, error) {
 	var err error
 	p := ef.planner
@@ -449,6 +479,7 @@ func (ef *execFactory) ConstructMergeJoin(
 	rightCols := planColumns(rightPlan)
 	pred := makePredicate(joinType, leftCols, rightCols, onCond)
 	node := p.makeJoinNode(leftPlan, rightPlan, pred, estimatedLeftRowCount, estimatedRightRowCount)
+	node.partitionAligned = partitionAligned
 	pred.leftEqKey = leftEqColsAreKey
 	pred.rightEqKey = rightEqColsAreKey
 
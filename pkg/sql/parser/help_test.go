@@ -184,6 +184,10 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE TENANT ??`, `CREATE VIRTUAL CLUSTER`},
 
 		{`CREATE LOGICAL REPLICATION STREAM ??`, `CREATE LOGICAL REPLICATION STREAM`},
+		{`CREATE LOGICAL REPLICATION MESH ??`, `CREATE LOGICAL REPLICATION STREAM`},
+
+		{`REPLAY ??`, `REPLAY DEAD LETTER QUEUE`},
+		{`REPLAY DEAD LETTER QUEUE FOR JOB 123 ??`, `REPLAY DEAD LETTER QUEUE`},
 
 		{`CREATE USER blih ??`, `CREATE ROLE`},
 		{`CREATE USER blih WITH ??`, `CREATE ROLE`},
@@ -207,6 +211,7 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE TABLE blah AS ??`, `CREATE TABLE`},
 		{`CREATE TABLE blah AS (SELECT 1) ??`, `CREATE TABLE`},
 		{`CREATE TABLE blah AS SELECT 1 ??`, `SELECT`},
+		{`CREATE TABLE blah CLONE OF foo ??`, `CREATE TABLE`},
 
 		{`CREATE TYPE blah AS ENUM ??`, `CREATE TYPE`},
 		{`DROP TYPE ??`, `DROP TYPE`},
@@ -591,6 +596,9 @@ func TestContextualHelp(t *testing.T) {
 		{`RESTORE foo FROM LATEST IN '/bar' ??`, `RESTORE`},
 		{`RESTORE DATABASE ??`, `RESTORE`},
 
+		{`VERIFY BACKUP ??`, `VERIFY BACKUP`},
+		{`VERIFY BACKUP FROM LATEST IN 'bar' ??`, `VERIFY BACKUP`},
+
 		{`IMPORT INTO ??`, `IMPORT`},
 
 		{`EXPORT ??`, `EXPORT`},
@@ -599,6 +607,7 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE SCHEDULE ??`, `CREATE SCHEDULE`},
 		{`CREATE SCHEDULE FOR BACKUP ??`, `CREATE SCHEDULE FOR BACKUP`},
 		{`CREATE SCHEDULE FOR CHANGEFEED ??`, `CREATE SCHEDULE FOR CHANGEFEED`},
+		{`CREATE SCHEDULE FOR VERIFY BACKUP ??`, `CREATE SCHEDULE FOR VERIFY BACKUP`},
 		{`ALTER BACKUP SCHEDULE ??`, `ALTER BACKUP SCHEDULE`},
 
 		{`CREATE CHANGEFEED FOR foo ??`, `CREATE CHANGEFEED`},
@@ -211,7 +211,7 @@ func (l *lexer) Lex(lval *sqlSymType) int {
 			}
 		}
 
-	case NOT, WITH, AS, GENERATED, NULLS, RESET, ROLE, USER, ON, TENANT, CLUSTER, SET, CREATE, FOR:
+	case NOT, WITH, AS, GENERATED, NULLS, RESET, ROLE, USER, ON, TENANT, CLUSTER, SET, CREATE, FOR, SYSTEM:
 		nextToken := sqlSymType{}
 		if l.lastPos+1 < len(l.tokens) {
 			nextToken = l.tokens[l.lastPos+1]
@@ -252,6 +252,11 @@ func (l *lexer) Lex(lval *sqlSymType) int {
 			switch nextToken.id {
 			case TIME, ORDINALITY, BUCKET_COUNT:
 				lval.id = WITH_LA
+			case SYSTEM:
+				switch secondToken.id {
+				case VERSIONING:
+					lval.id = WITH_LA
+				}
 			}
 		case NULLS:
 			switch nextToken.id {
@@ -326,6 +331,13 @@ func (l *lexer) Lex(lval *sqlSymType) int {
 				lval.id = FOR_TABLE
 			case JOB:
 				lval.id = FOR_JOB
+			case SYSTEM_TIME:
+				lval.id = FOR_SYSTEM_TIME
+			}
+		case SYSTEM:
+			switch nextToken.id {
+			case VERSIONING:
+				lval.id = SYSTEM_VERSIONING
 			}
 		}
 	}
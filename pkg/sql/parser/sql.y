@@ -334,6 +334,12 @@ func (u *sqlSymUnion) tableNames() tree.TableNames {
 func (u *sqlSymUnion) indexFlags() *tree.IndexFlags {
     return u.val.(*tree.IndexFlags)
 }
+func (u *sqlSymUnion) tableSample() *tree.TableSample {
+    return u.val.(*tree.TableSample)
+}
+func (u *sqlSymUnion) systemTimeClause() *tree.SystemTimeClause {
+    return u.val.(*tree.SystemTimeClause)
+}
 func (u *sqlSymUnion) arraySubscript() *tree.ArraySubscript {
     return u.val.(*tree.ArraySubscript)
 }
@@ -1000,7 +1006,7 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %token <str> BOOLEAN BOTH BOX2D BY BYPASSRLS
 
 %token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASE CAST CBRT CHANGEFEED CHAR
-%token <str> CHARACTER CHARACTERISTICS CHECK CHECK_FILES CLOSE
+%token <str> CHARACTER CHARACTERISTICS CHECK CHECK_FILES CLONE CLOSE
 %token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
 %token <str> CONFLICT CONNECTION CONNECTIONS CONSTRAINT CONSTRAINTS CONTAINS CONTROLCHANGEFEED CONTROLJOB
@@ -1009,7 +1015,7 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %token <str> CURRENT_ROLE CURRENT_TIME CURRENT_TIMESTAMP
 %token <str> CURRENT_USER CURSOR CYCLE
 
-%token <str> DATA DATABASE DATABASES DATE DAY DEBUG_IDS DEC DECIMAL DEFAULT DEFAULTS DEFINER
+%token <str> DATA DATABASE DATABASES DATE DAY DEAD DEBUG_IDS DEC DECIMAL DEFAULT DEFAULTS DEFINER
 %token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
 %token <str> DISABLE DISCARD DISTANCE DISTINCT DO DOMAIN DOUBLE DROP
 
@@ -1029,7 +1035,7 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %token <str> GEOMETRYCOLLECTION GEOMETRYCOLLECTIONM GEOMETRYCOLLECTIONZ GEOMETRYCOLLECTIONZM
 %token <str> GLOBAL GOAL GRANT GRANTEE GRANTS GREATEST GROUP GROUPING GROUPS
 
-%token <str> HAVING HASH HEADER HIGH HISTOGRAM HOLD HOUR
+%token <str> HAVING HASH HEADER HIGH HISTOGRAM HISTORY HOLD HOUR
 
 %token <str> IDENTITY
 %token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMEDIATELY IMMUTABLE IMPORT IN INCLUDE
@@ -1045,11 +1051,11 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %token <str> KEY KEYS KMS KV
 
 %token <str> LABEL LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
-%token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LEVEL LIKE LIMIT
+%token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LETTER LEVEL LIKE LIMIT
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
 %token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGGED LOGICAL LOGICALLY LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODE MONTH MOVE
+%token <str> MATCH MATERIALIZED MERGE MESH MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODE MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
@@ -1069,13 +1075,13 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %token <str> POSITION PRECEDING PRECISION PREPARE PREPARED PRESERVE PRIMARY PRIOR PRIORITY PRIVILEGES
 %token <str> PROCEDURAL PROCEDURE PROCEDURES PROVISIONSRC PUBLIC PUBLICATION
 
-%token <str> QUERIES QUERY QUOTE
+%token <str> QUERIES QUERY QUEUE QUOTE
 
 %token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFERENCING REFRESH
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
-%token <str> RELATIVE RELOCATE REMOVE_PATH REMOVE_REGIONS RENAME REPEATABLE REPLACE REPLICATED REPLICATION
+%token <str> RELATIVE RELOCATE REMOVE_PATH REMOVE_REGIONS RENAME REPEATABLE REPLACE REPLAY REPLICATED REPLICATION
 %token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESTRICTIVE RESUME RETENTION RETURNING RETURN RETURNS REVISION_HISTORY
-%token <str> REVOKE RIGHT ROLE ROLES ROLLBACK ROLLUP ROUTINES ROW ROWS RSHIFT RULE RUN RUNNING
+%token <str> REVOKE RIGHT ROLE ROLES ROLLBACK ROLLUP ROUTINES ROW ROW_FILTER ROWS RSHIFT RULE RUN RUNNING
 
 %token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMA_ONLY SCHEMAS SCRUB
 %token <str> SEARCH SECOND SECONDARY SECURITY SECURITY_INVOKER SELECT SEQUENCE SEQUENCES
@@ -1084,9 +1090,9 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SKIP_MISSING_UDFS SMALLINT SMALLSERIAL
 %token <str> SNAPSHOT SOME SOURCE SPLIT SQL SQLLOGIN
 %token <str> STABLE START STATE STATEMENT STATISTICS STATUS STDIN STDOUT STOP STRAIGHT STREAM STRICT STRING STORAGE STORE STORED STORING SUBJECT SUBSTRING SUPER
-%token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
+%token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SYSTEM_TIME SQRT SUBSCRIPTION STATEMENTS
 
-%token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
+%token <str> TABLE TABLES TABLESAMPLE TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
 %token <str> TIES TIME TIMETZ TIMESTAMP TIMESTAMPTZ TO THROTTLING TRAILING TRACE
 %token <str> TRANSACTION TRANSACTIONS TRANSFER TRANSFORM TREAT TRIGGER TRIGGERS TRIM TRUE
 %token <str> TRUNCATE TRUSTED TYPE TYPES
@@ -1095,7 +1101,7 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %token <str> UNBOUNDED UNCOMMITTED UNIDIRECTIONAL UNION UNIQUE UNKNOWN UNLISTEN UNLOGGED UNSAFE_RESTORE_INCOMPATIBLE_VERSION UNSPLIT
 %token <str> UPDATE UPDATES_CLUSTER_MONITORING_METRICS UPSERT UNSET UNTIL USE USER USERS USING UUID
 
-%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VECTOR VERIFY_BACKUP_TABLE_DATA VIEW VARIABLES VARYING VIEWACTIVITY VIEWACTIVITYREDACTED
+%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VECTOR VERIFY VERIFY_BACKUP_TABLE_DATA VERSIONING VIEW VARIABLES VARYING VIEWACTIVITY VIEWACTIVITYREDACTED
 %token <str> VIEWCLUSTERSETTING VIRTUAL VISIBLE INVISIBLE VISIBILITY VOLATILE VOTERS
 %token <str> VIRTUAL_CLUSTER_NAME VIRTUAL_CLUSTER
 
@@ -1124,9 +1130,13 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 // references.
 // - TENANT_ALL is used to differentiate `ALTER TENANT <id>` from
 // `ALTER TENANT ALL`. Ditto `CLUSTER_ALL` and `CLUSTER ALL`.
+// - FOR_SYSTEM_TIME differentiates the FOR SYSTEM_TIME clause of a table
+// reference from the FOR UPDATE/SHARE locking clauses.
+// - SYSTEM_VERSIONING differentiates `ADD SYSTEM VERSIONING` from adding a
+// column named `system`.
 %token NOT_LA NULLS_LA WITH_LA AS_LA GENERATED_ALWAYS GENERATED_BY_DEFAULT RESET_ALL ROLE_ALL
 %token USER_ALL ON_LA TENANT_ALL CLUSTER_ALL SET_TRACING CREATE_CHANGEFEED_FOR_DATABASE FOR_TABLE
-%token FOR_JOB
+%token FOR_JOB FOR_SYSTEM_TIME SYSTEM_VERSIONING
 
 %union {
   id    int32
@@ -1295,12 +1305,14 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %type <tree.Statement> create_index_stmt
 %type <tree.Statement> create_role_stmt
 %type <tree.Statement> create_schedule_for_backup_stmt
+%type <tree.Statement> create_schedule_for_verify_backup_stmt
 %type <tree.Statement> alter_backup_schedule
 %type <tree.Statement> create_schema_stmt
 %type <tree.Statement> create_table_stmt
 %type <tree.Statement> create_table_as_stmt
 %type <tree.Statement> create_virtual_cluster_stmt
 %type <tree.Statement> create_logical_replication_stream_stmt
+%type <tree.Statement> replay_dead_letter_queue_stmt
 %type <tree.Statement> create_view_stmt
 %type <tree.Statement> create_sequence_stmt
 %type <tree.Statement> create_func_stmt
@@ -1365,6 +1377,7 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %type <tree.Statement> resume_stmt resume_jobs_stmt resume_schedules_stmt resume_all_jobs_stmt
 %type <tree.Statement> drop_schedule_stmt
 %type <tree.Statement> restore_stmt
+%type <tree.Statement> verify_backup_stmt
 %type <tree.StringOrPlaceholderOptList> string_or_placeholder_opt_list
 %type <tree.Statement> revoke_stmt
 %type <tree.Statement> refresh_stmt
@@ -1545,6 +1558,7 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %type <str> family_name opt_family_name table_alias_name constraint_name target_name zone_name partition_name collation_name
 %type <str> db_object_name_component
 %type <*tree.UnresolvedObjectName> table_name db_name standalone_index_name sequence_name type_name
+%type <*tree.UnresolvedObjectName> opt_history_table
 %type <*tree.UnresolvedObjectName> view_name db_object_name simple_db_object_name complex_db_object_name
 %type <[]*tree.UnresolvedObjectName> type_name_list
 %type <str> schema_name opt_in_schema
@@ -1578,7 +1592,7 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %type <[]tree.RangePartition> range_partitions
 %type <empty> opt_all_clause
 %type <empty> opt_privileges_clause
-%type <bool> distinct_clause opt_with_data
+%type <bool> distinct_clause opt_with_data opt_materialized_view_with
 %type <tree.DistinctOn> distinct_on_clause
 %type <tree.NameList> opt_column_list insert_column_list opt_stats_columns query_stats_cols
 // Note that "no index" variants exist to disable custom ORDER BY <index> syntax
@@ -1666,6 +1680,9 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %type <*tree.IndexFlags> opt_index_flags
 %type <*tree.IndexFlags> index_flags_param
 %type <*tree.IndexFlags> index_flags_param_list
+%type <*tree.TableSample> opt_tablesample_clause
+%type <*tree.SystemTimeClause> opt_system_time_clause
+%type <tree.Expr> opt_repeatable_clause
 %type <tree.Expr> a_expr b_expr c_expr d_expr typed_literal
 %type <tree.Expr> substr_from substr_for
 %type <tree.Expr> in_expr
@@ -1681,7 +1698,7 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %type <treecmp.ComparisonOperator> sub_type
 %type <tree.Expr> numeric_only
 %type <tree.AliasClause> alias_clause opt_alias_clause func_alias_clause opt_func_alias_clause
-%type <bool> opt_ordinality opt_compact
+%type <bool> opt_ordinality opt_compact opt_system_versioning
 %type <*tree.Order> sortby sortby_index
 %type <tree.IndexElem> index_elem index_elem_options create_as_param
 %type <tree.TableExpr> table_ref numeric_table_ref func_table
@@ -2032,6 +2049,8 @@ alter_ddl_stmt:
 //   ALTER TABLE ... SET SCHEMA <newschemaname>
 //   ALTER TABLE ... SET LOCALITY [REGIONAL BY [TABLE IN <region> | ROW] | GLOBAL]
 //   ALTER TABLE ... {ENABLE | DISABLE | FORCE | NO FORCE} ROW LEVEL SECURITY
+//   ALTER TABLE ... ADD SYSTEM VERSIONING [USE HISTORY TABLE <tablename>]
+//   ALTER TABLE ... DROP SYSTEM VERSIONING
 //
 // Column qualifiers:
 //   [CONSTRAINT <constraintname>] {NULL | NOT NULL | UNIQUE | PRIMARY KEY | CHECK (<expr>) | DEFAULT <expr>}
@@ -3111,6 +3130,28 @@ alter_table_cmd:
       Mode: $1.rlsTableMode(),
     }
   }
+  // ALTER TABLE <name> ADD SYSTEM VERSIONING [USE HISTORY TABLE <name>]
+| ADD SYSTEM_VERSIONING VERSIONING opt_history_table
+  {
+    $$.val = &tree.AlterTableAddSystemVersioning{
+      HistoryTable: $4.unresolvedObjectName(),
+    }
+  }
+  // ALTER TABLE <name> DROP SYSTEM VERSIONING
+| DROP SYSTEM_VERSIONING VERSIONING
+  {
+    $$.val = &tree.AlterTableDropSystemVersioning{}
+  }
+
+opt_history_table:
+  USE HISTORY TABLE table_name
+  {
+    $$.val = $4.unresolvedObjectName()
+  }
+| /* EMPTY */
+  {
+    $$.val = (*tree.UnresolvedObjectName)(nil)
+  }
 
 audit_mode:
   READ WRITE { $$.val = tree.AuditModeReadWrite }
@@ -3696,6 +3737,42 @@ create_schedule_for_backup_stmt:
   }
  | CREATE SCHEDULE schedule_label_spec FOR BACKUP error // SHOW HELP: CREATE SCHEDULE FOR BACKUP
 
+// %Help: CREATE SCHEDULE FOR VERIFY BACKUP - verify backups periodically
+// %Category: CCL
+// %Text:
+// CREATE SCHEDULE [IF NOT EXISTS]
+// [<description>]
+// FOR VERIFY BACKUP IN <collection...>
+// [WITH <verify_backup_option>[=<value>] [, ...]]
+// RECURRING <crontab>
+// [WITH EXPERIMENTAL SCHEDULE OPTIONS <schedule_option>[= <value>] [, ...] ]
+//
+// Each run of the schedule verifies the latest backup in the collection.
+//
+// WITH <options>:
+//   Options specific to VERIFY BACKUP: See VERIFY BACKUP options
+//
+// SCHEDULE OPTIONS:
+//   first_run, on_execution_failure and on_previous_running: See CREATE
+//   SCHEDULE FOR BACKUP
+//
+// %SeeAlso: VERIFY BACKUP, CREATE SCHEDULE FOR BACKUP
+create_schedule_for_verify_backup_stmt:
+  CREATE SCHEDULE /*$3=*/schedule_label_spec FOR VERIFY BACKUP IN /*$8=*/string_or_placeholder_opt_list
+  /*$9=*/opt_with_options /*$10=*/cron_expr /*$11=*/opt_with_schedule_options
+  {
+    $$.val = &tree.ScheduledVerifyBackup{
+      VerifyBackup: &tree.VerifyBackup{
+        From:    $8.stringOrPlaceholderOptList(),
+        Options: $9.kvOptions(),
+      },
+      ScheduleLabelSpec: *($3.scheduleLabelSpec()),
+      Recurrence:        $10.expr(),
+      ScheduleOptions:   $11.kvOptions(),
+    }
+  }
+| CREATE SCHEDULE schedule_label_spec FOR VERIFY error // SHOW HELP: CREATE SCHEDULE FOR VERIFY BACKUP
+
 // %Help: ALTER BACKUP SCHEDULE - alter an existing backup schedule
 // %Category: CCL
 // %Text:
@@ -4012,6 +4089,7 @@ drop_external_connection_stmt:
 //    detached: execute restore job asynchronously, without waiting for its completion
 //    skip_localities_check: ignore difference of zone configuration between restore cluster and backup cluster
 //    new_db_name: renames the restored database. only applies to database restores
+//    row_filter: only restore the rows of the restored tables that match the predicate
 //    include_all_virtual_clusters: enable backups of all virtual clusters during a cluster backup
 // %SeeAlso: BACKUP, WEBDOCS/restore.html
 restore_stmt:
@@ -4062,6 +4140,33 @@ restore_stmt:
   }
 | RESTORE error // SHOW HELP: RESTORE
 
+// %Help: VERIFY BACKUP - verify that a backup can be restored
+// %Category: CCL
+// %Text:
+// VERIFY BACKUP FROM <subdirectory> IN <collection...>
+// [ WITH <option> [= <value>] [, ...] ]
+//
+// Restores a backup, or a sample of its tables, into scratch databases and
+// compares the fingerprints of the restored tables to the fingerprints
+// recorded when the backup was taken. The scratch databases are dropped
+// afterwards.
+//
+// Options:
+//    sample = <fraction>: verify only this fraction of the tables
+//    detached: execute as a detached job
+//
+// %SeeAlso: RESTORE, SHOW BACKUP
+verify_backup_stmt:
+  VERIFY BACKUP FROM string_or_placeholder IN string_or_placeholder_opt_list opt_with_options
+  {
+    $$.val = &tree.VerifyBackup{
+      Subdir:  $4.expr(),
+      From:    $6.stringOrPlaceholderOptList(),
+      Options: $7.kvOptions(),
+    }
+  }
+| VERIFY error // SHOW HELP: VERIFY BACKUP
+
 string_or_placeholder_opt_list:
   string_or_placeholder
   {
@@ -4186,6 +4291,10 @@ restore_options:
   {
     $$.val = &tree.RestoreOptions{RemoveRegions: true, SkipLocalitiesCheck: true}
   }
+| ROW_FILTER '=' string_or_placeholder
+  {
+    $$.val = &tree.RestoreOptions{RowFilter: $3.expr()}
+  }
 
 virtual_cluster_opt:
   TENANT  { /* SKIP DOC */ }
@@ -4235,13 +4344,16 @@ import_stmt:
 // %Category: CCL
 // %Text:
 // EXPORT INTO <format> <datafile> [WITH <option> [= value] [,...]] FROM <query>
+// EXPORT DATABASE <name> INTO SQL <directory> [WITH <option> [= value] [,...]] [AS OF SYSTEM TIME <expr>]
 //
 // Formats:
 //    CSV
 //    Parquet
+//    SQL
 //
 // Options:
-//    delimiter = '...'   [CSV-specific]
+//    delimiter = '...'   [CSV- and SQL-specific]
+//    table_name = '...'  [SQL-specific]
 //
 // %SeeAlso: SELECT
 export_stmt:
@@ -4249,6 +4361,10 @@ export_stmt:
   {
     $$.val = &tree.Export{Query: $7.slct(), FileFormat: $3, File: $4.expr(), Options: $5.kvOptions()}
   }
+| EXPORT DATABASE database_name INTO import_format string_or_placeholder opt_with_options opt_as_of_clause
+  {
+    $$.val = &tree.ExportDatabase{Database: tree.Name($3), FileFormat: $5, File: $6.expr(), Options: $7.kvOptions(), AsOf: $8.asOfClause()}
+  }
 | EXPORT error // SHOW HELP: EXPORT
 
 string_or_placeholder:
@@ -4780,6 +4896,11 @@ check_stmt:
 //  < FUNCTION 'udf' FOR TABLE local_name  , ... > |
 //  < DISCARD = 'ttl-deletes' >
 // ]
+//
+// CREATE LOGICAL REPLICATION MESH
+//  FROM <TABLE name | TABLES (name, ...)>
+//  ON ('peer_uri', ...)
+//  [WITH < MODE = immediate | validated > | < DISCARD = 'ttl-deletes' > | < LABEL = label >]
 create_logical_replication_stream_stmt:
   CREATE LOGICAL REPLICATION STREAM FROM logical_replication_resources ON string_or_placeholder INTO logical_replication_resources opt_logical_replication_options
   {
@@ -4801,7 +4922,45 @@ create_logical_replication_stream_stmt:
       Options: *$9.logicalReplicationOptions(),
     }
   }
+| CREATE LOGICAL REPLICATION MESH FROM logical_replication_resources ON '(' string_or_placeholder_list ')' opt_logical_replication_options
+  {
+    /* SKIP DOC */
+    $$.val = &tree.CreateLogicalReplicationStream{
+      From: $6.logicalReplicationResources(),
+      Into: $6.logicalReplicationResources(),
+      MeshURIs: $9.exprs(),
+      Options: *$11.logicalReplicationOptions(),
+    }
+  }
 | CREATE LOGICAL REPLICATION STREAM error // SHOW HELP: CREATE LOGICAL REPLICATION STREAM
+| CREATE LOGICAL REPLICATION MESH error // SHOW HELP: CREATE LOGICAL REPLICATION STREAM
+
+// %Help: REPLAY DEAD LETTER QUEUE - re-apply rows from the dead letter queues of a logical replication stream
+// %Category: Experimental
+// %Text:
+// REPLAY DEAD LETTER QUEUE FOR JOB <job_id>
+//  [WITH <option> [= <value>] [, ...]]
+//
+// Rows which are re-applied, or which lose to a newer local version of the
+// row, are removed from the dead letter queues.
+//
+// Options:
+//    table_name = <name>: only replay rows of this destination table
+//    error_class = <class>: only replay rows which stopped retrying because of
+//                           'size limit', 'age limit' or 'not retryable'
+//    start_time = <timestamp>: only replay rows added at or after this time
+//    end_time = <timestamp>: only replay rows added before this time
+//
+// %SeeAlso: CREATE LOGICAL REPLICATION STREAM
+replay_dead_letter_queue_stmt:
+  REPLAY DEAD LETTER QUEUE FOR JOB a_expr opt_with_options
+  {
+    $$.val = &tree.ReplayDeadLetterQueue{
+      JobID:   $7.expr(),
+      Options: $8.kvOptions(),
+    }
+  }
+| REPLAY error // SHOW HELP: REPLAY DEAD LETTER QUEUE
 
 logical_replication_resources:
   TABLE db_object_name
@@ -5068,10 +5227,12 @@ source_replication_options:
 // %Category: Group
 // %Text:
 // CREATE SCHEDULE FOR BACKUP,
-// CREATE SCHEDULE FOR CHANGEFEED
+// CREATE SCHEDULE FOR CHANGEFEED,
+// CREATE SCHEDULE FOR VERIFY BACKUP
 create_schedule_stmt:
   create_schedule_for_changefeed_stmt // EXTEND WITH HELP: CREATE SCHEDULE FOR CHANGEFEED
 | create_schedule_for_backup_stmt     // EXTEND WITH HELP: CREATE SCHEDULE FOR BACKUP
+| create_schedule_for_verify_backup_stmt // EXTEND WITH HELP: CREATE SCHEDULE FOR VERIFY BACKUP
 | CREATE SCHEDULE error               // SHOW HELP: CREATE SCHEDULE
 
 // %Help: CREATE EXTENSION - pseudo-statement for PostgreSQL compatibility
@@ -6937,6 +7098,7 @@ preparable_stmt:
 | insert_stmt    // EXTEND WITH HELP: INSERT
 | inspect_stmt   // EXTEND WITH HELP: INSPECT
 | pause_stmt     // help texts in sub-rule
+| replay_dead_letter_queue_stmt // EXTEND WITH HELP: REPLAY DEAD LETTER QUEUE
 | reset_stmt     // help texts in sub-rule
 | restore_stmt   // EXTEND WITH HELP: RESTORE
 | resume_stmt    // help texts in sub-rule
@@ -6951,6 +7113,7 @@ preparable_stmt:
 | truncate_stmt     // EXTEND WITH HELP: TRUNCATE
 | update_stmt       // EXTEND WITH HELP: UPDATE
 | upsert_stmt       // EXTEND WITH HELP: UPSERT
+| verify_backup_stmt // EXTEND WITH HELP: VERIFY BACKUP
 
 // These are statements that can be used as a data source using the special
 // syntax with brackets. These are a subset of preparable_stmt.
@@ -11002,8 +11165,9 @@ alter_schema_stmt:
 // %Help: CREATE TABLE - create a new table
 // %Category: DDL
 // %Text:
-// CREATE [[GLOBAL | LOCAL] {TEMPORARY | TEMP}] TABLE [IF NOT EXISTS] <tablename> ( <elements...> ) [<on_commit>]
+// CREATE [[GLOBAL | LOCAL] {TEMPORARY | TEMP}] TABLE [IF NOT EXISTS] <tablename> ( <elements...> ) [WITH SYSTEM VERSIONING] [<on_commit>]
 // CREATE [[GLOBAL | LOCAL] {TEMPORARY | TEMP}] TABLE [IF NOT EXISTS] <tablename> [( <colnames...> )] AS <source> [<on commit>]
+// CREATE TABLE <tablename> CLONE OF <tablename> [AS OF SYSTEM TIME <expr>]
 //
 // Table elements:
 //    <name> <type> [<qualifiers...>]
@@ -11032,7 +11196,7 @@ alter_schema_stmt:
 // WEBDOCS/create-table.html
 // WEBDOCS/create-table-as.html
 create_table_stmt:
-  CREATE opt_persistence_temp_table TABLE table_name '(' opt_table_elem_list ')' opt_create_table_inherits opt_partition_by_table opt_table_with opt_create_table_on_commit opt_locality
+  CREATE opt_persistence_temp_table TABLE table_name '(' opt_table_elem_list ')' opt_create_table_inherits opt_partition_by_table opt_table_with opt_system_versioning opt_create_table_on_commit opt_locality
   {
     name := $4.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateTable{
@@ -11043,11 +11207,12 @@ create_table_stmt:
       PartitionByTable: $9.partitionByTable(),
       Persistence: $2.persistence(),
       StorageParams: $10.storageParams(),
-      OnCommit: $11.createTableOnCommitSetting(),
-      Locality: $12.locality(),
+      SystemVersioning: $11.bool(),
+      OnCommit: $12.createTableOnCommitSetting(),
+      Locality: $13.locality(),
     }
   }
-| CREATE opt_persistence_temp_table TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' opt_create_table_inherits opt_partition_by_table opt_table_with opt_create_table_on_commit opt_locality
+| CREATE opt_persistence_temp_table TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' opt_create_table_inherits opt_partition_by_table opt_table_with opt_system_versioning opt_create_table_on_commit opt_locality
   {
     name := $7.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateTable{
@@ -11058,8 +11223,20 @@ create_table_stmt:
       PartitionByTable: $12.partitionByTable(),
       Persistence: $2.persistence(),
       StorageParams: $13.storageParams(),
-      OnCommit: $14.createTableOnCommitSetting(),
-      Locality: $15.locality(),
+      SystemVersioning: $14.bool(),
+      OnCommit: $15.createTableOnCommitSetting(),
+      Locality: $16.locality(),
+    }
+  }
+| CREATE opt_persistence_temp_table TABLE table_name CLONE OF table_name opt_as_of_clause
+  {
+    if $2.persistence().IsTemporary() {
+      return unimplemented(sqllex, "create temporary table clone")
+    }
+    $$.val = &tree.CloneTable{
+      Table: $4.unresolvedObjectName().ToTableName(),
+      Source: $7.unresolvedObjectName().ToTableName(),
+      AsOf: $8.asOfClause(),
     }
   }
 
@@ -11086,6 +11263,16 @@ opt_table_with:
     return unimplemented(sqllex, "create table with oids")
   }
 
+opt_system_versioning:
+  WITH_LA SYSTEM_VERSIONING VERSIONING
+  {
+    $$.val = true
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
 opt_create_table_inherits:
   /* EMPTY */
   {
@@ -12253,10 +12440,12 @@ role_or_group_or_user:
 // %Category: DDL
 // %Text:
 // CREATE [TEMPORARY | TEMP] VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] [WITH ( <option> [= <value>] [, ....] )] AS <source>
-// CREATE [TEMPORARY | TEMP] MATERIALIZED VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source> [WITH [NO] DATA]
+// CREATE [TEMPORARY | TEMP] MATERIALIZED VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] [WITH ( incremental )] AS <source> [WITH [NO] DATA]
 //
 // Options:
 //   security_invoker [= { true | false | 1 | 0 }]: controls view permissions (defaults to true if specified without value)
+//   incremental: keeps a materialized view up to date with a background job
+//                instead of REFRESH MATERIALIZED VIEW
 // %SeeAlso: CREATE TABLE, SHOW CREATE, WEBDOCS/create-view.html
 create_view_stmt:
   CREATE opt_temp opt_view_recursive VIEW view_name opt_column_list opt_view_with AS select_stmt
@@ -12300,31 +12489,43 @@ create_view_stmt:
       Replace: false,
     }
   }
-| CREATE MATERIALIZED VIEW view_name opt_column_list AS select_stmt opt_with_data
+| CREATE MATERIALIZED VIEW view_name opt_column_list opt_materialized_view_with AS select_stmt opt_with_data
   {
     name := $4.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
       Name: name,
       ColumnNames: $5.nameList(),
-      AsSource: $7.slct(),
+      AsSource: $8.slct(),
       Materialized: true,
-      WithData: $8.bool(),
+      WithData: $9.bool(),
+      Incremental: $6.bool(),
     }
   }
-| CREATE MATERIALIZED VIEW IF NOT EXISTS view_name opt_column_list AS select_stmt opt_with_data
+| CREATE MATERIALIZED VIEW IF NOT EXISTS view_name opt_column_list opt_materialized_view_with AS select_stmt opt_with_data
   {
     name := $7.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
       Name: name,
       ColumnNames: $8.nameList(),
-      AsSource: $10.slct(),
+      AsSource: $11.slct(),
       Materialized: true,
       IfNotExists: true,
-      WithData: $11.bool(),
+      WithData: $12.bool(),
+      Incremental: $9.bool(),
     }
   }
 | CREATE opt_temp opt_view_recursive VIEW error // SHOW HELP: CREATE VIEW
 
+opt_materialized_view_with:
+  /* EMPTY */
+  {
+    $$.val = false
+  }
+| WITH '(' INCREMENTAL ')'
+  {
+    $$.val = true
+  }
+
 opt_with_data:
   WITH NO DATA error
   {
@@ -15043,6 +15244,52 @@ opt_index_flags:
     $$.val = (*tree.IndexFlags)(nil)
   }
 
+opt_tablesample_clause:
+  TABLESAMPLE name '(' a_expr ')' opt_repeatable_clause
+  {
+    method, err := tree.TableSampleMethodFromString($2)
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = &tree.TableSample{Method: method, Percent: $4.expr(), Repeatable: $6.expr()}
+  }
+| /* EMPTY */
+  {
+    $$.val = (*tree.TableSample)(nil)
+  }
+
+opt_system_time_clause:
+  FOR_SYSTEM_TIME SYSTEM_TIME AS OF a_expr
+  {
+    $$.val = &tree.SystemTimeClause{Kind: tree.SystemTimeAsOf, From: $5.expr()}
+  }
+| FOR_SYSTEM_TIME SYSTEM_TIME BETWEEN b_expr AND a_expr
+  {
+    $$.val = &tree.SystemTimeClause{Kind: tree.SystemTimeBetween, From: $4.expr(), To: $6.expr()}
+  }
+| FOR_SYSTEM_TIME SYSTEM_TIME FROM b_expr TO a_expr
+  {
+    $$.val = &tree.SystemTimeClause{Kind: tree.SystemTimeFromTo, From: $4.expr(), To: $6.expr()}
+  }
+| FOR_SYSTEM_TIME SYSTEM_TIME ALL
+  {
+    $$.val = &tree.SystemTimeClause{Kind: tree.SystemTimeAll}
+  }
+| /* EMPTY */
+  {
+    $$.val = (*tree.SystemTimeClause)(nil)
+  }
+
+opt_repeatable_clause:
+  REPEATABLE '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
 // %Help: <SOURCE> - define a data source for SELECT
 // %Category: DML
 // %Text:
@@ -15056,6 +15303,7 @@ opt_index_flags:
 //   <source> NATURAL [ <jointype> ] JOIN <source>
 //   <source> CROSS JOIN <source>
 //   <source> WITH ORDINALITY
+//   <tablename> [AS <alias>] TABLESAMPLE { BERNOULLI | SYSTEM } ( <percent> ) [REPEATABLE ( <seed> )]
 //   '[' EXPLAIN ... ']'
 //   '[' SHOW ... ']'
 //
@@ -15073,24 +15321,27 @@ opt_index_flags:
 //
 // %SeeAlso: WEBDOCS/table-expressions.html
 table_ref:
-  numeric_table_ref opt_index_flags opt_ordinality opt_alias_clause
+  numeric_table_ref opt_index_flags opt_ordinality opt_alias_clause opt_tablesample_clause
   {
     /* SKIP DOC */
     $$.val = &tree.AliasedTableExpr{
-        Expr:       $1.tblExpr(),
-        IndexFlags: $2.indexFlags(),
-        Ordinality: $3.bool(),
-        As:         $4.aliasClause(),
+        Expr:        $1.tblExpr(),
+        IndexFlags:  $2.indexFlags(),
+        Ordinality:  $3.bool(),
+        As:          $4.aliasClause(),
+        TableSample: $5.tableSample(),
     }
   }
-| relation_expr opt_index_flags opt_ordinality opt_alias_clause
+| relation_expr opt_index_flags opt_system_time_clause opt_ordinality opt_alias_clause opt_tablesample_clause
   {
     name := $1.unresolvedObjectName().ToTableName()
     $$.val = &tree.AliasedTableExpr{
-      Expr:       &name,
-      IndexFlags: $2.indexFlags(),
-      Ordinality: $3.bool(),
-      As:         $4.aliasClause(),
+      Expr:        &name,
+      IndexFlags:  $2.indexFlags(),
+      SystemTime:  $3.systemTimeClause(),
+      Ordinality:  $4.bool(),
+      As:          $5.aliasClause(),
+      TableSample: $6.tableSample(),
     }
   }
 | select_with_parens opt_ordinality opt_alias_clause
@@ -18575,6 +18826,7 @@ unreserved_keyword:
 | CASCADE
 | CHANGEFEED
 | CHECK_FILES
+| CLONE
 | CLOSE
 | CLUSTER
 | CLUSTERS
@@ -18612,6 +18864,7 @@ unreserved_keyword:
 | DATABASE
 | DATABASES
 | DAY
+| DEAD
 | DEALLOCATE
 | DEBUG_IDS
 | DECLARE
@@ -18689,6 +18942,7 @@ unreserved_keyword:
 | HEADER
 | HIGH
 | HISTOGRAM
+| HISTORY
 | HOLD
 | HOUR
 | IDENTITY
@@ -18732,6 +18986,7 @@ unreserved_keyword:
 | LEAKPROOF
 | LEASE
 | LESS
+| LETTER
 | LEVEL
 | LINESTRING
 | LINESTRINGM
@@ -18751,6 +19006,7 @@ unreserved_keyword:
 | MATERIALIZED
 | MAXVALUE
 | MERGE
+| MESH
 | METHOD
 | MINUTE
 | MINVALUE
@@ -18853,6 +19109,7 @@ unreserved_keyword:
 | PUBLICATION
 | QUERIES
 | QUERY
+| QUEUE
 | QUOTE
 | RANGE
 | RANGES
@@ -18876,6 +19133,7 @@ unreserved_keyword:
 | RENAME
 | REPEATABLE
 | REPLACE
+| REPLAY
 | REPLICATED
 | REPLICATION
 | RESET
@@ -18895,6 +19153,7 @@ unreserved_keyword:
 | ROLLBACK
 | ROLLUP
 | ROUTINES
+| ROW_FILTER
 | ROWS
 | RULE
 | RUN
@@ -18967,6 +19226,7 @@ unreserved_keyword:
 | SURVIVAL
 | SYNTAX
 | SYSTEM
+| SYSTEM_TIME
 | TABLES
 | TABLESPACE
 | TEMP
@@ -19011,7 +19271,9 @@ unreserved_keyword:
 | VALUE
 | VARIABLES
 | VARYING
+| VERIFY
 | VERIFY_BACKUP_TABLE_DATA
+| VERSIONING
 | VIEW
 | VIEWACTIVITY
 | VIEWACTIVITYREDACTED
@@ -19092,6 +19354,7 @@ bare_label_keywords:
 | CHARACTERISTICS
 | CHECK
 | CHECK_FILES
+| CLONE
 | CLOSE
 | CLUSTER
 | CLUSTERS
@@ -19141,6 +19404,7 @@ bare_label_keywords:
 | DATA
 | DATABASE
 | DATABASES
+| DEAD
 | DEALLOCATE
 | DEBUG_IDS
 | DEC
@@ -19238,6 +19502,7 @@ bare_label_keywords:
 | HEADER
 | HIGH
 | HISTOGRAM
+| HISTORY
 | HOLD
 | IDENTITY
 | IF
@@ -19303,6 +19568,7 @@ bare_label_keywords:
 | LEAST
 | LEFT
 | LESS
+| LETTER
 | LEVEL
 | LIKE
 | LINESTRING
@@ -19325,6 +19591,7 @@ bare_label_keywords:
 | MATERIALIZED
 | MAXVALUE
 | MERGE
+| MESH
 | METHOD
 | MINVALUE
 | MODE
@@ -19439,6 +19706,7 @@ bare_label_keywords:
 | PUBLICATION
 | QUERIES
 | QUERY
+| QUEUE
 | QUOTE
 | RANGE
 | RANGES
@@ -19464,6 +19732,7 @@ bare_label_keywords:
 | RENAME
 | REPEATABLE
 | REPLACE
+| REPLAY
 | REPLICATED
 | REPLICATION
 | RESET
@@ -19485,6 +19754,7 @@ bare_label_keywords:
 | ROLLUP
 | ROUTINES
 | ROW
+| ROW_FILTER
 | ROWS
 | RULE
 | RUN
@@ -19565,8 +19835,10 @@ bare_label_keywords:
 | SYMMETRIC
 | SYNTAX
 | SYSTEM
+| SYSTEM_TIME
 | TABLE
 | TABLES
+| TABLESAMPLE
 | TABLESPACE
 | TEMP
 | TEMPLATE
@@ -19626,7 +19898,9 @@ bare_label_keywords:
 | VARIABLES
 | VARIADIC
 | VECTOR
+| VERIFY
 | VERIFY_BACKUP_TABLE_DATA
+| VERSIONING
 | VIEW
 | VIEWACTIVITY
 | VIEWACTIVITYREDACTED
@@ -19752,6 +20026,7 @@ type_func_name_no_crdb_extra_keyword:
 | OVERLAPS
 | RIGHT
 | SIMILAR
+| TABLESAMPLE
 
 // CockroachDB-specific keywords that can be used in type/function
 // identifiers.
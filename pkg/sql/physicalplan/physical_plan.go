@@ -246,13 +246,17 @@ func (p *PhysicalPlan) NewStage(containsRemoteProcessor bool, allowPartialDistri
 // NewStageOnNodes is the same as NewStage but takes in the information about
 // the nodes participating in the new stage and the gateway.
 func (p *PhysicalPlan) NewStageOnNodes(sqlInstanceIDs []base.SQLInstanceID) int32 {
-	// We have a remote processor either when we have multiple nodes
-	// participating in the stage or the single processor is scheduled not on
-	// the gateway.
-	return p.NewStage(
-		len(sqlInstanceIDs) > 1 || sqlInstanceIDs[0] != p.GatewaySQLInstanceID, /* containsRemoteProcessor */
-		false, /* allowPartialDistribution */
-	)
+	// We have a remote processor when any of the processors is scheduled not
+	// on the gateway. Note that sqlInstanceIDs can contain the gateway multiple
+	// times when a stage of a local plan has parallel processors.
+	containsRemoteProcessor := false
+	for _, id := range sqlInstanceIDs {
+		if id != p.GatewaySQLInstanceID {
+			containsRemoteProcessor = true
+			break
+		}
+	}
+	return p.NewStage(containsRemoteProcessor, false /* allowPartialDistribution */)
 }
 
 // SetMergeOrdering sets p.MergeOrdering.
@@ -1080,65 +1084,108 @@ func (p *PhysicalPlan) AddJoinStage(
 	resultTypes []*types.T,
 	finalizeLastStageCb func(*PhysicalPlan),
 ) {
-	pIdxStart := ProcessorIdx(len(p.Processors))
-	stageID := p.NewStageOnNodes(sqlInstanceIDs)
+	p.AddPartitionWiseJoinStage(
+		ctx,
+		[]JoinPartition{{
+			SQLInstanceIDs: sqlInstanceIDs,
+			LeftRouters:    leftRouters,
+			RightRouters:   rightRouters,
+		}},
+		core, post, leftEqCols, rightEqCols, leftTypes, rightTypes,
+		leftMergeOrd, rightMergeOrd, resultTypes, finalizeLastStageCb,
+	)
+}
 
-	for _, sqlInstanceID := range sqlInstanceIDs {
-		inputs := make([]execinfrapb.InputSyncSpec, 0, 2)
-		inputs = append(inputs, execinfrapb.InputSyncSpec{ColumnTypes: leftTypes})
-		inputs = append(inputs, execinfrapb.InputSyncSpec{ColumnTypes: rightTypes})
+// JoinPartition is a set of join processors of a partition-wise join along
+// with the left and right-side outputs that are wired to them. Rows are only
+// distributed among the join processors of the same partition, so the outputs
+// of each partition must contain all the rows that can match each other.
+type JoinPartition struct {
+	SQLInstanceIDs            []base.SQLInstanceID
+	LeftRouters, RightRouters []ProcessorIdx
+}
 
-		proc := Processor{
-			SQLInstanceID: sqlInstanceID,
-			Spec: execinfrapb.ProcessorSpec{
-				Input:       inputs,
-				Core:        core,
-				Post:        post,
-				Output:      []execinfrapb.OutputRouterSpec{{Type: execinfrapb.OutputRouterSpec_PASS_THROUGH}},
-				StageID:     stageID,
-				ResultTypes: resultTypes,
-			},
-		}
-		p.Processors = append(p.Processors, proc)
+// AddPartitionWiseJoinStage adds join processors for each of the given
+// partitions, and wires the left and right-side outputs of each partition to
+// the processors of the partition. The nodes of different partitions must be
+// disjoint.
+func (p *PhysicalPlan) AddPartitionWiseJoinStage(
+	ctx context.Context,
+	partitions []JoinPartition,
+	core execinfrapb.ProcessorCoreUnion,
+	post execinfrapb.PostProcessSpec,
+	leftEqCols, rightEqCols []uint32,
+	leftTypes, rightTypes []*types.T,
+	leftMergeOrd, rightMergeOrd execinfrapb.Ordering,
+	resultTypes []*types.T,
+	finalizeLastStageCb func(*PhysicalPlan),
+) {
+	var sqlInstanceIDs []base.SQLInstanceID
+	for _, part := range partitions {
+		sqlInstanceIDs = append(sqlInstanceIDs, part.SQLInstanceIDs...)
 	}
+	stageID := p.NewStageOnNodes(sqlInstanceIDs)
+	p.ResultRouters = p.ResultRouters[:0]
 
-	if len(sqlInstanceIDs) > 1 {
-		// Parallel hash or merge join: we distribute rows (by hash of
-		// equality columns) to len(nodes) join processors.
-
-		// Set up the left routers.
-		for _, resultProc := range leftRouters {
-			p.Processors[resultProc].Spec.Output[0] = execinfrapb.OutputRouterSpec{
-				Type:        execinfrapb.OutputRouterSpec_BY_HASH,
-				HashColumns: leftEqCols,
+	for _, part := range partitions {
+		pIdxStart := ProcessorIdx(len(p.Processors))
+		for _, sqlInstanceID := range part.SQLInstanceIDs {
+			inputs := make([]execinfrapb.InputSyncSpec, 0, 2)
+			inputs = append(inputs, execinfrapb.InputSyncSpec{ColumnTypes: leftTypes})
+			inputs = append(inputs, execinfrapb.InputSyncSpec{ColumnTypes: rightTypes})
+
+			proc := Processor{
+				SQLInstanceID: sqlInstanceID,
+				Spec: execinfrapb.ProcessorSpec{
+					Input:       inputs,
+					Core:        core,
+					Post:        post,
+					Output:      []execinfrapb.OutputRouterSpec{{Type: execinfrapb.OutputRouterSpec_PASS_THROUGH}},
+					StageID:     stageID,
+					ResultTypes: resultTypes,
+				},
 			}
+			p.Processors = append(p.Processors, proc)
 		}
-		// Set up the right routers.
-		for _, resultProc := range rightRouters {
-			p.Processors[resultProc].Spec.Output[0] = execinfrapb.OutputRouterSpec{
-				Type:        execinfrapb.OutputRouterSpec_BY_HASH,
-				HashColumns: rightEqCols,
+
+		if len(part.SQLInstanceIDs) > 1 {
+			// Parallel hash or merge join: we distribute rows (by hash of
+			// equality columns) to len(nodes) join processors.
+
+			// Set up the left routers.
+			for _, resultProc := range part.LeftRouters {
+				p.Processors[resultProc].Spec.Output[0] = execinfrapb.OutputRouterSpec{
+					Type:        execinfrapb.OutputRouterSpec_BY_HASH,
+					HashColumns: leftEqCols,
+				}
+			}
+			// Set up the right routers.
+			for _, resultProc := range part.RightRouters {
+				p.Processors[resultProc].Spec.Output[0] = execinfrapb.OutputRouterSpec{
+					Type:        execinfrapb.OutputRouterSpec_BY_HASH,
+					HashColumns: rightEqCols,
+				}
 			}
 		}
-	}
-	p.ResultRouters = p.ResultRouters[:0]
 
-	// Connect the left and right routers to the output joiners. Each joiner
-	// corresponds to a hash bucket.
-	for bucket := 0; bucket < len(sqlInstanceIDs); bucket++ {
-		pIdx := pIdxStart + ProcessorIdx(bucket)
+		// Connect the left and right routers to the output joiners. Each joiner
+		// corresponds to a hash bucket.
+		for bucket := 0; bucket < len(part.SQLInstanceIDs); bucket++ {
+			pIdx := pIdxStart + ProcessorIdx(bucket)
 
-		// Connect left routers to the processor's first input. Currently the join
-		// node doesn't care about the orderings of the left and right results.
-		p.MergeResultStreams(ctx, leftRouters, bucket, leftMergeOrd, pIdx, 0, false, /* forceSerialization */
-			SerialStreamErrorSpec{},
-		)
-		// Connect right routers to the processor's second input if it has one.
-		p.MergeResultStreams(ctx, rightRouters, bucket, rightMergeOrd, pIdx, 1, false, /* forceSerialization */
-			SerialStreamErrorSpec{},
-		)
+			// Connect left routers to the processor's first input. Currently the
+			// join node doesn't care about the orderings of the left and right
+			// results.
+			p.MergeResultStreams(ctx, part.LeftRouters, bucket, leftMergeOrd, pIdx, 0, false, /* forceSerialization */
+				SerialStreamErrorSpec{},
+			)
+			// Connect right routers to the processor's second input if it has one.
+			p.MergeResultStreams(ctx, part.RightRouters, bucket, rightMergeOrd, pIdx, 1, false, /* forceSerialization */
+				SerialStreamErrorSpec{},
+			)
 
-		p.ResultRouters = append(p.ResultRouters, pIdx)
+			p.ResultRouters = append(p.ResultRouters, pIdx)
+		}
 	}
 	if finalizeLastStageCb != nil {
 		finalizeLastStageCb(p)
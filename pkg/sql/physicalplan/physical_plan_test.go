@@ -11,6 +11,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -282,3 +283,60 @@ func TestProjectionAndRendering(t *testing.T) {
 		}
 	}
 }
+
+func TestAddPartitionWiseJoinStage(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// The left inputs are processors 0-2 and the right inputs are processors
+	// 3-5, on nodes 1-3.
+	p := PhysicalPlan{PhysicalInfrastructure: &PhysicalInfrastructure{GatewaySQLInstanceID: 1}}
+	for i := 0; i < 6; i++ {
+		p.Processors = append(p.Processors, Processor{
+			SQLInstanceID: base.SQLInstanceID(i%3 + 1),
+			Spec: execinfrapb.ProcessorSpec{
+				Output: []execinfrapb.OutputRouterSpec{{Type: execinfrapb.OutputRouterSpec_PASS_THROUGH}},
+			},
+		})
+	}
+	p.AddPartitionWiseJoinStage(
+		context.Background(),
+		[]JoinPartition{
+			{SQLInstanceIDs: []base.SQLInstanceID{1, 2}, LeftRouters: []ProcessorIdx{0, 1}, RightRouters: []ProcessorIdx{3, 4}},
+			{SQLInstanceIDs: []base.SQLInstanceID{3}, LeftRouters: []ProcessorIdx{2}, RightRouters: []ProcessorIdx{5}},
+		},
+		execinfrapb.ProcessorCoreUnion{HashJoiner: &execinfrapb.HashJoinerSpec{}},
+		execinfrapb.PostProcessSpec{},
+		[]uint32{0}, []uint32{0},
+		nil /* leftTypes */, nil, /* rightTypes */
+		execinfrapb.Ordering{}, execinfrapb.Ordering{},
+		nil /* resultTypes */, nil, /* finalizeLastStageCb */
+	)
+
+	require.Equal(t, []ProcessorIdx{6, 7, 8}, p.ResultRouters)
+	for i, sqlInstanceID := range []base.SQLInstanceID{1, 2, 3} {
+		require.Equal(t, sqlInstanceID, p.Processors[6+i].SQLInstanceID)
+	}
+
+	// Rows are distributed by hash within the first partition, and passed
+	// through within the second one.
+	for i, expected := range []execinfrapb.OutputRouterSpec_Type{
+		execinfrapb.OutputRouterSpec_BY_HASH,
+		execinfrapb.OutputRouterSpec_BY_HASH,
+		execinfrapb.OutputRouterSpec_PASS_THROUGH,
+		execinfrapb.OutputRouterSpec_BY_HASH,
+		execinfrapb.OutputRouterSpec_BY_HASH,
+		execinfrapb.OutputRouterSpec_PASS_THROUGH,
+	} {
+		require.Equal(t, expected, p.Processors[i].Spec.Output[0].Type)
+	}
+
+	// Each joiner only receives rows from the inputs of its partition.
+	sources := make(map[ProcessorIdx][]ProcessorIdx)
+	for _, s := range p.Streams {
+		sources[s.DestProcessor] = append(sources[s.DestProcessor], s.SourceProcessor)
+	}
+	require.Equal(t, []ProcessorIdx{0, 1, 3, 4}, sources[6])
+	require.Equal(t, []ProcessorIdx{0, 1, 3, 4}, sources[7])
+	require.Equal(t, []ProcessorIdx{2, 5}, sources[8])
+}
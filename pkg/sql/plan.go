@@ -338,6 +338,11 @@ type planTop struct {
 	// diagrams, are saved here.
 	distSQLFlowInfos []flowInfo
 
+	// cardinalityChecks contains the estimates for the cardinality checks in
+	// the plan, indexed by check ID minus one. It is used to re-optimize the
+	// statement when a check fails.
+	cardinalityChecks []memo.CardinalityObservation
+
 	instrumentation *instrumentationHelper
 }
 
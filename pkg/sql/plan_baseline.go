@@ -0,0 +1,134 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/hintpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/hints"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/explain"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// planBaseline returns the plan baseline for the statement and its hint ID, if
+// the statement has one. If there are multiple plan baselines for the
+// statement fingerprint, the oldest one is used.
+func (s *Statement) planBaseline() (hintID int64, _ *hintpb.PlanBaseline) {
+	for i := range s.Hints {
+		if baseline := s.Hints[i].PlanBaseline; baseline != nil {
+			return s.HintIDs[i], baseline
+		}
+	}
+	return 0, nil
+}
+
+// canEvolvePlanBaseline returns true if executions of the statement can be used
+// to evolve its plan baseline. This is not the case for statements that wrap
+// the statement with the baseline, like EXPLAIN, since their latencies are not
+// representative of the wrapped statement.
+func (s *Statement) canEvolvePlanBaseline() bool {
+	switch s.AST.(type) {
+	case *tree.CopyTo, *tree.Explain, *tree.ExplainAnalyze, *tree.Prepare:
+		return false
+	}
+	return true
+}
+
+// initPlanBaseline looks up the plan baseline for the current statement. If
+// allowExploration is true, it also decides whether the statement should be
+// planned without the baseline in order to sample an alternative plan for
+// baseline evolution. It must be called once before the statement is planned.
+func (p *planner) initPlanBaseline(allowExploration bool) {
+	p.planBaselineHintID, p.planBaseline = p.stmt.planBaseline()
+	p.exploringPlanBaseline = false
+	if allowExploration && p.planBaseline != nil && p.stmt.canEvolvePlanBaseline() {
+		if evolver := p.execCfg.PlanBaselineEvolver; evolver != nil {
+			p.exploringPlanBaseline = evolver.ShouldExplore(p.planBaseline)
+		}
+	}
+}
+
+// setPlanBaseline directs the optimizer to prefer plans that match the plan
+// baseline for the current statement, if there is one. If the statement is
+// being planned without its baseline in order to sample an alternative plan,
+// the resulting memo must not be reused for other executions.
+func (opc *optPlanningCtx) setPlanBaseline(ctx context.Context) {
+	p := opc.p
+	if p.planBaseline == nil {
+		return
+	}
+	if p.exploringPlanBaseline {
+		opc.log(ctx, "planning without plan baseline to sample an alternative plan")
+		opc.allowMemoReuse = false
+		opc.useCache = false
+		return
+	}
+	baseline, err := explain.DecodePlanGistToBaseline(p.planBaseline.PlanGist, opc.catalog)
+	if err != nil {
+		// Do not return the error. Instead we'll simply plan the query without
+		// the baseline.
+		log.VEventf(
+			ctx, 1, "failed to decode plan gist of plan baseline %d: %v", p.planBaselineHintID, err,
+		)
+		return
+	}
+	opc.optimizer.Memo().SetPlanBaseline(baseline)
+}
+
+// recordPlanBaselineExecution records the latency of the current statement for
+// the evolution of its plan baseline, if it has one.
+func (p *planner) recordPlanBaselineExecution(
+	ctx context.Context, stmtErr error, latency time.Duration,
+) {
+	if p.planBaseline == nil || stmtErr != nil || !p.stmt.canEvolvePlanBaseline() {
+		return
+	}
+	evolver := p.execCfg.PlanBaselineEvolver
+	if evolver == nil {
+		return
+	}
+	evolver.RecordExecution(
+		ctx, p.planBaselineHintID, p.planBaseline, p.instrumentation.planGist.String(), latency,
+	)
+}
+
+// planBaselineStatus describes how the plan baseline for the current statement
+// was used, given the plan gist of the executed plan. It returns the empty
+// string if the statement has no plan baseline.
+func (p *planner) planBaselineStatus(gist string) string {
+	switch {
+	case p.planBaseline == nil:
+		return ""
+	case p.exploringPlanBaseline:
+		return "exploring"
+	case gist == p.planBaseline.PlanGist:
+		return "matched"
+	default:
+		return "not matched"
+	}
+}
+
+// CreatePlanBaseline is part of the eval.Planner interface.
+func (p *planner) CreatePlanBaseline(
+	ctx context.Context, statementFingerprint string, planGist string, fixed bool,
+) (int64, error) {
+	var hint hintpb.StatementHintUnion
+	hint.SetValue(&hintpb.PlanBaseline{PlanGist: planGist, Fixed: fixed})
+	return hints.InsertHintIntoDB(ctx, p.InternalSQLTxn(), statementFingerprint, hint)
+}
+
+// SetPlanBaselineFixed is part of the eval.Planner interface.
+func (p *planner) SetPlanBaselineFixed(ctx context.Context, hintID int64, fixed bool) (bool, error) {
+	return hints.SetPlanBaselineFixedInDB(ctx, p.InternalSQLTxn(), hintID, fixed)
+}
+
+// DropPlanBaseline is part of the eval.Planner interface.
+func (p *planner) DropPlanBaseline(ctx context.Context, hintID int64) (bool, error) {
+	return hints.DeletePlanBaselineFromDB(ctx, p.InternalSQLTxn(), hintID)
+}
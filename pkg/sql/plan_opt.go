@@ -56,6 +56,8 @@ var queryCacheEnabled = settings.RegisterBoolSetting(
 func (p *planner) prepareUsingOptimizer(
 	ctx context.Context, origin prep.StatementOrigin,
 ) (planFlags, error) {
+	p.initPlanBaseline(false /* allowExploration */)
+
 	opc := &p.optPlanningCtx
 
 	// If there are externally-injected hints, first try preparing with the
@@ -285,6 +287,7 @@ func (p *planner) makeOptimizerPlan(ctx context.Context) error {
 	ctx, sp := tracing.ChildSpan(ctx, "optimizer")
 	defer sp.Finish()
 	p.curPlan.init(&p.stmt, &p.instrumentation)
+	p.initPlanBaseline(true /* allowExploration */)
 
 	opc := &p.optPlanningCtx
 
@@ -500,6 +503,17 @@ func (opc *optPlanningCtx) reset(ctx context.Context) {
 		opc.allowMemoReuse = false
 		opc.useCache = false
 	}
+
+	// If the statement is being re-optimized after a cardinality misestimate,
+	// the memo is built with the observed row counts, so it must not be reused
+	// for other executions of the statement.
+	if !p.cardinalityFeedback.Empty() {
+		opc.optimizer.Memo().SetCardinalityFeedback(p.cardinalityFeedback)
+		opc.allowMemoReuse = false
+		opc.useCache = false
+	}
+
+	opc.setPlanBaseline(ctx)
 }
 
 func (opc *optPlanningCtx) log(ctx context.Context, msg string) {
@@ -1016,6 +1030,9 @@ func (opc *optPlanningCtx) runExecBuilder(
 		if disableTelemetryAndPlanGists {
 			bld.DisableTelemetry()
 		}
+		if opc.allowCardinalityChecks(mem) {
+			bld.EnableCardinalityChecks()
+		}
 		plan, err := bld.Build()
 		if err != nil {
 			return err
@@ -1031,6 +1048,9 @@ func (opc *optPlanningCtx) runExecBuilder(
 		if disableTelemetryAndPlanGists {
 			bld.DisableTelemetry()
 		}
+		if opc.allowCardinalityChecks(mem) {
+			bld.EnableCardinalityChecks()
+		}
 		plan, err := bld.Build()
 		if err != nil {
 			return err
@@ -1048,6 +1068,7 @@ func (opc *optPlanningCtx) runExecBuilder(
 	planTop.instrumentation.joinAlgorithmCounts = bld.JoinAlgorithmCounts
 	planTop.instrumentation.scanCounts = bld.ScanCounts
 	planTop.instrumentation.indexesUsed = bld.IndexesUsed
+	planTop.cardinalityChecks = bld.CardinalityChecks
 
 	if opc.gf.Initialized() {
 		planTop.instrumentation.planGist = opc.gf.PlanGist()
@@ -1086,6 +1107,18 @@ func (opc *optPlanningCtx) runExecBuilder(
 	return nil
 }
 
+// allowCardinalityChecks returns true if cardinality checks can be added to
+// the plan for the given memo, allowing the statement to be re-optimized if the
+// checks fail. This is only the case if the statement can be safely executed
+// again, i.e., if it does not perform mutations or call volatile functions.
+func (opc *optPlanningCtx) allowCardinalityChecks(mem *memo.Memo) bool {
+	if !opc.p.allowCardinalityChecks {
+		return false
+	}
+	rel := mem.RootExpr().Relational()
+	return !rel.CanMutate && !rel.VolatilitySet.HasVolatile()
+}
+
 // DecodeGist Avoid an import cycle by keeping the cat out of the tree. If
 // external is true gist is from a foreign database and we use nil catalog.
 func (p *planner) DecodeGist(ctx context.Context, gist string, external bool) ([]string, error) {
@@ -33,10 +33,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schematelemetry/schematelemetrycontroller"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
 	"github.com/cockroachdb/cockroach/pkg/sql/evalcatalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/hintpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/hints"
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/prep"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -320,6 +322,30 @@ type planner struct {
 	// but for statement retries.
 	autoRetryStmtCounter int
 
+	// allowCardinalityChecks is true if the plan for the current statement can
+	// include cardinality checks, allowing the statement to be re-optimized
+	// when the optimizer's row count estimates turn out to be severely wrong.
+	allowCardinalityChecks bool
+
+	// cardinalityFeedback contains the row counts observed during previous
+	// executions of the current statement that failed cardinality checks. It is
+	// used by the optimizer when re-optimizing the statement.
+	cardinalityFeedback memo.CardinalityFeedback
+
+	// reoptimizations records the failed cardinality checks that caused the
+	// current statement to be re-optimized.
+	reoptimizations []*execinfrapb.CardinalityMisestimateError
+
+	// planBaseline is the plan baseline for the current statement, if it has
+	// one, and planBaselineHintID is its hint ID.
+	planBaseline       *hintpb.PlanBaseline
+	planBaselineHintID int64
+
+	// exploringPlanBaseline is true if the current statement is planned without
+	// its plan baseline in order to sample an alternative plan for baseline
+	// evolution.
+	exploringPlanBaseline bool
+
 	// skipUnsafeInternalsCheck is used to skip the check that the
 	// planner is not used for unsafe internal statements.
 	skipUnsafeInternalsCheck bool
@@ -1008,6 +1034,12 @@ func (p *planner) resetPlanner(
 	p.autoRetryCounter = 0
 	p.autoRetryStmtReason = nil
 	p.autoRetryStmtCounter = 0
+	p.allowCardinalityChecks = false
+	p.cardinalityFeedback = memo.CardinalityFeedback{}
+	p.reoptimizations = nil
+	p.planBaseline = nil
+	p.planBaselineHintID = 0
+	p.exploringPlanBaseline = false
 
 	p.usingHintInjection = false
 }
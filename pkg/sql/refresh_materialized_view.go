@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
 )
 
 type refreshMaterializedViewNode struct {
@@ -33,6 +34,13 @@ func (p *planner) RefreshMaterializedView(
 	if !desc.MaterializedView() {
 		return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a materialized view", desc.Name)
 	}
+	if jobID := desc.IncrementalRefreshJobID; jobID != 0 {
+		return nil, errors.WithHintf(
+			pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"materialized view %q is maintained incrementally and cannot be refreshed", desc.Name),
+			"the view is kept up to date by job %d", jobID,
+		)
+	}
 
 	hasOwnership, err := p.HasOwnership(ctx, desc)
 	if err != nil {
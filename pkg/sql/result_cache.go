@@ -0,0 +1,200 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/memsize"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// resultCacheEnabled returns true if the query result cache is enabled for the
+// current statement, either by the result_cache_enabled session setting or by
+// a statement hint.
+func (ex *connExecutor) resultCacheEnabled(stmt *Statement) bool {
+	if ex.sessionData().ResultCacheEnabled {
+		return true
+	}
+	for i := range stmt.Hints {
+		if stmt.Hints[i].ResultCache != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// makeResultCacheKey returns the key of the cached result of the current
+// statement and the IDs of the tables it reads, if the result of the statement
+// can be cached. Only the results of read-only statements in implicit
+// transactions that read tables and evaluate only immutable expressions can be
+// cached. Explicit transactions are excluded because the cache cannot observe
+// the transaction's own writes, nor record the cached reads for transaction
+// refreshes.
+func (ex *connExecutor) makeResultCacheKey(
+	planner *planner,
+) (key string, tables []descpb.ID, ok bool) {
+	stmt := planner.stmt
+	if ex.server.cfg.ResultCache == nil || ex.executorType == executorTypeInternal ||
+		!planner.extendedEvalCtx.TxnImplicit || getPausablePortalInfo(planner) != nil ||
+		!ex.resultCacheEnabled(&stmt) {
+		return "", nil, false
+	}
+	if _, isSelect := stmt.AST.(*tree.Select); !isSelect {
+		return "", nil, false
+	}
+	flags := planner.curPlan.flags
+	if flags.IsSet(planFlagContainsMutation) || flags.IsSet(planFlagContainsLocking) {
+		return "", nil, false
+	}
+	mem := planner.curPlan.mem
+	if mem == nil {
+		return "", nil, false
+	}
+	root, isRel := mem.RootExpr().(memo.RelExpr)
+	if !isRel {
+		return "", nil, false
+	}
+	if vs := root.Relational().VolatilitySet; vs.HasStable() || vs.HasVolatile() {
+		return "", nil, false
+	}
+	md := mem.Metadata()
+	if md.HasUserDefinedRoutines() || len(md.AllSequences()) > 0 {
+		return "", nil, false
+	}
+
+	// Collect the versions of all the descriptors that the result depends on.
+	var descs []resultcache.DescriptorVersion
+	for _, tab := range md.AllTables() {
+		if tab.Table.IsVirtualTable() {
+			return "", nil, false
+		}
+		id := descpb.ID(tab.Table.ID())
+		tables = append(tables, id)
+		descs = append(descs, resultcache.DescriptorVersion{
+			ID: id, Version: descpb.DescriptorVersion(tab.Table.Version()),
+		})
+	}
+	if len(tables) == 0 {
+		return "", nil, false
+	}
+	for _, v := range md.AllViews() {
+		descs = append(descs, resultcache.DescriptorVersion{
+			ID: descpb.ID(v.ID()), Version: descpb.DescriptorVersion(v.Version()),
+		})
+	}
+	for _, typ := range md.AllUserDefinedTypes() {
+		descs = append(descs, resultcache.DescriptorVersion{
+			ID:      catid.UserDefinedOIDToID(typ.Oid()),
+			Version: descpb.DescriptorVersion(typ.TypeMeta.Version),
+		})
+	}
+
+	var placeholders strings.Builder
+	for i, v := range planner.EvalContext().Placeholders.Values {
+		if i > 0 {
+			placeholders.WriteByte(0)
+		}
+		placeholders.WriteString(tree.AsStringWithFlags(v, tree.FmtSerializable))
+	}
+	key = resultcache.MakeKey(planner.User(), stmt.SQL, placeholders.String(), descs)
+	return key, tables, true
+}
+
+// execWithResultCache executes the current statement using the given exec
+// function. If the result of the statement can be cached, the statement is
+// served from the query result cache instead when there is a valid cached
+// result, and otherwise its result is added to the cache.
+func (ex *connExecutor) execWithResultCache(
+	ctx context.Context,
+	planner *planner,
+	res RestrictedCommandResult,
+	exec func(res RestrictedCommandResult) (topLevelQueryStats, error),
+) (topLevelQueryStats, error) {
+	key, tables, ok := ex.makeResultCacheKey(planner)
+	if !ok {
+		return exec(res)
+	}
+	cache := ex.server.cfg.ResultCache
+	readTS := planner.Txn().ReadTimestamp()
+	if rows, ok := cache.Get(key, readTS); ok {
+		for _, row := range rows {
+			if err := res.AddRow(ctx, row); err != nil {
+				return topLevelQueryStats{}, err
+			}
+		}
+		return topLevelQueryStats{}, nil
+	}
+
+	capture := &resultCacheWriter{
+		RestrictedCommandResult: res,
+		maxSize:                 resultcache.MaxEntrySize.Get(&ex.server.cfg.Settings.SV),
+	}
+	stats, err := exec(capture)
+	if err == nil && res.Err() == nil && !capture.overflow &&
+		readTS == planner.Txn().ReadTimestamp() {
+		cache.Add(ctx, key, readTS, tables, capture.rows, capture.size)
+	}
+	return stats, err
+}
+
+// resultCacheWriter is a RestrictedCommandResult that captures the rows of a
+// statement's result so that they can be added to the query result cache.
+type resultCacheWriter struct {
+	RestrictedCommandResult
+
+	rows    []tree.Datums
+	size    int64
+	maxSize int64
+
+	// overflow is set once the result is too large to be cached.
+	overflow bool
+}
+
+var _ RestrictedCommandResult = &resultCacheWriter{}
+
+// AddRow is part of the RestrictedCommandResult interface.
+func (w *resultCacheWriter) AddRow(ctx context.Context, row tree.Datums) error {
+	if !w.overflow {
+		w.size += memsize.DatumsOverhead + int64(len(row))*memsize.DatumOverhead
+		for _, d := range row {
+			w.size += int64(d.Size())
+		}
+		if w.size > w.maxSize {
+			w.overflow = true
+			w.rows = nil
+		} else {
+			w.rows = append(w.rows, append(tree.Datums(nil), row...))
+		}
+	}
+	return w.RestrictedCommandResult.AddRow(ctx, row)
+}
+
+// AddBatch is part of the RestrictedCommandResult interface.
+func (w *resultCacheWriter) AddBatch(context.Context, coldata.Batch) error {
+	return errors.AssertionFailedf("AddBatch is not supported by resultCacheWriter")
+}
+
+// SupportsAddBatch is part of the RestrictedCommandResult interface. Batches
+// are not supported so that all rows are passed through AddRow.
+func (w *resultCacheWriter) SupportsAddBatch() bool {
+	return false
+}
+
+// TruncateBufferedResults is part of the RestrictedCommandResult interface.
+// Truncating the result invalidates the captured rows.
+func (w *resultCacheWriter) TruncateBufferedResults(idx int) bool {
+	w.overflow = true
+	w.rows = nil
+	return w.RestrictedCommandResult.TruncateBufferedResults(idx)
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultCacheEndToEnd(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	for _, l := range []serverutils.ApplicationLayerInterface{s.ApplicationLayer(), s.SystemLayer()} {
+		kvserver.RangefeedEnabled.Override(ctx, &l.ClusterSettings().SV, true)
+	}
+	resultcache.MaxStaleness.Override(ctx, &s.ApplicationLayer().ClusterSettings().SV, time.Minute)
+	metrics := s.ApplicationLayer().ExecutorConfig().(ExecutorConfig).ResultCache.Metrics()
+
+	// Use a single connection so that the session setting applies to every
+	// statement.
+	db.SetMaxOpenConns(1)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE TABLE kv (k INT PRIMARY KEY, v INT)")
+	sqlDB.Exec(t, "INSERT INTO kv VALUES (1, 10), (2, 20)")
+	sqlDB.Exec(t, "SET result_cache_enabled = true")
+
+	const query = "SELECT k, v FROM kv ORDER BY k"
+	hits, invalidations := metrics.Hits.Count(), metrics.Invalidations.Count()
+
+	// Repeated statements reading at the present time are served from the
+	// cache.
+	sqlDB.CheckQueryResults(t, query, [][]string{{"1", "10"}, {"2", "20"}})
+	require.Equal(t, hits, metrics.Hits.Count())
+	sqlDB.CheckQueryResults(t, query, [][]string{{"1", "10"}, {"2", "20"}})
+	sqlDB.CheckQueryResults(t, query, [][]string{{"1", "10"}, {"2", "20"}})
+	require.Equal(t, hits+2, metrics.Hits.Count())
+
+	// A write invalidates the cached result once the rangefeed observes it.
+	sqlDB.Exec(t, "UPDATE kv SET v = 11 WHERE k = 1")
+	testutils.SucceedsSoon(t, func() error {
+		if metrics.Invalidations.Count() == invalidations {
+			return errors.New("cached result not invalidated yet")
+		}
+		return nil
+	})
+	hits = metrics.Hits.Count()
+	sqlDB.CheckQueryResults(t, query, [][]string{{"1", "11"}, {"2", "20"}})
+	require.Equal(t, hits, metrics.Hits.Count())
+	sqlDB.CheckQueryResults(t, query, [][]string{{"1", "11"}, {"2", "20"}})
+	require.Equal(t, hits+1, metrics.Hits.Count())
+
+	// Without bounded staleness, statements reading at the present time are not
+	// served from the cache, since the closed timestamp lags behind them.
+	resultcache.MaxStaleness.Override(ctx, &s.ApplicationLayer().ClusterSettings().SV, 0)
+	hits = metrics.Hits.Count()
+	sqlDB.CheckQueryResults(t, query, [][]string{{"1", "11"}, {"2", "20"}})
+	require.Equal(t, hits, metrics.Hits.Count())
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package resultcache
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+)
+
+var _ metric.Struct = (*Metrics)(nil)
+
+// Metrics exposes result cache metrics.
+type Metrics struct {
+	Hits          *metric.Counter
+	Misses        *metric.Counter
+	Invalidations *metric.Counter
+	Evictions     *metric.Counter
+	Entries       *metric.Gauge
+}
+
+func makeMetrics() Metrics {
+	return Metrics{
+		Hits:          metric.NewCounter(metaHits),
+		Misses:        metric.NewCounter(metaMisses),
+		Invalidations: metric.NewCounter(metaInvalidations),
+		Evictions:     metric.NewCounter(metaEvictions),
+		Entries:       metric.NewGauge(metaEntries),
+	}
+}
+
+// MetricStruct makes Metrics a metric.Struct.
+func (m *Metrics) MetricStruct() {}
+
+var (
+	metaHits = metric.Metadata{
+		Name:        "sql.result_cache.hits",
+		Help:        "Number of statements served from the query result cache",
+		Measurement: "Statements",
+		Unit:        metric.Unit_COUNT,
+		MetricType:  io_prometheus_client.MetricType_COUNTER,
+	}
+	metaMisses = metric.Metadata{
+		Name:        "sql.result_cache.misses",
+		Help:        "Number of statements eligible for the query result cache that could not be served from it",
+		Measurement: "Statements",
+		Unit:        metric.Unit_COUNT,
+		MetricType:  io_prometheus_client.MetricType_COUNTER,
+	}
+	metaInvalidations = metric.Metadata{
+		Name:        "sql.result_cache.invalidations",
+		Help:        "Number of query result cache entries invalidated by writes to the tables they read",
+		Measurement: "Entries",
+		Unit:        metric.Unit_COUNT,
+		MetricType:  io_prometheus_client.MetricType_COUNTER,
+	}
+	metaEvictions = metric.Metadata{
+		Name:        "sql.result_cache.evictions",
+		Help:        "Number of query result cache entries evicted due to memory pressure",
+		Measurement: "Entries",
+		Unit:        metric.Unit_COUNT,
+		MetricType:  io_prometheus_client.MetricType_COUNTER,
+	}
+	metaEntries = metric.Metadata{
+		Name:        "sql.result_cache.entries",
+		Help:        "Number of entries in the query result cache",
+		Measurement: "Entries",
+		Unit:        metric.Unit_COUNT,
+		MetricType:  io_prometheus_client.MetricType_GAUGE,
+	}
+)
@@ -0,0 +1,365 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package resultcache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/container/list"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// MaxMemory is the maximum amount of memory used by the result cache on each
+// node.
+var MaxMemory = settings.RegisterByteSizeSetting(
+	settings.ApplicationLevel,
+	"sql.result_cache.max_memory",
+	"maximum amount of memory used on each node by the query result cache",
+	64<<20, /* 64 MiB */
+)
+
+// MaxEntrySize is the maximum size of the result of a single statement that
+// can be stored in the result cache.
+var MaxEntrySize = settings.RegisterByteSizeSetting(
+	settings.ApplicationLevel,
+	"sql.result_cache.max_entry_size",
+	"maximum size of the result of a single statement that can be stored in "+
+		"the query result cache",
+	1<<20, /* 1 MiB */
+)
+
+// MaxStaleness is the maximum staleness of the results served from the result
+// cache.
+var MaxStaleness = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.result_cache.max_staleness",
+	"maximum staleness of results served from the query result cache; if zero, "+
+		"only statements reading at or below the closed timestamp, such as AS OF SYSTEM TIME "+
+		"follower_read_timestamp() queries, are served from the cache; otherwise, a statement "+
+		"may be served a result that misses writes, including writes of its own session, "+
+		"committed up to this long before it read",
+	0,
+	settings.NonNegativeDuration,
+)
+
+// DescriptorVersion identifies a version of a descriptor that a cached result
+// depends on.
+type DescriptorVersion struct {
+	ID      descpb.ID
+	Version descpb.DescriptorVersion
+}
+
+// MakeKey returns the key of the cached result of a statement. Results are
+// keyed by the user executing the statement (which can affect the rows that
+// are visible through row-level security policies), the SQL of the statement,
+// the values of its placeholders, and the versions of the descriptors of the
+// tables, views and types it depends on.
+func MakeKey(
+	user username.SQLUsername, sql string, placeholders string, descs []DescriptorVersion,
+) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\x00%s\x00%s", user.Normalized(), sql, placeholders)
+	for _, d := range descs {
+		fmt.Fprintf(&b, "\x00%d@%d", d.ID, d.Version)
+	}
+	return b.String()
+}
+
+// Cache is a node-level cache of the results of read-only statements.
+//
+// A cached result is valid as long as the tables read by the statement have
+// not been written to after the timestamp at which the result was read. Writes
+// are detected via a rangefeed over the span of each table with cached
+// results, and invalidate the results read before them. Writes above the
+// rangefeed frontier (i.e., the closed timestamp) of a table may not have been
+// observed yet, so a result is only known to be current up to the later of its
+// read timestamp and the frontier of every table it read. By default, a cached
+// result is only served to statements reading at or below that time, which in
+// practice means statements reading at slightly historical timestamps (e.g.,
+// AS OF SYSTEM TIME follower_read_timestamp()), since the closed timestamp lags
+// the present by a few seconds. Statements reading at the present time are
+// served from the cache if the user opts into bounded staleness with
+// sql.result_cache.max_staleness, in which case the result may miss writes that
+// were committed at most that long before the statement read.
+//
+// A Cache can be used by multiple threads in parallel.
+type Cache struct {
+	st      *cluster.Settings
+	codec   keys.SQLCodec
+	stopper *stop.Stopper
+	metrics Metrics
+
+	// startRangefeed starts a rangefeed that notifies the given watcher of
+	// writes to its table. It is a field so that it can be overridden in tests.
+	startRangefeed func(ctx context.Context, w *tableWatcher) (*rangefeed.RangeFeed, error)
+
+	mu struct {
+		syncutil.Mutex
+
+		// acc accounts for the memory used by cached results.
+		acc mon.BoundAccount
+
+		// entries contains all cached results, keyed by their cache key.
+		entries map[string]*entry
+
+		// lru contains all cached results in most recently used order.
+		lru list.List[*entry]
+
+		// tables contains a watcher for each table read by a cached result.
+		tables map[descpb.ID]*tableWatcher
+	}
+}
+
+// entry is a cached result.
+type entry struct {
+	key string
+
+	// readTS is the timestamp at which the result was read.
+	readTS hlc.Timestamp
+
+	// tables are the tables read by the statement.
+	tables []descpb.ID
+
+	rows []tree.Datums
+	size int64
+
+	elem *list.Element[*entry]
+}
+
+// tableWatcher watches a table for writes that invalidate cached results that
+// read the table.
+type tableWatcher struct {
+	id descpb.ID
+
+	// startTS is the timestamp at which the rangefeed was started. Results read
+	// before this timestamp cannot be cached, since writes between the read
+	// timestamp and startTS would not be observed.
+	startTS hlc.Timestamp
+
+	// frontier is the timestamp up to which all writes to the table have been
+	// observed.
+	frontier hlc.Timestamp
+
+	// entries are the cached results that read the table.
+	entries map[*entry]struct{}
+
+	feed *rangefeed.RangeFeed
+}
+
+// New creates a new Cache. The account is used to account for the memory used
+// by cached results.
+func New(
+	st *cluster.Settings,
+	codec keys.SQLCodec,
+	f *rangefeed.Factory,
+	stopper *stop.Stopper,
+	acc mon.BoundAccount,
+) *Cache {
+	c := &Cache{
+		st:      st,
+		codec:   codec,
+		stopper: stopper,
+		metrics: makeMetrics(),
+	}
+	c.startRangefeed = func(ctx context.Context, w *tableWatcher) (*rangefeed.RangeFeed, error) {
+		return f.RangeFeed(
+			ctx,
+			fmt.Sprintf("result-cache-%d", w.id),
+			[]roachpb.Span{codec.TableSpan(uint32(w.id))},
+			w.startTS,
+			func(ctx context.Context, value *kvpb.RangeFeedValue) {
+				c.onWrite(ctx, w, value.Timestamp())
+			},
+			rangefeed.WithOnDeleteRange(func(ctx context.Context, value *kvpb.RangeFeedDeleteRange) {
+				c.onWrite(ctx, w, value.Timestamp)
+			}),
+			rangefeed.WithOnFrontierAdvance(func(ctx context.Context, ts hlc.Timestamp) {
+				c.onFrontierAdvance(w, ts)
+			}),
+			rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+				log.Dev.Warningf(ctx, "result cache rangefeed for table %d failed: %v", w.id, err)
+				c.onWrite(ctx, w, hlc.MaxTimestamp)
+			}),
+		)
+	}
+	c.mu.acc = acc
+	c.mu.entries = make(map[string]*entry)
+	c.mu.lru.Init()
+	c.mu.tables = make(map[descpb.ID]*tableWatcher)
+	return c
+}
+
+// Metrics returns the cache's metrics.
+func (c *Cache) Metrics() *Metrics {
+	return &c.metrics
+}
+
+// Get returns the cached result with the given key, if there is one that is
+// valid for a statement reading at the given timestamp, up to the staleness
+// allowed by MaxStaleness. The returned rows must not be modified.
+func (c *Cache) Get(key string, readTS hlc.Timestamp) (rows []tree.Datums, ok bool) {
+	minCurrentTS := readTS.Add(-MaxStaleness.Get(&c.st.SV).Nanoseconds(), 0)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.mu.entries[key]
+	if !ok || readTS.Less(e.readTS) {
+		c.metrics.Misses.Inc(1)
+		return nil, false
+	}
+	for _, id := range e.tables {
+		// No write to the table after e.readTS has been observed, or the entry
+		// would have been invalidated, so the result is current up to the later
+		// of e.readTS and the frontier.
+		current := c.mu.tables[id].frontier
+		current.Forward(e.readTS)
+		if current.Less(minCurrentTS) {
+			// Writes that are too far below readTS may not have been observed yet.
+			c.metrics.Misses.Inc(1)
+			return nil, false
+		}
+	}
+	c.mu.lru.MoveToFront(e.elem)
+	c.metrics.Hits.Inc(1)
+	return e.rows, true
+}
+
+// Add adds the result of a statement, read at the given timestamp from the
+// given tables, to the cache. size is the memory footprint of the rows. The
+// result is not cached if it is too large, or if it was read at a timestamp
+// before the cache started watching one of the tables for writes.
+func (c *Cache) Add(
+	ctx context.Context,
+	key string,
+	readTS hlc.Timestamp,
+	tables []descpb.ID,
+	rows []tree.Datums,
+	size int64,
+) {
+	if size > MaxEntrySize.Get(&c.st.SV) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if old, ok := c.mu.entries[key]; ok {
+		if !old.readTS.Less(readTS) {
+			return
+		}
+		c.removeLocked(ctx, old)
+	}
+	for _, id := range tables {
+		if w, ok := c.mu.tables[id]; ok && readTS.Less(w.startTS) {
+			return
+		}
+	}
+
+	// Evict the least recently used results to make room for the new one.
+	maxMemory := MaxMemory.Get(&c.st.SV)
+	if size > maxMemory {
+		return
+	}
+	for c.mu.acc.Used()+size > maxMemory && c.mu.lru.Len() > 0 {
+		c.removeLocked(ctx, c.mu.lru.Back().Value)
+		c.metrics.Evictions.Inc(1)
+	}
+	if err := c.mu.acc.Grow(ctx, size); err != nil {
+		return
+	}
+
+	e := &entry{key: key, readTS: readTS, tables: tables, rows: rows, size: size}
+	for _, id := range tables {
+		w, ok := c.mu.tables[id]
+		if !ok {
+			w = &tableWatcher{id: id, startTS: readTS, frontier: readTS, entries: make(map[*entry]struct{})}
+			feed, err := c.startRangefeed(ctx, w)
+			if err != nil {
+				log.VEventf(ctx, 1, "failed to start result cache rangefeed for table %d: %v", id, err)
+				c.mu.acc.Shrink(ctx, size)
+				for _, id := range e.tables {
+					c.removeFromTableLocked(ctx, e, id)
+				}
+				return
+			}
+			w.feed = feed
+			c.mu.tables[id] = w
+		}
+		w.entries[e] = struct{}{}
+	}
+	e.elem = c.mu.lru.PushFront(e)
+	c.mu.entries[key] = e
+	c.metrics.Entries.Inc(1)
+}
+
+// onWrite invalidates the cached results that read the watcher's table before
+// the given write timestamp.
+func (c *Cache) onWrite(ctx context.Context, w *tableWatcher, ts hlc.Timestamp) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mu.tables[w.id] != w {
+		return
+	}
+	for e := range w.entries {
+		if e.readTS.Less(ts) {
+			c.removeLocked(ctx, e)
+			c.metrics.Invalidations.Inc(1)
+		}
+	}
+}
+
+// onFrontierAdvance records that all writes to the watcher's table at or
+// below the given timestamp have been observed.
+func (c *Cache) onFrontierAdvance(w *tableWatcher, ts hlc.Timestamp) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w.frontier.Forward(ts)
+}
+
+// removeLocked removes the given entry from the cache.
+func (c *Cache) removeLocked(ctx context.Context, e *entry) {
+	delete(c.mu.entries, e.key)
+	c.mu.lru.Remove(e.elem)
+	c.mu.acc.Shrink(ctx, e.size)
+	c.metrics.Entries.Dec(1)
+	for _, id := range e.tables {
+		c.removeFromTableLocked(ctx, e, id)
+	}
+}
+
+// removeFromTableLocked removes the given entry from the watcher of the given
+// table, and stops watching the table if no other cached results read it.
+func (c *Cache) removeFromTableLocked(ctx context.Context, e *entry, id descpb.ID) {
+	w, ok := c.mu.tables[id]
+	if !ok {
+		return
+	}
+	delete(w.entries, e)
+	if len(w.entries) > 0 {
+		return
+	}
+	delete(c.mu.tables, id)
+	if feed := w.feed; feed != nil {
+		// Close the rangefeed asynchronously, since closing it waits for its
+		// callbacks to finish, and they need to acquire the mutex. If the
+		// stopper is quiescing, the rangefeed is closed by the stopper.
+		_ = c.stopper.RunAsyncTask(ctx, "result-cache-close-rangefeed", func(context.Context) {
+			feed.Close()
+		})
+	}
+}
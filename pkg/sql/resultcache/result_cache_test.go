@@ -0,0 +1,110 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package resultcache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultCache(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	c := New(st, keys.SystemSQLCodec, nil /* f */, nil /* stopper */, *mon.NewStandaloneUnlimitedAccount())
+	watchers := make(map[descpb.ID]*tableWatcher)
+	c.startRangefeed = func(_ context.Context, w *tableWatcher) (*rangefeed.RangeFeed, error) {
+		watchers[w.id] = w
+		return nil, nil
+	}
+
+	ts := func(walltime int64) hlc.Timestamp {
+		return hlc.Timestamp{WallTime: walltime}
+	}
+	rows := []tree.Datums{{tree.NewDInt(1)}}
+	const a, b = descpb.ID(100), descpb.ID(101)
+
+	// A result can be served at its read timestamp, but not at later timestamps
+	// until the frontier of every table it read has advanced past them.
+	c.Add(ctx, "k1", ts(10), []descpb.ID{a, b}, rows, 10)
+	res, ok := c.Get("k1", ts(10))
+	require.True(t, ok)
+	require.Equal(t, rows, res)
+	_, ok = c.Get("k1", ts(15))
+	require.False(t, ok)
+	c.onFrontierAdvance(watchers[a], ts(20))
+	_, ok = c.Get("k1", ts(15))
+	require.False(t, ok)
+	c.onFrontierAdvance(watchers[b], ts(20))
+	_, ok = c.Get("k1", ts(15))
+	require.True(t, ok)
+	_, ok = c.Get("k1", ts(5))
+	require.False(t, ok)
+
+	// Results read before a table was watched cannot be cached.
+	c.Add(ctx, "k2", ts(5), []descpb.ID{a}, rows, 10)
+	_, ok = c.Get("k2", ts(5))
+	require.False(t, ok)
+	c.Add(ctx, "k2", ts(15), []descpb.ID{a}, rows, 10)
+	_, ok = c.Get("k2", ts(15))
+	require.True(t, ok)
+
+	// A write invalidates the results read before it.
+	c.onWrite(ctx, watchers[a], ts(12))
+	_, ok = c.Get("k1", ts(15))
+	require.False(t, ok)
+	_, ok = c.Get("k2", ts(15))
+	require.True(t, ok)
+	require.Equal(t, int64(1), c.metrics.Invalidations.Count())
+	require.NotContains(t, c.mu.tables, b)
+
+	// Results are evicted in least recently used order when the cache is full.
+	MaxMemory.Override(ctx, &st.SV, 30)
+	c.Add(ctx, "k3", ts(15), []descpb.ID{a}, rows, 10)
+	c.Add(ctx, "k4", ts(15), []descpb.ID{a}, rows, 10)
+	_, ok = c.Get("k2", ts(15))
+	require.True(t, ok)
+	c.Add(ctx, "k5", ts(15), []descpb.ID{a}, rows, 10)
+	_, ok = c.Get("k3", ts(15))
+	require.False(t, ok)
+	require.Equal(t, int64(1), c.metrics.Evictions.Count())
+	require.Equal(t, int64(30), c.mu.acc.Used())
+
+	// Results that are too large are not cached.
+	c.Add(ctx, "k6", ts(15), []descpb.ID{a}, rows, 40)
+	_, ok = c.Get("k6", ts(15))
+	require.False(t, ok)
+	require.Equal(t, int64(3), c.metrics.Entries.Value())
+
+	// With bounded staleness, a result is served to statements reading up to
+	// the maximum staleness after the later of its read timestamp and the
+	// frontier, unless a write was observed.
+	MaxStaleness.Override(ctx, &st.SV, 10)
+	c.Add(ctx, "k7", ts(20), []descpb.ID{a}, rows, 10)
+	_, ok = c.Get("k7", ts(30))
+	require.True(t, ok)
+	_, ok = c.Get("k7", ts(31))
+	require.False(t, ok)
+	c.onFrontierAdvance(watchers[a], ts(25))
+	_, ok = c.Get("k7", ts(35))
+	require.True(t, ok)
+	c.onWrite(ctx, watchers[a], ts(26))
+	_, ok = c.Get("k7", ts(30))
+	require.False(t, ok)
+}
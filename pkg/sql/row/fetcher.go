@@ -353,6 +353,9 @@ type FetcherInitArgs struct {
 	// row is being processed. In practice, this means that span IDs must be
 	// passed in when SpansCanOverlap is true.
 	SpansCanOverlap bool
+	// Sampler, if set, restricts the fetched rows to a TABLESAMPLE sample. It is
+	// ignored if WillUseKVProvider is true.
+	Sampler *Sampler
 }
 
 // Init sets up a Fetcher for a given table and index.
@@ -528,6 +531,9 @@ func (rf *Fetcher) Init(ctx context.Context, args FetcherInitArgs) error {
 		}
 		rf.kvFetcher = newKVFetcher(newTxnKVFetcherInternal(fetcherArgs))
 	}
+	if args.Sampler != nil && rf.kvFetcher != nil {
+		rf.kvFetcher.SetSampler(args.Sampler)
+	}
 
 	return nil
 }
@@ -38,10 +38,20 @@ type KVFetcher struct {
 
 	batchResponse []byte
 	spanID        int
+
+	// sampler, if set, restricts the fetched KVs to the rows that are part of a
+	// TABLESAMPLE sample.
+	sampler *Sampler
 }
 
 var _ storage.NextKVer = &KVFetcher{}
 
+// SetSampler configures the fetcher to only return the KVs of the rows that are
+// included by the given sampler.
+func (f *KVFetcher) SetSampler(sampler *Sampler) {
+	f.sampler = sampler
+}
+
 // newTxnKVFetcher creates a new txnKVFetcher.
 //
 // If acc is non-nil, this fetcher will track its fetches and must be Closed.
@@ -262,6 +272,21 @@ func newKVFetcher(batchFetcher KVBatchFetcher) *KVFetcher {
 // following nextKV call.
 func (f *KVFetcher) nextKV(
 	ctx context.Context, mvccDecodeStrategy storage.MVCCDecodingStrategy,
+) (ok bool, kv roachpb.KeyValue, spanID int, err error) {
+	if f.sampler == nil {
+		return f.nextUnsampledKV(ctx, mvccDecodeStrategy)
+	}
+	for {
+		ok, kv, spanID, err = f.nextUnsampledKV(ctx, mvccDecodeStrategy)
+		if !ok || err != nil || f.sampler.includesKV(kv.Key) {
+			return ok, kv, spanID, err
+		}
+	}
+}
+
+// nextUnsampledKV is like nextKV, but it ignores the sampler.
+func (f *KVFetcher) nextUnsampledKV(
+	ctx context.Context, mvccDecodeStrategy storage.MVCCDecodingStrategy,
 ) (ok bool, kv roachpb.KeyValue, spanID int, err error) {
 	for {
 		// Only one of f.kvs or f.batchResponse will be set at a given time. Which
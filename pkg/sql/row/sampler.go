@@ -0,0 +1,151 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package row
+
+import (
+	"bytes"
+	"context"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+)
+
+// Sampler decides which rows (or blocks of rows) are part of a TABLESAMPLE
+// sample. A key is sampled if its hash, seeded with the seed of the sample,
+// falls below the sampling fraction. As a result, the same seed always samples
+// the same rows of an unchanged table, which is what REPEATABLE requires, and
+// each row is sampled independently of all other rows.
+type Sampler struct {
+	seed uint64
+	// threshold is the largest hash of a sampled key.
+	threshold uint64
+	// all is true if every key is sampled.
+	all bool
+
+	// lastRowPrefix and lastIncluded cache the decision for the most recent
+	// row, so that the KVs of a row with multiple column families are only
+	// hashed once.
+	lastRowPrefix []byte
+	lastIncluded  bool
+}
+
+// MakeSampler returns a Sampler that samples each key with the given
+// probability.
+func MakeSampler(fraction float64, seed uint64) Sampler {
+	s := Sampler{seed: seed}
+	switch {
+	case fraction >= 1:
+		s.all = true
+	case fraction > 0:
+		s.threshold = uint64(fraction * math.MaxUint64)
+	}
+	return s
+}
+
+// Includes returns whether the given key is part of the sample.
+func (s *Sampler) Includes(key roachpb.Key) bool {
+	if s.all {
+		return true
+	}
+	return hashKey(s.seed, key) < s.threshold
+}
+
+// SampleSpans implements block sampling (TABLESAMPLE SYSTEM): it splits the
+// given spans at range boundaries and returns the pieces whose start key is
+// part of the sample. The ranges that are not sampled are never read. If ds is
+// nil, each span is sampled as a whole.
+func (s *Sampler) SampleSpans(
+	ctx context.Context, ds *kvcoord.DistSender, spans roachpb.Spans,
+) (roachpb.Spans, error) {
+	if s.all {
+		return spans, nil
+	}
+	var sampled roachpb.Spans
+	if ds == nil {
+		for _, sp := range spans {
+			if s.Includes(sp.Key) {
+				sampled = append(sampled, sp)
+			}
+		}
+		return sampled, nil
+	}
+	ri := kvcoord.MakeRangeIterator(ds)
+	for _, sp := range spans {
+		if len(sp.EndKey) == 0 {
+			if s.Includes(sp.Key) {
+				sampled = append(sampled, sp)
+			}
+			continue
+		}
+		rs, err := keys.SpanAddr(sp)
+		if err != nil {
+			return nil, err
+		}
+		for ri.Seek(ctx, rs.Key, kvcoord.Ascending); ; ri.Next(ctx) {
+			if !ri.Valid() {
+				return nil, ri.Error()
+			}
+			desc := ri.Desc()
+			start, end := rs.Key, rs.EndKey
+			if start.Less(desc.StartKey) {
+				start = desc.StartKey
+			}
+			if desc.EndKey.Less(end) {
+				end = desc.EndKey
+			}
+			if s.Includes(start.AsRawKey()) {
+				sampled = append(sampled, roachpb.Span{Key: start.AsRawKey(), EndKey: end.AsRawKey()})
+			}
+			if !ri.NeedAnother(rs) {
+				break
+			}
+		}
+	}
+	return sampled, nil
+}
+
+// includesKV returns whether the row that the given KV belongs to is part of
+// the sample.
+func (s *Sampler) includesKV(key roachpb.Key) bool {
+	if s.all {
+		return true
+	}
+	n, err := keys.GetRowPrefixLength(key)
+	if err != nil {
+		// This is not a table key; sample it on its own.
+		n = len(key)
+	}
+	prefix := key[:n]
+	if s.lastRowPrefix != nil && bytes.Equal(prefix, s.lastRowPrefix) {
+		return s.lastIncluded
+	}
+	s.lastRowPrefix = append(s.lastRowPrefix[:0], prefix...)
+	s.lastIncluded = s.Includes(prefix)
+	return s.lastIncluded
+}
+
+// hashKey computes the seeded FNV-1a hash of the given key, followed by a
+// finalizer that spreads the hashes of similar keys over the full range of
+// uint64.
+func hashKey(seed uint64, key roachpb.Key) uint64 {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+	h := uint64(offset64) ^ seed
+	for _, c := range key {
+		h ^= uint64(c)
+		h *= prime64
+	}
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb3f99fe1a7cd
+	h ^= h >> 33
+	return h
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package row
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampler(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numRows = 10000
+	rowKey := func(i int) roachpb.Key {
+		return encoding.EncodeVarintAscending(keys.SystemSQLCodec.IndexPrefix(100, 1), int64(i))
+	}
+	countSampled := func(s Sampler) (n int) {
+		for i := 0; i < numRows; i++ {
+			if s.Includes(rowKey(i)) {
+				n++
+			}
+		}
+		return n
+	}
+
+	require.Equal(t, 0, countSampled(MakeSampler(0, 1)))
+	require.Equal(t, numRows, countSampled(MakeSampler(1, 1)))
+	n := countSampled(MakeSampler(0.25, 1))
+	require.InDelta(t, numRows/4, n, numRows/20)
+
+	// The same seed samples the same rows, and different seeds sample
+	// different rows.
+	a, b, c := MakeSampler(0.5, 7), MakeSampler(0.5, 7), MakeSampler(0.5, 8)
+	var differ bool
+	for i := 0; i < numRows; i++ {
+		require.Equal(t, a.Includes(rowKey(i)), b.Includes(rowKey(i)))
+		differ = differ || a.Includes(rowKey(i)) != c.Includes(rowKey(i))
+	}
+	require.True(t, differ)
+
+	// All the column families of a row are sampled together.
+	s := MakeSampler(0.5, 3)
+	for i := 0; i < 100; i++ {
+		key := rowKey(i)
+		expected := s.Includes(key)
+		for family := uint32(0); family < 3; family++ {
+			require.Equal(t, expected, s.includesKV(keys.MakeFamilyKey(key.Clone(), family)))
+		}
+	}
+}
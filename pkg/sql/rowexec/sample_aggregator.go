@@ -100,6 +100,9 @@ func newSampleAggregator(
 		if s.GenerateHistogram && len(s.Columns) != 1 {
 			return nil, errors.Errorf("histograms require one column")
 		}
+		if s.GenerateExtendedStatistics && len(s.Columns) < 2 {
+			return nil, errors.Errorf("extended statistics require multiple columns")
+		}
 	}
 
 	// Limit the memory use by creating a child monitor with a hard limit.
@@ -148,6 +151,11 @@ func newSampleAggregator(
 		if spec.Sketches[i].GenerateHistogram {
 			sampleCols.Add(int(spec.Sketches[i].Columns[0]))
 		}
+		if spec.Sketches[i].GenerateExtendedStatistics {
+			for _, col := range spec.Sketches[i].Columns {
+				sampleCols.Add(int(col))
+			}
+		}
 	}
 
 	s.sr.Init(
@@ -522,6 +530,12 @@ func (s *sampleAggregator) writeResults(ctx context.Context) error {
 					return err
 				}
 				histogram = &h
+			} else if si.spec.GenerateExtendedStatistics && len(s.sr.Get()) != 0 {
+				h, err := s.generateExtendedStatistics(ctx, &si)
+				if err != nil {
+					return err
+				}
+				histogram = h
 			}
 
 			columnIDs := make([]descpb.ColumnID, len(si.spec.Columns))
@@ -671,6 +685,32 @@ func (s *sampleAggregator) generateHistogram(
 	return h, err
 }
 
+// generateExtendedStatistics returns the extended statistics (on the columns
+// of the given multi-column sketch) from the samples. It returns nil if the
+// extended statistics could not be generated due to memory limits.
+func (s *sampleAggregator) generateExtendedStatistics(
+	ctx context.Context, si *sketchInfo,
+) (*stats.HistogramData, error) {
+	colIdxs := make([]int, len(si.spec.Columns))
+	colTypes := make([]*types.T, len(si.spec.Columns))
+	for i, c := range si.spec.Columns {
+		colIdxs[i] = int(c)
+		colTypes[i] = s.inTypes[c]
+	}
+	h, err := stats.BuildExtendedStatistics(
+		ctx, &s.tempMemAcc, s.sr.Get(), colIdxs, colTypes, si.numRows, int(si.spec.MaxMostCommonValues),
+	)
+	if err != nil {
+		if code := pgerror.GetPGCode(err); code != pgcode.OutOfMemory {
+			return nil, err
+		}
+		log.Dev.Info(ctx, "skipping extended statistics due to excessive memory utilization")
+		telemetry.Inc(sqltelemetry.StatsHistogramOOMCounter)
+		return nil, nil
+	}
+	return &h, nil
+}
+
 var _ execinfra.DoesNotUseTxn = &sampleAggregator{}
 
 // DoesNotUseTxn implements the DoesNotUseTxn interface.
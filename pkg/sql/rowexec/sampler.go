@@ -131,6 +131,11 @@ func newSamplerProcessor(
 		if spec.Sketches[i].GenerateHistogram {
 			sampleCols.Add(int(spec.Sketches[i].Columns[0]))
 		}
+		if spec.Sketches[i].GenerateExtendedStatistics {
+			for _, col := range spec.Sketches[i].Columns {
+				sampleCols.Add(int(col))
+			}
+		}
 	}
 	for i := range spec.InvertedSketches {
 		var sr stats.SampleReservoir
@@ -135,6 +135,11 @@ func newTableReader(
 		return nil, err
 	}
 
+	var sampler *row.Sampler
+	if spec.Sample != nil && spec.Sample.Method == execinfrapb.TableSampleSpec_BERNOULLI {
+		s := row.MakeSampler(spec.Sample.Fraction, spec.Sample.Seed)
+		sampler = &s
+	}
 	var fetcher row.Fetcher
 	if err := fetcher.Init(
 		ctx,
@@ -151,12 +156,21 @@ func newTableReader(
 			Spec:                       &spec.FetchSpec,
 			TraceKV:                    flowCtx.TraceKV,
 			ForceProductionKVBatchSize: flowCtx.EvalCtx.TestingKnobs.ForceProductionValues,
+			Sampler:                    sampler,
 		},
 	); err != nil {
 		return nil, err
 	}
 
 	tr.Spans = spec.Spans
+	if spec.Sample != nil && spec.Sample.Method == execinfrapb.TableSampleSpec_SYSTEM {
+		// Only read the ranges that are part of the sample.
+		s := row.MakeSampler(spec.Sample.Fraction, spec.Sample.Seed)
+		var err error
+		if tr.Spans, err = s.SampleSpans(ctx, flowCtx.Cfg.DistSender, tr.Spans); err != nil {
+			return nil, err
+		}
+	}
 	if !tr.ignoreMisplannedRanges {
 		// Make a copy of the spans so that we could get the misplanned ranges
 		// info.
@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -77,6 +78,10 @@ type scanNode struct {
 	// order for this optimization to work, the DistSQL planner must create a
 	// local plan.
 	localityOptimized bool
+
+	// sample, if set, restricts the scan to a pseudo-random sample of its rows
+	// (see TABLESAMPLE).
+	sample *execinfrapb.TableSampleSpec
 }
 
 // fetchPlanningInfo contains information common to operators that fetch rows
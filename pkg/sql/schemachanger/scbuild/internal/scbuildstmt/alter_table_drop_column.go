@@ -35,6 +35,7 @@ func alterTableDropColumn(
 	panicIfRegionChangeUnderwayOnRBRTable(b, "DROP COLUMN", tbl.TableID)
 	checkSafeUpdatesForDropColumn(b)
 	checkRegionalByRowColumnConflict(b, tbl, n)
+	checkSystemVersioningColumnConflict(b, tbl, n)
 
 	col, elts, done := resolveColumnForDropColumn(b, tn, tbl, n)
 	if done {
@@ -89,6 +90,25 @@ func checkRegionalByRowColumnConflict(b BuildCtx, tbl *scpb.Table, n *tree.Alter
 	}
 }
 
+func checkSystemVersioningColumnConflict(
+	b BuildCtx, tbl *scpb.Table, n *tree.AlterTableDropColumn,
+) {
+	sv := retrieveTableSystemVersioning(b, tbl.TableID)
+	if sv == nil {
+		return
+	}
+	if getColumnIDFromColumnName(b, tbl.TableID, n.Column, false /* required */) == sv.RowStartColumnID {
+		panic(errors.WithHint(
+			pgerror.Newf(
+				pgcode.InvalidColumnReference,
+				"cannot drop column %s as it is the row start column of a system-versioned table",
+				n.Column,
+			),
+			"You must drop system versioning before dropping this column.",
+		))
+	}
+}
+
 func resolveColumnForDropColumn(
 	b BuildCtx, tn *tree.TableName, tbl *scpb.Table, n *tree.AlterTableDropColumn,
 ) (col *scpb.Column, elts ElementResultSet, done bool) {
@@ -0,0 +1,152 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+func alterTableAddSystemVersioning(
+	b BuildCtx,
+	tn *tree.TableName,
+	tbl *scpb.Table,
+	stmt tree.Statement,
+	n *tree.AlterTableAddSystemVersioning,
+) {
+	if retrieveTableSystemVersioning(b, tbl.TableID) != nil {
+		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"table %q is already system-versioned", tn.Object()))
+	}
+
+	// Resolve the history table, which defaults to <table>_history.
+	histName := n.HistoryTable
+	if histName == nil {
+		defaultName := *tn
+		defaultName.ObjectName = tn.ObjectName + tree.SystemVersioningHistoryTableSuffix
+		histName = defaultName.ToUnresolvedObjectName()
+	}
+	_, _, hist := scpb.FindTable(b.ResolveTable(histName, ResolveParams{
+		RequiredPrivilege: privilege.CREATE,
+	}))
+	if hist.TableID == tbl.TableID {
+		panic(pgerror.Newf(pgcode.InvalidTableDefinition,
+			"table %q cannot be its own history table", tn.Object()))
+	}
+	if retrieveTableSystemVersioning(b, hist.TableID) != nil {
+		panic(pgerror.Newf(pgcode.InvalidTableDefinition,
+			"history table %q cannot be system-versioned", histName))
+	}
+
+	// The history table must have a column with a matching type for each
+	// visible column of the table, and the row start and row end columns.
+	histCols := publicColumnTypesByName(b, hist.TableID)
+	requireHistoryColumn := func(name tree.Name, typ *types.T) {
+		if histTyp, ok := histCols[name]; !ok {
+			panic(pgerror.Newf(pgcode.UndefinedColumn,
+				"history table %q has no column %q", histName, name))
+		} else if !histTyp.Identical(typ) {
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"column %q of history table %q has type %s, expected %s",
+				name, histName, histTyp.SQLString(), typ.SQLString()))
+		}
+	}
+	requireHistoryColumn(tree.SystemVersioningRowStartColName, types.TimestampTZ)
+	requireHistoryColumn(tree.SystemVersioningRowEndColName, types.TimestampTZ)
+	scpb.ForEachColumn(b.QueryByID(tbl.TableID), func(
+		_ scpb.Status, target scpb.TargetStatus, col *scpb.Column,
+	) {
+		if target != scpb.ToPublic || col.IsSystemColumn || col.IsInaccessible ||
+			isColumnHidden(b, tbl.TableID, col.ColumnID) {
+			return
+		}
+		name := mustRetrieveColumnName(b, tbl.TableID, col.ColumnID).Name
+		if name == string(tree.SystemVersioningRowStartColName) {
+			return
+		}
+		requireHistoryColumn(tree.Name(name), mustRetrieveColumnTypeElem(b, tbl.TableID, col.ColumnID).Type)
+	})
+
+	// Add the hidden row start column, unless the table already has one.
+	rowStartColID := getColumnIDFromColumnName(
+		b, tbl.TableID, tree.SystemVersioningRowStartColName, false, /* required */
+	)
+	if rowStartColID == 0 {
+		alterTableAddColumn(b, tn, tbl, stmt, &tree.AlterTableAddColumn{
+			ColumnDef: tree.SystemVersioningRowStartColumnDef(),
+		})
+		rowStartColID = getColumnIDFromColumnName(
+			b, tbl.TableID, tree.SystemVersioningRowStartColName, true, /* required */
+		)
+	} else if typ := mustRetrieveColumnTypeElem(b, tbl.TableID, rowStartColID).Type; !typ.Identical(types.TimestampTZ) ||
+		retrieveColumnNotNull(b, tbl.TableID, rowStartColID) == nil {
+		panic(pgerror.Newf(pgcode.InvalidTableDefinition,
+			"row start column %q must be of type TIMESTAMPTZ NOT NULL",
+			tree.SystemVersioningRowStartColName))
+	}
+
+	b.Add(&scpb.TableSystemVersioning{
+		TableID:          tbl.TableID,
+		HistoryTableID:   hist.TableID,
+		RowStartColumnID: rowStartColID,
+	})
+}
+
+func alterTableDropSystemVersioning(
+	b BuildCtx,
+	tn *tree.TableName,
+	tbl *scpb.Table,
+	stmt tree.Statement,
+	n *tree.AlterTableDropSystemVersioning,
+) {
+	sv := retrieveTableSystemVersioning(b, tbl.TableID)
+	if sv == nil {
+		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"table %q is not system-versioned", tn.Object()))
+	}
+	// The row start column and the history table are left in place, so that
+	// versioning can be enabled again later.
+	b.Drop(sv)
+}
+
+// retrieveTableSystemVersioning returns the TableSystemVersioning element of
+// the table, or nil if the table is not system-versioned.
+func retrieveTableSystemVersioning(b BuildCtx, tableID catid.DescID) *scpb.TableSystemVersioning {
+	return b.QueryByID(tableID).FilterTableSystemVersioning().
+		Filter(func(_ scpb.Status, target scpb.TargetStatus, _ *scpb.TableSystemVersioning) bool {
+			return target == scpb.ToPublic
+		}).MustGetZeroOrOneElement()
+}
+
+// publicColumnTypesByName returns the types of the public columns of the
+// table, keyed by column name.
+func publicColumnTypesByName(b BuildCtx, tableID catid.DescID) map[tree.Name]*types.T {
+	ret := make(map[tree.Name]*types.T)
+	scpb.ForEachColumnName(b.QueryByID(tableID), func(
+		_ scpb.Status, target scpb.TargetStatus, e *scpb.ColumnName,
+	) {
+		if target != scpb.ToPublic {
+			return
+		}
+		if typ := retrieveColumnTypeElem(b, tableID, e.ColumnID); typ != nil {
+			ret[tree.Name(e.Name)] = typ.Type
+		}
+	})
+	return ret
+}
+
+// isColumnHidden returns true if the column is, or is becoming, hidden.
+func isColumnHidden(b BuildCtx, tableID catid.DescID, columnID catid.ColumnID) bool {
+	return !b.QueryByID(tableID).FilterColumnHidden().
+		Filter(func(_ scpb.Status, target scpb.TargetStatus, e *scpb.ColumnHidden) bool {
+			return e.ColumnID == columnID && target == scpb.ToPublic
+		}).IsEmpty()
+}
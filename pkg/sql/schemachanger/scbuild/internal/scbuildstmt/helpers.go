@@ -1179,7 +1179,8 @@ func maybeCleanupSchemaLocked(b BuildCtx, id catid.DescID) {
 // allowed on this table. A schema change is disallowed if one of the following
 // is true:
 //   - The table is referenced by logical data replication jobs, and the statement
-//     is not in the allow list of LDR schema changes.
+//     is not in the allow list of LDR schema changes, unless the session allows
+//     schema changes on such tables.
 //   - schema_locked if the current version does not support transient drops
 //     of the lock.
 //
@@ -1204,7 +1205,7 @@ func checkTableSchemaChangePrerequisites(
 		}
 	}
 	_, _, ldrJobIDs := scpb.FindLDRJobIDs(tableElements)
-	if ldrJobIDs != nil && len(ldrJobIDs.JobIDs) > 0 {
+	if ldrJobIDs != nil && len(ldrJobIDs.JobIDs) > 0 && !b.SessionData().AllowSchemaChangesOnLDRTables {
 		var virtualColNames []string
 		scpb.ForEachColumnType(tableElements, func(current scpb.Status, target scpb.TargetStatus, colTypeElem *scpb.ColumnType) {
 			if !colTypeElem.IsVirtual {
@@ -98,6 +98,15 @@ func Truncate(b BuildCtx, stmt *tree.Truncate) {
 
 func truncateTable(b BuildCtx, n *tree.Truncate, elts ElementResultSet) {
 	tbl := elts.FilterTable().MustGetOneElement()
+	// Truncating a system-versioned table would discard its rows without
+	// recording them in its history table.
+	if retrieveTableSystemVersioning(b, tbl.TableID) != nil {
+		ns := elts.FilterNamespace().MustGetOneElement()
+		panic(errors.WithHint(pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot truncate system-versioned table %q", ns.Name),
+			"use DELETE to record the deleted rows in the history table, "+
+				"or remove system versioning with ALTER TABLE ... DROP SYSTEM VERSIONING"))
+	}
 	// Fall back to legacy schema changer if we need to rewrite index references.
 	backRefs := b.BackReferences(tbl.TableID)
 	backRefs.FilterView().ForEach(func(current scpb.Status, target scpb.TargetStatus, e *scpb.View) {
@@ -481,6 +481,13 @@ func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
 	if tbl.IsRowLevelSecurityForced() {
 		w.ev(scpb.Status_PUBLIC, &scpb.RowLevelSecurityForced{TableID: tbl.GetID()})
 	}
+	if sv := tbl.TableDesc().SystemVersioning; sv != nil {
+		w.ev(scpb.Status_PUBLIC, &scpb.TableSystemVersioning{
+			TableID:          tbl.GetID(),
+			HistoryTableID:   sv.HistoryTableID,
+			RowStartColumnID: sv.RowStartColumnID,
+		})
+	}
 	if tbl.TableDesc().LDRJobIDs != nil {
 		w.ev(scpb.Status_PUBLIC, &scpb.LDRJobIDs{
 			TableID: tbl.GetID(),
@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 )
@@ -31,6 +32,31 @@ func (i *immediateVisitor) SetTableSchemaLocked(
 	return nil
 }
 
+func (i *immediateVisitor) SetTableSystemVersioning(
+	ctx context.Context, op scop.SetTableSystemVersioning,
+) error {
+	tbl, err := i.checkOutTable(ctx, op.TableID)
+	if err != nil {
+		return err
+	}
+	tbl.SystemVersioning = &descpb.TableDescriptor_SystemVersioning{
+		HistoryTableID:   op.HistoryTableID,
+		RowStartColumnID: op.RowStartColumnID,
+	}
+	return nil
+}
+
+func (i *immediateVisitor) RemoveTableSystemVersioning(
+	ctx context.Context, op scop.RemoveTableSystemVersioning,
+) error {
+	tbl, err := i.checkOutTable(ctx, op.TableID)
+	if err != nil {
+		return err
+	}
+	tbl.SystemVersioning = nil
+	return nil
+}
+
 func (d *deferredVisitor) UpdateTTLScheduleMetadata(
 	ctx context.Context, op scop.UpdateTTLScheduleMetadata,
 ) error {
@@ -1204,6 +1204,20 @@ type ForcedRowLevelSecurityMode struct {
 	Forced  bool
 }
 
+// SetTableSystemVersioning enables system versioning on a table.
+type SetTableSystemVersioning struct {
+	immediateMutationOp
+	TableID          descpb.ID
+	HistoryTableID   descpb.ID
+	RowStartColumnID descpb.ColumnID
+}
+
+// RemoveTableSystemVersioning disables system versioning on a table.
+type RemoveTableSystemVersioning struct {
+	immediateMutationOp
+	TableID descpb.ID
+}
+
 // MarkRecreatedIndexAsInvisible is used to mark secondary indexes recreated
 // after a primary key swap as invisible. This is to prevent their use before
 // primary key swap is complete.
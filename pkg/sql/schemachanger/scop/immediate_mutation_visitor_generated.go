@@ -172,6 +172,8 @@ type ImmediateMutationVisitor interface {
 	AddPartitionZoneConfig(context.Context, AddPartitionZoneConfig) error
 	EnableRowLevelSecurityMode(context.Context, EnableRowLevelSecurityMode) error
 	ForcedRowLevelSecurityMode(context.Context, ForcedRowLevelSecurityMode) error
+	SetTableSystemVersioning(context.Context, SetTableSystemVersioning) error
+	RemoveTableSystemVersioning(context.Context, RemoveTableSystemVersioning) error
 	MarkRecreatedIndexAsInvisible(context.Context, MarkRecreatedIndexAsInvisible) error
 	MarkRecreatedIndexesAsVisible(context.Context, MarkRecreatedIndexesAsVisible) error
 	MarkRecreatedIndexAsVisible(context.Context, MarkRecreatedIndexAsVisible) error
@@ -953,6 +955,16 @@ func (op ForcedRowLevelSecurityMode) Visit(ctx context.Context, v ImmediateMutat
 	return v.ForcedRowLevelSecurityMode(ctx, op)
 }
 
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTableSystemVersioning) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTableSystemVersioning(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveTableSystemVersioning) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveTableSystemVersioning(ctx, op)
+}
+
 // Visit is part of the ImmediateMutationOp interface.
 func (op MarkRecreatedIndexAsInvisible) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
 	return v.MarkRecreatedIndexAsInvisible(ctx, op)
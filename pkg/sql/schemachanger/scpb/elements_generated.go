@@ -2785,6 +2785,43 @@ func (c *ElementCollection[E]) FilterTableSchemaLocked() *ElementCollection[*Tab
 	return (*ElementCollection[*TableSchemaLocked])(ret)
 }
 
+func (e TableSystemVersioning) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_TableSystemVersioning) Element() Element {
+	return e.TableSystemVersioning
+}
+
+// ForEachTableSystemVersioning iterates over elements of type TableSystemVersioning.
+// Deprecated
+func ForEachTableSystemVersioning(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *TableSystemVersioning),
+) {
+  c.FilterTableSystemVersioning().ForEach(fn)
+}
+
+// FindTableSystemVersioning finds the first element of type TableSystemVersioning.
+// Deprecated
+func FindTableSystemVersioning(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *TableSystemVersioning) {
+	if tc := c.FilterTableSystemVersioning(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*TableSystemVersioning)
+	}
+	return current, target, element
+}
+
+// TableSystemVersioningElements filters elements of type TableSystemVersioning.
+func (c *ElementCollection[E]) FilterTableSystemVersioning() *ElementCollection[*TableSystemVersioning] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*TableSystemVersioning)
+		return ok
+	})
+	return (*ElementCollection[*TableSystemVersioning])(ret)
+}
+
 func (e TableZoneConfig) element() {}
 
 // Element implements ElementGetter.
@@ -3532,6 +3569,8 @@ func (e* ElementProto) SetElement(element Element) {
 			e.ElementOneOf = &ElementProto_TablePartitioning{ TablePartitioning: t}
 		case *TableSchemaLocked:
 			e.ElementOneOf = &ElementProto_TableSchemaLocked{ TableSchemaLocked: t}
+		case *TableSystemVersioning:
+			e.ElementOneOf = &ElementProto_TableSystemVersioning{ TableSystemVersioning: t}
 		case *TableZoneConfig:
 			e.ElementOneOf = &ElementProto_TableZoneConfig{ TableZoneConfig: t}
 		case *TemporaryIndex:
@@ -3645,6 +3684,7 @@ func GetElementOneOfProtos() []interface{} {
 	((*ElementProto_TableLocalitySecondaryRegion)(nil)),
 	((*ElementProto_TablePartitioning)(nil)),
 	((*ElementProto_TableSchemaLocked)(nil)),
+	((*ElementProto_TableSystemVersioning)(nil)),
 	((*ElementProto_TableZoneConfig)(nil)),
 	((*ElementProto_TemporaryIndex)(nil)),
 	((*ElementProto_Trigger)(nil)),
@@ -3743,6 +3783,7 @@ func GetElementTypes() []interface{} {
 	((*TableLocalitySecondaryRegion)(nil)),
 	((*TablePartitioning)(nil)),
 	((*TableSchemaLocked)(nil)),
+	((*TableSystemVersioning)(nil)),
 	((*TableZoneConfig)(nil)),
 	((*TemporaryIndex)(nil)),
 	((*Trigger)(nil)),
@@ -0,0 +1,36 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+)
+
+func init() {
+	opRegistry.register((*scpb.TableSystemVersioning)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_PUBLIC,
+				emit(func(this *scpb.TableSystemVersioning) *scop.SetTableSystemVersioning {
+					return &scop.SetTableSystemVersioning{
+						TableID:          this.TableID,
+						HistoryTableID:   this.HistoryTableID,
+						RowStartColumnID: this.RowStartColumnID,
+					}
+				}),
+			),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			to(scpb.Status_ABSENT,
+				emit(func(this *scpb.TableSystemVersioning) *scop.RemoveTableSystemVersioning {
+					return &scop.RemoveTableSystemVersioning{TableID: this.TableID}
+				}),
+			),
+		),
+	)
+}
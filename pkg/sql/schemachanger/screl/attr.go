@@ -541,6 +541,11 @@ var elementSchemaOptions = []rel.SchemaOption{
 	rel.EntityMapping(t((*scpb.RowLevelSecurityForced)(nil)),
 		rel.EntityAttr(DescID, "TableID"),
 	),
+	rel.EntityMapping(t((*scpb.TableSystemVersioning)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(ReferencedDescID, "HistoryTableID"),
+		rel.EntityAttr(ColumnID, "RowStartColumnID"),
+	),
 	rel.EntityMapping(t((*scpb.LDRJobIDs)(nil)),
 		rel.EntityAttr(DescID, "TableID"),
 	),
@@ -213,6 +213,21 @@ var StartCompactionJob func(
 	start, end hlc.Timestamp,
 ) (jobspb.JobID, error)
 
+var StartContinuousBackupJob func(
+	ctx context.Context,
+	planner interface{},
+	collectionURI, incrLoc []string,
+	fullBackupPath string,
+	encryptionOpts jobspb.BackupEncryptionOptions,
+) (jobspb.JobID, error)
+
+var DeleteUnreusedBackupFiles func(
+	ctx context.Context,
+	planner interface{},
+	collectionURI string,
+	fullBackupPath string,
+) (int, error)
+
 // builtins contains the built-in functions indexed by name.
 //
 // For use in other packages, see AllBuiltinNames and GetBuiltinProperties().
@@ -9443,6 +9458,66 @@ WHERE object_id = table_descriptor_id
 			},
 		},
 	),
+	"crdb_internal.start_continuous_backup": makeBuiltin(
+		tree.FunctionProperties{
+			Undocumented:     true,
+			DistsqlBlocklist: true, // applicable only on the gateway
+			ReturnLabels:     []string{"job_id"},
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "backup_stmt", Typ: types.String},
+				{Name: "full_backup_path", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Info: "Starts a job which continuously appends the changes to the backed up spans to " +
+				"the backup chain of the full backup, so it can be restored as of any time since.",
+			Volatility: volatility.Volatile,
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if StartContinuousBackupJob == nil {
+					return nil, errors.Newf("missing StartContinuousBackupJob")
+				}
+				backupAST, encryption, err := makeBackupASTFromStmt(args[0])
+				if err != nil {
+					return nil, err
+				}
+				collectionURI := exprSliceToStrSlice(backupAST.To)
+				incrLoc := exprSliceToStrSlice(backupAST.Options.IncrementalStorage)
+				fullPath := string(tree.MustBeDString(args[1]))
+				jobID, err := StartContinuousBackupJob(
+					ctx, evalCtx.Planner, collectionURI, incrLoc, fullPath, encryption,
+				)
+				return tree.NewDInt(tree.DInt(jobID)), err
+			},
+		},
+	),
+	"crdb_internal.delete_unreused_backup_files": makeBuiltin(
+		tree.FunctionProperties{
+			Undocumented:     true,
+			DistsqlBlocklist: true, // applicable only on the gateway
+			ReturnLabels:     []string{"files_deleted"},
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "collection_uri", Typ: types.String},
+				{Name: "full_backup_path", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Info: "Deletes the data files of the full backup that are not reused by any other " +
+				"full backup in the collection, and returns the number of deleted files. The " +
+				"backup's metadata is left in place.",
+			Volatility: volatility.Volatile,
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if DeleteUnreusedBackupFiles == nil {
+					return nil, errors.Newf("missing DeleteUnreusedBackupFiles")
+				}
+				deleted, err := DeleteUnreusedBackupFiles(
+					ctx, evalCtx.Planner, string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1])),
+				)
+				return tree.NewDInt(tree.DInt(deleted)), err
+			},
+		},
+	),
 	"crdb_internal.process_vector_index_fixups": makeBuiltin(
 		tree.FunctionProperties{
 			Category:     builtinconstants.CategoryTesting,
@@ -9760,6 +9835,125 @@ WHERE object_id = table_descriptor_id
 			Volatility: volatility.Volatile,
 		},
 	),
+	"crdb_internal.create_plan_baseline": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "statement_fingerprint", Typ: types.String},
+				{Name: "plan_gist", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return createPlanBaseline(ctx, evalCtx, args[0], args[1], false /* fixed */)
+			},
+			Info: "This function is used to create a plan baseline for the given statement" +
+				" fingerprint in the system.statement_hints table. The optimizer prefers plans" +
+				" matching the plan gist of the baseline. The baseline is replaced automatically" +
+				" when a different plan is proven to be faster. It returns the hint ID of the" +
+				" newly created baseline.",
+			Volatility: volatility.Volatile,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "statement_fingerprint", Typ: types.String},
+				{Name: "plan_gist", Typ: types.String},
+				{Name: "fixed", Typ: types.Bool},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return createPlanBaseline(ctx, evalCtx, args[0], args[1], bool(tree.MustBeDBool(args[2])))
+			},
+			Info: "This function is used to create a plan baseline for the given statement" +
+				" fingerprint in the system.statement_hints table. The optimizer prefers plans" +
+				" matching the plan gist of the baseline. Unless fixed is true, the baseline is" +
+				" replaced automatically when a different plan is proven to be faster. It returns" +
+				" the hint ID of the newly created baseline.",
+			Volatility: volatility.Volatile,
+		},
+	),
+	"crdb_internal.set_plan_baseline_fixed": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "hint_id", Typ: types.Int},
+				{Name: "fixed", Typ: types.Bool},
+			},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				hintID := int64(tree.MustBeDInt(args[0]))
+				fixed := bool(tree.MustBeDBool(args[1]))
+				ok, err := evalCtx.Planner.SetPlanBaselineFixed(ctx, hintID, fixed)
+				if err != nil {
+					return nil, err
+				}
+				return tree.MakeDBool(tree.DBool(ok)), nil
+			},
+			Info: "This function is used to fix a plan baseline, which prevents it from being" +
+				" replaced automatically, or to unfix it. It returns false if there is no plan" +
+				" baseline with the given hint ID.",
+			Volatility: volatility.Volatile,
+		},
+	),
+	"crdb_internal.drop_plan_baseline": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "hint_id", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				hintID := int64(tree.MustBeDInt(args[0]))
+				ok, err := evalCtx.Planner.DropPlanBaseline(ctx, hintID)
+				if err != nil {
+					return nil, err
+				}
+				return tree.MakeDBool(tree.DBool(ok)), nil
+			},
+			Info: "This function is used to remove a plan baseline from the system.statement_hints" +
+				" table. It returns false if there is no plan baseline with the given hint ID.",
+			Volatility: volatility.Volatile,
+		},
+	),
+	"crdb_internal.enable_result_cache": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "statement_fingerprint", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				stmtFingerprint := string(tree.MustBeDString(args[0]))
+				if _, err := parserutils.ParseOne(stmtFingerprint); err != nil {
+					return nil, pgerror.Wrap(
+						err, pgcode.InvalidParameterValue, "could not parse statement fingerprint",
+					)
+				}
+				var hint hintpb.StatementHintUnion
+				hint.SetValue(&hintpb.ResultCache{})
+				hintID, err := evalCtx.Planner.InsertStatementHint(ctx, stmtFingerprint, hint)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDInt(tree.DInt(hintID)), nil
+			},
+			Info: "This function is used to enable the query result cache for statements with" +
+				" the given fingerprint by inserting a statement hint into the" +
+				" system.statement_hints table. It returns the hint ID of the newly created hint.",
+			Volatility: volatility.Volatile,
+		},
+	),
 	"crdb_internal.clear_statement_hints_cache": makeBuiltin(
 		tree.FunctionProperties{
 			Category:         builtinconstants.CategorySystemRepair,
@@ -12463,6 +12657,28 @@ func withMaxStaleness(
 	return tree.MakeDTimestampTZ(t, time.Microsecond)
 }
 
+// createPlanBaseline validates the statement fingerprint and plan gist and
+// inserts a plan baseline for them into the system.statement_hints table.
+func createPlanBaseline(
+	ctx context.Context, evalCtx *eval.Context, fingerprint, gist tree.Datum, fixed bool,
+) (tree.Datum, error) {
+	stmtFingerprint := string(tree.MustBeDString(fingerprint))
+	planGist := string(tree.MustBeDString(gist))
+	if _, err := parserutils.ParseOne(stmtFingerprint); err != nil {
+		return nil, pgerror.Wrap(
+			err, pgcode.InvalidParameterValue, "could not parse statement fingerprint",
+		)
+	}
+	if _, err := evalCtx.Planner.DecodeGist(ctx, planGist, true /* external */); err != nil {
+		return nil, pgerror.Wrap(err, pgcode.InvalidParameterValue, "could not decode plan gist")
+	}
+	hintID, err := evalCtx.Planner.CreatePlanBaseline(ctx, stmtFingerprint, planGist, fixed)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDInt(tree.DInt(hintID)), nil
+}
+
 func jsonNumInvertedIndexEntries(_ *eval.Context, val tree.Datum) (tree.Datum, error) {
 	if val == tree.DNull {
 		return tree.DZero, nil
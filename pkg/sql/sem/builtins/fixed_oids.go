@@ -2863,6 +2863,13 @@ var builtinOidsArray = []string{
 	2908: `crdb_internal.inject_hint(statement_fingerprint: string, donor_sql: string) -> int`,
 	2909: `crdb_internal.clear_statement_hints_cache() -> void`,
 	2910: `crdb_internal.await_statement_hints_cache() -> void`,
+	2911: `crdb_internal.start_continuous_backup(backup_stmt: string, full_backup_path: string) -> int`,
+	2912: `crdb_internal.create_plan_baseline(statement_fingerprint: string, plan_gist: string) -> int`,
+	2913: `crdb_internal.create_plan_baseline(statement_fingerprint: string, plan_gist: string, fixed: bool) -> int`,
+	2914: `crdb_internal.set_plan_baseline_fixed(hint_id: int, fixed: bool) -> bool`,
+	2915: `crdb_internal.drop_plan_baseline(hint_id: int) -> bool`,
+	2916: `crdb_internal.enable_result_cache(statement_fingerprint: string) -> int`,
+	2917: `crdb_internal.delete_unreused_backup_files(collection_uri: string, full_backup_path: string) -> int`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
@@ -477,6 +477,21 @@ type Planner interface {
 	// created hint.
 	InsertStatementHint(ctx context.Context, statementFingerprint string, hint hintpb.StatementHintUnion) (int64, error)
 
+	// CreatePlanBaseline adds a plan baseline with the given plan gist for the
+	// given statement fingerprint to the system.statement_hints table. It
+	// returns the hint ID of the newly created baseline.
+	CreatePlanBaseline(ctx context.Context, statementFingerprint string, planGist string, fixed bool) (int64, error)
+
+	// SetPlanBaselineFixed sets whether the plan baseline with the given hint ID
+	// is fixed, i.e., excluded from automatic evolution. It returns false if
+	// there is no plan baseline with the given hint ID.
+	SetPlanBaselineFixed(ctx context.Context, hintID int64, fixed bool) (bool, error)
+
+	// DropPlanBaseline removes the plan baseline with the given hint ID from the
+	// system.statement_hints table. It returns false if there is no plan
+	// baseline with the given hint ID.
+	DropPlanBaseline(ctx context.Context, hintID int64) (bool, error)
+
 	// UsingHintInjection returns whether we are planning with externally-injected
 	// hints.
 	UsingHintInjection() bool
@@ -53,31 +53,33 @@ type AlterTableCmd interface {
 	alterTableCmd()
 }
 
-func (*AlterTableAddColumn) alterTableCmd()          {}
-func (*AlterTableAddConstraint) alterTableCmd()      {}
-func (*AlterTableAlterColumnType) alterTableCmd()    {}
-func (*AlterTableAlterPrimaryKey) alterTableCmd()    {}
-func (*AlterTableDropColumn) alterTableCmd()         {}
-func (*AlterTableDropConstraint) alterTableCmd()     {}
-func (*AlterTableDropNotNull) alterTableCmd()        {}
-func (*AlterTableDropStored) alterTableCmd()         {}
-func (*AlterTableSetNotNull) alterTableCmd()         {}
-func (*AlterTableRenameColumn) alterTableCmd()       {}
-func (*AlterTableRenameConstraint) alterTableCmd()   {}
-func (*AlterTableSetAudit) alterTableCmd()           {}
-func (*AlterTableSetDefault) alterTableCmd()         {}
-func (*AlterTableSetOnUpdate) alterTableCmd()        {}
-func (*AlterTableSetVisible) alterTableCmd()         {}
-func (*AlterTableValidateConstraint) alterTableCmd() {}
-func (*AlterTablePartitionByTable) alterTableCmd()   {}
-func (*AlterTableInjectStats) alterTableCmd()        {}
-func (*AlterTableSetStorageParams) alterTableCmd()   {}
-func (*AlterTableResetStorageParams) alterTableCmd() {}
-func (*AlterTableAddIdentity) alterTableCmd()        {}
-func (*AlterTableSetIdentity) alterTableCmd()        {}
-func (*AlterTableIdentity) alterTableCmd()           {}
-func (*AlterTableDropIdentity) alterTableCmd()       {}
-func (*AlterTableSetRLSMode) alterTableCmd()         {}
+func (*AlterTableAddColumn) alterTableCmd()            {}
+func (*AlterTableAddConstraint) alterTableCmd()        {}
+func (*AlterTableAlterColumnType) alterTableCmd()      {}
+func (*AlterTableAlterPrimaryKey) alterTableCmd()      {}
+func (*AlterTableDropColumn) alterTableCmd()           {}
+func (*AlterTableDropConstraint) alterTableCmd()       {}
+func (*AlterTableDropNotNull) alterTableCmd()          {}
+func (*AlterTableDropStored) alterTableCmd()           {}
+func (*AlterTableSetNotNull) alterTableCmd()           {}
+func (*AlterTableRenameColumn) alterTableCmd()         {}
+func (*AlterTableRenameConstraint) alterTableCmd()     {}
+func (*AlterTableSetAudit) alterTableCmd()             {}
+func (*AlterTableSetDefault) alterTableCmd()           {}
+func (*AlterTableSetOnUpdate) alterTableCmd()          {}
+func (*AlterTableSetVisible) alterTableCmd()           {}
+func (*AlterTableValidateConstraint) alterTableCmd()   {}
+func (*AlterTablePartitionByTable) alterTableCmd()     {}
+func (*AlterTableInjectStats) alterTableCmd()          {}
+func (*AlterTableSetStorageParams) alterTableCmd()     {}
+func (*AlterTableResetStorageParams) alterTableCmd()   {}
+func (*AlterTableAddIdentity) alterTableCmd()          {}
+func (*AlterTableSetIdentity) alterTableCmd()          {}
+func (*AlterTableIdentity) alterTableCmd()             {}
+func (*AlterTableDropIdentity) alterTableCmd()         {}
+func (*AlterTableSetRLSMode) alterTableCmd()           {}
+func (*AlterTableAddSystemVersioning) alterTableCmd()  {}
+func (*AlterTableDropSystemVersioning) alterTableCmd() {}
 
 var _ AlterTableCmd = &AlterTableAddColumn{}
 var _ AlterTableCmd = &AlterTableAddConstraint{}
@@ -103,6 +105,8 @@ var _ AlterTableCmd = &AlterTableSetIdentity{}
 var _ AlterTableCmd = &AlterTableIdentity{}
 var _ AlterTableCmd = &AlterTableDropIdentity{}
 var _ AlterTableCmd = &AlterTableSetRLSMode{}
+var _ AlterTableCmd = &AlterTableAddSystemVersioning{}
+var _ AlterTableCmd = &AlterTableDropSystemVersioning{}
 
 // ColumnMutationCmd is the subset of AlterTableCmds that modify an
 // existing column.
@@ -941,6 +945,43 @@ func (node *AlterTableSetRLSMode) Format(ctx *FmtCtx) {
 	ctx.WriteString(" ROW LEVEL SECURITY")
 }
 
+// AlterTableAddSystemVersioning represents the following alter table command:
+// ADD SYSTEM VERSIONING [USE HISTORY TABLE <name>]
+type AlterTableAddSystemVersioning struct {
+	// HistoryTable is the table that stores the previous versions of the rows
+	// of the table. If unset, it is the table named <table>_history in the
+	// schema of the table.
+	HistoryTable *UnresolvedObjectName
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableAddSystemVersioning) TelemetryName() string {
+	return "add_system_versioning"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableAddSystemVersioning) Format(ctx *FmtCtx) {
+	ctx.WriteString(" ADD SYSTEM VERSIONING")
+	if node.HistoryTable != nil {
+		ctx.WriteString(" USE HISTORY TABLE ")
+		ctx.FormatNode(node.HistoryTable)
+	}
+}
+
+// AlterTableDropSystemVersioning represents the following alter table command:
+// DROP SYSTEM VERSIONING
+type AlterTableDropSystemVersioning struct{}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableDropSystemVersioning) TelemetryName() string {
+	return "drop_system_versioning"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableDropSystemVersioning) Format(ctx *FmtCtx) {
+	ctx.WriteString(" DROP SYSTEM VERSIONING")
+}
+
 // GetTableType returns a string representing the type of table the command
 // is operating on.
 // It is assumed if the table is not a sequence or a view, then it is a
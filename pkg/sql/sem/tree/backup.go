@@ -129,6 +129,7 @@ type RestoreOptions struct {
 	ExperimentalOnline               bool
 	ExperimentalCopy                 bool
 	RemoveRegions                    bool
+	RowFilter                        Expr
 }
 
 func (opts *RestoreOptions) OnlineImpl() bool {
@@ -181,6 +182,28 @@ func (node *Restore) Format(ctx *FmtCtx) {
 	}
 }
 
+// VerifyBackup represents a VERIFY BACKUP statement.
+type VerifyBackup struct {
+	Subdir  Expr
+	From    StringOrPlaceholderOptList
+	Options KVOptions
+}
+
+var _ Statement = &VerifyBackup{}
+
+// Format implements the NodeFormatter interface.
+func (node *VerifyBackup) Format(ctx *FmtCtx) {
+	ctx.WriteString("VERIFY BACKUP FROM ")
+	ctx.FormatNode(node.Subdir)
+	ctx.WriteString(" IN ")
+	ctx.FormatURIs(node.From)
+	if node.Options != nil {
+		ctx.WriteString(" WITH OPTIONS (")
+		ctx.FormatNode(&node.Options)
+		ctx.WriteString(")")
+	}
+}
+
 // KVOption is a key-value option.
 type KVOption struct {
 	Key   Name
@@ -502,6 +525,12 @@ func (o *RestoreOptions) Format(ctx *FmtCtx) {
 		maybeAddSep()
 		ctx.WriteString("remove_regions")
 	}
+
+	if o.RowFilter != nil {
+		maybeAddSep()
+		ctx.WriteString("row_filter = ")
+		ctx.FormatNode(o.RowFilter)
+	}
 }
 
 // CombineWith merges other backup options into this backup options struct.
@@ -659,6 +688,12 @@ func (o *RestoreOptions) CombineWith(other *RestoreOptions) error {
 		o.RemoveRegions = other.RemoveRegions
 	}
 
+	if o.RowFilter == nil {
+		o.RowFilter = other.RowFilter
+	} else if other.RowFilter != nil {
+		return errors.New("row_filter option specified multiple times")
+	}
+
 	return nil
 }
 
@@ -685,7 +720,8 @@ func (o RestoreOptions) IsDefault() bool {
 		o.ExecutionLocality == options.ExecutionLocality &&
 		o.ExperimentalOnline == options.ExperimentalOnline &&
 		o.ExperimentalCopy == options.ExperimentalCopy &&
-		o.RemoveRegions == options.RemoveRegions
+		o.RemoveRegions == options.RemoveRegions &&
+		o.RowFilter == options.RowFilter
 }
 
 // BackupTargetList represents a list of targets.
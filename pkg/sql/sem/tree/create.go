@@ -1499,6 +1499,27 @@ const (
 	CreateTableOnCommitPreserveRows
 )
 
+// CloneTable represents a CREATE TABLE ... CLONE OF statement, which creates a
+// new table with the rows of an existing table as of a time in its retained
+// MVCC history.
+type CloneTable struct {
+	Table  TableName
+	Source TableName
+	AsOf   AsOfClause
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CloneTable) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TABLE ")
+	ctx.FormatNode(&node.Table)
+	ctx.WriteString(" CLONE OF ")
+	ctx.FormatNode(&node.Source)
+	if node.AsOf.Expr != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.AsOf)
+	}
+}
+
 // CreateTable represents a CREATE TABLE statement.
 type CreateTable struct {
 	IfNotExists      bool
@@ -1506,6 +1527,10 @@ type CreateTable struct {
 	PartitionByTable *PartitionByTable
 	Persistence      Persistence
 	StorageParams    StorageParams
+	// SystemVersioning is true if the table is created WITH SYSTEM VERSIONING,
+	// in which case the previous versions of its rows are kept in a history
+	// table.
+	SystemVersioning bool
 	OnCommit         CreateTableOnCommitSetting
 	// In CREATE...AS queries, Defs represents a list of ColumnTableDefs, one for
 	// each column, and a ConstraintTableDef for each constraint on a subset of
@@ -1581,6 +1606,9 @@ func (node *CreateTable) FormatBody(ctx *FmtCtx) {
 			ctx.FormatNode(&node.StorageParams)
 			ctx.WriteByte(')')
 		}
+		if node.SystemVersioning {
+			ctx.WriteString(" WITH SYSTEM VERSIONING")
+		}
 	}
 	switch node.OnCommit {
 	case CreateTableOnCommitUnset:
@@ -1982,6 +2010,9 @@ type CreateView struct {
 	Replace      bool
 	Materialized bool
 	WithData     bool
+	// Incremental is set for materialized views created WITH (incremental),
+	// which are kept up to date by a job rather than by REFRESH.
+	Incremental bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -2020,6 +2051,10 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 		ctx.WriteString(` )`)
 	}
 
+	if node.Incremental {
+		ctx.WriteString(` WITH ( incremental )`)
+	}
+
 	ctx.WriteString(" AS ")
 	ctx.FormatNode(node.AsSource)
 	if node.Materialized && node.WithData {
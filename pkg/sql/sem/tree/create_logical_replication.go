@@ -17,6 +17,9 @@ type CreateLogicalReplicationStream struct {
 	Into        LogicalReplicationResources
 	CreateTable bool
 	Options     LogicalReplicationOptions
+	// MeshURIs, if set, lists the URIs of every cluster of a logical replication
+	// mesh, in which case From and Into are the same tables and PGURL is unset.
+	MeshURIs Exprs
 }
 
 type LogicalReplicationResources struct {
@@ -43,7 +46,13 @@ var _ NodeFormatter = &LogicalReplicationOptions{}
 
 // Format implements the NodeFormatter interface.
 func (node *CreateLogicalReplicationStream) Format(ctx *FmtCtx) {
-	if node.CreateTable {
+	if len(node.MeshURIs) > 0 {
+		ctx.WriteString("CREATE LOGICAL REPLICATION MESH FROM ")
+		ctx.FormatNode(&node.From)
+		ctx.WriteString(" ON (")
+		ctx.FormatNode(&node.MeshURIs)
+		ctx.WriteString(")")
+	} else if node.CreateTable {
 		ctx.WriteString("CREATE LOGICALLY REPLICATED ")
 		ctx.FormatNode(&node.Into)
 		ctx.WriteString(" FROM ")
@@ -268,3 +277,22 @@ func (o LogicalReplicationOptions) IsDefault() bool {
 		o.BidirectionalURI == options.BidirectionalURI &&
 		o.ParentID == options.ParentID
 }
+
+// ReplayDeadLetterQueue represents a REPLAY DEAD LETTER QUEUE statement.
+type ReplayDeadLetterQueue struct {
+	JobID   Expr
+	Options KVOptions
+}
+
+var _ Statement = &ReplayDeadLetterQueue{}
+
+// Format implements the NodeFormatter interface.
+func (node *ReplayDeadLetterQueue) Format(ctx *FmtCtx) {
+	ctx.WriteString("REPLAY DEAD LETTER QUEUE FOR JOB ")
+	ctx.FormatNode(node.JobID)
+	if node.Options != nil {
+		ctx.WriteString(" WITH OPTIONS (")
+		ctx.FormatNode(&node.Options)
+		ctx.WriteString(")")
+	}
+}
@@ -638,6 +638,7 @@ const (
 	TTLUpdateExpr                   SchemaExprContext = "TTL UPDATE"
 	PolicyUsingExpr                 SchemaExprContext = "POLICY USING"
 	PolicyWithCheckExpr             SchemaExprContext = "POLICY WITH CHECK"
+	RestoreRowFilterExpr            SchemaExprContext = "RESTORE ROW FILTER"
 )
 
 func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
@@ -29,3 +29,34 @@ func (node *Export) Format(ctx *FmtCtx) {
 	ctx.WriteString(" FROM ")
 	ctx.FormatNode(node.Query)
 }
+
+// ExportDatabase represents an EXPORT DATABASE statement, which writes a
+// logical dump of a database that can be replayed by a SQL client.
+type ExportDatabase struct {
+	Database   Name
+	FileFormat string
+	File       Expr
+	Options    KVOptions
+	AsOf       AsOfClause
+}
+
+var _ Statement = &ExportDatabase{}
+
+// Format implements the NodeFormatter interface.
+func (node *ExportDatabase) Format(ctx *FmtCtx) {
+	ctx.WriteString("EXPORT DATABASE ")
+	ctx.FormatNode(&node.Database)
+	ctx.WriteString(" INTO ")
+	ctx.WriteString(node.FileFormat)
+	ctx.WriteString(" ")
+	ctx.FormatURI(node.File)
+	if node.Options != nil {
+		ctx.WriteString(" WITH OPTIONS(")
+		ctx.FormatNode(&node.Options)
+		ctx.WriteString(")")
+	}
+	if node.AsOf.Expr != nil {
+		ctx.WriteString(" ")
+		ctx.FormatNode(&node.AsOf)
+	}
+}
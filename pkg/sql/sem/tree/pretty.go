@@ -706,6 +706,9 @@ func (node *AliasedTableExpr) doc(p *PrettyCfg) pretty.Doc {
 			p.Doc(node.IndexFlags),
 		)
 	}
+	if node.SystemTime != nil {
+		d = p.nestUnder(d, p.Doc(node.SystemTime))
+	}
 	if node.Ordinality {
 		d = pretty.Concat(
 			d,
@@ -721,6 +724,9 @@ func (node *AliasedTableExpr) doc(p *PrettyCfg) pretty.Doc {
 			),
 		)
 	}
+	if node.TableSample != nil {
+		d = p.nestUnder(d, p.Doc(node.TableSample))
+	}
 	return d
 }
 
@@ -1302,6 +1308,9 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 			),
 		)
 	}
+	if node.SystemVersioning && !node.As() {
+		clauses = append(clauses, pretty.Keyword("WITH SYSTEM VERSIONING"))
+	}
 	switch node.OnCommit {
 	case CreateTableOnCommitUnset:
 	case CreateTableOnCommitPreserveRows:
@@ -1358,6 +1367,15 @@ func (node *CreateView) doc(p *PrettyCfg) pretty.Doc {
 			),
 		)
 	}
+	if node.Incremental {
+		d = pretty.ConcatSpace(
+			d,
+			pretty.ConcatSpace(
+				pretty.Keyword("WITH"),
+				p.bracket("(", pretty.Text("incremental"), ")"),
+			),
+		)
+	}
 	d = p.nestUnder(
 		pretty.ConcatSpace(d, pretty.Keyword("AS")),
 		p.Doc(node.AsSource),
@@ -143,3 +143,36 @@ func (node *ScheduledChangefeed) Format(ctx *FmtCtx) {
 		ctx.FormatNode(&node.ScheduleOptions)
 	}
 }
+
+// ScheduledVerifyBackup represents a schedule that verifies the latest backup
+// in a collection.
+type ScheduledVerifyBackup struct {
+	*VerifyBackup
+	ScheduleLabelSpec LabelSpec
+	Recurrence        Expr
+	ScheduleOptions   KVOptions
+}
+
+var _ Statement = &ScheduledVerifyBackup{}
+
+// Format implements the NodeFormatter interface.
+func (node *ScheduledVerifyBackup) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE SCHEDULE")
+	ctx.FormatNode(&node.ScheduleLabelSpec)
+	ctx.WriteString(" FOR VERIFY BACKUP IN ")
+	ctx.FormatURIs(node.From)
+
+	if node.Options != nil {
+		ctx.WriteString(" WITH OPTIONS (")
+		ctx.FormatNode(&node.Options)
+		ctx.WriteString(")")
+	}
+
+	ctx.WriteString(" RECURRING ")
+	ctx.FormatNode(node.Recurrence)
+
+	if node.ScheduleOptions != nil {
+		ctx.WriteString(" WITH SCHEDULE OPTIONS ")
+		ctx.FormatNode(&node.ScheduleOptions)
+	}
+}
@@ -17,6 +17,7 @@ package tree
 import (
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -626,6 +627,12 @@ type AliasedTableExpr struct {
 	Ordinality bool
 	Lateral    bool
 	As         AliasClause
+	// TableSample, if set, restricts the scan of the table to a random sample
+	// of its rows.
+	TableSample *TableSample
+	// SystemTime, if set, queries the history of a system-versioned table
+	// instead of its current rows.
+	SystemTime *SystemTimeClause
 }
 
 // Format implements the NodeFormatter interface.
@@ -637,6 +644,10 @@ func (node *AliasedTableExpr) Format(ctx *FmtCtx) {
 	if node.IndexFlags != nil && !ctx.HasFlags(FmtHideHints) {
 		ctx.FormatNode(node.IndexFlags)
 	}
+	if node.SystemTime != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.SystemTime)
+	}
 	if node.Ordinality {
 		ctx.WriteString(" WITH ORDINALITY")
 	}
@@ -644,6 +655,122 @@ func (node *AliasedTableExpr) Format(ctx *FmtCtx) {
 		ctx.WriteString(" AS ")
 		ctx.FormatNode(&node.As)
 	}
+	if node.TableSample != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.TableSample)
+	}
+}
+
+// TableSampleMethod is the sampling method of a TABLESAMPLE clause.
+type TableSampleMethod uint8
+
+const (
+	// TableSampleBernoulli includes each row of the table in the sample
+	// independently with the given probability.
+	TableSampleBernoulli TableSampleMethod = iota + 1
+	// TableSampleSystem includes whole blocks of rows of the table in the sample
+	// with the given probability. It is less precise than TableSampleBernoulli,
+	// but it skips the blocks which are not part of the sample instead of
+	// reading them.
+	TableSampleSystem
+)
+
+var tableSampleMethodName = [...]string{
+	TableSampleBernoulli: "BERNOULLI",
+	TableSampleSystem:    "SYSTEM",
+}
+
+func (m TableSampleMethod) String() string {
+	if m == 0 || int(m) >= len(tableSampleMethodName) {
+		return fmt.Sprintf("TableSampleMethod(%d)", m)
+	}
+	return tableSampleMethodName[m]
+}
+
+// TableSampleMethodFromString returns the TABLESAMPLE method with the given
+// case-insensitive name.
+func TableSampleMethodFromString(name string) (TableSampleMethod, error) {
+	for m, n := range tableSampleMethodName {
+		if m != 0 && strings.EqualFold(n, name) {
+			return TableSampleMethod(m), nil
+		}
+	}
+	return 0, pgerror.Newf(pgcode.UndefinedObject, "tablesample method %s does not exist", name)
+}
+
+// TableSample represents a TABLESAMPLE clause.
+type TableSample struct {
+	Method TableSampleMethod
+	// Percent is the percentage of the rows of the table to return.
+	Percent Expr
+	// Repeatable, if set, is the seed of the sample. Scans with the same seed
+	// return the same sample as long as the table does not change.
+	Repeatable Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TableSample) Format(ctx *FmtCtx) {
+	ctx.WriteString("TABLESAMPLE ")
+	ctx.WriteString(node.Method.String())
+	ctx.WriteString(" (")
+	ctx.FormatNode(node.Percent)
+	ctx.WriteByte(')')
+	if node.Repeatable != nil {
+		ctx.WriteString(" REPEATABLE (")
+		ctx.FormatNode(node.Repeatable)
+		ctx.WriteByte(')')
+	}
+}
+
+// SystemTimeKind is the kind of period specification of a FOR SYSTEM_TIME
+// clause.
+type SystemTimeKind uint8
+
+const (
+	// SystemTimeAsOf selects the rows that were current at the given time.
+	SystemTimeAsOf SystemTimeKind = iota
+	// SystemTimeBetween selects the rows that were current at any time between
+	// the given times, inclusive.
+	SystemTimeBetween
+	// SystemTimeFromTo selects the rows that were current at any time from the
+	// first given time, inclusive, to the second given time, exclusive.
+	SystemTimeFromTo
+	// SystemTimeAll selects all the current and historical rows.
+	SystemTimeAll
+)
+
+// SystemTimeClause represents a FOR SYSTEM_TIME clause, which queries the
+// history of a system-versioned table.
+type SystemTimeClause struct {
+	Kind SystemTimeKind
+	// From is the time of an AS OF clause, or the start of the period of a
+	// BETWEEN or FROM clause. It is unset for ALL.
+	From Expr
+	// To is the end of the period of a BETWEEN or FROM clause. It is unset for
+	// AS OF and ALL.
+	To Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *SystemTimeClause) Format(ctx *FmtCtx) {
+	ctx.WriteString("FOR SYSTEM_TIME ")
+	switch node.Kind {
+	case SystemTimeAsOf:
+		ctx.WriteString("AS OF ")
+		ctx.FormatNode(node.From)
+	case SystemTimeBetween:
+		ctx.WriteString("BETWEEN ")
+		ctx.FormatNode(node.From)
+		ctx.WriteString(" AND ")
+		ctx.FormatNode(node.To)
+	case SystemTimeFromTo:
+		ctx.WriteString("FROM ")
+		ctx.FormatNode(node.From)
+		ctx.WriteString(" TO ")
+		ctx.FormatNode(node.To)
+	case SystemTimeAll:
+		ctx.WriteString("ALL")
+	}
 }
 
 // ParenTableExpr represents a parenthesized TableExpr.
@@ -1399,6 +1399,10 @@ const (
 	// ScheduledChangefeedExecutor is an executor responsible for
 	// the execution of the scheduled changefeeds.
 	ScheduledChangefeedExecutor
+
+	// ScheduledVerifyBackupExecutor is an executor responsible for
+	// the execution of the scheduled backup verifications.
+	ScheduledVerifyBackupExecutor
 )
 
 var scheduleExecutorInternalNames = map[ScheduledJobExecutorType]string{
@@ -1408,6 +1412,7 @@ var scheduleExecutorInternalNames = map[ScheduledJobExecutorType]string{
 	ScheduledRowLevelTTLExecutor:        "scheduled-row-level-ttl-executor",
 	ScheduledSchemaTelemetryExecutor:    "scheduled-schema-telemetry-executor",
 	ScheduledChangefeedExecutor:         "scheduled-changefeed-executor",
+	ScheduledVerifyBackupExecutor:       "scheduled-verify-backup-executor",
 }
 
 // InternalName returns an internal executor name.
@@ -1429,6 +1434,8 @@ func (t ScheduledJobExecutorType) UserName() string {
 		return "SCHEMA TELEMETRY"
 	case ScheduledChangefeedExecutor:
 		return "CHANGEFEED"
+	case ScheduledVerifyBackupExecutor:
+		return "VERIFY BACKUP"
 	}
 	return "unsupported-executor"
 }
@@ -188,7 +188,7 @@ func CanWriteData(stmt Statement) bool {
 	case *Insert, *Delete, *Update, *Truncate:
 		return true
 	// Import operations.
-	case *CopyFrom, *Import, *Restore:
+	case *CopyFrom, *Import, *Restore, *CloneTable:
 		return true
 	// Backup creates a job and allows you to write into userfiles.
 	case *Backup:
@@ -197,7 +197,8 @@ func CanWriteData(stmt Statement) bool {
 	case *Split, *Unsplit, *Relocate, *RelocateRange, *Scatter:
 		return true
 	// Replication operations.
-	case *CreateTenantFromReplication, *AlterTenantReplication, *CreateLogicalReplicationStream:
+	case *CreateTenantFromReplication, *AlterTenantReplication, *CreateLogicalReplicationStream,
+		*ReplayDeadLetterQueue:
 		return true
 	}
 	return false
@@ -280,9 +281,14 @@ var _ CCLOnlyStatement = &CreateChangefeed{}
 var _ CCLOnlyStatement = &AlterChangefeed{}
 var _ CCLOnlyStatement = &Import{}
 var _ CCLOnlyStatement = &Export{}
+var _ CCLOnlyStatement = &ExportDatabase{}
 var _ CCLOnlyStatement = &ScheduledBackup{}
+var _ CCLOnlyStatement = &ScheduledVerifyBackup{}
+var _ CCLOnlyStatement = &VerifyBackup{}
 var _ CCLOnlyStatement = &CreateTenantFromReplication{}
 var _ CCLOnlyStatement = &CreateLogicalReplicationStream{}
+var _ CCLOnlyStatement = &ReplayDeadLetterQueue{}
+var _ CCLOnlyStatement = &CloneTable{}
 
 // StatementReturnType implements the Statement interface.
 func (*AlterChangefeed) StatementReturnType() StatementReturnType { return Rows }
@@ -826,6 +832,19 @@ func (*CannedOptPlan) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of statement.
 func (*CannedOptPlan) StatementTag() string { return "PREPARE AS OPT PLAN" }
 
+// StatementReturnType implements the Statement interface.
+func (*CloneTable) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*CloneTable) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CloneTable) StatementTag() string { return "CREATE TABLE CLONE" }
+
+func (*CloneTable) cclOnlyStatement() {}
+
+func (*CloneTable) planHookStatement() {}
+
 // StatementReturnType implements the Statement interface.
 func (*CloseCursor) StatementReturnType() StatementReturnType { return Ack }
 
@@ -967,6 +986,21 @@ func (*ScheduledChangefeed) cclOnlyStatement() {}
 
 func (*ScheduledChangefeed) planHookStatement() {}
 
+// StatementReturnType implements the Statement interface.
+func (*ScheduledVerifyBackup) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*ScheduledVerifyBackup) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ScheduledVerifyBackup) StatementTag() string { return "SCHEDULED VERIFY BACKUP" }
+
+func (*ScheduledVerifyBackup) cclOnlyStatement() {}
+
+func (*ScheduledVerifyBackup) planHookStatement() {}
+
+func (*ScheduledVerifyBackup) hiddenFromShowQueries() {}
+
 // StatementReturnType implements the Statement interface.
 func (*CreateDatabase) StatementReturnType() StatementReturnType { return DDL }
 
@@ -1343,6 +1377,19 @@ func (*Export) planHookStatement() {}
 // StatementTag returns a short string identifying the type of statement.
 func (*Export) StatementTag() string { return "EXPORT" }
 
+// StatementReturnType implements the Statement interface.
+func (*ExportDatabase) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*ExportDatabase) StatementType() StatementType { return TypeDML }
+
+func (*ExportDatabase) cclOnlyStatement() {}
+
+func (*ExportDatabase) planHookStatement() {}
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ExportDatabase) StatementTag() string { return "EXPORT DATABASE" }
+
 // StatementReturnType implements the Statement interface.
 func (*Grant) StatementReturnType() StatementReturnType { return DDL }
 
@@ -1574,6 +1621,19 @@ func (n *RelocateRange) StatementTag() string {
 	return "RELOCATE RANGE " + n.SubjectReplicas.String()
 }
 
+// StatementReturnType implements the Statement interface.
+func (*ReplayDeadLetterQueue) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*ReplayDeadLetterQueue) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ReplayDeadLetterQueue) StatementTag() string { return "REPLAY DEAD LETTER QUEUE" }
+
+func (*ReplayDeadLetterQueue) cclOnlyStatement() {}
+
+func (*ReplayDeadLetterQueue) planHookStatement() {}
+
 // StatementReturnType implements the Statement interface.
 func (*Restore) StatementReturnType() StatementReturnType { return Rows }
 
@@ -2420,6 +2480,21 @@ func (*ValuesClause) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of statement.
 func (*ValuesClause) StatementTag() string { return "VALUES" }
 
+// StatementReturnType implements the Statement interface.
+func (*VerifyBackup) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*VerifyBackup) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*VerifyBackup) StatementTag() string { return "VERIFY BACKUP" }
+
+func (*VerifyBackup) cclOnlyStatement() {}
+
+func (*VerifyBackup) planHookStatement() {}
+
+func (*VerifyBackup) hiddenFromShowQueries() {}
+
 // StatementReturnType implements the Statement interface.
 func (*CreateRoutine) StatementReturnType() StatementReturnType { return DDL }
 
@@ -2609,6 +2684,7 @@ func (n *ControlJobsOfType) String() string                   { return AsString(
 func (n *CancelQueries) String() string                       { return AsString(n) }
 func (n *CancelSessions) String() string                      { return AsString(n) }
 func (n *CannedOptPlan) String() string                       { return AsString(n) }
+func (n *CloneTable) String() string                          { return AsString(n) }
 func (n *CloseCursor) String() string                         { return AsString(n) }
 func (n *CommentOnColumn) String() string                     { return AsString(n) }
 func (n *CommentOnConstraint) String() string                 { return AsString(n) }
@@ -2658,6 +2734,7 @@ func (n *Execute) String() string                             { return AsString(
 func (n *Explain) String() string                             { return AsString(n) }
 func (n *ExplainAnalyze) String() string                      { return AsString(n) }
 func (n *Export) String() string                              { return AsString(n) }
+func (n *ExportDatabase) String() string                      { return AsString(n) }
 func (n *CreateExternalConnection) String() string            { return AsString(n) }
 func (n *AlterExternalConnection) String() string             { return AsString(n) }
 func (n *CheckExternalConnection) String() string             { return AsString(n) }
@@ -2683,6 +2760,7 @@ func (n *RenameDatabase) String() string                      { return AsString(
 func (n *ReparentDatabase) String() string                    { return AsString(n) }
 func (n *RenameIndex) String() string                         { return AsString(n) }
 func (n *RenameTable) String() string                         { return AsString(n) }
+func (n *ReplayDeadLetterQueue) String() string               { return AsString(n) }
 func (n *Restore) String() string                             { return AsString(n) }
 func (n *RoutineReturn) String() string                       { return AsString(n) }
 func (n *Revoke) String() string                              { return AsString(n) }
@@ -2693,6 +2771,7 @@ func (n *RollbackTransaction) String() string                 { return AsString(
 func (n *Savepoint) String() string                           { return AsString(n) }
 func (n *Scatter) String() string                             { return AsString(n) }
 func (n *ScheduledBackup) String() string                     { return AsString(n) }
+func (n *ScheduledVerifyBackup) String() string               { return AsString(n) }
 func (n *Scrub) String() string                               { return AsString(n) }
 func (n *Select) String() string                              { return AsString(n) }
 func (n *SelectClause) String() string                        { return AsString(n) }
@@ -2773,3 +2852,4 @@ func (n *UnionClause) String() string                         { return AsString(
 func (n *Unsplit) String() string                             { return AsString(n) }
 func (n *Update) String() string                              { return AsString(n) }
 func (n *ValuesClause) String() string                        { return AsString(n) }
+func (n *VerifyBackup) String() string                        { return AsString(n) }
@@ -0,0 +1,54 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/types"
+
+const (
+	// SystemVersioningRowStartColName is the name of the hidden column of a
+	// system-versioned table that stores the time at which each row version
+	// became current. The history table has a column with the same name.
+	SystemVersioningRowStartColName Name = "valid_from"
+	// SystemVersioningRowEndColName is the name of the column of a history
+	// table that stores the time at which each row version stopped being
+	// current.
+	SystemVersioningRowEndColName Name = "valid_to"
+	// SystemVersioningHistoryTableSuffix is appended to the name of a
+	// system-versioned table to form the name of its history table, if no
+	// history table is specified.
+	SystemVersioningHistoryTableSuffix = "_history"
+)
+
+// SystemVersioningTimestampExpr is the expression that is used as the row
+// start time of new row versions and as the row end time of old row versions.
+// All row versions written by a transaction share the same timestamp.
+var SystemVersioningTimestampExpr Expr = &FuncExpr{
+	Func: WrapFunction("transaction_timestamp"),
+}
+
+// SystemVersioningRowStartColumnDef returns the definition of the hidden row
+// start column that is added to a system-versioned table.
+func SystemVersioningRowStartColumnDef() *ColumnTableDef {
+	c := &ColumnTableDef{
+		Name:   SystemVersioningRowStartColName,
+		Type:   types.TimestampTZ,
+		Hidden: true,
+	}
+	c.Nullable.Nullability = NotNull
+	c.DefaultExpr.Expr = SystemVersioningTimestampExpr
+	c.OnUpdateExpr.Expr = SystemVersioningTimestampExpr
+	return c
+}
+
+// SystemVersioningHistoryColumnDefs returns the definitions of the row start
+// and row end columns of a history table.
+func SystemVersioningHistoryColumnDefs() TableDefs {
+	start := &ColumnTableDef{Name: SystemVersioningRowStartColName, Type: types.TimestampTZ}
+	start.Nullable.Nullability = NotNull
+	end := &ColumnTableDef{Name: SystemVersioningRowEndColName, Type: types.TimestampTZ}
+	end.Nullable.Nullability = NotNull
+	return TableDefs{start, end}
+}
@@ -590,9 +590,38 @@ func (expr *Subquery) WalkTableExpr(v Visitor) TableExpr {
 // WalkTableExpr implements the TableExpr interface.
 func (expr *AliasedTableExpr) WalkTableExpr(v Visitor) TableExpr {
 	newExpr, changed := walkTableExpr(v, expr.Expr)
+	sample := expr.TableSample
+	if sample != nil {
+		percent, changedPercent := WalkExpr(v, sample.Percent)
+		repeatable, changedRepeatable := sample.Repeatable, false
+		if repeatable != nil {
+			repeatable, changedRepeatable = WalkExpr(v, repeatable)
+		}
+		if changedPercent || changedRepeatable {
+			sample = &TableSample{Method: sample.Method, Percent: percent, Repeatable: repeatable}
+			changed = true
+		}
+	}
+	systemTime := expr.SystemTime
+	if systemTime != nil {
+		from, changedFrom := systemTime.From, false
+		if from != nil {
+			from, changedFrom = WalkExpr(v, from)
+		}
+		to, changedTo := systemTime.To, false
+		if to != nil {
+			to, changedTo = WalkExpr(v, to)
+		}
+		if changedFrom || changedTo {
+			systemTime = &SystemTimeClause{Kind: systemTime.Kind, From: from, To: to}
+			changed = true
+		}
+	}
 	if changed {
 		exprCopy := *expr
 		exprCopy.Expr = newExpr
+		exprCopy.TableSample = sample
+		exprCopy.SystemTime = systemTime
 		return &exprCopy
 	}
 	return expr
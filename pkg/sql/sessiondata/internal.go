@@ -71,12 +71,23 @@ type InternalExecutorOverride struct {
 	// write of unspecified origin, and 2+ are reserved to identify remote writes
 	// from specific clusters.
 	OriginIDForLogicalDataReplication uint32
+	// LocalOriginIDForLogicalDataReplication is the origin ID that identifies
+	// the local cluster in a logical replication mesh, used to break ties
+	// between writes with equal origin timestamps.
+	LocalOriginIDForLogicalDataReplication uint32
 	// OriginTimestampForLogicalDataReplication is the mvcc timestamp the data
 	// written in this session were originally written with before being
 	// replicated via Logical Data Replication. The creator of this internal
 	// executor session is responsible for ensuring that every row it writes via
 	// the internal executor had this origin timestamp.
 	OriginTimestampForLogicalDataReplication hlc.Timestamp
+	// AllowSchemaChangesOnLDRTables, if true, permits schema changes on tables
+	// referenced by logical data replication jobs which would otherwise be
+	// disallowed.
+	AllowSchemaChangesOnLDRTables bool
+	// AllowMaterializedViewMutations, if true, permits writes to the backing
+	// tables of materialized views.
+	AllowMaterializedViewMutations bool
 	// PlanCacheMode, if set, overrides the plan_cache_mode session variable.
 	PlanCacheMode *sessiondatapb.PlanCacheMode
 	// DisablePlanGists, if true, overrides the disable_plan_gists session var.
@@ -369,6 +369,22 @@ func (m *SessionDataMutator) SetOptimizerUseMergedPartialStatistics(val bool) {
 	m.Data.OptimizerUseMergedPartialStatistics = val
 }
 
+func (m *SessionDataMutator) SetOptimizerUseExtendedStatistics(val bool) {
+	m.Data.OptimizerUseExtendedStatistics = val
+}
+
+func (m *SessionDataMutator) SetOptimizerUseAdaptiveReoptimization(val bool) {
+	m.Data.OptimizerUseAdaptiveReoptimization = val
+}
+
+func (m *SessionDataMutator) SetResultCacheEnabled(val bool) {
+	m.Data.ResultCacheEnabled = val
+}
+
+func (m *SessionDataMutator) SetMaxLocalParallelism(val int64) {
+	m.Data.MaxLocalParallelism = val
+}
+
 func (m *SessionDataMutator) SetOptimizerUseHistograms(val bool) {
 	m.Data.OptimizerUseHistograms = val
 }
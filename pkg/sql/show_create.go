@@ -216,6 +216,19 @@ func ShowCreateTable(
 		return "", err
 	}
 
+	// Like foreign keys, system versioning references another table, so it is
+	// omitted from the create statement without FK clauses and shown in the
+	// alter statements instead.
+	if displayOptions.FKDisplayMode != OmitFKClausesFromCreate {
+		stmt, err := showSystemVersioningStatement(tn, desc, lCtx, dbPrefix)
+		if err != nil && displayOptions.FKDisplayMode != OmitMissingFKClausesFromCreate {
+			return "", err
+		} else if err == nil && stmt != "" {
+			f.WriteString(";\n")
+			f.WriteString(stmt)
+		}
+	}
+
 	if !displayOptions.IgnoreComments {
 		if err := showComments(tn, desc, selectComment(ctx, p, desc.GetID()), &f.Buffer); err != nil {
 			return "", err
@@ -257,6 +270,34 @@ func showRLSAlterStatement(tn *tree.TableName, table catalog.TableDescriptor) (s
 	return f.CloseAndGetString(), nil
 }
 
+// showSystemVersioningStatement returns the ALTER TABLE ... ADD SYSTEM
+// VERSIONING statement of a system-versioned table, or the empty string if the
+// table is not system-versioned.
+func showSystemVersioningStatement(
+	tn *tree.TableName, table catalog.TableDescriptor, lCtx simpleSchemaResolver, dbPrefix string,
+) (string, error) {
+	sv := table.TableDesc().SystemVersioning
+	if sv == nil {
+		return "", nil
+	}
+	hist, err := lCtx.getTableByID(sv.HistoryTableID)
+	if err != nil {
+		return "", err
+	}
+	histName, err := getTableNameFromTableDescriptor(lCtx, hist, dbPrefix)
+	if err != nil {
+		return "", err
+	}
+	f := tree.NewFmtCtx(tree.FmtSimple)
+	f.FormatNode(&tree.AlterTable{
+		Table: tn.ToUnresolvedObjectName(),
+		Cmds: tree.AlterTableCmds{
+			&tree.AlterTableAddSystemVersioning{HistoryTable: histName.ToUnresolvedObjectName()},
+		},
+	})
+	return f.CloseAndGetString(), nil
+}
+
 // showPolicyStatement returns a string of the row level security POLICY statements
 func showPolicyStatement(
 	ctx context.Context,
@@ -135,7 +135,11 @@ func ShowCreateView(
 			f.WriteRune(',')
 		}
 	}
-	f.WriteString(") AS ")
+	f.WriteString(")")
+	if desc.TableDesc().IncrementalRefreshJobID != 0 {
+		f.WriteString(" WITH ( incremental )")
+	}
+	f.WriteString(" AS ")
 
 	cfg := tree.DefaultPrettyCfg()
 	cfg.UseTabs = true
@@ -0,0 +1,228 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package stats
+
+import (
+	"context"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/container/heap"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+)
+
+// ExtendedStatisticsClusterMode controls the cluster setting for enabling
+// collection of extended statistics (most common values and dependency
+// degrees) for multi-column statistics.
+var ExtendedStatisticsClusterMode = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.stats.extended_statistics_collection.enabled",
+	"whether to collect most common values and dependency degrees for multi-column statistics",
+	true,
+)
+
+// MaxExtendedStatisticsMCVs controls the cluster setting for the maximum
+// number of most common values collected for each multi-column statistic.
+var MaxExtendedStatisticsMCVs = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.stats.extended_statistics.max_most_common_values",
+	"maximum number of most common values to collect for each multi-column statistic",
+	100,
+	settings.NonNegativeIntWithMaximum(math.MaxUint32),
+)
+
+// BuildExtendedStatistics returns a HistogramData containing the extended
+// statistics of a multi-column statistic, computed from a set of samples.
+// colIdxs are the indexes of the statistic's columns in the sampled rows, and
+// colTypes their types. numRows is the total number of rows from which the
+// samples were taken (including rows with NULL values).
+//
+// Two kinds of extended statistics are computed, only considering samples
+// which have no NULL values on the columns:
+//
+//   - The most common combinations of values, up to maxMCVs of them. As with
+//     single-column histograms, a combination is only considered common if it
+//     occurs more often than the average combination.
+//
+//   - The dependency degree of the last column on the preceding columns. This
+//     is the fraction of samples in groups (of samples with equal values on the
+//     preceding columns) where all samples have the same value on the last
+//     column. A degree of 1 means that the last column is functionally
+//     determined by the preceding columns, e.g. state by (city), while a
+//     degree close to 0 means the columns are independent.
+func BuildExtendedStatistics(
+	ctx context.Context,
+	memAcc *mon.BoundAccount,
+	samples []SampledRow,
+	colIdxs []int,
+	colTypes []*types.T,
+	numRows int64,
+	maxMCVs int,
+) (HistogramData, error) {
+	if len(colIdxs) < 2 || len(colIdxs) != len(colTypes) {
+		return HistogramData{}, errors.AssertionFailedf(
+			"extended statistics require at least two columns with types",
+		)
+	}
+	version := HistVersion
+	h := HistogramData{
+		ColumnTypes: colTypes,
+		Version:     version,
+	}
+
+	// combination is a distinct combination of values in the samples.
+	type combination struct {
+		values [][]byte
+		count  int
+	}
+	// prefixGroup tracks the samples with the same values on all but the last
+	// column.
+	type prefixGroup struct {
+		last       string
+		count      int
+		consistent bool
+	}
+	var combinations []combination
+	combinationIdx := make(map[string]int)
+	prefixGroups := make(map[string]*prefixGroup)
+	nonNullSamples := 0
+	var key []byte
+
+EachSample:
+	for _, sample := range samples {
+		key = key[:0]
+		values := make([][]byte, len(colIdxs))
+		prefixLen := 0
+		for i, colIdx := range colIdxs {
+			d := sample.Row[colIdx].Datum
+			if d == nil {
+				return HistogramData{}, errors.AssertionFailedf("value in column %d not decoded", colIdx)
+			}
+			if d == tree.DNull {
+				continue EachSample
+			}
+			enc, err := EncodeUpperBound(version, d)
+			if err != nil {
+				return HistogramData{}, err
+			}
+			values[i] = enc
+			if i == len(colIdxs)-1 {
+				prefixLen = len(key)
+			}
+			// Encoded values are self-delimiting, so the concatenation of the
+			// values uniquely identifies the combination.
+			key = append(key, enc...)
+		}
+		nonNullSamples++
+
+		if idx, ok := combinationIdx[string(key)]; ok {
+			combinations[idx].count++
+		} else {
+			if err := memAcc.Grow(ctx, int64(2*len(key))); err != nil {
+				return HistogramData{}, err
+			}
+			combinationIdx[string(key)] = len(combinations)
+			combinations = append(combinations, combination{values: values, count: 1})
+		}
+
+		prefix, last := string(key[:prefixLen]), string(key[prefixLen:])
+		if g, ok := prefixGroups[prefix]; ok {
+			g.count++
+			g.consistent = g.consistent && g.last == last
+		} else {
+			prefixGroups[prefix] = &prefixGroup{last: last, count: 1, consistent: true}
+		}
+	}
+	if nonNullSamples == 0 {
+		return h, nil
+	}
+
+	// Compute the dependency degree.
+	supportingSamples := 0
+	for _, g := range prefixGroups {
+		if g.consistent {
+			supportingSamples += g.count
+		}
+	}
+	h.DependencyDegree = float64(supportingSamples) / float64(nonNullSamples)
+
+	// Use a heap to find the most common combinations.
+	mcvs := make(MCVHeap, 0, maxMCVs+1)
+	heap.Init[MCV](&mcvs)
+	for i := range combinations {
+		heap.Push[MCV](&mcvs, MCV{idx: i, count: combinations[i].count})
+		if len(mcvs) > maxMCVs {
+			heap.Pop[MCV](&mcvs)
+		}
+	}
+
+	// Only keep the combinations that are actually common. If the frequency of
+	// any combination is less than or equal to the average sample frequency,
+	// remove it.
+	expectedCount := nonNullSamples / len(combinations)
+	for len(mcvs) > 0 && mcvs[0].count <= expectedCount {
+		heap.Pop[MCV](&mcvs)
+	}
+
+	// Scale the sample counts to the total number of rows, in order of
+	// decreasing frequency.
+	scale := float64(numRows) / float64(len(samples))
+	h.MostCommonValues = make([]HistogramData_MultiColumnValue, len(mcvs))
+	for i := len(mcvs) - 1; i >= 0; i-- {
+		mcv := heap.Pop[MCV](&mcvs)
+		h.MostCommonValues[i] = HistogramData_MultiColumnValue{
+			NumEq:  int64(math.Round(float64(mcv.count) * scale)),
+			Values: combinations[mcv.idx].values,
+		}
+	}
+	return h, nil
+}
+
+// HasExtendedStatistics returns true if the HistogramData contains the
+// extended statistics of a multi-column statistic rather than a histogram.
+func (h *HistogramData) HasExtendedStatistics() bool {
+	return h != nil && len(h.ColumnTypes) > 0
+}
+
+// DecodeMostCommonValues decodes the most common values of the extended
+// statistics in HistogramData. Combinations containing enum values that were
+// dropped are skipped.
+func (h *HistogramData) DecodeMostCommonValues() ([]cat.MultiColumnValue, error) {
+	if len(h.MostCommonValues) == 0 {
+		return nil, nil
+	}
+	var a tree.DatumAlloc
+	mcvs := make([]cat.MultiColumnValue, 0, len(h.MostCommonValues))
+EachValue:
+	for i := range h.MostCommonValues {
+		mcv := &h.MostCommonValues[i]
+		if len(mcv.Values) != len(h.ColumnTypes) {
+			return nil, errors.AssertionFailedf(
+				"expected %d most common values, found %d", len(h.ColumnTypes), len(mcv.Values),
+			)
+		}
+		values := make(tree.Datums, len(mcv.Values))
+		for j, typ := range h.ColumnTypes {
+			datum, err := DecodeUpperBound(h.Version, typ, &a, mcv.Values[j])
+			if err != nil {
+				if typ.Family() == types.EnumFamily && errors.Is(err, types.EnumValueNotFound) {
+					continue EachValue
+				}
+				return nil, err
+			}
+			values[j] = datum
+		}
+		mcvs = append(mcvs, cat.MultiColumnValue{
+			NumEq:  float64(mcv.NumEq),
+			Values: values,
+		})
+	}
+	return mcvs, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package stats
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildExtendedStatistics(t *testing.T) {
+	type expMCV struct {
+		numEq  int64
+		values string
+	}
+	testCases := []struct {
+		// Each sample is a pair of values, where "NULL" represents NULL.
+		samples    [][2]string
+		numRows    int64
+		maxMCVs    int
+		dependency float64
+		mcvs       []expMCV
+	}{
+		{
+			// The state is determined by the city.
+			samples: [][2]string{
+				{"NYC", "NY"}, {"LA", "CA"}, {"NYC", "NY"}, {"SF", "CA"}, {"NYC", "NY"},
+				{"LA", "CA"}, {"Albany", "NY"}, {"NYC", "NY"}, {"NULL", "NY"}, {"LA", "CA"},
+			},
+			numRows:    100,
+			maxMCVs:    10,
+			dependency: 1,
+			mcvs: []expMCV{
+				{numEq: 40, values: "('NYC', 'NY')"},
+				{numEq: 30, values: "('LA', 'CA')"},
+			},
+		},
+		{
+			// Only the single most common value is kept.
+			samples: [][2]string{
+				{"NYC", "NY"}, {"LA", "CA"}, {"NYC", "NY"}, {"SF", "CA"}, {"NYC", "NY"},
+				{"LA", "CA"}, {"Albany", "NY"}, {"NYC", "NY"}, {"NULL", "NY"}, {"LA", "CA"},
+			},
+			numRows:    10,
+			maxMCVs:    1,
+			dependency: 1,
+			mcvs: []expMCV{
+				{numEq: 4, values: "('NYC', 'NY')"},
+			},
+		},
+		{
+			// The columns are independent, and no combination is more common than
+			// the others.
+			samples: [][2]string{
+				{"a", "x"}, {"a", "y"}, {"b", "x"}, {"b", "y"},
+				{"a", "x"}, {"a", "y"}, {"b", "x"}, {"b", "y"},
+			},
+			numRows:    8,
+			maxMCVs:    10,
+			dependency: 0,
+		},
+		{
+			// Only the samples with a = 'b' support the dependency.
+			samples: [][2]string{
+				{"a", "x"}, {"a", "x"}, {"a", "x"}, {"a", "y"}, {"b", "x"}, {"b", "x"},
+			},
+			numRows:    6,
+			maxMCVs:    10,
+			dependency: 2.0 / 6.0,
+			mcvs: []expMCV{
+				{numEq: 3, values: "('a', 'x')"},
+			},
+		},
+		{
+			// All samples have NULL values.
+			samples: [][2]string{
+				{"NULL", "x"}, {"a", "NULL"},
+			},
+			numRows: 2,
+			maxMCVs: 10,
+		},
+	}
+
+	ctx := context.Background()
+	colTypes := []*types.T{types.String, types.String}
+	for i, tc := range testCases {
+		t.Run("", func(t *testing.T) {
+			samples := make([]SampledRow, len(tc.samples))
+			for j, s := range tc.samples {
+				row := make(rowenc.EncDatumRow, 2)
+				for k := range s {
+					var d tree.Datum = tree.DNull
+					if s[k] != "NULL" {
+						d = tree.NewDString(s[k])
+					}
+					row[k] = rowenc.EncDatum{Datum: d}
+				}
+				samples[j] = SampledRow{Row: row, Rank: uint64(j)}
+			}
+
+			memAcc := mon.NewStandaloneUnlimitedAccount()
+			h, err := BuildExtendedStatistics(
+				ctx, memAcc, samples, []int{0, 1}, colTypes, tc.numRows, tc.maxMCVs,
+			)
+			require.NoError(t, err)
+			require.True(t, h.HasExtendedStatistics())
+			if math.Abs(h.DependencyDegree-tc.dependency) > 1e-9 {
+				t.Errorf("test case %d: expected dependency degree %f, got %f",
+					i, tc.dependency, h.DependencyDegree)
+			}
+
+			mcvs, err := h.DecodeMostCommonValues()
+			require.NoError(t, err)
+			require.Len(t, mcvs, len(tc.mcvs))
+			for j := range mcvs {
+				require.Equal(t, float64(tc.mcvs[j].numEq), mcvs[j].NumEq)
+				require.Equal(t, tc.mcvs[j].values, tree.AsString(&mcvs[j].Values))
+			}
+		})
+	}
+}
@@ -351,6 +351,30 @@ func forecastColumnStatistics(
 		}
 	}
 
+	// Carry over the extended statistics of the latest observed multi-column
+	// statistic, scaling the most common values to the forecasted row count.
+	if latest := observed[0]; latest.HistogramData.HasExtendedStatistics() {
+		histData := HistogramData{
+			ColumnTypes:      latest.HistogramData.ColumnTypes,
+			Version:          latest.HistogramData.Version,
+			DependencyDegree: latest.HistogramData.DependencyDegree,
+		}
+		if latest.RowCount > 0 {
+			scale := rowCount / float64(latest.RowCount)
+			histData.MostCommonValues = make(
+				[]HistogramData_MultiColumnValue, len(latest.HistogramData.MostCommonValues),
+			)
+			for i, mcv := range latest.HistogramData.MostCommonValues {
+				mcv.NumEq = int64(math.Round(float64(mcv.NumEq) * scale))
+				histData.MostCommonValues[i] = mcv
+			}
+		}
+		forecast.HistogramData = &histData
+		if forecast.MostCommonValues, err = histData.DecodeMostCommonValues(); err != nil {
+			return nil, err
+		}
+	}
+
 	return forecast, nil
 }
 
@@ -40,6 +40,24 @@ type JSONStatistic struct {
 	HistogramVersion    HistogramVersion  `json:"histo_version,omitempty"`
 	PartialPredicate    string            `json:"partial_predicate,omitempty"`
 	FullStatisticID     uint64            `json:"full_statistic_id,omitempty"`
+	// ExtendedColumnTypes contains the string representations of the column
+	// types of a multi-column statistic with extended statistics (or is unset
+	// if there are no extended statistics). Parsable with
+	// tree.GetTypeFromValidSQLSyntax.
+	ExtendedColumnTypes []string               `json:"ext_col_types,omitempty"`
+	MostCommonValues    []JSONMultiColumnValue `json:"most_common_values,omitempty"`
+	DependencyDegree    float64                `json:"dependency_degree,omitempty"`
+}
+
+// JSONMultiColumnValue is a struct used for JSON marshaling and unmarshaling
+// of the most common values of extended statistics.
+//
+// See HistogramData for a description of the fields.
+type JSONMultiColumnValue struct {
+	NumEq int64 `json:"num_eq"`
+	// Values contains the string representation of the datum for each column;
+	// parsable with sqlbase.ParseDatumStringAs.
+	Values []string `json:"values"`
 }
 
 // JSONHistoBucket is a struct used for JSON marshaling and unmarshaling of
@@ -55,8 +73,12 @@ type JSONHistoBucket struct {
 	UpperBound string `json:"upper_bound"`
 }
 
-// SetHistogram fills in the HistogramColumnType and HistogramBuckets fields.
+// SetHistogram fills in the HistogramColumnType and HistogramBuckets fields,
+// or the extended statistics fields if h contains extended statistics.
 func (js *JSONStatistic) SetHistogram(ctx context.Context, h *HistogramData) error {
+	if h.HasExtendedStatistics() {
+		return js.setExtendedStatistics(h)
+	}
 	typ := h.ColumnType
 	if typ == nil {
 		return fmt.Errorf("histogram type is unset")
@@ -85,6 +107,34 @@ func (js *JSONStatistic) SetHistogram(ctx context.Context, h *HistogramData) err
 	return nil
 }
 
+// setExtendedStatistics fills in the ExtendedColumnTypes, MostCommonValues and
+// DependencyDegree fields.
+func (js *JSONStatistic) setExtendedStatistics(h *HistogramData) error {
+	js.ExtendedColumnTypes = make([]string, len(h.ColumnTypes))
+	for i, typ := range h.ColumnTypes {
+		js.ExtendedColumnTypes[i] = typ.SQLStringFullyQualified()
+	}
+	js.HistogramVersion = h.Version
+	js.DependencyDegree = h.DependencyDegree
+
+	mcvs, err := h.DecodeMostCommonValues()
+	if err != nil {
+		return err
+	}
+	js.MostCommonValues = make([]JSONMultiColumnValue, len(mcvs))
+	for i := range mcvs {
+		values := make([]string, len(mcvs[i].Values))
+		for j, d := range mcvs[i].Values {
+			values[j] = tree.AsStringWithFlags(d, tree.FmtExport|tree.FmtAlwaysQualifyUserDefinedTypeNames)
+		}
+		js.MostCommonValues[i] = JSONMultiColumnValue{
+			NumEq:  int64(mcvs[i].NumEq),
+			Values: values,
+		}
+	}
+	return nil
+}
+
 // DecodeAndSetHistogram decodes a histogram marshaled as a Bytes datum and
 // fills in the JSONStatistic histogram fields.
 func (js *JSONStatistic) DecodeAndSetHistogram(
@@ -104,26 +154,43 @@ func (js *JSONStatistic) DecodeAndSetHistogram(
 	if err := protoutil.Unmarshal([]byte(*datum.(*tree.DBytes)), h); err != nil {
 		return err
 	}
-	// If the serialized column type is user defined, then it needs to be
+	// If the serialized column types are user defined, then they need to be
 	// hydrated before use.
-	if h.ColumnType.UserDefined() {
+	hydrate := func(typ *types.T) (*types.T, error) {
+		if !typ.UserDefined() {
+			return typ, nil
+		}
 		resolver := semaCtx.GetTypeResolver()
 		if resolver == nil {
-			return errors.AssertionFailedf("attempt to resolve user defined type with nil TypeResolver")
+			return nil, errors.AssertionFailedf("attempt to resolve user defined type with nil TypeResolver")
 		}
-		typ, err := resolver.ResolveTypeByOID(ctx, h.ColumnType.Oid())
+		return resolver.ResolveTypeByOID(ctx, typ.Oid())
+	}
+	if h.ColumnType != nil {
+		typ, err := hydrate(h.ColumnType)
 		if err != nil {
 			return err
 		}
 		h.ColumnType = typ
 	}
+	for i := range h.ColumnTypes {
+		typ, err := hydrate(h.ColumnTypes[i])
+		if err != nil {
+			return err
+		}
+		h.ColumnTypes[i] = typ
+	}
 	return js.SetHistogram(ctx, h)
 }
 
-// GetHistogram converts the json histogram into HistogramData.
+// GetHistogram converts the json histogram (or extended statistics) into
+// HistogramData.
 func (js *JSONStatistic) GetHistogram(
 	ctx context.Context, semaCtx *tree.SemaContext, evalCtx *eval.Context,
 ) (*HistogramData, error) {
+	if len(js.ExtendedColumnTypes) > 0 {
+		return js.getExtendedStatistics(ctx, semaCtx, evalCtx)
+	}
 	if js.HistogramColumnType == "" {
 		return nil, nil
 	}
@@ -156,6 +223,54 @@ func (js *JSONStatistic) GetHistogram(
 	return h, nil
 }
 
+// getExtendedStatistics converts the json extended statistics into
+// HistogramData.
+func (js *JSONStatistic) getExtendedStatistics(
+	ctx context.Context, semaCtx *tree.SemaContext, evalCtx *eval.Context,
+) (*HistogramData, error) {
+	if len(js.ExtendedColumnTypes) != len(js.Columns) {
+		return nil, errors.Newf(
+			"expected %d extended statistics column types, found %d",
+			len(js.Columns), len(js.ExtendedColumnTypes),
+		)
+	}
+	h := &HistogramData{
+		ColumnTypes:      make([]*types.T, len(js.ExtendedColumnTypes)),
+		Version:          js.HistogramVersion,
+		DependencyDegree: js.DependencyDegree,
+	}
+	for i, typStr := range js.ExtendedColumnTypes {
+		colTypeRef, err := parser.GetTypeFromValidSQLSyntax(typStr)
+		if err != nil {
+			return nil, err
+		}
+		if h.ColumnTypes[i], err = tree.ResolveType(ctx, colTypeRef, semaCtx.GetTypeResolver()); err != nil {
+			return nil, err
+		}
+	}
+	h.MostCommonValues = make([]HistogramData_MultiColumnValue, len(js.MostCommonValues))
+	for i := range js.MostCommonValues {
+		mcv := &js.MostCommonValues[i]
+		if len(mcv.Values) != len(h.ColumnTypes) {
+			return nil, errors.Newf(
+				"expected %d most common values, found %d", len(h.ColumnTypes), len(mcv.Values),
+			)
+		}
+		h.MostCommonValues[i].NumEq = mcv.NumEq
+		h.MostCommonValues[i].Values = make([][]byte, len(mcv.Values))
+		for j, typ := range h.ColumnTypes {
+			val, err := rowenc.ParseDatumStringAs(ctx, typ, mcv.Values[j], evalCtx, semaCtx)
+			if err != nil {
+				return nil, err
+			}
+			if h.MostCommonValues[i].Values[j], err = EncodeUpperBound(h.Version, val); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return h, nil
+}
+
 // IsPartial returns true if this statistic was collected with USING EXTREMES
 // or with a WHERE clause.
 func (js *JSONStatistic) IsPartial() bool {
@@ -47,6 +47,10 @@ type TableStatistic struct {
 
 	// Histogram is the decoded histogram data.
 	Histogram []cat.HistogramBucket
+
+	// MostCommonValues are the decoded most common values of the extended
+	// statistics of a multi-column statistic.
+	MostCommonValues []cat.MultiColumnValue
 }
 
 // A TableStatisticsCache contains an LRU cache of []*TableStatistic objects,
@@ -703,6 +707,31 @@ func (sc *TableStatisticsCache) parseStats(
 		// the memory to be GCed.
 		res.HistogramData.Buckets = nil
 	}
+	if res.HistogramData.HasExtendedStatistics() {
+		for i, typ := range res.HistogramData.ColumnTypes {
+			if !typ.UserDefined() {
+				continue
+			}
+			// Hydrate the types of the extended statistics in the same way as
+			// the type of the histogram above.
+			if typeResolver != nil {
+				typ, err = typeResolver.ResolveTypeByOID(ctx, typ.Oid())
+			} else {
+				err = sc.db.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+					resolver := descs.NewDistSQLTypeResolver(txn.Descriptors(), txn.KV())
+					typ, err = resolver.ResolveTypeByOID(ctx, typ.Oid())
+					return err
+				})
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			res.HistogramData.ColumnTypes[i] = typ
+		}
+		if res.MostCommonValues, err = res.HistogramData.DecodeMostCommonValues(); err != nil {
+			return nil, nil, err
+		}
+	}
 	return res, udt, nil
 }
 
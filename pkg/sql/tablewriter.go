@@ -88,6 +88,9 @@ type tableWriterBase struct {
 	// originID is an identifier for the cluster that originally wrote the data
 	// being written by the table writer during Logical Data Replication.
 	originID uint32
+	// localOriginID identifies the local cluster in a Logical Data Replication
+	// mesh, and is used to break ties between equal origin timestamps.
+	localOriginID uint32
 	// originTimestamp is the timestamp the data written by this table writer were
 	// originally written with before being replicated via Logical Data
 	// Replication.
@@ -113,11 +116,13 @@ func (tb *tableWriterBase) init(
 	tb.lockTimeout = 0
 	tb.deadlockTimeout = 0
 	tb.originID = 0
+	tb.localOriginID = 0
 	tb.originTimestamp = hlc.Timestamp{}
 	if evalCtx != nil {
 		tb.lockTimeout = evalCtx.SessionData().LockTimeout
 		tb.deadlockTimeout = evalCtx.SessionData().DeadlockTimeout
 		tb.originID = evalCtx.SessionData().OriginIDForLogicalDataReplication
+		tb.localOriginID = evalCtx.SessionData().LocalOriginIDForLogicalDataReplication
 		tb.originTimestamp = evalCtx.SessionData().OriginTimestampForLogicalDataReplication
 	}
 	tb.forceProductionBatchSizes = evalCtx != nil && evalCtx.TestingKnobs.ForceProductionValues
@@ -232,6 +237,7 @@ func (tb *tableWriterBase) initNewBatch() {
 		tb.b.Header.WriteOptions = &kvpb.WriteOptions{
 			OriginID:        tb.originID,
 			OriginTimestamp: tb.originTimestamp,
+			LocalOriginID:   tb.localOriginID,
 		}
 	}
 }
@@ -80,6 +80,15 @@ func (t *truncateNode) startExec(params runParams) error {
 		tableDesc := toTraverse[idx]
 		toTraverse = toTraverse[:idx]
 
+		// Truncating a system-versioned table would discard its rows without
+		// recording them in its history table.
+		if tableDesc.SystemVersioning != nil {
+			return errors.WithHint(pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot truncate system-versioned table %q", tableDesc.Name),
+				"use DELETE to record the deleted rows in the history table, "+
+					"or remove system versioning with ALTER TABLE ... DROP SYSTEM VERSIONING")
+		}
+
 		maybeEnqueue := func(tableID descpb.ID, msg string) error {
 			// Check if we're already truncating the referencing table.
 			if _, ok := toTruncate[tableID]; ok {
@@ -985,6 +985,40 @@ var varGen = map[string]sessionVar{
 		GlobalDefault: globalTrue,
 	},
 
+	// CockroachDB extension.
+	`optimizer_use_extended_statistics`: {
+		GetStringVal: makePostgresBoolGetStringValFn(`optimizer_use_extended_statistics`),
+		Set: func(_ context.Context, m sessionmutator.SessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar("optimizer_use_extended_statistics", s)
+			if err != nil {
+				return err
+			}
+			m.SetOptimizerUseExtendedStatistics(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData().OptimizerUseExtendedStatistics), nil
+		},
+		GlobalDefault: globalTrue,
+	},
+
+	// CockroachDB extension.
+	`optimizer_use_adaptive_reoptimization`: {
+		GetStringVal: makePostgresBoolGetStringValFn(`optimizer_use_adaptive_reoptimization`),
+		Set: func(_ context.Context, m sessionmutator.SessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar("optimizer_use_adaptive_reoptimization", s)
+			if err != nil {
+				return err
+			}
+			m.SetOptimizerUseAdaptiveReoptimization(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData().OptimizerUseAdaptiveReoptimization), nil
+		},
+		GlobalDefault: globalFalse,
+	},
+
 	// CockroachDB extension.
 	`optimizer_use_histograms`: {
 		GetStringVal: makePostgresBoolGetStringValFn(`optimizer_use_histograms`),
@@ -1326,6 +1360,29 @@ var varGen = map[string]sessionVar{
 	// See https://www.postgresql.org/docs/10/static/runtime-config-preset.html#GUC-MAX-INDEX-KEYS
 	`max_index_keys`: makeReadOnlyVar("32"),
 
+	// CockroachDB extension.
+	`max_local_parallelism`: {
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return strconv.FormatInt(evalCtx.SessionData().MaxLocalParallelism, 10), nil
+		},
+		GetStringVal: makeIntGetStringValFn(`max_local_parallelism`),
+		Set: func(_ context.Context, m sessionmutator.SessionDataMutator, s string) error {
+			i, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return err
+			}
+			if i < 1 || i > maxLocalParallelism {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"max_local_parallelism must be between 1 and %d: %d", maxLocalParallelism, i)
+			}
+			m.SetMaxLocalParallelism(i)
+			return nil
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return "1"
+		},
+	},
+
 	// Supported for PG compatibility only. MaxInt32 indicates no limit.
 	// See https://www.postgresql.org/docs/10/runtime-config-resource.html#GUC-MAX-PREPARED-TRANSACTIONS
 	`max_prepared_transactions`: makeReadOnlyVar(strconv.Itoa(math.MaxInt32)),
@@ -1338,6 +1395,23 @@ var varGen = map[string]sessionVar{
 		},
 	},
 
+	// CockroachDB extension.
+	`result_cache_enabled`: {
+		GetStringVal: makePostgresBoolGetStringValFn(`result_cache_enabled`),
+		Set: func(_ context.Context, m sessionmutator.SessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar("result_cache_enabled", s)
+			if err != nil {
+				return err
+			}
+			m.SetResultCacheEnabled(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData().ResultCacheEnabled), nil
+		},
+		GlobalDefault: globalFalse,
+	},
+
 	// CockroachDB extension.
 	// TODO(dan): This should also work with SET.
 	`results_buffer_size`: {
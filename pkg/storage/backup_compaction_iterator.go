@@ -16,6 +16,10 @@ import (
 // latest valid key of a given MVCC key, including point tombstones, at or below the
 // asOfTimestamp, if set.
 //
+// If constructed with NewBackupRevisionCompactionIterator, Next instead
+// surfaces every revision of a key at or below the asOfTimestamp, which is used
+// to compact backups taken with revision history.
+//
 // The iterator assumes that it will not encounter any write intents and that the
 // wrapped SimpleMVCCIterator *only* surfaces point keys.
 type BackupCompactionIterator struct {
@@ -24,6 +28,9 @@ type BackupCompactionIterator struct {
 	// asOf is the latest timestamp of a key surfaced by the iterator.
 	asOf hlc.Timestamp
 
+	// revisions is set if Next surfaces all revisions of a key.
+	revisions bool
+
 	// valid tracks if the current key is valid.
 	valid bool
 
@@ -50,9 +57,29 @@ func (f *BackupCompactionIterator) Close() {
 	f.iter.Close()
 }
 
-// Next is identical to NextKey, as BackupCompactionIterator only surfaces live keys.
+// NewBackupRevisionCompactionIterator creates a new BackupCompactionIterator
+// which surfaces all revisions of a key at or below asOf. The asOf timestamp
+// cannot be empty.
+func NewBackupRevisionCompactionIterator(
+	iter SimpleMVCCIterator, asOf hlc.Timestamp,
+) (*BackupCompactionIterator, error) {
+	f, err := NewBackupCompactionIterator(iter, asOf)
+	if err != nil {
+		return nil, err
+	}
+	f.revisions = true
+	return f, nil
+}
+
+// Next is identical to NextKey, as BackupCompactionIterator only surfaces live
+// keys, unless the iterator surfaces all revisions.
 func (f *BackupCompactionIterator) Next() {
-	f.NextKey()
+	if !f.revisions {
+		f.NextKey()
+		return
+	}
+	f.iter.Next()
+	f.advance()
 }
 
 func (f *BackupCompactionIterator) NextKey() {
@@ -65,7 +92,14 @@ func (f *BackupCompactionIterator) SeekGE(originalKey MVCCKey) {
 	synthetic := MVCCKey{Key: originalKey.Key, Timestamp: f.asOf}
 	f.iter.SeekGE(synthetic)
 	if f.advance(); f.valid && f.UnsafeKey().Less(originalKey) {
-		f.NextKey()
+		if !f.revisions {
+			f.NextKey()
+			return
+		}
+		// Later revisions of the key at or below asOf precede originalKey.
+		for f.valid && f.UnsafeKey().Less(originalKey) {
+			f.Next()
+		}
 	}
 }
 
@@ -108,6 +108,57 @@ func TestBackupCompactionIterator(t *testing.T) {
 	}
 }
 
+func TestBackupRevisionCompactionIterator(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	pebble, err := Open(context.Background(), InMemory(),
+		cluster.MakeTestingClusterSettings(), CacheSize(1<<20 /* 1 MiB */))
+	require.NoError(t, err)
+	defer pebble.Close()
+
+	// Like TestBackupCompactionIterator, but Next surfaces every revision at or
+	// below the AOST, while NextKey still surfaces only the latest one.
+	tests := []struct {
+		asOfTest
+		expectedNext string
+	}{
+		{asOfTest{input: "a1b1", expectedNextKey: "a1b1", asOf: ""}, "a1b1"},
+		{asOfTest{input: "a2a1", expectedNextKey: "a2", asOf: ""}, "a2a1"},
+		{asOfTest{input: "a2Xa1b2b1X", expectedNextKey: "a2Xb2", asOf: ""}, "a2Xa1b2b1X"},
+		{asOfTest{input: "a3a2a1b3b1", expectedNextKey: "a2b1", asOf: "2"}, "a2a1b1"},
+		{asOfTest{input: "b3c2c1", expectedNextKey: "c2", asOf: "2"}, "c2c1"},
+	}
+
+	for i, test := range tests {
+		name := fmt.Sprintf("Test %d: %s, AOST %s", i, test.input, test.asOf)
+		t.Run(name, func(t *testing.T) {
+			batch := pebble.NewBatch()
+			defer batch.Close()
+			populateBatch(t, batch, test.input)
+			iter, err := batch.NewMVCCIterator(context.Background(), MVCCKeyAndIntentsIterKind, IterOptions{UpperBound: roachpb.KeyMax})
+			require.NoError(t, err)
+			defer iter.Close()
+
+			subtests := []iterSubtest{
+				{"Next", test.expectedNext, SimpleMVCCIterator.Next},
+				{"NextKey", test.expectedNextKey, SimpleMVCCIterator.NextKey},
+			}
+			for _, subtest := range subtests {
+				t.Run(subtest.name, func(t *testing.T) {
+					asOf := hlc.MaxTimestamp
+					if test.asOf != "" {
+						asOf = hlc.Timestamp{WallTime: int64(test.asOf[0])}
+					}
+					it, err := NewBackupRevisionCompactionIterator(iter, asOf)
+					require.NoError(t, err)
+					iterateSimpleMVCCIterator(t, it, subtest)
+				})
+			}
+		})
+	}
+}
+
 func TestBackupCompactionIteratorSeek(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -423,8 +423,14 @@ func (v *optionalValue) ToPointer() *roachpb.Value {
 	return &cpy
 }
 
+// isOriginTimestampWinner returns whether a value proposed with the given
+// origin timestamp and origin ID wins last-write-wins against this value, along
+// with the origin timestamp of this value. If the timestamps are equal, the
+// proposed value only wins if localOriginID is set and proposedOriginID is
+// greater than the origin ID of this value, which is localOriginID if this
+// value was written locally.
 func (v *optionalValue) isOriginTimestampWinner(
-	proposedTS hlc.Timestamp, inclusive bool,
+	proposedTS hlc.Timestamp, proposedOriginID, localOriginID uint32,
 ) (bool, hlc.Timestamp) {
 	if !v.exists {
 		return true, hlc.Timestamp{}
@@ -435,7 +441,14 @@ func (v *optionalValue) isOriginTimestampWinner(
 		existTS = v.MVCCValueHeader.OriginTimestamp
 	}
 
-	return existTS.Less(proposedTS) || (inclusive && existTS.Equal(proposedTS)), existTS
+	if existTS.Equal(proposedTS) && localOriginID != 0 {
+		existOriginID := v.MVCCValueHeader.OriginID
+		if existOriginID == 0 {
+			existOriginID = localOriginID
+		}
+		return proposedOriginID > existOriginID, existTS
+	}
+	return existTS.Less(proposedTS), existTS
 }
 
 // isSysLocal returns whether the key is system-local.
@@ -2915,6 +2928,12 @@ type ConditionalPutWriteOptions struct {
 	// See the comment on the OriginTimestamp field of
 	// kvpb.ConditionalPutRequest for more details.
 	OriginTimestamp hlc.Timestamp
+	// LocalOriginID, if set, breaks OriginTimestamp ties in favor of the value
+	// with the greater origin ID.
+	//
+	// See the comment on the LocalOriginID field of kvpb.WriteOptions for more
+	// details.
+	LocalOriginID uint32
 }
 
 // MVCCConditionalPut sets the value for a specified key only if the expected
@@ -3031,7 +3050,9 @@ func mvccConditionalPutUsingIter(
 		}
 	} else {
 		valueFn = func(existVal optionalValue) (roachpb.Value, error) {
-			originTSWinner, existTS := existVal.isOriginTimestampWinner(opts.OriginTimestamp, false)
+			originTSWinner, existTS := existVal.isOriginTimestampWinner(
+				opts.OriginTimestamp, opts.OriginID, opts.LocalOriginID,
+			)
 			if !originTSWinner {
 				return roachpb.Value{}, &kvpb.ConditionFailedError{
 					OriginTimestampOlderThan: existTS,
@@ -91,6 +91,7 @@ var (
 //	    cput                  [t=<name>] [ts=<int>[,<int>]] [localTs=<int>[,<int>]] [resolve [status=<txnstatus>]]
 //	                          [ambiguousReplay] [maxLockConflicts=<int>] [targetLockConflictBytes=<int>]
 //	                          k=<key> v=<string> [raw] [cond=<string>]
+//	                          [origin_ts=<int>[,<int>]] [origin_id=<int>] [local_origin_id=<int>]
 //
 //	     del                  [t=<name>] [ts=<int>[,<int>]] [localTs=<int>[,<int>]] [resolve [status=<txnstatus>]]
 //	                          [ambiguousReplay] [maxLockConflicts=<int>] [targetLockConflictBytes=<int>] k=<key>
@@ -1238,6 +1239,13 @@ func cmdCPut(e *evalCtx) error {
 	if e.hasArg("origin_ts") {
 		originTimestamp = e.getTsWithName("origin_ts")
 	}
+	var originID, localOriginID int
+	if e.hasArg("origin_id") {
+		e.scanArg("origin_id", &originID)
+	}
+	if e.hasArg("local_origin_id") {
+		e.scanArg("local_origin_id", &localOriginID)
+	}
 
 	resolve, resolveStatus := e.getResolve()
 
@@ -1249,9 +1257,11 @@ func cmdCPut(e *evalCtx) error {
 				Stats:                          e.ms,
 				ReplayWriteTimestampProtection: e.getAmbiguousReplay(),
 				MaxLockConflicts:               e.getMaxLockConflicts(),
+				OriginID:                       uint32(originID),
 			},
 			AllowIfDoesNotExist: behavior,
 			OriginTimestamp:     originTimestamp,
+			LocalOriginID:       uint32(localOriginID),
 		}
 		acq, err := storage.MVCCConditionalPut(e.ctx, rw, key, ts, val, expVal, opts)
 		if err != nil {